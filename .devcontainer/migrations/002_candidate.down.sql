@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS candidate.ix_candidate__updated;
+DROP INDEX IF EXISTS candidate.ix_candidate__area;
+DROP INDEX IF EXISTS candidate.ix_candidate__email;
+DROP INDEX IF EXISTS candidate.ix_candidate__phone;
+DROP INDEX IF EXISTS candidate.ix_candidate__name;
+
+DROP TABLE IF EXISTS candidate.candidate;
+
+DROP TYPE IF EXISTS candidate.EDUCATION_LEVEL;
+DROP TYPE IF EXISTS candidate.GENDER;
+
+DROP SCHEMA IF EXISTS candidate;
@@ -0,0 +1,46 @@
+CREATE SCHEMA candidate;
+
+CREATE TYPE candidate.GENDER AS enum (
+  'none',
+  'male',
+  'female'
+);
+
+CREATE TYPE candidate.EDUCATION_LEVEL AS enum (
+  'none',
+  'secondary',
+  'specialSecondary',
+  'unfinishedHigher',
+  'higher',
+  'bachelor',
+  'master',
+  'candidate',
+  'doctor'
+);
+
+CREATE TABLE candidate.candidate (
+  id              TEXT,
+  name            TEXT                       NOT NULL,
+  phone           TEXT,
+  email           TEXT,
+  specialization  TEXT,
+  gender          candidate.GENDER           NOT NULL,
+  birth_date      DATE,
+  area            TEXT,
+  salary          int,
+  education_level candidate.EDUCATION_LEVEL  NOT NULL,
+  education       JSONB,
+  experience      JSONB,
+  languages       TEXT[],
+  skills          TEXT[],
+  created         TIMESTAMP                  NOT NULL,
+  updated         TIMESTAMP                  NOT NULL,
+
+  CONSTRAINT pk_candidate__id PRIMARY KEY (id)
+);
+
+CREATE INDEX ix_candidate__name    ON candidate.candidate (name);
+CREATE INDEX ix_candidate__phone   ON candidate.candidate (phone);
+CREATE INDEX ix_candidate__email   ON candidate.candidate (email);
+CREATE INDEX ix_candidate__area    ON candidate.candidate (area);
+CREATE INDEX ix_candidate__updated ON candidate.candidate (updated);
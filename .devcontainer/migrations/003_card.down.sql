@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS card.ix_comment__card_id;
+
+DROP TABLE IF EXISTS card.comment;
+
+DROP INDEX IF EXISTS card.ix_card__updated;
+DROP INDEX IF EXISTS card.ix_card__stage;
+DROP INDEX IF EXISTS card.ix_card__candidate_id;
+
+DROP TABLE IF EXISTS card.card;
+
+DROP TYPE IF EXISTS card.STAGE;
+
+DROP SCHEMA IF EXISTS card;
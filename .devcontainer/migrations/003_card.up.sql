@@ -0,0 +1,42 @@
+CREATE SCHEMA card;
+
+CREATE TYPE card.STAGE AS enum (
+  'none',
+  'new',
+  'screening',
+  'interview',
+  'offer',
+  'hired',
+  'rejected'
+);
+
+CREATE TABLE card.card (
+  id            TEXT,
+  vacancy_id    TEXT        NOT NULL,
+  candidate_id  TEXT        NOT NULL,
+  stage         card.STAGE  NOT NULL,
+  created       TIMESTAMP   NOT NULL,
+  updated       TIMESTAMP   NOT NULL,
+
+  CONSTRAINT pk_card__id PRIMARY KEY (id),
+  CONSTRAINT uq_card__vacancy_id__candidate_id UNIQUE (vacancy_id, candidate_id),
+  CONSTRAINT fk_card__vacancy_id FOREIGN KEY (vacancy_id) REFERENCES vacancy.vacancy (id),
+  CONSTRAINT fk_card__candidate_id FOREIGN KEY (candidate_id) REFERENCES candidate.candidate (id)
+);
+
+CREATE INDEX ix_card__candidate_id ON card.card (candidate_id);
+CREATE INDEX ix_card__stage        ON card.card (stage);
+CREATE INDEX ix_card__updated      ON card.card (updated);
+
+CREATE TABLE card.comment (
+  id       TEXT,
+  card_id  TEXT       NOT NULL,
+  author   TEXT       NOT NULL,
+  text     TEXT       NOT NULL,
+  created  TIMESTAMP  NOT NULL,
+
+  CONSTRAINT pk_comment__id PRIMARY KEY (id),
+  CONSTRAINT fk_comment__card_id FOREIGN KEY (card_id) REFERENCES card.card (id)
+);
+
+CREATE INDEX ix_comment__card_id ON card.comment (card_id);
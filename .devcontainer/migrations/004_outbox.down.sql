@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS outbox.consumer;
+
+DROP INDEX IF EXISTS outbox.ix_event__aggregate_id;
+DROP INDEX IF EXISTS outbox.ix_event__type;
+
+DROP TABLE IF EXISTS outbox.event;
+
+DROP SCHEMA IF EXISTS outbox;
@@ -0,0 +1,24 @@
+CREATE SCHEMA outbox;
+
+CREATE TABLE outbox.event (
+  seq           BIGSERIAL,
+  id            TEXT       NOT NULL,
+  type          TEXT       NOT NULL,
+  aggregate_id  TEXT       NOT NULL,
+  payload       JSONB      NOT NULL,
+  created       TIMESTAMP  NOT NULL,
+
+  CONSTRAINT pk_event__seq PRIMARY KEY (seq),
+  CONSTRAINT uq_event__id UNIQUE (id)
+);
+
+CREATE INDEX ix_event__type         ON outbox.event (type);
+CREATE INDEX ix_event__aggregate_id ON outbox.event (aggregate_id);
+
+CREATE TABLE outbox.consumer (
+  name      TEXT,
+  position  BIGINT     NOT NULL,
+  updated   TIMESTAMP  NOT NULL,
+
+  CONSTRAINT pk_consumer__name PRIMARY KEY (name)
+);
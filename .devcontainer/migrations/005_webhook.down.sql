@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS webhook.ix_delivery__created;
+DROP INDEX IF EXISTS webhook.ix_delivery__status__next_attempt;
+
+DROP TABLE IF EXISTS webhook.delivery;
+
+DROP TYPE IF EXISTS webhook.DELIVERY_STATUS;
+
+DROP TABLE IF EXISTS webhook.webhook;
+
+DROP SCHEMA IF EXISTS webhook;
@@ -0,0 +1,42 @@
+CREATE SCHEMA webhook;
+
+CREATE TABLE webhook.webhook (
+  id       TEXT,
+  url      TEXT       NOT NULL,
+  events   TEXT[]     NOT NULL,
+  secret   TEXT       NOT NULL,
+  active   BOOLEAN    NOT NULL,
+  created  TIMESTAMP  NOT NULL,
+  updated  TIMESTAMP  NOT NULL,
+
+  CONSTRAINT pk_webhook__id PRIMARY KEY (id)
+);
+
+CREATE TYPE webhook.DELIVERY_STATUS AS enum (
+  'none',
+  'pending',
+  'delivered',
+  'dead'
+);
+
+CREATE TABLE webhook.delivery (
+  id             TEXT,
+  webhook_id     TEXT                     NOT NULL,
+  event_id       TEXT                     NOT NULL,
+  event_type     TEXT                     NOT NULL,
+  payload        JSONB                    NOT NULL,
+  status         webhook.DELIVERY_STATUS  NOT NULL,
+  attempts       int                      NOT NULL,
+  next_attempt   TIMESTAMP                NOT NULL,
+  response_code  int                      NOT NULL,
+  last_error     TEXT                     NOT NULL,
+  created        TIMESTAMP                NOT NULL,
+  updated        TIMESTAMP                NOT NULL,
+
+  CONSTRAINT pk_delivery__id PRIMARY KEY (id),
+  CONSTRAINT uq_delivery__webhook_id__event_id UNIQUE (webhook_id, event_id),
+  CONSTRAINT fk_delivery__webhook_id FOREIGN KEY (webhook_id) REFERENCES webhook.webhook (id) ON DELETE CASCADE
+);
+
+CREATE INDEX ix_delivery__status__next_attempt ON webhook.delivery (status, next_attempt);
+CREATE INDEX ix_delivery__created              ON webhook.delivery (created);
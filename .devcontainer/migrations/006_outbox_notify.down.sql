@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS tr_event__notify ON outbox.event;
+
+DROP FUNCTION IF EXISTS outbox.notify();
@@ -0,0 +1,10 @@
+CREATE FUNCTION outbox.notify() RETURNS trigger AS $$
+BEGIN
+  PERFORM pg_notify('outbox_event', '');
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tr_event__notify
+  AFTER INSERT ON outbox.event
+  FOR EACH STATEMENT EXECUTE FUNCTION outbox.notify();
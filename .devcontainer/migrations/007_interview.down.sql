@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS interview.ix_interview__start_at;
+DROP INDEX IF EXISTS interview.ix_interview__interviewers;
+DROP INDEX IF EXISTS interview.ix_interview__card_id;
+
+DROP TABLE IF EXISTS interview.interview;
+
+DROP TYPE IF EXISTS interview.INTERVIEW_STATUS;
+DROP TYPE IF EXISTS interview.INTERVIEW_FORMAT;
+
+DROP SCHEMA IF EXISTS interview;
@@ -0,0 +1,38 @@
+CREATE SCHEMA interview;
+
+CREATE TYPE interview.INTERVIEW_FORMAT AS enum (
+  'none',
+  'onsite',
+  'video',
+  'phone'
+);
+
+CREATE TYPE interview.INTERVIEW_STATUS AS enum (
+  'none',
+  'scheduled',
+  'completed',
+  'cancelled'
+);
+
+CREATE TABLE interview.interview (
+  id            TEXT,
+  card_id       TEXT                        NOT NULL,
+  interviewers  TEXT[]                      NOT NULL,
+  start_at      TIMESTAMPTZ                 NOT NULL,
+  end_at        TIMESTAMPTZ                 NOT NULL,
+  format        interview.INTERVIEW_FORMAT  NOT NULL,
+  location      TEXT                        NOT NULL,
+  link          TEXT                        NOT NULL,
+  status        interview.INTERVIEW_STATUS  NOT NULL,
+  sequence      int                         NOT NULL,
+  created       TIMESTAMP                   NOT NULL,
+  updated       TIMESTAMP                   NOT NULL,
+
+  CONSTRAINT pk_interview__id PRIMARY KEY (id),
+  CONSTRAINT fk_interview__card_id FOREIGN KEY (card_id) REFERENCES card.card (id),
+  CONSTRAINT ck_interview__slot CHECK (end_at > start_at)
+);
+
+CREATE INDEX ix_interview__card_id      ON interview.interview (card_id);
+CREATE INDEX ix_interview__interviewers ON interview.interview USING GIN (interviewers);
+CREATE INDEX ix_interview__start_at     ON interview.interview (start_at);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS scorecard.ix_scorecard__card_id;
+
+DROP TABLE IF EXISTS scorecard.scorecard;
+
+DROP TYPE IF EXISTS scorecard.RECOMMENDATION;
+
+DROP SCHEMA IF EXISTS scorecard;
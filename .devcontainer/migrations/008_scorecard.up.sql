@@ -0,0 +1,28 @@
+CREATE SCHEMA scorecard;
+
+CREATE TYPE scorecard.RECOMMENDATION AS enum (
+  'none',
+  'strongNo',
+  'no',
+  'yes',
+  'strongYes'
+);
+
+CREATE TABLE scorecard.scorecard (
+  id              TEXT,
+  interview_id    TEXT                      NOT NULL,
+  card_id         TEXT                      NOT NULL,
+  interviewer     TEXT                      NOT NULL,
+  ratings         JSONB                     NOT NULL,
+  recommendation  scorecard.RECOMMENDATION  NOT NULL,
+  notes           TEXT                      NOT NULL,
+  created         TIMESTAMP                 NOT NULL,
+  updated         TIMESTAMP                 NOT NULL,
+
+  CONSTRAINT pk_scorecard__id PRIMARY KEY (id),
+  CONSTRAINT uq_scorecard__interview_id__interviewer UNIQUE (interview_id, interviewer),
+  CONSTRAINT fk_scorecard__interview_id FOREIGN KEY (interview_id) REFERENCES interview.interview (id),
+  CONSTRAINT fk_scorecard__card_id FOREIGN KEY (card_id) REFERENCES card.card (id)
+);
+
+CREATE INDEX ix_scorecard__card_id ON scorecard.scorecard (card_id);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS offer.ix_offer__status;
+DROP INDEX IF EXISTS offer.ix_offer__card_id;
+
+DROP TABLE IF EXISTS offer.offer;
+
+DROP TYPE IF EXISTS offer.STATUS;
+
+DROP SCHEMA IF EXISTS offer;
+
+ALTER TABLE vacancy.vacancy DROP COLUMN IF EXISTS budget;
@@ -0,0 +1,33 @@
+ALTER TABLE vacancy.vacancy ADD COLUMN budget int NOT NULL DEFAULT 0;
+
+CREATE SCHEMA offer;
+
+CREATE TYPE offer.STATUS AS enum (
+  'none',
+  'pending',
+  'approved',
+  'rejected',
+  'accepted',
+  'declined',
+  'withdrawn'
+);
+
+CREATE TABLE offer.offer (
+  id          TEXT,
+  card_id     TEXT          NOT NULL,
+  position    TEXT          NOT NULL,
+  salary      int           NOT NULL,
+  start_date  TIMESTAMP     NOT NULL,
+  expires     TIMESTAMP     NOT NULL,
+  status      offer.STATUS  NOT NULL,
+  approvals   JSONB         NOT NULL,
+  warnings    TEXT[]        NOT NULL,
+  created     TIMESTAMP     NOT NULL,
+  updated     TIMESTAMP     NOT NULL,
+
+  CONSTRAINT pk_offer__id PRIMARY KEY (id),
+  CONSTRAINT fk_offer__card_id FOREIGN KEY (card_id) REFERENCES card.card (id)
+);
+
+CREATE INDEX ix_offer__card_id ON offer.offer (card_id);
+CREATE INDEX ix_offer__status  ON offer.offer (status);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS resume.ix_resume__candidate_id;
+
+DROP TABLE IF EXISTS resume.resume;
+
+DROP TYPE IF EXISTS resume.STATUS;
+
+DROP SCHEMA IF EXISTS resume;
@@ -0,0 +1,25 @@
+CREATE SCHEMA resume;
+
+CREATE TYPE resume.STATUS AS enum (
+  'none',
+  'draft',
+  'confirmed'
+);
+
+CREATE TABLE resume.resume (
+  id            TEXT,
+  filename      TEXT           NOT NULL,
+  content_type  TEXT           NOT NULL,
+  size          bigint         NOT NULL,
+  text          TEXT           NOT NULL,
+  draft         JSONB          NOT NULL,
+  candidate_id  TEXT           NOT NULL,
+  status        resume.STATUS  NOT NULL,
+  data          BYTEA          NOT NULL,
+  created       TIMESTAMP      NOT NULL,
+  updated       TIMESTAMP      NOT NULL,
+
+  CONSTRAINT pk_resume__id PRIMARY KEY (id)
+);
+
+CREATE INDEX ix_resume__candidate_id ON resume.resume (candidate_id);
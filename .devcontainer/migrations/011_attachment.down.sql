@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS attachment.ix_attachment__sha256;
+DROP INDEX IF EXISTS attachment.ix_attachment__card_id;
+DROP INDEX IF EXISTS attachment.ix_attachment__candidate_id;
+
+DROP TABLE IF EXISTS attachment.attachment;
+
+DROP SCHEMA IF EXISTS attachment;
@@ -0,0 +1,20 @@
+CREATE SCHEMA attachment;
+
+CREATE TABLE attachment.attachment (
+  id            TEXT,
+  candidate_id  TEXT       NOT NULL,
+  card_id       TEXT       NOT NULL,
+  filename      TEXT       NOT NULL,
+  content_type  TEXT       NOT NULL,
+  size          bigint     NOT NULL,
+  sha256        TEXT       NOT NULL,
+  uploader      TEXT       NOT NULL,
+  created       TIMESTAMP  NOT NULL,
+
+  CONSTRAINT pk_attachment__id PRIMARY KEY (id),
+  CONSTRAINT fk_attachment__candidate_id FOREIGN KEY (candidate_id) REFERENCES candidate.candidate (id)
+);
+
+CREATE INDEX ix_attachment__candidate_id ON attachment.attachment (candidate_id);
+CREATE INDEX ix_attachment__card_id      ON attachment.attachment (card_id);
+CREATE INDEX ix_attachment__sha256       ON attachment.attachment (sha256);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS candidate.ix_redirect__candidate_id;
+
+DROP TABLE IF EXISTS candidate.redirect;
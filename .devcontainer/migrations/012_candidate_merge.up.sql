@@ -0,0 +1,23 @@
+-- Contacts are kept normalized for duplicate detection. Phones which can not
+-- be brought to E.164 here are left as is until edited.
+UPDATE candidate.candidate SET email = lower(trim(email)) WHERE email IS NOT NULL;
+
+UPDATE candidate.candidate SET phone = CASE
+  WHEN phone ~ '^\s*\+' THEN '+' || regexp_replace(phone, '\D', '', 'g')
+  WHEN regexp_replace(phone, '\D', '', 'g') ~ '^00[1-9]\d{7,14}$' THEN '+' || substr(regexp_replace(phone, '\D', '', 'g'), 3)
+  WHEN regexp_replace(phone, '\D', '', 'g') ~ '^[78]\d{10}$' THEN '+7' || substr(regexp_replace(phone, '\D', '', 'g'), 2)
+  WHEN regexp_replace(phone, '\D', '', 'g') ~ '^\d{10}$' THEN '+7' || regexp_replace(phone, '\D', '', 'g')
+  ELSE phone
+END
+WHERE phone <> '' AND phone ~ '^[\s0-9+().-]+$';
+
+CREATE TABLE candidate.redirect (
+  id            TEXT,
+  candidate_id  TEXT       NOT NULL,
+  created       TIMESTAMP  NOT NULL,
+
+  CONSTRAINT pk_redirect__id PRIMARY KEY (id),
+  CONSTRAINT fk_redirect__candidate_id FOREIGN KEY (candidate_id) REFERENCES candidate.candidate (id)
+);
+
+CREATE INDEX ix_redirect__candidate_id ON candidate.redirect (candidate_id);
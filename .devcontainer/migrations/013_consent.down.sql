@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS consent.ix_consent__expires;
+DROP INDEX IF EXISTS consent.ix_consent__candidate_id;
+
+DROP TABLE IF EXISTS consent.consent;
+
+DROP TYPE IF EXISTS consent.PURPOSE;
+
+DROP SCHEMA IF EXISTS consent;
+
+ALTER TABLE candidate.candidate DROP COLUMN IF EXISTS anonymized;
@@ -0,0 +1,26 @@
+ALTER TABLE candidate.candidate ADD COLUMN anonymized TIMESTAMP;
+
+CREATE SCHEMA consent;
+
+CREATE TYPE consent.PURPOSE AS enum (
+  'none',
+  'recruitment',
+  'talentPool'
+);
+
+CREATE TABLE consent.consent (
+  id            TEXT,
+  candidate_id  TEXT             NOT NULL,
+  purpose       consent.PURPOSE  NOT NULL,
+  source        TEXT             NOT NULL,
+  given         TIMESTAMP        NOT NULL,
+  expires       TIMESTAMP        NOT NULL,
+  withdrawn     TIMESTAMP,
+  created       TIMESTAMP        NOT NULL,
+
+  CONSTRAINT pk_consent__id PRIMARY KEY (id),
+  CONSTRAINT fk_consent__candidate_id FOREIGN KEY (candidate_id) REFERENCES candidate.candidate (id)
+);
+
+CREATE INDEX ix_consent__candidate_id ON consent.consent (candidate_id);
+CREATE INDEX ix_consent__expires      ON consent.consent (expires);
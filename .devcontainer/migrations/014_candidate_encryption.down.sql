@@ -0,0 +1,12 @@
+-- Only unencrypted data survives going down, ciphertexts can not be
+-- decrypted here and encrypted birth dates fail to convert.
+DROP INDEX IF EXISTS candidate.ix_candidate__email_index;
+DROP INDEX IF EXISTS candidate.ix_candidate__phone_index;
+
+CREATE INDEX ix_candidate__phone ON candidate.candidate (phone);
+CREATE INDEX ix_candidate__email ON candidate.candidate (email);
+
+ALTER TABLE candidate.candidate DROP COLUMN IF EXISTS email_index;
+ALTER TABLE candidate.candidate DROP COLUMN IF EXISTS phone_index;
+
+ALTER TABLE candidate.candidate ALTER COLUMN birth_date TYPE DATE USING birth_date::DATE;
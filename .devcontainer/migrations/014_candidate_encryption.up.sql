@@ -0,0 +1,15 @@
+-- Phone, email and birth date hold AES-GCM ciphertexts once hr keys rotate
+-- runs, equality lookups go through blind indexes instead. Until then the
+-- indexes are the values themselves, like with no keyring.
+ALTER TABLE candidate.candidate ALTER COLUMN birth_date TYPE TEXT USING to_char(birth_date, 'YYYY-MM-DD');
+
+ALTER TABLE candidate.candidate ADD COLUMN phone_index TEXT NOT NULL DEFAULT '';
+ALTER TABLE candidate.candidate ADD COLUMN email_index TEXT NOT NULL DEFAULT '';
+
+UPDATE candidate.candidate SET phone_index = coalesce(phone, ''), email_index = coalesce(email, '');
+
+DROP INDEX IF EXISTS candidate.ix_candidate__phone;
+DROP INDEX IF EXISTS candidate.ix_candidate__email;
+
+CREATE INDEX ix_candidate__phone_index ON candidate.candidate (phone_index);
+CREATE INDEX ix_candidate__email_index ON candidate.candidate (email_index);
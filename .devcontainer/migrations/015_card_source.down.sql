@@ -0,0 +1 @@
+ALTER TABLE card.card DROP COLUMN IF EXISTS source;
@@ -0,0 +1 @@
+ALTER TABLE card.card ADD COLUMN source TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,26 @@
+DROP INDEX IF EXISTS mail.ix_message__candidate_id;
+
+DROP TABLE IF EXISTS mail.message;
+
+DROP TYPE IF EXISTS mail.DIRECTION;
+
+DROP SCHEMA IF EXISTS mail;
+
+-- Values can not be dropped from an enum, the type is built again.
+UPDATE card.card SET stage = 'new' WHERE stage = 'inbox';
+
+ALTER TYPE card.STAGE RENAME TO STAGE_OLD;
+
+CREATE TYPE card.STAGE AS enum (
+  'none',
+  'new',
+  'screening',
+  'interview',
+  'offer',
+  'hired',
+  'rejected'
+);
+
+ALTER TABLE card.card ALTER COLUMN stage TYPE card.STAGE USING stage::TEXT::card.STAGE;
+
+DROP TYPE card.STAGE_OLD;
@@ -0,0 +1,29 @@
+ALTER TYPE card.STAGE ADD VALUE 'inbox';
+
+CREATE SCHEMA mail;
+
+CREATE TYPE mail.DIRECTION AS enum (
+  'none',
+  'inbound',
+  'outbound'
+);
+
+CREATE TABLE mail.message (
+  id            TEXT,
+  message_id    TEXT            NOT NULL,
+  direction     mail.DIRECTION  NOT NULL,
+  candidate_id  TEXT            NOT NULL,
+  card_id       TEXT            NOT NULL,
+  sender        TEXT            NOT NULL,
+  recipient     TEXT            NOT NULL,
+  subject       TEXT            NOT NULL,
+  text          TEXT            NOT NULL,
+  sent          TIMESTAMP       NOT NULL,
+  created       TIMESTAMP       NOT NULL,
+
+  CONSTRAINT pk_message__id PRIMARY KEY (id),
+  CONSTRAINT uq_message__message_id UNIQUE (message_id),
+  CONSTRAINT fk_message__candidate_id FOREIGN KEY (candidate_id) REFERENCES candidate.candidate (id)
+);
+
+CREATE INDEX ix_message__candidate_id ON mail.message (candidate_id);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS mail.ix_message__status__next_attempt;
+
+ALTER TABLE mail.message
+  DROP COLUMN IF EXISTS last_error,
+  DROP COLUMN IF EXISTS next_attempt,
+  DROP COLUMN IF EXISTS attempts,
+  DROP COLUMN IF EXISTS status,
+  DROP COLUMN IF EXISTS files,
+  DROP COLUMN IF EXISTS template;
+
+DROP TYPE IF EXISTS mail.STATUS;
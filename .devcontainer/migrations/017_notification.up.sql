@@ -0,0 +1,17 @@
+CREATE TYPE mail.STATUS AS enum (
+  'none',
+  'received',
+  'pending',
+  'sent',
+  'dead'
+);
+
+ALTER TABLE mail.message
+  ADD COLUMN template      TEXT         NOT NULL DEFAULT '',
+  ADD COLUMN files         JSONB        NOT NULL DEFAULT '[]',
+  ADD COLUMN status        mail.STATUS  NOT NULL DEFAULT 'received',
+  ADD COLUMN attempts      INTEGER      NOT NULL DEFAULT 0,
+  ADD COLUMN next_attempt  TIMESTAMP    NOT NULL DEFAULT now(),
+  ADD COLUMN last_error    TEXT         NOT NULL DEFAULT '';
+
+CREATE INDEX ix_message__status__next_attempt ON mail.message (status, next_attempt);
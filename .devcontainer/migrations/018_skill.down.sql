@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS skill.ix_term__skill_id;
+DROP INDEX IF EXISTS skill.ix_term__key__pattern;
+
+DROP TABLE IF EXISTS skill.term;
+
+DROP INDEX IF EXISTS skill.ix_skill__category;
+DROP INDEX IF EXISTS skill.ix_skill__name;
+
+DROP TABLE IF EXISTS skill.skill;
+
+DROP SCHEMA IF EXISTS skill;
@@ -0,0 +1,50 @@
+CREATE SCHEMA skill;
+
+CREATE TABLE skill.skill (
+  id        TEXT,
+  name      TEXT       NOT NULL,
+  synonyms  TEXT[]     NOT NULL DEFAULT '{}',
+  category  TEXT       NOT NULL DEFAULT '',
+  created   TIMESTAMP  NOT NULL,
+  updated   TIMESTAMP  NOT NULL,
+
+  CONSTRAINT pk_skill__id PRIMARY KEY (id)
+);
+
+CREATE INDEX ix_skill__name     ON skill.skill (name);
+CREATE INDEX ix_skill__category ON skill.skill (category);
+
+-- Keys of names and synonyms: lower case without spaces, dots, dashes and
+-- underscores. A key belongs to one skill only.
+CREATE TABLE skill.term (
+  key       TEXT,
+  skill_id  TEXT  NOT NULL,
+
+  CONSTRAINT pk_term__key PRIMARY KEY (key),
+  CONSTRAINT fk_term__skill_id FOREIGN KEY (skill_id) REFERENCES skill.skill (id) ON DELETE CASCADE
+);
+
+CREATE INDEX ix_term__key__pattern ON skill.term (key text_pattern_ops);
+CREATE INDEX ix_term__skill_id     ON skill.term (skill_id);
+
+-- The catalog starts with skills already in use, the most used spelling
+-- becomes the name.
+WITH used AS (
+  SELECT title FROM vacancy.skill
+  UNION ALL
+  SELECT unnest(skills) FROM candidate.candidate
+), spelled AS (
+  SELECT lower(regexp_replace(title, '[[:space:]._-]', '', 'g')) AS key, title, count(*) AS uses
+  FROM used
+  GROUP BY 1, 2
+), named AS (
+  SELECT DISTINCT ON (key) key, btrim(regexp_replace(title, '[[:space:]]+', ' ', 'g')) AS name
+  FROM spelled
+  WHERE key <> ''
+  ORDER BY key, uses DESC, title
+)
+INSERT INTO skill.skill (id, name, created, updated)
+SELECT md5(key)::UUID::TEXT, name, now(), now() FROM named;
+
+INSERT INTO skill.term (key, skill_id)
+SELECT lower(regexp_replace(name, '[[:space:]._-]', '', 'g')), id FROM skill.skill;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS dictionary.ix_item__parent_id;
+DROP INDEX IF EXISTS dictionary.ux_item__kind__name;
+
+DROP TABLE IF EXISTS dictionary.item;
+
+DROP TYPE IF EXISTS dictionary.KIND;
+
+DROP SCHEMA IF EXISTS dictionary;
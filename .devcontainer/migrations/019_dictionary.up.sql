@@ -0,0 +1,63 @@
+CREATE SCHEMA dictionary;
+
+CREATE TYPE dictionary.KIND AS enum (
+  'none',
+  'area',
+  'department',
+  'specialization'
+);
+
+CREATE TABLE dictionary.item (
+  id         TEXT,
+  kind       dictionary.KIND  NOT NULL,
+  name       TEXT             NOT NULL,
+  parent_id  TEXT,
+  created    TIMESTAMP        NOT NULL,
+  updated    TIMESTAMP        NOT NULL,
+
+  CONSTRAINT pk_item__id PRIMARY KEY (id),
+  CONSTRAINT fk_item__parent_id FOREIGN KEY (parent_id) REFERENCES dictionary.item (id)
+);
+
+CREATE UNIQUE INDEX ux_item__kind__name ON dictionary.item (kind, lower(name));
+CREATE INDEX ix_item__parent_id         ON dictionary.item (parent_id);
+
+-- Dictionaries start with the free text values in use as roots, the most
+-- used spelling becomes the name. Values are then rewritten to the names.
+WITH used AS (
+  SELECT 'area' AS kind, area AS value FROM vacancy.vacancy
+  UNION ALL
+  SELECT 'department', department FROM vacancy.vacancy
+  UNION ALL
+  SELECT 'area', area FROM candidate.candidate
+  UNION ALL
+  SELECT 'specialization', specialization FROM candidate.candidate
+), spelled AS (
+  SELECT kind, btrim(regexp_replace(value, '[[:space:]]+', ' ', 'g')) AS name, count(*) AS uses
+  FROM used
+  WHERE value IS NOT NULL
+  GROUP BY 1, 2
+), named AS (
+  SELECT DISTINCT ON (kind, lower(name)) kind, name
+  FROM spelled
+  WHERE name <> ''
+  ORDER BY kind, lower(name), uses DESC, name
+)
+INSERT INTO dictionary.item (id, kind, name, created, updated)
+SELECT md5(kind || lower(name))::UUID::TEXT, kind::dictionary.KIND, name, now(), now() FROM named;
+
+UPDATE vacancy.vacancy v SET area = i.name
+FROM dictionary.item i
+WHERE i.kind = 'area' AND lower(btrim(regexp_replace(v.area, '[[:space:]]+', ' ', 'g'))) = lower(i.name);
+
+UPDATE vacancy.vacancy v SET department = i.name
+FROM dictionary.item i
+WHERE i.kind = 'department' AND lower(btrim(regexp_replace(v.department, '[[:space:]]+', ' ', 'g'))) = lower(i.name);
+
+UPDATE candidate.candidate c SET area = i.name
+FROM dictionary.item i
+WHERE i.kind = 'area' AND lower(btrim(regexp_replace(c.area, '[[:space:]]+', ' ', 'g'))) = lower(i.name);
+
+UPDATE candidate.candidate c SET specialization = i.name
+FROM dictionary.item i
+WHERE i.kind = 'specialization' AND lower(btrim(regexp_replace(c.specialization, '[[:space:]]+', ' ', 'g'))) = lower(i.name);
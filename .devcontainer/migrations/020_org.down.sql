@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS vacancy.ix_vacancy__recruiter;
+DROP INDEX IF EXISTS vacancy.ix_vacancy__hiring_manager;
+
+ALTER TABLE vacancy.vacancy DROP COLUMN IF EXISTS recruiter;
+ALTER TABLE vacancy.vacancy DROP COLUMN IF EXISTS hiring_manager;
+
+DROP INDEX IF EXISTS org.ix_manager__email;
+
+DROP TABLE IF EXISTS org.manager;
+
+DROP SCHEMA IF EXISTS org;
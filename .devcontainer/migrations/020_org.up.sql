@@ -0,0 +1,18 @@
+CREATE SCHEMA org;
+
+CREATE TABLE org.manager (
+  department_id  TEXT,
+  email          TEXT  NOT NULL,
+  position       int   NOT NULL,
+
+  CONSTRAINT pk_manager__department_id__email PRIMARY KEY (department_id, email),
+  CONSTRAINT fk_manager__department_id FOREIGN KEY (department_id) REFERENCES dictionary.item (id) ON DELETE CASCADE
+);
+
+CREATE INDEX ix_manager__email ON org.manager (email);
+
+ALTER TABLE vacancy.vacancy ADD COLUMN hiring_manager TEXT NOT NULL DEFAULT '';
+ALTER TABLE vacancy.vacancy ADD COLUMN recruiter TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX ix_vacancy__hiring_manager ON vacancy.vacancy (hiring_manager);
+CREATE INDEX ix_vacancy__recruiter      ON vacancy.vacancy (recruiter);
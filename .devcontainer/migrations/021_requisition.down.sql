@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS vacancy.ix_vacancy__requisition_id;
+
+ALTER TABLE vacancy.vacancy DROP COLUMN IF EXISTS requisition_id;
+
+DROP INDEX IF EXISTS requisition.ix_requisition__created;
+DROP INDEX IF EXISTS requisition.ix_requisition__requester;
+DROP INDEX IF EXISTS requisition.ix_requisition__status;
+
+DROP TABLE IF EXISTS requisition.requisition;
+
+DROP TYPE IF EXISTS requisition.STATUS;
+
+DROP SCHEMA IF EXISTS requisition;
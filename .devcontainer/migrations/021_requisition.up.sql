@@ -0,0 +1,35 @@
+CREATE SCHEMA requisition;
+
+CREATE TYPE requisition.STATUS AS enum (
+  'none',
+  'pending',
+  'approved',
+  'rejected',
+  'withdrawn'
+);
+
+CREATE TABLE requisition.requisition (
+  id             TEXT,
+  title          TEXT                NOT NULL,
+  department     TEXT                NOT NULL,
+  positions      int                 NOT NULL,
+  budget         int                 NOT NULL,
+  salary_min     int                 NOT NULL,
+  salary_max     int                 NOT NULL,
+  justification  TEXT                NOT NULL,
+  requester      TEXT                NOT NULL,
+  status         requisition.STATUS  NOT NULL,
+  approvals      JSONB               NOT NULL,
+  created        TIMESTAMP           NOT NULL,
+  updated        TIMESTAMP           NOT NULL,
+
+  CONSTRAINT pk_requisition__id PRIMARY KEY (id)
+);
+
+CREATE INDEX ix_requisition__status    ON requisition.requisition (status);
+CREATE INDEX ix_requisition__requester ON requisition.requisition (requester);
+CREATE INDEX ix_requisition__created   ON requisition.requisition (created);
+
+ALTER TABLE vacancy.vacancy ADD COLUMN requisition_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+
+CREATE INDEX ix_vacancy__requisition_id ON vacancy.vacancy (requisition_id);
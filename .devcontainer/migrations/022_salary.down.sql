@@ -0,0 +1,13 @@
+ALTER TABLE candidate.candidate DROP COLUMN IF EXISTS salary_period;
+ALTER TABLE candidate.candidate DROP COLUMN IF EXISTS salary_currency;
+
+DROP TYPE IF EXISTS candidate.SALARY_PERIOD;
+
+ALTER TABLE vacancy.vacancy DROP COLUMN IF EXISTS salary_public;
+ALTER TABLE vacancy.vacancy DROP COLUMN IF EXISTS salary_period;
+ALTER TABLE vacancy.vacancy DROP COLUMN IF EXISTS salary_gross;
+ALTER TABLE vacancy.vacancy DROP COLUMN IF EXISTS salary_currency;
+ALTER TABLE vacancy.vacancy DROP COLUMN IF EXISTS salary_max;
+ALTER TABLE vacancy.vacancy DROP COLUMN IF EXISTS salary_min;
+
+DROP TYPE IF EXISTS vacancy.SALARY_PERIOD;
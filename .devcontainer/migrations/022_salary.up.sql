@@ -0,0 +1,21 @@
+CREATE TYPE vacancy.SALARY_PERIOD AS enum (
+  'none',
+  'month',
+  'year'
+);
+
+ALTER TABLE vacancy.vacancy ADD COLUMN salary_min int NOT NULL DEFAULT 0;
+ALTER TABLE vacancy.vacancy ADD COLUMN salary_max int NOT NULL DEFAULT 0;
+ALTER TABLE vacancy.vacancy ADD COLUMN salary_currency TEXT NOT NULL DEFAULT '';
+ALTER TABLE vacancy.vacancy ADD COLUMN salary_gross BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE vacancy.vacancy ADD COLUMN salary_period vacancy.SALARY_PERIOD NOT NULL DEFAULT 'none';
+ALTER TABLE vacancy.vacancy ADD COLUMN salary_public BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TYPE candidate.SALARY_PERIOD AS enum (
+  'none',
+  'month',
+  'year'
+);
+
+ALTER TABLE candidate.candidate ADD COLUMN salary_currency TEXT NOT NULL DEFAULT '';
+ALTER TABLE candidate.candidate ADD COLUMN salary_period candidate.SALARY_PERIOD NOT NULL DEFAULT 'none';
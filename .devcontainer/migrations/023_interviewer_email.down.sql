@@ -0,0 +1 @@
+-- Original case of interviewer emails is not kept, they stay normalized.
@@ -0,0 +1,3 @@
+-- Interviewers are matched by normalized emails to find conflicts.
+UPDATE interview.interview
+SET interviewers = ARRAY(SELECT lower(trim(v)) FROM unnest(interviewers) AS v);
@@ -0,0 +1,9 @@
+-- Scorecards submitted under differently cased emails of the same
+-- interviewer collapse into the latest one.
+DELETE FROM scorecard.scorecard s
+USING scorecard.scorecard newer
+WHERE s.interview_id = newer.interview_id
+  AND lower(trim(s.interviewer)) = lower(trim(newer.interviewer))
+  AND (s.updated, s.id) < (newer.updated, newer.id);
+
+UPDATE scorecard.scorecard SET interviewer = lower(trim(interviewer));
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS candidate.ix_candidate__name_key;
+
+ALTER TABLE candidate.candidate DROP COLUMN IF EXISTS name_key;
@@ -0,0 +1,28 @@
+-- Duplicates are looked up by names brought to the same case, script and
+-- word order like entities.NameKey does.
+ALTER TABLE candidate.candidate ADD COLUMN name_key TEXT NOT NULL DEFAULT '';
+
+CREATE FUNCTION candidate.name_key(name TEXT) RETURNS TEXT AS $$
+  SELECT coalesce(string_agg(word, ' ' ORDER BY word COLLATE "C"), '')
+  FROM (
+    SELECT translate(
+      replace(replace(replace(replace(replace(replace(replace(replace(replace(replace(
+        w, 'щ', 'shch'), 'ж', 'zh'), 'х', 'kh'), 'ц', 'ts'), 'ч', 'ch'), 'ш', 'sh'), 'ю', 'yu'), 'я', 'ya'), 'ъ', ''), 'ь', ''),
+      'абвгдеёзийклмнопрстуфыэ',
+      'abvgdeeziyklmnoprstufye'
+    ) AS word
+    FROM regexp_split_to_table(lower(name), '[^[:alpha:]]+') AS w
+    WHERE w <> ''
+  ) words
+$$ LANGUAGE SQL IMMUTABLE;
+
+UPDATE candidate.candidate SET name_key = candidate.name_key(name);
+
+DROP FUNCTION candidate.name_key(TEXT);
+
+-- Names written with a typo share most trigrams of the key, so the index
+-- narrows them down for entities.FindDuplicates to compare.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX ix_candidate__name_key ON candidate.candidate
+USING GIN (name_key gin_trgm_ops);
@@ -0,0 +1,2 @@
+-- Only unencrypted drafts survive going down, ciphertexts fail to convert.
+ALTER TABLE resume.resume ALTER COLUMN draft TYPE JSONB USING draft::JSONB;
@@ -0,0 +1,6 @@
+-- Text, draft and the original file of resumes and sender, recipient,
+-- subject and text of mail hold AES-GCM ciphertexts once hr keys rotate
+-- runs, so drafts are no longer JSON. Attachments in the blob storage and
+-- files of letters waiting to be sent are not encrypted, letters keep only
+-- file names once sent.
+ALTER TABLE resume.resume ALTER COLUMN draft TYPE TEXT USING draft::TEXT;
@@ -9,6 +9,11 @@ func NewDefaultCommand(version string) *cobra.Command {
 	}
 
 	root.AddCommand(Server())
+	root.AddCommand(Import())
+	root.AddCommand(Vacancies())
+	root.AddCommand(Ingest())
+	root.AddCommand(Dictionaries())
+	root.AddCommand(Keys())
 	root.AddCommand(Version(version))
 
 	return root
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"gpb.ru/hr/internal/hr/dictionaries"
+	"gpb.ru/hr/internal/hr/repos/postgres"
+)
+
+func Dictionaries() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dictionaries",
+		Short: "Manage dictionaries of areas, departments and specializations.",
+	}
+	cmd.AddCommand(seedDictionaries())
+	return cmd
+}
+
+func seedDictionaries() *cobra.Command {
+	pgurl := ""
+
+	cmd := &cobra.Command{
+		Use:   "seed [file]",
+		Short: "Seed dictionaries from a YAML file, - reads stdin.",
+		Long: "Seed dictionaries from a YAML file with area, department and " +
+			"specialization trees of items having a name and nested items. " +
+			"Missing items are created, existing ones get the spelling and " +
+			"the parent of the file, others are kept.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var input io.Reader = os.Stdin
+			if args[0] != "-" {
+				file, err := os.Open(args[0])
+				if err != nil {
+					log.Printf("[error] %s", err)
+					return
+				}
+				defer file.Close()
+				input = file
+			}
+			data, err := io.ReadAll(input)
+			if err != nil {
+				log.Printf("[error] reading seed: %s", err)
+				return
+			}
+			seed, err := dictionaries.Parse(data)
+			if err != nil {
+				log.Printf("[error] reading seed: %s", err)
+				return
+			}
+
+			pg, err := postgres.New(pgurl, nil)
+			if err != nil {
+				log.Printf("[error] database connection error: %s", err)
+				return
+			}
+			defer pg.Close(context.Background())
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Minute)
+			defer cancel()
+			result, err := dictionaries.Apply(ctx, pg.Dictionary, seed)
+			if err != nil {
+				log.Printf("[error] seeding dictionaries: %s", err)
+			}
+			if result != nil {
+				cmd.Printf("created %d, updated %d, unchanged %d\n", result.Created, result.Updated, result.Unchanged)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&pgurl, "db", "localhost:5432", "Postgres url.")
+
+	return cmd
+}
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/internal/hr/repos/postgres"
+	"gpb.ru/hr/internal/hr/resumes"
+)
+
+func Import() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import data from files.",
+	}
+	cmd.AddCommand(importCandidates())
+	return cmd
+}
+
+func importCandidates() *cobra.Command {
+	pgurl := ""
+	format := ""
+	dryRun := false
+	keyringPath := ""
+
+	cmd := &cobra.Command{
+		Use:   "candidates [file]",
+		Short: "Import candidates from JSON Resume or hh.ru resumes, - reads stdin.",
+		Long: "Import candidates from JSON Resume or hh.ru resumes. The file holds " +
+			"a JSON array of resumes or resumes one after another.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			resumeFormat, err := resumes.ParseFormat(format)
+			if err != nil {
+				log.Printf("[error] %s", err)
+				return
+			}
+
+			var input io.Reader = os.Stdin
+			if args[0] != "-" {
+				file, err := os.Open(args[0])
+				if err != nil {
+					log.Printf("[error] %s", err)
+					return
+				}
+				defer file.Close()
+				input = file
+			}
+			docs, err := resumes.Split(input)
+			if err != nil {
+				log.Printf("[error] reading resumes: %s", err)
+				return
+			}
+
+			var pg *postgres.Postgres
+			// Without the database skills, areas and specializations are
+			// kept as written.
+			skills := entities.NewSkillIndex(nil)
+			dicts := entities.NewDictionaries(nil)
+			if !dryRun {
+				keys, err := loadKeyring(keyringPath)
+				if err != nil {
+					log.Printf("[error] keyring error: %s", err)
+					return
+				}
+				pg, err = postgres.New(pgurl, keys)
+				if err != nil {
+					log.Printf("[error] database connection error: %s", err)
+					return
+				}
+				defer pg.Close(context.Background())
+
+				ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+				catalog, err := pg.Skill.List(ctx, repos.SkillFilter{})
+				cancel()
+				if err != nil {
+					log.Printf("[error] loading skill catalog: %s", err)
+					return
+				}
+				skills = entities.NewSkillIndex(catalog)
+
+				ctx, cancel = context.WithTimeout(cmd.Context(), 5*time.Second)
+				items, err := pg.Dictionary.List(ctx, repos.DictionaryFilter{})
+				cancel()
+				if err != nil {
+					log.Printf("[error] loading dictionaries: %s", err)
+					return
+				}
+				dicts = entities.NewDictionaries(items)
+			}
+
+			imported, failed := 0, 0
+			for i, doc := range docs {
+				result, err := resumes.Parse(doc, resumeFormat)
+				if err == nil {
+					err = result.Candidate.Validate()
+				}
+				if err == nil {
+					result.Candidate.NormalizeSkills(skills)
+					result.Candidate.FitDictionaries(dicts)
+				}
+				if err == nil && !dryRun {
+					ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+					err = pg.Candidate.Create(ctx, &result.Candidate)
+					cancel()
+				}
+				if err != nil {
+					cmd.Printf("#%d: error: %s\n", i+1, err)
+					failed++
+					continue
+				}
+
+				imported++
+				cmd.Printf("#%d: %s %s\n", i+1, result.Candidate.ID, result.Candidate.Name)
+				if len(result.Unmapped) > 0 {
+					cmd.Printf("    unmapped: %s\n", strings.Join(result.Unmapped, ", "))
+				}
+			}
+			cmd.Printf("imported %d, failed %d\n", imported, failed)
+		},
+	}
+
+	cmd.Flags().StringVar(&pgurl, "db", "localhost:5432", "Postgres url.")
+	cmd.Flags().StringVar(&keyringPath, "keyring", "", "Keyring file encrypting personal data of candidates, none if empty.")
+	cmd.Flags().StringVar(&format, "format", "", "Resume format: jsonresume or hh, detected if empty.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only print candidates without saving them.")
+
+	return cmd
+}
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"gpb.ru/hr/internal/hr/ingest"
+	"gpb.ru/hr/internal/hr/repos/postgres"
+	"gpb.ru/hr/pkg/mailbox"
+)
+
+func Ingest() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ingest",
+		Short: "Take applications in from outside sources.",
+	}
+	cmd.AddCommand(ingestMail())
+	return cmd
+}
+
+func ingestMail() *cobra.Command {
+	pgurl := ""
+	keyringPath := ""
+	blobDir := ""
+	s3Endpoint := ""
+	s3Bucket := ""
+	s3Region := ""
+	dryRun := false
+
+	cmd := &cobra.Command{
+		Use:   "mail [path]...",
+		Short: "Ingest emailed applications from .eml files, mbox files and Maildir folders.",
+		Long: "Ingest emailed applications. A path is an .eml file, an mbox file or a " +
+			"directory of .eml files and Maildir folders, e.g. the one an IMAP " +
+			"server or a mail client keeps locally. Senders become candidates with " +
+			"the attachments, subjects naming an active vacancy give cards in the " +
+			"inbox stage. Messages taken in before are skipped by Message-ID.",
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var files []string
+			for _, path := range args {
+				info, err := os.Stat(path)
+				if err != nil {
+					log.Printf("[error] %s", err)
+					return
+				}
+				if !info.IsDir() {
+					files = append(files, path)
+					continue
+				}
+				found, err := mailbox.Files(path)
+				if err != nil {
+					log.Printf("[error] listing %s: %s", path, err)
+					return
+				}
+				files = append(files, found...)
+			}
+
+			var mail *ingest.Mail
+			if !dryRun {
+				keys, err := loadKeyring(keyringPath)
+				if err != nil {
+					log.Printf("[error] keyring error: %s", err)
+					return
+				}
+				pg, err := postgres.New(pgurl, keys)
+				if err != nil {
+					log.Printf("[error] database connection error: %s", err)
+					return
+				}
+				defer pg.Close(context.Background())
+
+				blobs, err := blobStore(blobDir, s3Endpoint, s3Bucket, s3Region)
+				if err != nil {
+					log.Printf("[error] blob store error: %s", err)
+					return
+				}
+				mail = ingest.NewMail(pg.Repos, blobs)
+			}
+
+			ingested, skipped, failed := 0, 0, 0
+			for _, file := range files {
+				messages, err := mailbox.ReadFile(file)
+				if err != nil {
+					cmd.Printf("%s: error: %s\n", file, err)
+					failed++
+					continue
+				}
+				for i, data := range messages {
+					name := file
+					if len(messages) > 1 {
+						name = file + "#" + strconv.Itoa(i+1)
+					}
+
+					msg, err := mailbox.Parse(data)
+					if err != nil {
+						cmd.Printf("%s: error: %s\n", name, err)
+						failed++
+						continue
+					}
+					if dryRun {
+						ingested++
+						cmd.Printf("%s: %s %q, %d attachments\n", name, msg.From.Address, msg.Subject, len(msg.Attachments))
+						continue
+					}
+
+					ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+					result, err := mail.Ingest(ctx, msg)
+					cancel()
+					switch {
+					case err != nil:
+						cmd.Printf("%s: error: %s\n", name, err)
+						failed++
+					case result.Duplicate:
+						cmd.Printf("%s: %s already ingested\n", name, result.MessageID)
+						skipped++
+					default:
+						ingested++
+						cmd.Printf("%s: candidate %s", name, result.CandidateID)
+						if result.Existing {
+							cmd.Printf(" (existing)")
+						}
+						if result.VacancyID != uuid.Nil {
+							cmd.Printf(", vacancy %s, card %s", result.VacancyID, result.CardID)
+						}
+						cmd.Printf(", %d attachments\n", result.Attachments)
+					}
+				}
+			}
+			cmd.Printf("ingested %d, skipped %d, failed %d\n", ingested, skipped, failed)
+		},
+	}
+
+	cmd.Flags().StringVar(&pgurl, "db", "localhost:5432", "Postgres url.")
+	cmd.Flags().StringVar(&keyringPath, "keyring", "", "Keyring file encrypting personal data of candidates, none if empty.")
+	cmd.Flags().StringVar(&blobDir, "blob-dir", "blobs", "Directory of attachment contents.")
+	cmd.Flags().StringVar(
+		&s3Endpoint,
+		"s3-endpoint",
+		"",
+		"S3 compatible storage of attachment contents used instead of the directory, "+
+			"keys are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.",
+	)
+	cmd.Flags().StringVar(&s3Bucket, "s3-bucket", "hr", "S3 bucket of attachment contents.")
+	cmd.Flags().StringVar(&s3Region, "s3-region", "", "S3 region, us-east-1 if empty.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only print parsed messages without saving them.")
+
+	return cmd
+}
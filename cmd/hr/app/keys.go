@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"gpb.ru/hr/internal/hr/repos/postgres"
+	"gpb.ru/hr/pkg/keyring"
+)
+
+func Keys() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage keys encrypting personal data of candidates.",
+	}
+	cmd.AddCommand(addKey())
+	cmd.AddCommand(rotateKeys())
+	return cmd
+}
+
+func addKey() *cobra.Command {
+	path := ""
+
+	cmd := &cobra.Command{
+		Use:   "add [id]",
+		Short: "Add a new key to the keyring file and make it primary.",
+		Long: "Add a new key to the keyring file and make it primary, the file is " +
+			"created if it does not exist. Restart servers with the new keyring " +
+			"and run keys rotate to re-encrypt existing data.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := keyring.AddKey(path, args[0])
+			if err != nil {
+				log.Printf("[error] %s", err)
+				return
+			}
+			cmd.Printf("key %s added to %s\n", args[0], path)
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "keyring", "keyring.yaml", "Keyring file.")
+
+	return cmd
+}
+
+func rotateKeys() *cobra.Command {
+	pgurl := ""
+	path := ""
+
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Re-encrypt personal data with the primary key of the keyring.",
+		Long: "Re-encrypt phones, emails and birth dates of all candidates, their " +
+			"resumes and mail with the primary key and rebuild blind indexes of " +
+			"the candidates. Run it after adding a key and after setting up the " +
+			"keyring for existing unencrypted data. Old keys may be removed from " +
+			"the keyring once it succeeds.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			keys, err := keyring.Load(path)
+			if err != nil {
+				log.Printf("[error] keyring error: %s", err)
+				return
+			}
+			pg, err := postgres.New(pgurl, keys)
+			if err != nil {
+				log.Printf("[error] database connection error: %s", err)
+				return
+			}
+			defer pg.Close(context.Background())
+
+			rotated, err := pg.RotateKeys(cmd.Context())
+			if err != nil {
+				// Old keys are still in use, so the failure must not pass
+				// for success in scripts removing them afterwards.
+				log.Printf("[error] rotating keys after %d rows: %s", rotated, err)
+				pg.Close(context.Background())
+				os.Exit(1)
+			}
+			cmd.Printf("re-encrypted %d rows with key %s\n", rotated, keys.Primary())
+		},
+	}
+
+	cmd.Flags().StringVar(&pgurl, "db", "localhost:5432", "Postgres url.")
+	cmd.Flags().StringVar(&path, "keyring", "keyring.yaml", "Keyring file.")
+
+	return cmd
+}
+
+// loadKeyring reads the keyring file, no file means no encryption.
+func loadKeyring(path string) (*keyring.Keyring, error) {
+	if path == "" {
+		log.Printf("[info] no keyring, personal data of candidates is stored unencrypted")
+		return nil, nil
+	}
+	return keyring.Load(path)
+}
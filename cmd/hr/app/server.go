@@ -3,55 +3,403 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v3"
 
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/events"
+	"gpb.ru/hr/internal/hr/letters"
+	"gpb.ru/hr/internal/hr/notify"
+	"gpb.ru/hr/internal/hr/privacy"
+	"gpb.ru/hr/internal/hr/repos"
 	"gpb.ru/hr/internal/hr/repos/postgres"
 	"gpb.ru/hr/internal/hr/services"
+	"gpb.ru/hr/internal/hr/vacancies"
+	"gpb.ru/hr/internal/hr/webhooks"
+	"gpb.ru/hr/pkg/blob"
 )
 
 func Server() *cobra.Command {
 	pgurl := ""
+	grpcAddr := ""
+	offerChain := []string{}
+	requisitionChain := []string{}
+	requireRequisition := false
+	admins := []string{}
+	letterPath := ""
+	blobDir := ""
+	s3Endpoint := ""
+	s3Bucket := ""
+	s3Region := ""
+	attachmentLimit := int64(0)
+	keyringPath := ""
+	ratesPath := ""
+	feed := vacancies.FeedOptions{}
+	careersLimit := 0
+	trustedProxies := []string{}
+	smtpConfig := notify.SMTP{}
+	mailFrom := ""
+	mailCompany := ""
+	mailLocale := ""
+	mailTemplates := ""
+	mailTimezone := ""
+	interviewReminder := time.Duration(0)
 
 	cmd := &cobra.Command{
 		Use:   "serve [address]",
 		Short: "Run HR API server on the given address.",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			repos, err := postgres.New(pgurl)
+			keys, err := loadKeyring(keyringPath)
+			if err != nil {
+				log.Printf("[error] keyring error: %s", err)
+				return
+			}
+			pg, err := postgres.New(pgurl, keys)
 			if err != nil {
 				log.Printf("[error] database connection error: %s", err)
 				return
 			}
-			server := services.NewServer(args[0], repos.Candidate, repos.Vacancy)
+			server := services.NewServer(args[0], pg.Repos)
+
+			chain, err := parseApprovalChain(offerChain)
+			if err != nil {
+				log.Printf("[error] offer chain error: %s", err)
+				return
+			}
+			server.SetOfferChain(chain)
+			chain, err = parseApprovalChain(requisitionChain)
+			if err != nil {
+				log.Printf("[error] requisition chain error: %s", err)
+				return
+			}
+			server.SetRequisitionChain(chain)
+			server.SetRequisitionsRequired(requireRequisition)
+			server.SetAdmins(admins)
+			if letterPath != "" {
+				data, err := os.ReadFile(letterPath)
+				if err != nil {
+					log.Printf("[error] offer letter template error: %s", err)
+					return
+				}
+				tmpl, err := letters.Parse(string(data))
+				if err != nil {
+					log.Printf("[error] offer letter template error: %s", err)
+					return
+				}
+				server.SetLetterTemplate(tmpl)
+			}
+
+			blobs, err := blobStore(blobDir, s3Endpoint, s3Bucket, s3Region)
+			if err != nil {
+				log.Printf("[error] attachment storage error: %s", err)
+				return
+			}
+			server.SetBlobStore(blobs)
+			rates, err := loadExchangeRates(ratesPath)
+			if err != nil {
+				log.Printf("[error] exchange rates error: %s", err)
+				return
+			}
+			server.SetExchangeRates(rates)
+			feed.Currency = rates.Base
+			server.SetFeedOptions(feed)
+			if careersLimit > 0 {
+				server.SetCareersLimit(careersLimit)
+			}
+			err = server.SetTrustedProxies(trustedProxies)
+			if err != nil {
+				log.Printf("[error] trusted proxies error: %s", err)
+				return
+			}
+			if attachmentLimit > 0 {
+				server.SetAttachmentLimit(attachmentLimit)
+			}
 
-			done := make(chan struct{})
+			var notifier *notify.Notifier
+			if smtpConfig.Addr != "" {
+				location, err := time.LoadLocation(mailTimezone)
+				if err != nil {
+					log.Printf("[error] mail timezone error: %s", err)
+					return
+				}
+				templates, err := notify.LoadTemplates(mailTemplates, location)
+				if err != nil {
+					log.Printf("[error] mail templates error: %s", err)
+					return
+				}
+				if !templates.Has(mailLocale) {
+					log.Printf("[error] mail templates error: %s: %s", notify.ErrUnknownLocale, mailLocale)
+					return
+				}
+				notifier = notify.New(pg.Repos, templates, mailFrom)
+				notifier.Company = mailCompany
+				if notifier.Company == "" {
+					notifier.Company = feed.Company
+				}
+				notifier.Locale = mailLocale
+				notifier.Reminder = interviewReminder
+				server.SetNotifier(notifier)
+			}
+
+			done := make(chan struct{}, 2)
 			go func() {
-				defer close(done)
+				defer func() { done <- struct{}{} }()
 				err := server.Run()
 				if err != nil && !errors.Is(err, http.ErrServerClosed) {
 					log.Printf("[error] server running error: %s", err)
 				}
 			}()
 
+			var grpcServer *services.GRPCServer
+			if grpcAddr != "" {
+				grpcServer = services.NewGRPCServer(grpcAddr, pg.Repos)
+				grpcServer.SetRequisitionsRequired(requireRequisition)
+				grpcServer.SetAdmins(admins)
+				grpcServer.SetExchangeRates(rates)
+				go func() {
+					defer func() { done <- struct{}{} }()
+					err := grpcServer.Run()
+					if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+						log.Printf("[error] grpc server running error: %s", err)
+					}
+				}()
+			}
+
+			relayCtx, stopRelay := context.WithCancel(cmd.Context())
+			defer stopRelay()
+			relay := events.NewRelay(pg.Outbox, time.Second)
+			relay.Subscribe("log", events.Log)
+			relay.Subscribe("webhooks", webhooks.NewDispatcher(pg.Webhook, pg.Delivery))
+			if notifier != nil {
+				relay.Subscribe("notify", notifier)
+			}
+			go func() {
+				err := relay.Run(relayCtx)
+				if err != nil && !errors.Is(err, context.Canceled) {
+					log.Printf("[error] event relay error: %s", err)
+				}
+			}()
+
+			go func() {
+				for relayCtx.Err() == nil {
+					err := pg.ListenOutbox(relayCtx, server.Hub().Notify)
+					if err != nil && !errors.Is(err, context.Canceled) {
+						log.Printf("[error] outbox listener error: %s", err)
+						time.Sleep(time.Second)
+					}
+				}
+			}()
+
+			sender := webhooks.NewSender(pg.Webhook, pg.Delivery, nil)
+			go func() {
+				err := sender.Run(relayCtx)
+				if err != nil && !errors.Is(err, context.Canceled) {
+					log.Printf("[error] webhook sender error: %s", err)
+				}
+			}()
+
+			if notifier != nil {
+				smtpConfig.Password = os.Getenv("SMTP_PASSWORD")
+				mailer := notify.NewSender(pg.Message, smtpConfig)
+				go func() {
+					err := mailer.Run(relayCtx)
+					if err != nil && !errors.Is(err, context.Canceled) {
+						log.Printf("[error] mail sender error: %s", err)
+					}
+				}()
+				go func() {
+					err := notifier.Run(relayCtx)
+					if err != nil && !errors.Is(err, context.Canceled) {
+						log.Printf("[error] interview reminder error: %s", err)
+					}
+				}()
+			}
+
+			eraser := privacy.New(pg.Repos, blobs)
+			go func() {
+				err := eraser.Run(relayCtx)
+				if err != nil && !errors.Is(err, context.Canceled) {
+					log.Printf("[error] consent expiry sweeper error: %s", err)
+				}
+			}()
+
 			select {
 			case <-cmd.Context().Done():
-				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-				defer cancel()
+			case <-done:
+			}
+			stopRelay()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
 
-				err := server.Close(ctx)
+			err = server.Close(ctx)
+			if err != nil {
+				log.Printf("[error] server shutdown error: %s", err)
+			}
+			if grpcServer != nil {
+				err = grpcServer.Close(ctx)
 				if err != nil {
-					log.Printf("[error] server shutdown error: %s", err)
+					log.Printf("[error] grpc server shutdown error: %s", err)
 				}
-			case <-done:
 			}
 		},
 	}
 
 	cmd.Flags().StringVar(&pgurl, "db", "localhost:5432", "Postgres url.")
+	cmd.Flags().StringVar(&keyringPath, "keyring", "", "Keyring file encrypting personal data of candidates, none if empty.")
+	cmd.Flags().StringVar(&grpcAddr, "grpc", ":9090", "gRPC server address, empty to disable.")
+	cmd.Flags().StringSliceVar(
+		&offerChain,
+		"offer-chain",
+		nil,
+		"Default offer approval chain as role=email pairs in order of decision.",
+	)
+	cmd.Flags().StringSliceVar(
+		&requisitionChain,
+		"requisition-chain",
+		nil,
+		"Requisition approval chain as role=email pairs in order of decision, requisitions are approved right away if empty.",
+	)
+	cmd.Flags().BoolVar(
+		&requireRequisition,
+		"require-requisition",
+		false,
+		"Keep vacancies without an approved requisition from becoming active.",
+	)
+	cmd.Flags().StringSliceVar(&admins, "admins", nil, "Emails of users allowed to manage webhooks, merge catalog skills and set managers of departments.")
+	cmd.Flags().StringVar(&letterPath, "offer-template", "", "Offer letter template file.")
+	cmd.Flags().StringVar(&blobDir, "blob-dir", "blobs", "Directory of attachment contents.")
+	cmd.Flags().StringVar(
+		&s3Endpoint,
+		"s3-endpoint",
+		"",
+		"S3 compatible storage of attachment contents used instead of the directory, "+
+			"keys are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.",
+	)
+	cmd.Flags().StringVar(&s3Bucket, "s3-bucket", "hr", "S3 bucket of attachment contents.")
+	cmd.Flags().StringVar(&s3Region, "s3-region", "", "S3 region, us-east-1 if empty.")
+	cmd.Flags().Int64Var(&attachmentLimit, "attachment-limit", 0, "Maximum attachment size in bytes, 20 MiB if zero.")
+	cmd.Flags().StringVar(
+		&ratesPath,
+		"exchange-rates",
+		"",
+		"YAML file of the base currency, exchange rates and income tax salaries are compared with, rubles only if empty.",
+	)
+	cmd.Flags().IntVar(&careersLimit, "careers-limit", 0, "Applications a client address may send per hour, 10 if zero.")
+	cmd.Flags().StringSliceVar(
+		&trustedProxies,
+		"trusted-proxies",
+		nil,
+		"Addresses or CIDR ranges of proxies whose X-Forwarded-For tells client addresses of the careers site.",
+	)
+	cmd.Flags().StringVar(&feed.Company, "feed-company", "", "Employer name in vacancy feeds.")
+	cmd.Flags().StringVar(&feed.Country, "feed-country", "RU", "ISO 3166-1 country code of vacancy areas in feeds.")
+	cmd.Flags().StringVar(
+		&feed.URL,
+		"feed-url",
+		"",
+		"Vacancy page on the careers site with {id} standing for the vacancy ID, no links in feeds if empty.",
+	)
+	cmd.Flags().StringVar(
+		&smtpConfig.Addr,
+		"smtp-addr",
+		"",
+		"SMTP server host:port sending email notifications, none are sent if empty.",
+	)
+	cmd.Flags().StringVar(
+		&smtpConfig.Username,
+		"smtp-user",
+		"",
+		"SMTP user, the password is read from SMTP_PASSWORD, no authentication if empty.",
+	)
+	cmd.Flags().StringVar(&mailFrom, "mail-from", "hr@localhost", "Sender address of email notifications.")
+	cmd.Flags().StringVar(&mailCompany, "mail-company", "", "Employer name in email notifications, the feed one if empty.")
+	cmd.Flags().StringVar(&mailLocale, "mail-locale", notify.LocaleRU, "Locale of email notifications unless the candidate speaks English only.")
+	cmd.Flags().StringVar(
+		&mailTemplates,
+		"mail-templates",
+		"",
+		"Directory of <locale>.tmpl files redefining built in email notifications.",
+	)
+	cmd.Flags().StringVar(&mailTimezone, "mail-timezone", "Europe/Moscow", "Time zone of dates in email notifications.")
+	cmd.Flags().DurationVar(
+		&interviewReminder,
+		"interview-reminder",
+		time.Hour,
+		"How long before an interview interviewers are reminded of it, no reminders if zero.",
+	)
 
 	return cmd
 }
+
+// blobStore returns the S3 store if the endpoint is set and the local one
+// otherwise.
+func blobStore(dir, endpoint, bucket, region string) (repos.BlobStore, error) {
+	if endpoint != "" {
+		return blob.NewS3(
+			endpoint,
+			bucket,
+			region,
+			os.Getenv("AWS_ACCESS_KEY_ID"),
+			os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		)
+	}
+	return blob.NewFS(dir)
+}
+
+var errInvalidApprover = errors.New("approver must be given as role=email")
+
+// parseApprovalChain parses approval chain like
+// "hiring manager=lead@example.com,finance=cfo@example.com".
+func parseApprovalChain(values []string) ([]entities.Approval, error) {
+	chain := make([]entities.Approval, 0, len(values))
+	for _, v := range values {
+		role, approver, ok := strings.Cut(v, "=")
+		if !ok || approver == "" {
+			return nil, fmt.Errorf("%w: %q", errInvalidApprover, v)
+		}
+		chain = append(chain, entities.Approval{
+			Role:     strings.TrimSpace(role),
+			Approver: strings.TrimSpace(approver),
+		})
+	}
+	return chain, nil
+}
+
+// loadExchangeRates reads exchange rates like
+//
+//	base: RUB
+//	rates:
+//	  USD: 90.5
+//	  EUR: 98
+//	tax: 13
+//
+// from the file, the default ones if the path is empty.
+func loadExchangeRates(path string) (*entities.ExchangeRates, error) {
+	if path == "" {
+		return entities.DefaultExchangeRates(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rates entities.ExchangeRates
+	err = yaml.Unmarshal(data, &rates)
+	if err != nil {
+		return nil, err
+	}
+	err = rates.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	return &rates, nil
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/internal/hr/repos/postgres"
+	"gpb.ru/hr/internal/hr/requisitions"
+	"gpb.ru/hr/internal/hr/vacancies"
+)
+
+func Vacancies() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vacancies",
+		Short: "Exchange vacancies with CSV and XLSX tables.",
+	}
+	cmd.AddCommand(exportVacancies())
+	cmd.AddCommand(importVacancies())
+	return cmd
+}
+
+func exportVacancies() *cobra.Command {
+	pgurl := ""
+	format := ""
+
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Export vacancies to a table, - writes stdout.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if format != vacancies.FormatCSV && format != vacancies.FormatXLSX {
+				log.Printf("[error] %s", vacancies.ErrInvalidFormat)
+				return
+			}
+
+			pg, err := postgres.New(pgurl, nil)
+			if err != nil {
+				log.Printf("[error] database connection error: %s", err)
+				return
+			}
+			defer pg.Close(context.Background())
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			items, err := pg.Vacancy.List(ctx)
+			if err != nil {
+				log.Printf("[error] listing vacancies: %s", err)
+				return
+			}
+
+			var output io.Writer = os.Stdout
+			if args[0] != "-" {
+				file, err := os.Create(args[0])
+				if err != nil {
+					log.Printf("[error] %s", err)
+					return
+				}
+				defer file.Close()
+				output = file
+			}
+			w := bufio.NewWriter(output)
+			err = vacancies.Write(w, format, items)
+			if err == nil {
+				err = w.Flush()
+			}
+			if err != nil {
+				log.Printf("[error] writing vacancies: %s", err)
+				return
+			}
+			if args[0] != "-" {
+				cmd.Printf("exported %d\n", len(items))
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&pgurl, "db", "localhost:5432", "Postgres url.")
+	cmd.Flags().StringVar(&format, "format", vacancies.FormatCSV, "Table format: csv or xlsx.")
+
+	return cmd
+}
+
+func importVacancies() *cobra.Command {
+	pgurl := ""
+	format := ""
+	dryRun := false
+
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import vacancies from a table, - reads stdin.",
+		Long: "Import vacancies from a CSV or XLSX table with the columns of the " +
+			"export. Rows with id update vacancies, the others create them. " +
+			"Nothing is saved if any row fails.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var input io.Reader = os.Stdin
+			if args[0] != "-" {
+				file, err := os.Open(args[0])
+				if err != nil {
+					log.Printf("[error] %s", err)
+					return
+				}
+				defer file.Close()
+				input = file
+			}
+			data, err := io.ReadAll(input)
+			if err != nil {
+				log.Printf("[error] reading vacancies: %s", err)
+				return
+			}
+
+			tableFormat := format
+			if tableFormat == "" {
+				tableFormat = vacancies.Detect(data)
+			}
+			rows, err := vacancies.Read(data, tableFormat)
+			if err != nil {
+				log.Printf("[error] reading vacancies: %s", err)
+				return
+			}
+
+			pg, err := postgres.New(pgurl, nil)
+			if err != nil {
+				log.Printf("[error] database connection error: %s", err)
+				return
+			}
+			defer pg.Close(context.Background())
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Minute)
+			defer cancel()
+			skills, err := pg.Skill.List(ctx, repos.SkillFilter{})
+			if err != nil {
+				log.Printf("[error] loading skill catalog: %s", err)
+				return
+			}
+			items, err := pg.Dictionary.List(ctx, repos.DictionaryFilter{})
+			if err != nil {
+				log.Printf("[error] loading dictionaries: %s", err)
+				return
+			}
+			result, err := vacancies.Import(
+				ctx,
+				pg.Vacancy,
+				entities.NewSkillIndex(skills),
+				entities.NewDictionaries(items),
+				requisitions.New(pg.Repos),
+				rows,
+				dryRun,
+			)
+			if err != nil {
+				log.Printf("[error] importing vacancies: %s", err)
+				return
+			}
+
+			for _, row := range result.Rows {
+				if row.Error != "" {
+					cmd.Printf("row %d: error: %s\n", row.Line, row.Error)
+					continue
+				}
+				cmd.Printf("row %d: %s %s\n", row.Line, row.Action, row.VacancyID)
+			}
+			cmd.Printf("created %d, updated %d, failed %d", result.Created, result.Updated, result.Failed)
+			if !result.Saved() {
+				cmd.Printf(", nothing saved")
+			}
+			cmd.Printf("\n")
+		},
+	}
+
+	cmd.Flags().StringVar(&pgurl, "db", "localhost:5432", "Postgres url.")
+	cmd.Flags().StringVar(&format, "format", "", "Table format: csv or xlsx, detected if empty.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only validate rows without saving them.")
+
+	return cmd
+}
@@ -7,6 +7,8 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Time zones of email notifications are known without system tzdata.
+	_ "time/tzdata"
 
 	"gpb.ru/hr/cmd/hr/app"
 )
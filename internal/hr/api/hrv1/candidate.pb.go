@@ -0,0 +1,961 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: hr/v1/candidate.proto
+
+package hrv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Gender int32
+
+const (
+	Gender_GENDER_UNSPECIFIED Gender = 0
+	Gender_GENDER_MALE        Gender = 1
+	Gender_GENDER_FEMALE      Gender = 2
+)
+
+// Enum value maps for Gender.
+var (
+	Gender_name = map[int32]string{
+		0: "GENDER_UNSPECIFIED",
+		1: "GENDER_MALE",
+		2: "GENDER_FEMALE",
+	}
+	Gender_value = map[string]int32{
+		"GENDER_UNSPECIFIED": 0,
+		"GENDER_MALE":        1,
+		"GENDER_FEMALE":      2,
+	}
+)
+
+func (x Gender) Enum() *Gender {
+	p := new(Gender)
+	*p = x
+	return p
+}
+
+func (x Gender) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Gender) Descriptor() protoreflect.EnumDescriptor {
+	return file_hr_v1_candidate_proto_enumTypes[0].Descriptor()
+}
+
+func (Gender) Type() protoreflect.EnumType {
+	return &file_hr_v1_candidate_proto_enumTypes[0]
+}
+
+func (x Gender) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Gender.Descriptor instead.
+func (Gender) EnumDescriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{0}
+}
+
+type EducationLevel int32
+
+const (
+	EducationLevel_EDUCATION_LEVEL_UNSPECIFIED       EducationLevel = 0
+	EducationLevel_EDUCATION_LEVEL_SECONDARY         EducationLevel = 1
+	EducationLevel_EDUCATION_LEVEL_SPECIAL_SECONDARY EducationLevel = 2
+	EducationLevel_EDUCATION_LEVEL_UNFINISHED_HIGHER EducationLevel = 3
+	EducationLevel_EDUCATION_LEVEL_HIGHER            EducationLevel = 4
+	EducationLevel_EDUCATION_LEVEL_BACHELOR          EducationLevel = 5
+	EducationLevel_EDUCATION_LEVEL_MASTER            EducationLevel = 6
+	EducationLevel_EDUCATION_LEVEL_CANDIDATE         EducationLevel = 7
+	EducationLevel_EDUCATION_LEVEL_DOCTOR            EducationLevel = 8
+)
+
+// Enum value maps for EducationLevel.
+var (
+	EducationLevel_name = map[int32]string{
+		0: "EDUCATION_LEVEL_UNSPECIFIED",
+		1: "EDUCATION_LEVEL_SECONDARY",
+		2: "EDUCATION_LEVEL_SPECIAL_SECONDARY",
+		3: "EDUCATION_LEVEL_UNFINISHED_HIGHER",
+		4: "EDUCATION_LEVEL_HIGHER",
+		5: "EDUCATION_LEVEL_BACHELOR",
+		6: "EDUCATION_LEVEL_MASTER",
+		7: "EDUCATION_LEVEL_CANDIDATE",
+		8: "EDUCATION_LEVEL_DOCTOR",
+	}
+	EducationLevel_value = map[string]int32{
+		"EDUCATION_LEVEL_UNSPECIFIED":       0,
+		"EDUCATION_LEVEL_SECONDARY":         1,
+		"EDUCATION_LEVEL_SPECIAL_SECONDARY": 2,
+		"EDUCATION_LEVEL_UNFINISHED_HIGHER": 3,
+		"EDUCATION_LEVEL_HIGHER":            4,
+		"EDUCATION_LEVEL_BACHELOR":          5,
+		"EDUCATION_LEVEL_MASTER":            6,
+		"EDUCATION_LEVEL_CANDIDATE":         7,
+		"EDUCATION_LEVEL_DOCTOR":            8,
+	}
+)
+
+func (x EducationLevel) Enum() *EducationLevel {
+	p := new(EducationLevel)
+	*p = x
+	return p
+}
+
+func (x EducationLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EducationLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_hr_v1_candidate_proto_enumTypes[1].Descriptor()
+}
+
+func (EducationLevel) Type() protoreflect.EnumType {
+	return &file_hr_v1_candidate_proto_enumTypes[1]
+}
+
+func (x EducationLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EducationLevel.Descriptor instead.
+func (EducationLevel) EnumDescriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{1}
+}
+
+type Education struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Year          uint32                 `protobuf:"varint,2,opt,name=year,proto3" json:"year,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Education) Reset() {
+	*x = Education{}
+	mi := &file_hr_v1_candidate_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Education) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Education) ProtoMessage() {}
+
+func (x *Education) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_candidate_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Education.ProtoReflect.Descriptor instead.
+func (*Education) Descriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Education) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Education) GetYear() uint32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+type Experience struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Experience) Reset() {
+	*x = Experience{}
+	mi := &file_hr_v1_candidate_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Experience) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Experience) ProtoMessage() {}
+
+func (x *Experience) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_candidate_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Experience.ProtoReflect.Descriptor instead.
+func (*Experience) Descriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Experience) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Experience) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Experience) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Experience) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type Candidate struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Phone          string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email          string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Specialization string                 `protobuf:"bytes,5,opt,name=specialization,proto3" json:"specialization,omitempty"`
+	Gender         Gender                 `protobuf:"varint,6,opt,name=gender,proto3,enum=hr.v1.Gender" json:"gender,omitempty"`
+	BirthDate      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=birth_date,json=birthDate,proto3" json:"birth_date,omitempty"`
+	Area           string                 `protobuf:"bytes,8,opt,name=area,proto3" json:"area,omitempty"`
+	// Expected net salary.
+	Salary         uint32                 `protobuf:"varint,9,opt,name=salary,proto3" json:"salary,omitempty"`
+	EducationLevel EducationLevel         `protobuf:"varint,10,opt,name=education_level,json=educationLevel,proto3,enum=hr.v1.EducationLevel" json:"education_level,omitempty"`
+	Education      []*Education           `protobuf:"bytes,11,rep,name=education,proto3" json:"education,omitempty"`
+	Experience     []*Experience          `protobuf:"bytes,12,rep,name=experience,proto3" json:"experience,omitempty"`
+	Languages      []string               `protobuf:"bytes,13,rep,name=languages,proto3" json:"languages,omitempty"`
+	Skills         []string               `protobuf:"bytes,14,rep,name=skills,proto3" json:"skills,omitempty"`
+	Created        *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created,proto3" json:"created,omitempty"`
+	Updated        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated,proto3" json:"updated,omitempty"`
+	// ISO 4217 code of the salary, the base currency of exchange rates if empty.
+	// Currencies missing from the exchange rates are rejected.
+	SalaryCurrency string `protobuf:"bytes,17,opt,name=salary_currency,json=salaryCurrency,proto3" json:"salary_currency,omitempty"`
+	// Period of the salary, a month if unspecified.
+	SalaryPeriod  SalaryPeriod `protobuf:"varint,18,opt,name=salary_period,json=salaryPeriod,proto3,enum=hr.v1.SalaryPeriod" json:"salary_period,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candidate) Reset() {
+	*x = Candidate{}
+	mi := &file_hr_v1_candidate_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candidate) ProtoMessage() {}
+
+func (x *Candidate) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_candidate_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candidate.ProtoReflect.Descriptor instead.
+func (*Candidate) Descriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Candidate) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Candidate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Candidate) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Candidate) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Candidate) GetSpecialization() string {
+	if x != nil {
+		return x.Specialization
+	}
+	return ""
+}
+
+func (x *Candidate) GetGender() Gender {
+	if x != nil {
+		return x.Gender
+	}
+	return Gender_GENDER_UNSPECIFIED
+}
+
+func (x *Candidate) GetBirthDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BirthDate
+	}
+	return nil
+}
+
+func (x *Candidate) GetArea() string {
+	if x != nil {
+		return x.Area
+	}
+	return ""
+}
+
+func (x *Candidate) GetSalary() uint32 {
+	if x != nil {
+		return x.Salary
+	}
+	return 0
+}
+
+func (x *Candidate) GetEducationLevel() EducationLevel {
+	if x != nil {
+		return x.EducationLevel
+	}
+	return EducationLevel_EDUCATION_LEVEL_UNSPECIFIED
+}
+
+func (x *Candidate) GetEducation() []*Education {
+	if x != nil {
+		return x.Education
+	}
+	return nil
+}
+
+func (x *Candidate) GetExperience() []*Experience {
+	if x != nil {
+		return x.Experience
+	}
+	return nil
+}
+
+func (x *Candidate) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *Candidate) GetSkills() []string {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+func (x *Candidate) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Candidate) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *Candidate) GetSalaryCurrency() string {
+	if x != nil {
+		return x.SalaryCurrency
+	}
+	return ""
+}
+
+func (x *Candidate) GetSalaryPeriod() SalaryPeriod {
+	if x != nil {
+		return x.SalaryPeriod
+	}
+	return SalaryPeriod_SALARY_PERIOD_UNSPECIFIED
+}
+
+type ListCandidatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional vacancy to filter candidates by.
+	VacancyId     string `protobuf:"bytes,1,opt,name=vacancy_id,json=vacancyId,proto3" json:"vacancy_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCandidatesRequest) Reset() {
+	*x = ListCandidatesRequest{}
+	mi := &file_hr_v1_candidate_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCandidatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCandidatesRequest) ProtoMessage() {}
+
+func (x *ListCandidatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_candidate_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCandidatesRequest.ProtoReflect.Descriptor instead.
+func (*ListCandidatesRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListCandidatesRequest) GetVacancyId() string {
+	if x != nil {
+		return x.VacancyId
+	}
+	return ""
+}
+
+type ListCandidatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candidates    []*Candidate           `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCandidatesResponse) Reset() {
+	*x = ListCandidatesResponse{}
+	mi := &file_hr_v1_candidate_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCandidatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCandidatesResponse) ProtoMessage() {}
+
+func (x *ListCandidatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_candidate_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCandidatesResponse.ProtoReflect.Descriptor instead.
+func (*ListCandidatesResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListCandidatesResponse) GetCandidates() []*Candidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+type GetCandidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCandidateRequest) Reset() {
+	*x = GetCandidateRequest{}
+	mi := &file_hr_v1_candidate_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCandidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCandidateRequest) ProtoMessage() {}
+
+func (x *GetCandidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_candidate_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCandidateRequest.ProtoReflect.Descriptor instead.
+func (*GetCandidateRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCandidateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCandidateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candidate     *Candidate             `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCandidateResponse) Reset() {
+	*x = GetCandidateResponse{}
+	mi := &file_hr_v1_candidate_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCandidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCandidateResponse) ProtoMessage() {}
+
+func (x *GetCandidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_candidate_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCandidateResponse.ProtoReflect.Descriptor instead.
+func (*GetCandidateResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCandidateResponse) GetCandidate() *Candidate {
+	if x != nil {
+		return x.Candidate
+	}
+	return nil
+}
+
+type CreateCandidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candidate     *Candidate             `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCandidateRequest) Reset() {
+	*x = CreateCandidateRequest{}
+	mi := &file_hr_v1_candidate_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCandidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCandidateRequest) ProtoMessage() {}
+
+func (x *CreateCandidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_candidate_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCandidateRequest.ProtoReflect.Descriptor instead.
+func (*CreateCandidateRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateCandidateRequest) GetCandidate() *Candidate {
+	if x != nil {
+		return x.Candidate
+	}
+	return nil
+}
+
+type CreateCandidateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candidate     *Candidate             `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCandidateResponse) Reset() {
+	*x = CreateCandidateResponse{}
+	mi := &file_hr_v1_candidate_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCandidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCandidateResponse) ProtoMessage() {}
+
+func (x *CreateCandidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_candidate_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCandidateResponse.ProtoReflect.Descriptor instead.
+func (*CreateCandidateResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateCandidateResponse) GetCandidate() *Candidate {
+	if x != nil {
+		return x.Candidate
+	}
+	return nil
+}
+
+type UpdateCandidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candidate     *Candidate             `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCandidateRequest) Reset() {
+	*x = UpdateCandidateRequest{}
+	mi := &file_hr_v1_candidate_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCandidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCandidateRequest) ProtoMessage() {}
+
+func (x *UpdateCandidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_candidate_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCandidateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCandidateRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateCandidateRequest) GetCandidate() *Candidate {
+	if x != nil {
+		return x.Candidate
+	}
+	return nil
+}
+
+type UpdateCandidateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candidate     *Candidate             `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCandidateResponse) Reset() {
+	*x = UpdateCandidateResponse{}
+	mi := &file_hr_v1_candidate_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCandidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCandidateResponse) ProtoMessage() {}
+
+func (x *UpdateCandidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_candidate_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCandidateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCandidateResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_candidate_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateCandidateResponse) GetCandidate() *Candidate {
+	if x != nil {
+		return x.Candidate
+	}
+	return nil
+}
+
+var File_hr_v1_candidate_proto protoreflect.FileDescriptor
+
+const file_hr_v1_candidate_proto_rawDesc = "" +
+	"\n" +
+	"\x15hr/v1/candidate.proto\x12\x05hr.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x12hr/v1/salary.proto\"5\n" +
+	"\tEducation\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04year\x18\x02 \x01(\rR\x04year\"\xa4\x01\n" +
+	"\n" +
+	"Experience\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"\xb9\x05\n" +
+	"\tCandidate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12&\n" +
+	"\x0especialization\x18\x05 \x01(\tR\x0especialization\x12%\n" +
+	"\x06gender\x18\x06 \x01(\x0e2\r.hr.v1.GenderR\x06gender\x129\n" +
+	"\n" +
+	"birth_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tbirthDate\x12\x12\n" +
+	"\x04area\x18\b \x01(\tR\x04area\x12\x16\n" +
+	"\x06salary\x18\t \x01(\rR\x06salary\x12>\n" +
+	"\x0feducation_level\x18\n" +
+	" \x01(\x0e2\x15.hr.v1.EducationLevelR\x0eeducationLevel\x12.\n" +
+	"\teducation\x18\v \x03(\v2\x10.hr.v1.EducationR\teducation\x121\n" +
+	"\n" +
+	"experience\x18\f \x03(\v2\x11.hr.v1.ExperienceR\n" +
+	"experience\x12\x1c\n" +
+	"\tlanguages\x18\r \x03(\tR\tlanguages\x12\x16\n" +
+	"\x06skills\x18\x0e \x03(\tR\x06skills\x124\n" +
+	"\acreated\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x124\n" +
+	"\aupdated\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\aupdated\x12'\n" +
+	"\x0fsalary_currency\x18\x11 \x01(\tR\x0esalaryCurrency\x128\n" +
+	"\rsalary_period\x18\x12 \x01(\x0e2\x13.hr.v1.SalaryPeriodR\fsalaryPeriod\"6\n" +
+	"\x15ListCandidatesRequest\x12\x1d\n" +
+	"\n" +
+	"vacancy_id\x18\x01 \x01(\tR\tvacancyId\"J\n" +
+	"\x16ListCandidatesResponse\x120\n" +
+	"\n" +
+	"candidates\x18\x01 \x03(\v2\x10.hr.v1.CandidateR\n" +
+	"candidates\"%\n" +
+	"\x13GetCandidateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"F\n" +
+	"\x14GetCandidateResponse\x12.\n" +
+	"\tcandidate\x18\x01 \x01(\v2\x10.hr.v1.CandidateR\tcandidate\"H\n" +
+	"\x16CreateCandidateRequest\x12.\n" +
+	"\tcandidate\x18\x01 \x01(\v2\x10.hr.v1.CandidateR\tcandidate\"I\n" +
+	"\x17CreateCandidateResponse\x12.\n" +
+	"\tcandidate\x18\x01 \x01(\v2\x10.hr.v1.CandidateR\tcandidate\"H\n" +
+	"\x16UpdateCandidateRequest\x12.\n" +
+	"\tcandidate\x18\x01 \x01(\v2\x10.hr.v1.CandidateR\tcandidate\"I\n" +
+	"\x17UpdateCandidateResponse\x12.\n" +
+	"\tcandidate\x18\x01 \x01(\v2\x10.hr.v1.CandidateR\tcandidate*D\n" +
+	"\x06Gender\x12\x16\n" +
+	"\x12GENDER_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vGENDER_MALE\x10\x01\x12\x11\n" +
+	"\rGENDER_FEMALE\x10\x02*\xaf\x02\n" +
+	"\x0eEducationLevel\x12\x1f\n" +
+	"\x1bEDUCATION_LEVEL_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19EDUCATION_LEVEL_SECONDARY\x10\x01\x12%\n" +
+	"!EDUCATION_LEVEL_SPECIAL_SECONDARY\x10\x02\x12%\n" +
+	"!EDUCATION_LEVEL_UNFINISHED_HIGHER\x10\x03\x12\x1a\n" +
+	"\x16EDUCATION_LEVEL_HIGHER\x10\x04\x12\x1c\n" +
+	"\x18EDUCATION_LEVEL_BACHELOR\x10\x05\x12\x1a\n" +
+	"\x16EDUCATION_LEVEL_MASTER\x10\x06\x12\x1d\n" +
+	"\x19EDUCATION_LEVEL_CANDIDATE\x10\a\x12\x1a\n" +
+	"\x16EDUCATION_LEVEL_DOCTOR\x10\b2\xce\x02\n" +
+	"\x10CandidateService\x12M\n" +
+	"\x0eListCandidates\x12\x1c.hr.v1.ListCandidatesRequest\x1a\x1d.hr.v1.ListCandidatesResponse\x12G\n" +
+	"\fGetCandidate\x12\x1a.hr.v1.GetCandidateRequest\x1a\x1b.hr.v1.GetCandidateResponse\x12P\n" +
+	"\x0fCreateCandidate\x12\x1d.hr.v1.CreateCandidateRequest\x1a\x1e.hr.v1.CreateCandidateResponse\x12P\n" +
+	"\x0fUpdateCandidate\x12\x1d.hr.v1.UpdateCandidateRequest\x1a\x1e.hr.v1.UpdateCandidateResponseB%Z#gpb.ru/hr/internal/hr/api/hrv1;hrv1b\x06proto3"
+
+var (
+	file_hr_v1_candidate_proto_rawDescOnce sync.Once
+	file_hr_v1_candidate_proto_rawDescData []byte
+)
+
+func file_hr_v1_candidate_proto_rawDescGZIP() []byte {
+	file_hr_v1_candidate_proto_rawDescOnce.Do(func() {
+		file_hr_v1_candidate_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hr_v1_candidate_proto_rawDesc), len(file_hr_v1_candidate_proto_rawDesc)))
+	})
+	return file_hr_v1_candidate_proto_rawDescData
+}
+
+var file_hr_v1_candidate_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_hr_v1_candidate_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_hr_v1_candidate_proto_goTypes = []any{
+	(Gender)(0),                     // 0: hr.v1.Gender
+	(EducationLevel)(0),             // 1: hr.v1.EducationLevel
+	(*Education)(nil),               // 2: hr.v1.Education
+	(*Experience)(nil),              // 3: hr.v1.Experience
+	(*Candidate)(nil),               // 4: hr.v1.Candidate
+	(*ListCandidatesRequest)(nil),   // 5: hr.v1.ListCandidatesRequest
+	(*ListCandidatesResponse)(nil),  // 6: hr.v1.ListCandidatesResponse
+	(*GetCandidateRequest)(nil),     // 7: hr.v1.GetCandidateRequest
+	(*GetCandidateResponse)(nil),    // 8: hr.v1.GetCandidateResponse
+	(*CreateCandidateRequest)(nil),  // 9: hr.v1.CreateCandidateRequest
+	(*CreateCandidateResponse)(nil), // 10: hr.v1.CreateCandidateResponse
+	(*UpdateCandidateRequest)(nil),  // 11: hr.v1.UpdateCandidateRequest
+	(*UpdateCandidateResponse)(nil), // 12: hr.v1.UpdateCandidateResponse
+	(*timestamppb.Timestamp)(nil),   // 13: google.protobuf.Timestamp
+	(SalaryPeriod)(0),               // 14: hr.v1.SalaryPeriod
+}
+var file_hr_v1_candidate_proto_depIdxs = []int32{
+	13, // 0: hr.v1.Experience.start:type_name -> google.protobuf.Timestamp
+	13, // 1: hr.v1.Experience.end:type_name -> google.protobuf.Timestamp
+	0,  // 2: hr.v1.Candidate.gender:type_name -> hr.v1.Gender
+	13, // 3: hr.v1.Candidate.birth_date:type_name -> google.protobuf.Timestamp
+	1,  // 4: hr.v1.Candidate.education_level:type_name -> hr.v1.EducationLevel
+	2,  // 5: hr.v1.Candidate.education:type_name -> hr.v1.Education
+	3,  // 6: hr.v1.Candidate.experience:type_name -> hr.v1.Experience
+	13, // 7: hr.v1.Candidate.created:type_name -> google.protobuf.Timestamp
+	13, // 8: hr.v1.Candidate.updated:type_name -> google.protobuf.Timestamp
+	14, // 9: hr.v1.Candidate.salary_period:type_name -> hr.v1.SalaryPeriod
+	4,  // 10: hr.v1.ListCandidatesResponse.candidates:type_name -> hr.v1.Candidate
+	4,  // 11: hr.v1.GetCandidateResponse.candidate:type_name -> hr.v1.Candidate
+	4,  // 12: hr.v1.CreateCandidateRequest.candidate:type_name -> hr.v1.Candidate
+	4,  // 13: hr.v1.CreateCandidateResponse.candidate:type_name -> hr.v1.Candidate
+	4,  // 14: hr.v1.UpdateCandidateRequest.candidate:type_name -> hr.v1.Candidate
+	4,  // 15: hr.v1.UpdateCandidateResponse.candidate:type_name -> hr.v1.Candidate
+	5,  // 16: hr.v1.CandidateService.ListCandidates:input_type -> hr.v1.ListCandidatesRequest
+	7,  // 17: hr.v1.CandidateService.GetCandidate:input_type -> hr.v1.GetCandidateRequest
+	9,  // 18: hr.v1.CandidateService.CreateCandidate:input_type -> hr.v1.CreateCandidateRequest
+	11, // 19: hr.v1.CandidateService.UpdateCandidate:input_type -> hr.v1.UpdateCandidateRequest
+	6,  // 20: hr.v1.CandidateService.ListCandidates:output_type -> hr.v1.ListCandidatesResponse
+	8,  // 21: hr.v1.CandidateService.GetCandidate:output_type -> hr.v1.GetCandidateResponse
+	10, // 22: hr.v1.CandidateService.CreateCandidate:output_type -> hr.v1.CreateCandidateResponse
+	12, // 23: hr.v1.CandidateService.UpdateCandidate:output_type -> hr.v1.UpdateCandidateResponse
+	20, // [20:24] is the sub-list for method output_type
+	16, // [16:20] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_hr_v1_candidate_proto_init() }
+func file_hr_v1_candidate_proto_init() {
+	if File_hr_v1_candidate_proto != nil {
+		return
+	}
+	file_hr_v1_salary_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hr_v1_candidate_proto_rawDesc), len(file_hr_v1_candidate_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hr_v1_candidate_proto_goTypes,
+		DependencyIndexes: file_hr_v1_candidate_proto_depIdxs,
+		EnumInfos:         file_hr_v1_candidate_proto_enumTypes,
+		MessageInfos:      file_hr_v1_candidate_proto_msgTypes,
+	}.Build()
+	File_hr_v1_candidate_proto = out.File
+	file_hr_v1_candidate_proto_goTypes = nil
+	file_hr_v1_candidate_proto_depIdxs = nil
+}
@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: hr/v1/candidate.proto
+
+package hrv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CandidateService_ListCandidates_FullMethodName  = "/hr.v1.CandidateService/ListCandidates"
+	CandidateService_GetCandidate_FullMethodName    = "/hr.v1.CandidateService/GetCandidate"
+	CandidateService_CreateCandidate_FullMethodName = "/hr.v1.CandidateService/CreateCandidate"
+	CandidateService_UpdateCandidate_FullMethodName = "/hr.v1.CandidateService/UpdateCandidate"
+)
+
+// CandidateServiceClient is the client API for CandidateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CandidateService manages candidates.
+type CandidateServiceClient interface {
+	// ListCandidates returns candidates ordered by update time.
+	ListCandidates(ctx context.Context, in *ListCandidatesRequest, opts ...grpc.CallOption) (*ListCandidatesResponse, error)
+	// GetCandidate returns the candidate.
+	GetCandidate(ctx context.Context, in *GetCandidateRequest, opts ...grpc.CallOption) (*GetCandidateResponse, error)
+	// CreateCandidate creates candidate with the given properties.
+	CreateCandidate(ctx context.Context, in *CreateCandidateRequest, opts ...grpc.CallOption) (*CreateCandidateResponse, error)
+	// UpdateCandidate replaces properties of the given candidate.
+	UpdateCandidate(ctx context.Context, in *UpdateCandidateRequest, opts ...grpc.CallOption) (*UpdateCandidateResponse, error)
+}
+
+type candidateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCandidateServiceClient(cc grpc.ClientConnInterface) CandidateServiceClient {
+	return &candidateServiceClient{cc}
+}
+
+func (c *candidateServiceClient) ListCandidates(ctx context.Context, in *ListCandidatesRequest, opts ...grpc.CallOption) (*ListCandidatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCandidatesResponse)
+	err := c.cc.Invoke(ctx, CandidateService_ListCandidates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) GetCandidate(ctx context.Context, in *GetCandidateRequest, opts ...grpc.CallOption) (*GetCandidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCandidateResponse)
+	err := c.cc.Invoke(ctx, CandidateService_GetCandidate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) CreateCandidate(ctx context.Context, in *CreateCandidateRequest, opts ...grpc.CallOption) (*CreateCandidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCandidateResponse)
+	err := c.cc.Invoke(ctx, CandidateService_CreateCandidate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) UpdateCandidate(ctx context.Context, in *UpdateCandidateRequest, opts ...grpc.CallOption) (*UpdateCandidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCandidateResponse)
+	err := c.cc.Invoke(ctx, CandidateService_UpdateCandidate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CandidateServiceServer is the server API for CandidateService service.
+// All implementations must embed UnimplementedCandidateServiceServer
+// for forward compatibility.
+//
+// CandidateService manages candidates.
+type CandidateServiceServer interface {
+	// ListCandidates returns candidates ordered by update time.
+	ListCandidates(context.Context, *ListCandidatesRequest) (*ListCandidatesResponse, error)
+	// GetCandidate returns the candidate.
+	GetCandidate(context.Context, *GetCandidateRequest) (*GetCandidateResponse, error)
+	// CreateCandidate creates candidate with the given properties.
+	CreateCandidate(context.Context, *CreateCandidateRequest) (*CreateCandidateResponse, error)
+	// UpdateCandidate replaces properties of the given candidate.
+	UpdateCandidate(context.Context, *UpdateCandidateRequest) (*UpdateCandidateResponse, error)
+	mustEmbedUnimplementedCandidateServiceServer()
+}
+
+// UnimplementedCandidateServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCandidateServiceServer struct{}
+
+func (UnimplementedCandidateServiceServer) ListCandidates(context.Context, *ListCandidatesRequest) (*ListCandidatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCandidates not implemented")
+}
+func (UnimplementedCandidateServiceServer) GetCandidate(context.Context, *GetCandidateRequest) (*GetCandidateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) CreateCandidate(context.Context, *CreateCandidateRequest) (*CreateCandidateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) UpdateCandidate(context.Context, *UpdateCandidateRequest) (*UpdateCandidateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) mustEmbedUnimplementedCandidateServiceServer() {}
+func (UnimplementedCandidateServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeCandidateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CandidateServiceServer will
+// result in compilation errors.
+type UnsafeCandidateServiceServer interface {
+	mustEmbedUnimplementedCandidateServiceServer()
+}
+
+func RegisterCandidateServiceServer(s grpc.ServiceRegistrar, srv CandidateServiceServer) {
+	// If the following call panics, it indicates UnimplementedCandidateServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CandidateService_ServiceDesc, srv)
+}
+
+func _CandidateService_ListCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCandidatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).ListCandidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_ListCandidates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).ListCandidates(ctx, req.(*ListCandidatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_GetCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).GetCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_GetCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).GetCandidate(ctx, req.(*GetCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_CreateCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).CreateCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_CreateCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).CreateCandidate(ctx, req.(*CreateCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_UpdateCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).UpdateCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_UpdateCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).UpdateCandidate(ctx, req.(*UpdateCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CandidateService_ServiceDesc is the grpc.ServiceDesc for CandidateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CandidateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hr.v1.CandidateService",
+	HandlerType: (*CandidateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCandidates",
+			Handler:    _CandidateService_ListCandidates_Handler,
+		},
+		{
+			MethodName: "GetCandidate",
+			Handler:    _CandidateService_GetCandidate_Handler,
+		},
+		{
+			MethodName: "CreateCandidate",
+			Handler:    _CandidateService_CreateCandidate_Handler,
+		},
+		{
+			MethodName: "UpdateCandidate",
+			Handler:    _CandidateService_UpdateCandidate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hr/v1/candidate.proto",
+}
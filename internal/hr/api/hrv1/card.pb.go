@@ -0,0 +1,867 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: hr/v1/card.proto
+
+package hrv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CardStage int32
+
+const (
+	CardStage_CARD_STAGE_UNSPECIFIED CardStage = 0
+	CardStage_CARD_STAGE_NEW         CardStage = 1
+	CardStage_CARD_STAGE_SCREENING   CardStage = 2
+	CardStage_CARD_STAGE_INTERVIEW   CardStage = 3
+	CardStage_CARD_STAGE_OFFER       CardStage = 4
+	CardStage_CARD_STAGE_HIRED       CardStage = 5
+	CardStage_CARD_STAGE_REJECTED    CardStage = 6
+	CardStage_CARD_STAGE_INBOX       CardStage = 7
+)
+
+// Enum value maps for CardStage.
+var (
+	CardStage_name = map[int32]string{
+		0: "CARD_STAGE_UNSPECIFIED",
+		1: "CARD_STAGE_NEW",
+		2: "CARD_STAGE_SCREENING",
+		3: "CARD_STAGE_INTERVIEW",
+		4: "CARD_STAGE_OFFER",
+		5: "CARD_STAGE_HIRED",
+		6: "CARD_STAGE_REJECTED",
+		7: "CARD_STAGE_INBOX",
+	}
+	CardStage_value = map[string]int32{
+		"CARD_STAGE_UNSPECIFIED": 0,
+		"CARD_STAGE_NEW":         1,
+		"CARD_STAGE_SCREENING":   2,
+		"CARD_STAGE_INTERVIEW":   3,
+		"CARD_STAGE_OFFER":       4,
+		"CARD_STAGE_HIRED":       5,
+		"CARD_STAGE_REJECTED":    6,
+		"CARD_STAGE_INBOX":       7,
+	}
+)
+
+func (x CardStage) Enum() *CardStage {
+	p := new(CardStage)
+	*p = x
+	return p
+}
+
+func (x CardStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CardStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_hr_v1_card_proto_enumTypes[0].Descriptor()
+}
+
+func (CardStage) Type() protoreflect.EnumType {
+	return &file_hr_v1_card_proto_enumTypes[0]
+}
+
+func (x CardStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CardStage.Descriptor instead.
+func (CardStage) EnumDescriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{0}
+}
+
+type Comment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_hr_v1_card_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_card_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Comment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Comment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Comment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Comment) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type Card struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	VacancyId     string                 `protobuf:"bytes,2,opt,name=vacancy_id,json=vacancyId,proto3" json:"vacancy_id,omitempty"`
+	CandidateId   string                 `protobuf:"bytes,3,opt,name=candidate_id,json=candidateId,proto3" json:"candidate_id,omitempty"`
+	Stage         CardStage              `protobuf:"varint,4,opt,name=stage,proto3,enum=hr.v1.CardStage" json:"stage,omitempty"`
+	Comments      []*Comment             `protobuf:"bytes,5,rep,name=comments,proto3" json:"comments,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Card) Reset() {
+	*x = Card{}
+	mi := &file_hr_v1_card_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Card) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Card) ProtoMessage() {}
+
+func (x *Card) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_card_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Card.ProtoReflect.Descriptor instead.
+func (*Card) Descriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Card) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Card) GetVacancyId() string {
+	if x != nil {
+		return x.VacancyId
+	}
+	return ""
+}
+
+func (x *Card) GetCandidateId() string {
+	if x != nil {
+		return x.CandidateId
+	}
+	return ""
+}
+
+func (x *Card) GetStage() CardStage {
+	if x != nil {
+		return x.Stage
+	}
+	return CardStage_CARD_STAGE_UNSPECIFIED
+}
+
+func (x *Card) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *Card) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Card) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+type ListCardsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional vacancy to filter cards by.
+	VacancyId     string `protobuf:"bytes,1,opt,name=vacancy_id,json=vacancyId,proto3" json:"vacancy_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCardsRequest) Reset() {
+	*x = ListCardsRequest{}
+	mi := &file_hr_v1_card_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCardsRequest) ProtoMessage() {}
+
+func (x *ListCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_card_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCardsRequest.ProtoReflect.Descriptor instead.
+func (*ListCardsRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListCardsRequest) GetVacancyId() string {
+	if x != nil {
+		return x.VacancyId
+	}
+	return ""
+}
+
+type ListCardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cards         []*Card                `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCardsResponse) Reset() {
+	*x = ListCardsResponse{}
+	mi := &file_hr_v1_card_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCardsResponse) ProtoMessage() {}
+
+func (x *ListCardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_card_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCardsResponse.ProtoReflect.Descriptor instead.
+func (*ListCardsResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListCardsResponse) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+type GetCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardRequest) Reset() {
+	*x = GetCardRequest{}
+	mi := &file_hr_v1_card_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardRequest) ProtoMessage() {}
+
+func (x *GetCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_card_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardRequest.ProtoReflect.Descriptor instead.
+func (*GetCardRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCardRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *Card                  `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardResponse) Reset() {
+	*x = GetCardResponse{}
+	mi := &file_hr_v1_card_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardResponse) ProtoMessage() {}
+
+func (x *GetCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_card_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardResponse.ProtoReflect.Descriptor instead.
+func (*GetCardResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCardResponse) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type CreateCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VacancyId     string                 `protobuf:"bytes,1,opt,name=vacancy_id,json=vacancyId,proto3" json:"vacancy_id,omitempty"`
+	CandidateId   string                 `protobuf:"bytes,2,opt,name=candidate_id,json=candidateId,proto3" json:"candidate_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCardRequest) Reset() {
+	*x = CreateCardRequest{}
+	mi := &file_hr_v1_card_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCardRequest) ProtoMessage() {}
+
+func (x *CreateCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_card_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCardRequest.ProtoReflect.Descriptor instead.
+func (*CreateCardRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateCardRequest) GetVacancyId() string {
+	if x != nil {
+		return x.VacancyId
+	}
+	return ""
+}
+
+func (x *CreateCardRequest) GetCandidateId() string {
+	if x != nil {
+		return x.CandidateId
+	}
+	return ""
+}
+
+type CreateCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *Card                  `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCardResponse) Reset() {
+	*x = CreateCardResponse{}
+	mi := &file_hr_v1_card_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCardResponse) ProtoMessage() {}
+
+func (x *CreateCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_card_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCardResponse.ProtoReflect.Descriptor instead.
+func (*CreateCardResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateCardResponse) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type MoveCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Stage         CardStage              `protobuf:"varint,2,opt,name=stage,proto3,enum=hr.v1.CardStage" json:"stage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveCardRequest) Reset() {
+	*x = MoveCardRequest{}
+	mi := &file_hr_v1_card_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveCardRequest) ProtoMessage() {}
+
+func (x *MoveCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_card_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveCardRequest.ProtoReflect.Descriptor instead.
+func (*MoveCardRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MoveCardRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MoveCardRequest) GetStage() CardStage {
+	if x != nil {
+		return x.Stage
+	}
+	return CardStage_CARD_STAGE_UNSPECIFIED
+}
+
+type MoveCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *Card                  `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveCardResponse) Reset() {
+	*x = MoveCardResponse{}
+	mi := &file_hr_v1_card_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveCardResponse) ProtoMessage() {}
+
+func (x *MoveCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_card_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveCardResponse.ProtoReflect.Descriptor instead.
+func (*MoveCardResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MoveCardResponse) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type AddCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        string                 `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentRequest) Reset() {
+	*x = AddCommentRequest{}
+	mi := &file_hr_v1_card_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentRequest) ProtoMessage() {}
+
+func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_card_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AddCommentRequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type AddCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentResponse) Reset() {
+	*x = AddCommentResponse{}
+	mi := &file_hr_v1_card_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentResponse) ProtoMessage() {}
+
+func (x *AddCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_card_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentResponse.ProtoReflect.Descriptor instead.
+func (*AddCommentResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_card_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AddCommentResponse) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+var File_hr_v1_card_proto protoreflect.FileDescriptor
+
+const file_hr_v1_card_proto_rawDesc = "" +
+	"\n" +
+	"\x10hr/v1/card.proto\x12\x05hr.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"{\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x124\n" +
+	"\acreated\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\"\x98\x02\n" +
+	"\x04Card\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"vacancy_id\x18\x02 \x01(\tR\tvacancyId\x12!\n" +
+	"\fcandidate_id\x18\x03 \x01(\tR\vcandidateId\x12&\n" +
+	"\x05stage\x18\x04 \x01(\x0e2\x10.hr.v1.CardStageR\x05stage\x12*\n" +
+	"\bcomments\x18\x05 \x03(\v2\x0e.hr.v1.CommentR\bcomments\x124\n" +
+	"\acreated\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x124\n" +
+	"\aupdated\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\aupdated\"1\n" +
+	"\x10ListCardsRequest\x12\x1d\n" +
+	"\n" +
+	"vacancy_id\x18\x01 \x01(\tR\tvacancyId\"6\n" +
+	"\x11ListCardsResponse\x12!\n" +
+	"\x05cards\x18\x01 \x03(\v2\v.hr.v1.CardR\x05cards\" \n" +
+	"\x0eGetCardRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	"\x0fGetCardResponse\x12\x1f\n" +
+	"\x04card\x18\x01 \x01(\v2\v.hr.v1.CardR\x04card\"U\n" +
+	"\x11CreateCardRequest\x12\x1d\n" +
+	"\n" +
+	"vacancy_id\x18\x01 \x01(\tR\tvacancyId\x12!\n" +
+	"\fcandidate_id\x18\x02 \x01(\tR\vcandidateId\"5\n" +
+	"\x12CreateCardResponse\x12\x1f\n" +
+	"\x04card\x18\x01 \x01(\v2\v.hr.v1.CardR\x04card\"I\n" +
+	"\x0fMoveCardRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x05stage\x18\x02 \x01(\x0e2\x10.hr.v1.CardStageR\x05stage\"3\n" +
+	"\x10MoveCardResponse\x12\x1f\n" +
+	"\x04card\x18\x01 \x01(\v2\v.hr.v1.CardR\x04card\"X\n" +
+	"\x11AddCommentRequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\tR\x06cardId\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\">\n" +
+	"\x12AddCommentResponse\x12(\n" +
+	"\acomment\x18\x01 \x01(\v2\x0e.hr.v1.CommentR\acomment*\xca\x01\n" +
+	"\tCardStage\x12\x1a\n" +
+	"\x16CARD_STAGE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eCARD_STAGE_NEW\x10\x01\x12\x18\n" +
+	"\x14CARD_STAGE_SCREENING\x10\x02\x12\x18\n" +
+	"\x14CARD_STAGE_INTERVIEW\x10\x03\x12\x14\n" +
+	"\x10CARD_STAGE_OFFER\x10\x04\x12\x14\n" +
+	"\x10CARD_STAGE_HIRED\x10\x05\x12\x17\n" +
+	"\x13CARD_STAGE_REJECTED\x10\x06\x12\x14\n" +
+	"\x10CARD_STAGE_INBOX\x10\a2\xca\x02\n" +
+	"\vCardService\x12>\n" +
+	"\tListCards\x12\x17.hr.v1.ListCardsRequest\x1a\x18.hr.v1.ListCardsResponse\x128\n" +
+	"\aGetCard\x12\x15.hr.v1.GetCardRequest\x1a\x16.hr.v1.GetCardResponse\x12A\n" +
+	"\n" +
+	"CreateCard\x12\x18.hr.v1.CreateCardRequest\x1a\x19.hr.v1.CreateCardResponse\x12;\n" +
+	"\bMoveCard\x12\x16.hr.v1.MoveCardRequest\x1a\x17.hr.v1.MoveCardResponse\x12A\n" +
+	"\n" +
+	"AddComment\x12\x18.hr.v1.AddCommentRequest\x1a\x19.hr.v1.AddCommentResponseB%Z#gpb.ru/hr/internal/hr/api/hrv1;hrv1b\x06proto3"
+
+var (
+	file_hr_v1_card_proto_rawDescOnce sync.Once
+	file_hr_v1_card_proto_rawDescData []byte
+)
+
+func file_hr_v1_card_proto_rawDescGZIP() []byte {
+	file_hr_v1_card_proto_rawDescOnce.Do(func() {
+		file_hr_v1_card_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hr_v1_card_proto_rawDesc), len(file_hr_v1_card_proto_rawDesc)))
+	})
+	return file_hr_v1_card_proto_rawDescData
+}
+
+var file_hr_v1_card_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_hr_v1_card_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_hr_v1_card_proto_goTypes = []any{
+	(CardStage)(0),                // 0: hr.v1.CardStage
+	(*Comment)(nil),               // 1: hr.v1.Comment
+	(*Card)(nil),                  // 2: hr.v1.Card
+	(*ListCardsRequest)(nil),      // 3: hr.v1.ListCardsRequest
+	(*ListCardsResponse)(nil),     // 4: hr.v1.ListCardsResponse
+	(*GetCardRequest)(nil),        // 5: hr.v1.GetCardRequest
+	(*GetCardResponse)(nil),       // 6: hr.v1.GetCardResponse
+	(*CreateCardRequest)(nil),     // 7: hr.v1.CreateCardRequest
+	(*CreateCardResponse)(nil),    // 8: hr.v1.CreateCardResponse
+	(*MoveCardRequest)(nil),       // 9: hr.v1.MoveCardRequest
+	(*MoveCardResponse)(nil),      // 10: hr.v1.MoveCardResponse
+	(*AddCommentRequest)(nil),     // 11: hr.v1.AddCommentRequest
+	(*AddCommentResponse)(nil),    // 12: hr.v1.AddCommentResponse
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+}
+var file_hr_v1_card_proto_depIdxs = []int32{
+	13, // 0: hr.v1.Comment.created:type_name -> google.protobuf.Timestamp
+	0,  // 1: hr.v1.Card.stage:type_name -> hr.v1.CardStage
+	1,  // 2: hr.v1.Card.comments:type_name -> hr.v1.Comment
+	13, // 3: hr.v1.Card.created:type_name -> google.protobuf.Timestamp
+	13, // 4: hr.v1.Card.updated:type_name -> google.protobuf.Timestamp
+	2,  // 5: hr.v1.ListCardsResponse.cards:type_name -> hr.v1.Card
+	2,  // 6: hr.v1.GetCardResponse.card:type_name -> hr.v1.Card
+	2,  // 7: hr.v1.CreateCardResponse.card:type_name -> hr.v1.Card
+	0,  // 8: hr.v1.MoveCardRequest.stage:type_name -> hr.v1.CardStage
+	2,  // 9: hr.v1.MoveCardResponse.card:type_name -> hr.v1.Card
+	1,  // 10: hr.v1.AddCommentResponse.comment:type_name -> hr.v1.Comment
+	3,  // 11: hr.v1.CardService.ListCards:input_type -> hr.v1.ListCardsRequest
+	5,  // 12: hr.v1.CardService.GetCard:input_type -> hr.v1.GetCardRequest
+	7,  // 13: hr.v1.CardService.CreateCard:input_type -> hr.v1.CreateCardRequest
+	9,  // 14: hr.v1.CardService.MoveCard:input_type -> hr.v1.MoveCardRequest
+	11, // 15: hr.v1.CardService.AddComment:input_type -> hr.v1.AddCommentRequest
+	4,  // 16: hr.v1.CardService.ListCards:output_type -> hr.v1.ListCardsResponse
+	6,  // 17: hr.v1.CardService.GetCard:output_type -> hr.v1.GetCardResponse
+	8,  // 18: hr.v1.CardService.CreateCard:output_type -> hr.v1.CreateCardResponse
+	10, // 19: hr.v1.CardService.MoveCard:output_type -> hr.v1.MoveCardResponse
+	12, // 20: hr.v1.CardService.AddComment:output_type -> hr.v1.AddCommentResponse
+	16, // [16:21] is the sub-list for method output_type
+	11, // [11:16] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_hr_v1_card_proto_init() }
+func file_hr_v1_card_proto_init() {
+	if File_hr_v1_card_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hr_v1_card_proto_rawDesc), len(file_hr_v1_card_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hr_v1_card_proto_goTypes,
+		DependencyIndexes: file_hr_v1_card_proto_depIdxs,
+		EnumInfos:         file_hr_v1_card_proto_enumTypes,
+		MessageInfos:      file_hr_v1_card_proto_msgTypes,
+	}.Build()
+	File_hr_v1_card_proto = out.File
+	file_hr_v1_card_proto_goTypes = nil
+	file_hr_v1_card_proto_depIdxs = nil
+}
@@ -0,0 +1,129 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: hr/v1/salary.proto
+
+package hrv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SalaryPeriod int32
+
+const (
+	SalaryPeriod_SALARY_PERIOD_UNSPECIFIED SalaryPeriod = 0
+	SalaryPeriod_SALARY_PERIOD_MONTH       SalaryPeriod = 1
+	SalaryPeriod_SALARY_PERIOD_YEAR        SalaryPeriod = 2
+)
+
+// Enum value maps for SalaryPeriod.
+var (
+	SalaryPeriod_name = map[int32]string{
+		0: "SALARY_PERIOD_UNSPECIFIED",
+		1: "SALARY_PERIOD_MONTH",
+		2: "SALARY_PERIOD_YEAR",
+	}
+	SalaryPeriod_value = map[string]int32{
+		"SALARY_PERIOD_UNSPECIFIED": 0,
+		"SALARY_PERIOD_MONTH":       1,
+		"SALARY_PERIOD_YEAR":        2,
+	}
+)
+
+func (x SalaryPeriod) Enum() *SalaryPeriod {
+	p := new(SalaryPeriod)
+	*p = x
+	return p
+}
+
+func (x SalaryPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SalaryPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_hr_v1_salary_proto_enumTypes[0].Descriptor()
+}
+
+func (SalaryPeriod) Type() protoreflect.EnumType {
+	return &file_hr_v1_salary_proto_enumTypes[0]
+}
+
+func (x SalaryPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SalaryPeriod.Descriptor instead.
+func (SalaryPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_hr_v1_salary_proto_rawDescGZIP(), []int{0}
+}
+
+var File_hr_v1_salary_proto protoreflect.FileDescriptor
+
+const file_hr_v1_salary_proto_rawDesc = "" +
+	"\n" +
+	"\x12hr/v1/salary.proto\x12\x05hr.v1*^\n" +
+	"\fSalaryPeriod\x12\x1d\n" +
+	"\x19SALARY_PERIOD_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SALARY_PERIOD_MONTH\x10\x01\x12\x16\n" +
+	"\x12SALARY_PERIOD_YEAR\x10\x02B%Z#gpb.ru/hr/internal/hr/api/hrv1;hrv1b\x06proto3"
+
+var (
+	file_hr_v1_salary_proto_rawDescOnce sync.Once
+	file_hr_v1_salary_proto_rawDescData []byte
+)
+
+func file_hr_v1_salary_proto_rawDescGZIP() []byte {
+	file_hr_v1_salary_proto_rawDescOnce.Do(func() {
+		file_hr_v1_salary_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hr_v1_salary_proto_rawDesc), len(file_hr_v1_salary_proto_rawDesc)))
+	})
+	return file_hr_v1_salary_proto_rawDescData
+}
+
+var file_hr_v1_salary_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_hr_v1_salary_proto_goTypes = []any{
+	(SalaryPeriod)(0), // 0: hr.v1.SalaryPeriod
+}
+var file_hr_v1_salary_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_hr_v1_salary_proto_init() }
+func file_hr_v1_salary_proto_init() {
+	if File_hr_v1_salary_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hr_v1_salary_proto_rawDesc), len(file_hr_v1_salary_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_hr_v1_salary_proto_goTypes,
+		DependencyIndexes: file_hr_v1_salary_proto_depIdxs,
+		EnumInfos:         file_hr_v1_salary_proto_enumTypes,
+	}.Build()
+	File_hr_v1_salary_proto = out.File
+	file_hr_v1_salary_proto_goTypes = nil
+	file_hr_v1_salary_proto_depIdxs = nil
+}
@@ -0,0 +1,843 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: hr/v1/vacancy.proto
+
+package hrv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type VacancyStatus int32
+
+const (
+	VacancyStatus_VACANCY_STATUS_UNSPECIFIED VacancyStatus = 0
+	VacancyStatus_VACANCY_STATUS_DRAFT       VacancyStatus = 1
+	VacancyStatus_VACANCY_STATUS_ACTIVE      VacancyStatus = 2
+	VacancyStatus_VACANCY_STATUS_INACTIVE    VacancyStatus = 3
+)
+
+// Enum value maps for VacancyStatus.
+var (
+	VacancyStatus_name = map[int32]string{
+		0: "VACANCY_STATUS_UNSPECIFIED",
+		1: "VACANCY_STATUS_DRAFT",
+		2: "VACANCY_STATUS_ACTIVE",
+		3: "VACANCY_STATUS_INACTIVE",
+	}
+	VacancyStatus_value = map[string]int32{
+		"VACANCY_STATUS_UNSPECIFIED": 0,
+		"VACANCY_STATUS_DRAFT":       1,
+		"VACANCY_STATUS_ACTIVE":      2,
+		"VACANCY_STATUS_INACTIVE":    3,
+	}
+)
+
+func (x VacancyStatus) Enum() *VacancyStatus {
+	p := new(VacancyStatus)
+	*p = x
+	return p
+}
+
+func (x VacancyStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VacancyStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_hr_v1_vacancy_proto_enumTypes[0].Descriptor()
+}
+
+func (VacancyStatus) Type() protoreflect.EnumType {
+	return &file_hr_v1_vacancy_proto_enumTypes[0]
+}
+
+func (x VacancyStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VacancyStatus.Descriptor instead.
+func (VacancyStatus) EnumDescriptor() ([]byte, []int) {
+	return file_hr_v1_vacancy_proto_rawDescGZIP(), []int{0}
+}
+
+type Skill struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Important     bool                   `protobuf:"varint,2,opt,name=important,proto3" json:"important,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Skill) Reset() {
+	*x = Skill{}
+	mi := &file_hr_v1_vacancy_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Skill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Skill) ProtoMessage() {}
+
+func (x *Skill) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_vacancy_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Skill.ProtoReflect.Descriptor instead.
+func (*Skill) Descriptor() ([]byte, []int) {
+	return file_hr_v1_vacancy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Skill) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Skill) GetImportant() bool {
+	if x != nil {
+		return x.Important
+	}
+	return false
+}
+
+type Vacancy struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TemplateId   string                 `protobuf:"bytes,2,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Title        string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Status       VacancyStatus          `protobuf:"varint,4,opt,name=status,proto3,enum=hr.v1.VacancyStatus" json:"status,omitempty"`
+	Area         string                 `protobuf:"bytes,5,opt,name=area,proto3" json:"area,omitempty"`
+	Department   string                 `protobuf:"bytes,6,opt,name=department,proto3" json:"department,omitempty"`
+	Skills       []*Skill               `protobuf:"bytes,7,rep,name=skills,proto3" json:"skills,omitempty"`
+	Duties       []string               `protobuf:"bytes,8,rep,name=duties,proto3" json:"duties,omitempty"`
+	Requirements []string               `protobuf:"bytes,9,rep,name=requirements,proto3" json:"requirements,omitempty"`
+	// Required experience in years.
+	Experience uint32                 `protobuf:"varint,10,opt,name=experience,proto3" json:"experience,omitempty"`
+	Created    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created,proto3" json:"created,omitempty"`
+	Updated    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated,proto3" json:"updated,omitempty"`
+	// Highest monthly salary approved for the position, 0 if not limited.
+	Budget uint32 `protobuf:"varint,13,opt,name=budget,proto3" json:"budget,omitempty"`
+	// Email of the manager the position is opened for.
+	HiringManager string `protobuf:"bytes,14,opt,name=hiring_manager,json=hiringManager,proto3" json:"hiring_manager,omitempty"`
+	// Email of the recruiter filling the position.
+	Recruiter string `protobuf:"bytes,15,opt,name=recruiter,proto3" json:"recruiter,omitempty"`
+	// Requisition the position is opened by.
+	RequisitionId string `protobuf:"bytes,16,opt,name=requisition_id,json=requisitionId,proto3" json:"requisition_id,omitempty"`
+	// Salary offered for the position, the maximum is 0 if not limited.
+	SalaryMin uint32 `protobuf:"varint,17,opt,name=salary_min,json=salaryMin,proto3" json:"salary_min,omitempty"`
+	SalaryMax uint32 `protobuf:"varint,18,opt,name=salary_max,json=salaryMax,proto3" json:"salary_max,omitempty"`
+	// ISO 4217 code of the salary, the base currency of exchange rates if empty.
+	// Currencies missing from the exchange rates are rejected.
+	SalaryCurrency string `protobuf:"bytes,19,opt,name=salary_currency,json=salaryCurrency,proto3" json:"salary_currency,omitempty"`
+	// Income tax is yet to be withheld from the salary.
+	SalaryGross bool `protobuf:"varint,20,opt,name=salary_gross,json=salaryGross,proto3" json:"salary_gross,omitempty"`
+	// Period of the salary, a month if unspecified.
+	SalaryPeriod SalaryPeriod `protobuf:"varint,21,opt,name=salary_period,json=salaryPeriod,proto3,enum=hr.v1.SalaryPeriod" json:"salary_period,omitempty"`
+	// Show the salary on the careers site and in job feeds.
+	SalaryPublic  bool `protobuf:"varint,22,opt,name=salary_public,json=salaryPublic,proto3" json:"salary_public,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vacancy) Reset() {
+	*x = Vacancy{}
+	mi := &file_hr_v1_vacancy_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vacancy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vacancy) ProtoMessage() {}
+
+func (x *Vacancy) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_vacancy_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vacancy.ProtoReflect.Descriptor instead.
+func (*Vacancy) Descriptor() ([]byte, []int) {
+	return file_hr_v1_vacancy_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Vacancy) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Vacancy) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *Vacancy) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Vacancy) GetStatus() VacancyStatus {
+	if x != nil {
+		return x.Status
+	}
+	return VacancyStatus_VACANCY_STATUS_UNSPECIFIED
+}
+
+func (x *Vacancy) GetArea() string {
+	if x != nil {
+		return x.Area
+	}
+	return ""
+}
+
+func (x *Vacancy) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *Vacancy) GetSkills() []*Skill {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+func (x *Vacancy) GetDuties() []string {
+	if x != nil {
+		return x.Duties
+	}
+	return nil
+}
+
+func (x *Vacancy) GetRequirements() []string {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+func (x *Vacancy) GetExperience() uint32 {
+	if x != nil {
+		return x.Experience
+	}
+	return 0
+}
+
+func (x *Vacancy) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Vacancy) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *Vacancy) GetBudget() uint32 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+func (x *Vacancy) GetHiringManager() string {
+	if x != nil {
+		return x.HiringManager
+	}
+	return ""
+}
+
+func (x *Vacancy) GetRecruiter() string {
+	if x != nil {
+		return x.Recruiter
+	}
+	return ""
+}
+
+func (x *Vacancy) GetRequisitionId() string {
+	if x != nil {
+		return x.RequisitionId
+	}
+	return ""
+}
+
+func (x *Vacancy) GetSalaryMin() uint32 {
+	if x != nil {
+		return x.SalaryMin
+	}
+	return 0
+}
+
+func (x *Vacancy) GetSalaryMax() uint32 {
+	if x != nil {
+		return x.SalaryMax
+	}
+	return 0
+}
+
+func (x *Vacancy) GetSalaryCurrency() string {
+	if x != nil {
+		return x.SalaryCurrency
+	}
+	return ""
+}
+
+func (x *Vacancy) GetSalaryGross() bool {
+	if x != nil {
+		return x.SalaryGross
+	}
+	return false
+}
+
+func (x *Vacancy) GetSalaryPeriod() SalaryPeriod {
+	if x != nil {
+		return x.SalaryPeriod
+	}
+	return SalaryPeriod_SALARY_PERIOD_UNSPECIFIED
+}
+
+func (x *Vacancy) GetSalaryPublic() bool {
+	if x != nil {
+		return x.SalaryPublic
+	}
+	return false
+}
+
+type ListVacanciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVacanciesRequest) Reset() {
+	*x = ListVacanciesRequest{}
+	mi := &file_hr_v1_vacancy_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVacanciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVacanciesRequest) ProtoMessage() {}
+
+func (x *ListVacanciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_vacancy_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVacanciesRequest.ProtoReflect.Descriptor instead.
+func (*ListVacanciesRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_vacancy_proto_rawDescGZIP(), []int{2}
+}
+
+type ListVacanciesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Vacancies without skills, duties and requirements.
+	Vacancies     []*Vacancy `protobuf:"bytes,1,rep,name=vacancies,proto3" json:"vacancies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVacanciesResponse) Reset() {
+	*x = ListVacanciesResponse{}
+	mi := &file_hr_v1_vacancy_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVacanciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVacanciesResponse) ProtoMessage() {}
+
+func (x *ListVacanciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_vacancy_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVacanciesResponse.ProtoReflect.Descriptor instead.
+func (*ListVacanciesResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_vacancy_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListVacanciesResponse) GetVacancies() []*Vacancy {
+	if x != nil {
+		return x.Vacancies
+	}
+	return nil
+}
+
+type GetVacancyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVacancyRequest) Reset() {
+	*x = GetVacancyRequest{}
+	mi := &file_hr_v1_vacancy_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVacancyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVacancyRequest) ProtoMessage() {}
+
+func (x *GetVacancyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_vacancy_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVacancyRequest.ProtoReflect.Descriptor instead.
+func (*GetVacancyRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_vacancy_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetVacancyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetVacancyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vacancy       *Vacancy               `protobuf:"bytes,1,opt,name=vacancy,proto3" json:"vacancy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVacancyResponse) Reset() {
+	*x = GetVacancyResponse{}
+	mi := &file_hr_v1_vacancy_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVacancyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVacancyResponse) ProtoMessage() {}
+
+func (x *GetVacancyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_vacancy_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVacancyResponse.ProtoReflect.Descriptor instead.
+func (*GetVacancyResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_vacancy_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetVacancyResponse) GetVacancy() *Vacancy {
+	if x != nil {
+		return x.Vacancy
+	}
+	return nil
+}
+
+type CreateVacancyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vacancy       *Vacancy               `protobuf:"bytes,1,opt,name=vacancy,proto3" json:"vacancy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVacancyRequest) Reset() {
+	*x = CreateVacancyRequest{}
+	mi := &file_hr_v1_vacancy_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVacancyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVacancyRequest) ProtoMessage() {}
+
+func (x *CreateVacancyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_vacancy_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVacancyRequest.ProtoReflect.Descriptor instead.
+func (*CreateVacancyRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_vacancy_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateVacancyRequest) GetVacancy() *Vacancy {
+	if x != nil {
+		return x.Vacancy
+	}
+	return nil
+}
+
+type CreateVacancyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vacancy       *Vacancy               `protobuf:"bytes,1,opt,name=vacancy,proto3" json:"vacancy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVacancyResponse) Reset() {
+	*x = CreateVacancyResponse{}
+	mi := &file_hr_v1_vacancy_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVacancyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVacancyResponse) ProtoMessage() {}
+
+func (x *CreateVacancyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_vacancy_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVacancyResponse.ProtoReflect.Descriptor instead.
+func (*CreateVacancyResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_vacancy_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateVacancyResponse) GetVacancy() *Vacancy {
+	if x != nil {
+		return x.Vacancy
+	}
+	return nil
+}
+
+type UpdateVacancyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vacancy       *Vacancy               `protobuf:"bytes,1,opt,name=vacancy,proto3" json:"vacancy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVacancyRequest) Reset() {
+	*x = UpdateVacancyRequest{}
+	mi := &file_hr_v1_vacancy_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVacancyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVacancyRequest) ProtoMessage() {}
+
+func (x *UpdateVacancyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_vacancy_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVacancyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVacancyRequest) Descriptor() ([]byte, []int) {
+	return file_hr_v1_vacancy_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateVacancyRequest) GetVacancy() *Vacancy {
+	if x != nil {
+		return x.Vacancy
+	}
+	return nil
+}
+
+type UpdateVacancyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vacancy       *Vacancy               `protobuf:"bytes,1,opt,name=vacancy,proto3" json:"vacancy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVacancyResponse) Reset() {
+	*x = UpdateVacancyResponse{}
+	mi := &file_hr_v1_vacancy_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVacancyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVacancyResponse) ProtoMessage() {}
+
+func (x *UpdateVacancyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hr_v1_vacancy_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVacancyResponse.ProtoReflect.Descriptor instead.
+func (*UpdateVacancyResponse) Descriptor() ([]byte, []int) {
+	return file_hr_v1_vacancy_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateVacancyResponse) GetVacancy() *Vacancy {
+	if x != nil {
+		return x.Vacancy
+	}
+	return nil
+}
+
+var File_hr_v1_vacancy_proto protoreflect.FileDescriptor
+
+const file_hr_v1_vacancy_proto_rawDesc = "" +
+	"\n" +
+	"\x13hr/v1/vacancy.proto\x12\x05hr.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x12hr/v1/salary.proto\";\n" +
+	"\x05Skill\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x1c\n" +
+	"\timportant\x18\x02 \x01(\bR\timportant\"\x8d\x06\n" +
+	"\aVacancy\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vtemplate_id\x18\x02 \x01(\tR\n" +
+	"templateId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12,\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x14.hr.v1.VacancyStatusR\x06status\x12\x12\n" +
+	"\x04area\x18\x05 \x01(\tR\x04area\x12\x1e\n" +
+	"\n" +
+	"department\x18\x06 \x01(\tR\n" +
+	"department\x12$\n" +
+	"\x06skills\x18\a \x03(\v2\f.hr.v1.SkillR\x06skills\x12\x16\n" +
+	"\x06duties\x18\b \x03(\tR\x06duties\x12\"\n" +
+	"\frequirements\x18\t \x03(\tR\frequirements\x12\x1e\n" +
+	"\n" +
+	"experience\x18\n" +
+	" \x01(\rR\n" +
+	"experience\x124\n" +
+	"\acreated\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x124\n" +
+	"\aupdated\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\aupdated\x12\x16\n" +
+	"\x06budget\x18\r \x01(\rR\x06budget\x12%\n" +
+	"\x0ehiring_manager\x18\x0e \x01(\tR\rhiringManager\x12\x1c\n" +
+	"\trecruiter\x18\x0f \x01(\tR\trecruiter\x12%\n" +
+	"\x0erequisition_id\x18\x10 \x01(\tR\rrequisitionId\x12\x1d\n" +
+	"\n" +
+	"salary_min\x18\x11 \x01(\rR\tsalaryMin\x12\x1d\n" +
+	"\n" +
+	"salary_max\x18\x12 \x01(\rR\tsalaryMax\x12'\n" +
+	"\x0fsalary_currency\x18\x13 \x01(\tR\x0esalaryCurrency\x12!\n" +
+	"\fsalary_gross\x18\x14 \x01(\bR\vsalaryGross\x128\n" +
+	"\rsalary_period\x18\x15 \x01(\x0e2\x13.hr.v1.SalaryPeriodR\fsalaryPeriod\x12#\n" +
+	"\rsalary_public\x18\x16 \x01(\bR\fsalaryPublic\"\x16\n" +
+	"\x14ListVacanciesRequest\"E\n" +
+	"\x15ListVacanciesResponse\x12,\n" +
+	"\tvacancies\x18\x01 \x03(\v2\x0e.hr.v1.VacancyR\tvacancies\"#\n" +
+	"\x11GetVacancyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x12GetVacancyResponse\x12(\n" +
+	"\avacancy\x18\x01 \x01(\v2\x0e.hr.v1.VacancyR\avacancy\"@\n" +
+	"\x14CreateVacancyRequest\x12(\n" +
+	"\avacancy\x18\x01 \x01(\v2\x0e.hr.v1.VacancyR\avacancy\"A\n" +
+	"\x15CreateVacancyResponse\x12(\n" +
+	"\avacancy\x18\x01 \x01(\v2\x0e.hr.v1.VacancyR\avacancy\"@\n" +
+	"\x14UpdateVacancyRequest\x12(\n" +
+	"\avacancy\x18\x01 \x01(\v2\x0e.hr.v1.VacancyR\avacancy\"A\n" +
+	"\x15UpdateVacancyResponse\x12(\n" +
+	"\avacancy\x18\x01 \x01(\v2\x0e.hr.v1.VacancyR\avacancy*\x81\x01\n" +
+	"\rVacancyStatus\x12\x1e\n" +
+	"\x1aVACANCY_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14VACANCY_STATUS_DRAFT\x10\x01\x12\x19\n" +
+	"\x15VACANCY_STATUS_ACTIVE\x10\x02\x12\x1b\n" +
+	"\x17VACANCY_STATUS_INACTIVE\x10\x032\xb7\x02\n" +
+	"\x0eVacancyService\x12J\n" +
+	"\rListVacancies\x12\x1b.hr.v1.ListVacanciesRequest\x1a\x1c.hr.v1.ListVacanciesResponse\x12A\n" +
+	"\n" +
+	"GetVacancy\x12\x18.hr.v1.GetVacancyRequest\x1a\x19.hr.v1.GetVacancyResponse\x12J\n" +
+	"\rCreateVacancy\x12\x1b.hr.v1.CreateVacancyRequest\x1a\x1c.hr.v1.CreateVacancyResponse\x12J\n" +
+	"\rUpdateVacancy\x12\x1b.hr.v1.UpdateVacancyRequest\x1a\x1c.hr.v1.UpdateVacancyResponseB%Z#gpb.ru/hr/internal/hr/api/hrv1;hrv1b\x06proto3"
+
+var (
+	file_hr_v1_vacancy_proto_rawDescOnce sync.Once
+	file_hr_v1_vacancy_proto_rawDescData []byte
+)
+
+func file_hr_v1_vacancy_proto_rawDescGZIP() []byte {
+	file_hr_v1_vacancy_proto_rawDescOnce.Do(func() {
+		file_hr_v1_vacancy_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hr_v1_vacancy_proto_rawDesc), len(file_hr_v1_vacancy_proto_rawDesc)))
+	})
+	return file_hr_v1_vacancy_proto_rawDescData
+}
+
+var file_hr_v1_vacancy_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_hr_v1_vacancy_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_hr_v1_vacancy_proto_goTypes = []any{
+	(VacancyStatus)(0),            // 0: hr.v1.VacancyStatus
+	(*Skill)(nil),                 // 1: hr.v1.Skill
+	(*Vacancy)(nil),               // 2: hr.v1.Vacancy
+	(*ListVacanciesRequest)(nil),  // 3: hr.v1.ListVacanciesRequest
+	(*ListVacanciesResponse)(nil), // 4: hr.v1.ListVacanciesResponse
+	(*GetVacancyRequest)(nil),     // 5: hr.v1.GetVacancyRequest
+	(*GetVacancyResponse)(nil),    // 6: hr.v1.GetVacancyResponse
+	(*CreateVacancyRequest)(nil),  // 7: hr.v1.CreateVacancyRequest
+	(*CreateVacancyResponse)(nil), // 8: hr.v1.CreateVacancyResponse
+	(*UpdateVacancyRequest)(nil),  // 9: hr.v1.UpdateVacancyRequest
+	(*UpdateVacancyResponse)(nil), // 10: hr.v1.UpdateVacancyResponse
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(SalaryPeriod)(0),             // 12: hr.v1.SalaryPeriod
+}
+var file_hr_v1_vacancy_proto_depIdxs = []int32{
+	0,  // 0: hr.v1.Vacancy.status:type_name -> hr.v1.VacancyStatus
+	1,  // 1: hr.v1.Vacancy.skills:type_name -> hr.v1.Skill
+	11, // 2: hr.v1.Vacancy.created:type_name -> google.protobuf.Timestamp
+	11, // 3: hr.v1.Vacancy.updated:type_name -> google.protobuf.Timestamp
+	12, // 4: hr.v1.Vacancy.salary_period:type_name -> hr.v1.SalaryPeriod
+	2,  // 5: hr.v1.ListVacanciesResponse.vacancies:type_name -> hr.v1.Vacancy
+	2,  // 6: hr.v1.GetVacancyResponse.vacancy:type_name -> hr.v1.Vacancy
+	2,  // 7: hr.v1.CreateVacancyRequest.vacancy:type_name -> hr.v1.Vacancy
+	2,  // 8: hr.v1.CreateVacancyResponse.vacancy:type_name -> hr.v1.Vacancy
+	2,  // 9: hr.v1.UpdateVacancyRequest.vacancy:type_name -> hr.v1.Vacancy
+	2,  // 10: hr.v1.UpdateVacancyResponse.vacancy:type_name -> hr.v1.Vacancy
+	3,  // 11: hr.v1.VacancyService.ListVacancies:input_type -> hr.v1.ListVacanciesRequest
+	5,  // 12: hr.v1.VacancyService.GetVacancy:input_type -> hr.v1.GetVacancyRequest
+	7,  // 13: hr.v1.VacancyService.CreateVacancy:input_type -> hr.v1.CreateVacancyRequest
+	9,  // 14: hr.v1.VacancyService.UpdateVacancy:input_type -> hr.v1.UpdateVacancyRequest
+	4,  // 15: hr.v1.VacancyService.ListVacancies:output_type -> hr.v1.ListVacanciesResponse
+	6,  // 16: hr.v1.VacancyService.GetVacancy:output_type -> hr.v1.GetVacancyResponse
+	8,  // 17: hr.v1.VacancyService.CreateVacancy:output_type -> hr.v1.CreateVacancyResponse
+	10, // 18: hr.v1.VacancyService.UpdateVacancy:output_type -> hr.v1.UpdateVacancyResponse
+	15, // [15:19] is the sub-list for method output_type
+	11, // [11:15] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_hr_v1_vacancy_proto_init() }
+func file_hr_v1_vacancy_proto_init() {
+	if File_hr_v1_vacancy_proto != nil {
+		return
+	}
+	file_hr_v1_salary_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hr_v1_vacancy_proto_rawDesc), len(file_hr_v1_vacancy_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hr_v1_vacancy_proto_goTypes,
+		DependencyIndexes: file_hr_v1_vacancy_proto_depIdxs,
+		EnumInfos:         file_hr_v1_vacancy_proto_enumTypes,
+		MessageInfos:      file_hr_v1_vacancy_proto_msgTypes,
+	}.Build()
+	File_hr_v1_vacancy_proto = out.File
+	file_hr_v1_vacancy_proto_goTypes = nil
+	file_hr_v1_vacancy_proto_depIdxs = nil
+}
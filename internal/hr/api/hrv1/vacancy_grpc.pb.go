@@ -0,0 +1,251 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: hr/v1/vacancy.proto
+
+package hrv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	VacancyService_ListVacancies_FullMethodName = "/hr.v1.VacancyService/ListVacancies"
+	VacancyService_GetVacancy_FullMethodName    = "/hr.v1.VacancyService/GetVacancy"
+	VacancyService_CreateVacancy_FullMethodName = "/hr.v1.VacancyService/CreateVacancy"
+	VacancyService_UpdateVacancy_FullMethodName = "/hr.v1.VacancyService/UpdateVacancy"
+)
+
+// VacancyServiceClient is the client API for VacancyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// VacancyService manages vacancies.
+type VacancyServiceClient interface {
+	// ListVacancies returns vacancies ordered by update time.
+	ListVacancies(ctx context.Context, in *ListVacanciesRequest, opts ...grpc.CallOption) (*ListVacanciesResponse, error)
+	// GetVacancy returns the vacancy with skills.
+	GetVacancy(ctx context.Context, in *GetVacancyRequest, opts ...grpc.CallOption) (*GetVacancyResponse, error)
+	// CreateVacancy creates vacancy with the given properties.
+	CreateVacancy(ctx context.Context, in *CreateVacancyRequest, opts ...grpc.CallOption) (*CreateVacancyResponse, error)
+	// UpdateVacancy replaces properties of the given vacancy.
+	// Only admins and managers of the vacancy, passed in x-hr-user
+	// metadata, may change its owners, unowned vacancies included.
+	UpdateVacancy(ctx context.Context, in *UpdateVacancyRequest, opts ...grpc.CallOption) (*UpdateVacancyResponse, error)
+}
+
+type vacancyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVacancyServiceClient(cc grpc.ClientConnInterface) VacancyServiceClient {
+	return &vacancyServiceClient{cc}
+}
+
+func (c *vacancyServiceClient) ListVacancies(ctx context.Context, in *ListVacanciesRequest, opts ...grpc.CallOption) (*ListVacanciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVacanciesResponse)
+	err := c.cc.Invoke(ctx, VacancyService_ListVacancies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vacancyServiceClient) GetVacancy(ctx context.Context, in *GetVacancyRequest, opts ...grpc.CallOption) (*GetVacancyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVacancyResponse)
+	err := c.cc.Invoke(ctx, VacancyService_GetVacancy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vacancyServiceClient) CreateVacancy(ctx context.Context, in *CreateVacancyRequest, opts ...grpc.CallOption) (*CreateVacancyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateVacancyResponse)
+	err := c.cc.Invoke(ctx, VacancyService_CreateVacancy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vacancyServiceClient) UpdateVacancy(ctx context.Context, in *UpdateVacancyRequest, opts ...grpc.CallOption) (*UpdateVacancyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateVacancyResponse)
+	err := c.cc.Invoke(ctx, VacancyService_UpdateVacancy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VacancyServiceServer is the server API for VacancyService service.
+// All implementations must embed UnimplementedVacancyServiceServer
+// for forward compatibility.
+//
+// VacancyService manages vacancies.
+type VacancyServiceServer interface {
+	// ListVacancies returns vacancies ordered by update time.
+	ListVacancies(context.Context, *ListVacanciesRequest) (*ListVacanciesResponse, error)
+	// GetVacancy returns the vacancy with skills.
+	GetVacancy(context.Context, *GetVacancyRequest) (*GetVacancyResponse, error)
+	// CreateVacancy creates vacancy with the given properties.
+	CreateVacancy(context.Context, *CreateVacancyRequest) (*CreateVacancyResponse, error)
+	// UpdateVacancy replaces properties of the given vacancy.
+	// Only admins and managers of the vacancy, passed in x-hr-user
+	// metadata, may change its owners, unowned vacancies included.
+	UpdateVacancy(context.Context, *UpdateVacancyRequest) (*UpdateVacancyResponse, error)
+	mustEmbedUnimplementedVacancyServiceServer()
+}
+
+// UnimplementedVacancyServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVacancyServiceServer struct{}
+
+func (UnimplementedVacancyServiceServer) ListVacancies(context.Context, *ListVacanciesRequest) (*ListVacanciesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListVacancies not implemented")
+}
+func (UnimplementedVacancyServiceServer) GetVacancy(context.Context, *GetVacancyRequest) (*GetVacancyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetVacancy not implemented")
+}
+func (UnimplementedVacancyServiceServer) CreateVacancy(context.Context, *CreateVacancyRequest) (*CreateVacancyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateVacancy not implemented")
+}
+func (UnimplementedVacancyServiceServer) UpdateVacancy(context.Context, *UpdateVacancyRequest) (*UpdateVacancyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateVacancy not implemented")
+}
+func (UnimplementedVacancyServiceServer) mustEmbedUnimplementedVacancyServiceServer() {}
+func (UnimplementedVacancyServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeVacancyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VacancyServiceServer will
+// result in compilation errors.
+type UnsafeVacancyServiceServer interface {
+	mustEmbedUnimplementedVacancyServiceServer()
+}
+
+func RegisterVacancyServiceServer(s grpc.ServiceRegistrar, srv VacancyServiceServer) {
+	// If the following call panics, it indicates UnimplementedVacancyServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VacancyService_ServiceDesc, srv)
+}
+
+func _VacancyService_ListVacancies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVacanciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VacancyServiceServer).ListVacancies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VacancyService_ListVacancies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VacancyServiceServer).ListVacancies(ctx, req.(*ListVacanciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VacancyService_GetVacancy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVacancyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VacancyServiceServer).GetVacancy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VacancyService_GetVacancy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VacancyServiceServer).GetVacancy(ctx, req.(*GetVacancyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VacancyService_CreateVacancy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVacancyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VacancyServiceServer).CreateVacancy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VacancyService_CreateVacancy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VacancyServiceServer).CreateVacancy(ctx, req.(*CreateVacancyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VacancyService_UpdateVacancy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVacancyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VacancyServiceServer).UpdateVacancy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VacancyService_UpdateVacancy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VacancyServiceServer).UpdateVacancy(ctx, req.(*UpdateVacancyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VacancyService_ServiceDesc is the grpc.ServiceDesc for VacancyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VacancyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hr.v1.VacancyService",
+	HandlerType: (*VacancyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListVacancies",
+			Handler:    _VacancyService_ListVacancies_Handler,
+		},
+		{
+			MethodName: "GetVacancy",
+			Handler:    _VacancyService_GetVacancy_Handler,
+		},
+		{
+			MethodName: "CreateVacancy",
+			Handler:    _VacancyService_CreateVacancy_Handler,
+		},
+		{
+			MethodName: "UpdateVacancy",
+			Handler:    _VacancyService_UpdateVacancy_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hr/v1/vacancy.proto",
+}
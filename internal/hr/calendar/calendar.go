@@ -0,0 +1,127 @@
+// Package calendar writes iCalendar (RFC 5545) documents, e.g. interview
+// invites which calendar applications import or receive by email.
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Methods of iTIP (RFC 5546) scheduling messages.
+const (
+	MethodPublish = "PUBLISH"
+	MethodRequest = "REQUEST"
+	MethodCancel  = "CANCEL"
+)
+
+const (
+	prodID    = "-//gpb.ru//HR API//EN"
+	lineLimit = 75
+	crlf      = "\r\n"
+)
+
+// Event is a calendar event.
+type Event struct {
+	// UID identifies the event across revisions. Together with a growing
+	// Sequence it lets calendars update the event they already have.
+	UID         string
+	Sequence    int
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	// Organizer and Attendees are email addresses.
+	Organizer string
+	Attendees []string
+	Cancelled bool
+}
+
+// Write writes calendar with the given events.
+func Write(w io.Writer, method string, events ...Event) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeLine(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", prodID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", method)
+	for _, event := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(event.UID))
+		line("SEQUENCE", strconv.Itoa(event.Sequence))
+		line("DTSTAMP", formatTime(event.Stamp))
+		line("DTSTART", formatTime(event.Start))
+		line("DTEND", formatTime(event.End))
+		line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		if event.Location != "" {
+			line("LOCATION", escape(event.Location))
+		}
+		if event.URL != "" {
+			line("URL", event.URL)
+		}
+		if event.Organizer != "" {
+			line("ORGANIZER", "mailto:"+event.Organizer)
+		}
+		for _, attendee := range event.Attendees {
+			writeLine(bw, "ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:"+attendee)
+		}
+		if event.Cancelled {
+			line("STATUS", "CANCELLED")
+		} else {
+			line("STATUS", "CONFIRMED")
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	return bw.Flush()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// escape escapes TEXT value.
+func escape(v string) string {
+	return escaper.Replace(v)
+}
+
+// writeLine writes content line folded to lines of at most 75 octets
+// without splitting UTF-8 sequences.
+func writeLine(w *bufio.Writer, v string) {
+	limit := lineLimit
+	for len(v) > limit {
+		n := limit
+		for n > 0 && !utf8.RuneStart(v[n]) {
+			n--
+		}
+		w.WriteString(v[:n])
+		w.WriteString(crlf + " ")
+		v = v[n:]
+		// The leading space of continuation lines counts too.
+		limit = lineLimit - 1
+	}
+	w.WriteString(v)
+	w.WriteString(crlf)
+}
@@ -0,0 +1,65 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	start := time.Date(2024, 3, 1, 13, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+
+	var b strings.Builder
+	err := Write(&b, MethodRequest, Event{
+		UID:         "42@hr",
+		Sequence:    2,
+		Stamp:       start.Add(-24 * time.Hour),
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Summary:     "Interview: John Doe, Go developer",
+		Description: "Stage 1;\nbring laptop",
+		URL:         "https://meet.example.com/abc",
+		Organizer:   "hr@example.com",
+		Attendees:   []string{"lead@example.com"},
+	})
+	require.NoError(t, err)
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//gpb.ru//HR API//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:REQUEST",
+		"BEGIN:VEVENT",
+		"UID:42@hr",
+		"SEQUENCE:2",
+		"DTSTAMP:20240229T100000Z",
+		"DTSTART:20240301T100000Z",
+		"DTEND:20240301T110000Z",
+		`SUMMARY:Interview: John Doe\, Go developer`,
+		`DESCRIPTION:Stage 1\;\nbring laptop`,
+		"URL:https://meet.example.com/abc",
+		"ORGANIZER:mailto:hr@example.com",
+		"ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:lead@e",
+		" xample.com",
+		"STATUS:CONFIRMED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	require.Equal(t, want, b.String())
+}
+
+func TestWriteLine_Fold(t *testing.T) {
+	var b strings.Builder
+	err := Write(&b, MethodPublish, Event{Summary: strings.Repeat("ы", 100)})
+	require.NoError(t, err)
+
+	for _, line := range strings.Split(b.String(), "\r\n") {
+		require.LessOrEqual(t, len(line), lineLimit, line)
+		require.True(t, strings.ToValidUTF8(line, "?") == line, "line must not split runes")
+	}
+	require.Contains(t, strings.ReplaceAll(b.String(), "\r\n ", ""), "SUMMARY:"+strings.Repeat("ы", 100))
+}
@@ -0,0 +1,268 @@
+// Package careers lets candidates see active vacancies and apply to them
+// without a recruiter. Applications from known contacts are added to the
+// existing candidate instead of creating a duplicate.
+package careers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+// Source marks cards and consents of applications.
+const Source = "careers"
+
+var (
+	ErrConsentRequired = errors.New("consent to personal data processing is required")
+	ErrContactRequired = errors.New("email or phone is required")
+)
+
+// Vacancy is the public part of an active vacancy. Budget, template,
+// status and salaries not made public stay internal.
+type Vacancy struct {
+	ID           uuid.UUID `json:"id"`
+	Title        string    `json:"title"`
+	Area         string    `json:"area,omitempty"`
+	Department   string    `json:"department,omitempty"`
+	Skills       []string  `json:"skills"`
+	Duties       []string  `json:"duties"`
+	Requirements []string  `json:"requirements"`
+	Experience   uint32    `json:"experience"`
+	// Salary is nil unless the vacancy publishes it.
+	Salary  *entities.SalaryRange `json:"salary,omitempty"`
+	Created time.Time             `json:"created"`
+}
+
+func publicVacancy(v *entities.Vacancy) Vacancy {
+	skills := make([]string, len(v.Skills))
+	for i, skill := range v.Skills {
+		skills[i] = skill.Title
+	}
+	var salary *entities.SalaryRange
+	if v.Salary.Public && !v.Salary.Empty() {
+		public := v.Salary
+		salary = &public
+	}
+	return Vacancy{
+		ID:           v.ID,
+		Title:        v.Title,
+		Area:         v.Area,
+		Department:   v.Department,
+		Skills:       skills,
+		Duties:       nonNil(v.Duties),
+		Requirements: nonNil(v.Requirements),
+		Experience:   v.Experience,
+		Salary:       salary,
+		Created:      v.Created,
+	}
+}
+
+func nonNil(items []string) []string {
+	if items == nil {
+		return []string{}
+	}
+	return items
+}
+
+// Application is what the candidate submits.
+type Application struct {
+	VacancyID uuid.UUID
+	// Candidate holds the form fields filled in with the resume draft.
+	Candidate entities.Candidate
+	// Message is the cover letter, it becomes a comment of the card.
+	Message string
+	// Consent is the checkbox allowing to process personal data for the
+	// vacancy, applications without it are rejected.
+	Consent bool
+	// TalentPool allows offering other vacancies later.
+	TalentPool bool
+	// Resume is the parsed upload with its Data, nil if none.
+	Resume     *entities.Resume
+	ResumeData []byte
+}
+
+// Result tells what the application was saved as. It is not shown to the
+// applicant.
+type Result struct {
+	CandidateID uuid.UUID
+	CardID      uuid.UUID
+	// Duplicates are known candidates sharing contacts with the applicant.
+	// Anyone may apply with anyone's contacts, so a recruiter merges them
+	// rather than the application changing a known candidate.
+	Duplicates []entities.Duplicate
+}
+
+// Careers serves the public careers site.
+type Careers struct {
+	vacancy    repos.VacancyRepo
+	candidate  repos.CandidateRepo
+	card       repos.CardRepo
+	consent    repos.ConsentRepo
+	resume     repos.ResumeRepo
+	dictionary repos.DictionaryRepo
+
+	// ConsentTerm is how long consents given with applications last.
+	ConsentTerm time.Duration
+
+	now func() time.Time
+}
+
+// New creates careers with consents given for a year.
+func New(r repos.Repos) *Careers {
+	return &Careers{
+		vacancy:     r.Vacancy,
+		candidate:   r.Candidate,
+		card:        r.Card,
+		consent:     r.Consent,
+		resume:      r.Resume,
+		dictionary:  r.Dictionary,
+		ConsentTerm: 365 * 24 * time.Hour,
+		now:         time.Now,
+	}
+}
+
+// Vacancies returns active vacancies.
+func (c *Careers) Vacancies(ctx context.Context) ([]Vacancy, error) {
+	all, err := c.vacancy.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vacancies := make([]Vacancy, 0, len(all))
+	for i := range all {
+		if all[i].Status == entities.VacancyStatusActive {
+			vacancies = append(vacancies, publicVacancy(&all[i]))
+		}
+	}
+	return vacancies, nil
+}
+
+// Vacancy returns the active vacancy, others are not found.
+func (c *Careers) Vacancy(ctx context.Context, id uuid.UUID) (*Vacancy, error) {
+	v, err := c.vacancy.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if v.Status != entities.VacancyStatusActive {
+		return nil, repos.ErrVacancyNotFound
+	}
+	public := publicVacancy(v)
+	return &public, nil
+}
+
+// fitDictionaries clears the area and the specialization the applicant
+// typed if they are missing in dictionaries.
+func (c *Careers) fitDictionaries(ctx context.Context, candidate *entities.Candidate) error {
+	items, err := c.dictionary.List(ctx, repos.DictionaryFilter{})
+	if err != nil {
+		return err
+	}
+	candidate.FitDictionaries(entities.NewDictionaries(items))
+	return nil
+}
+
+// Validate checks the application before anything is saved.
+func (a *Application) Validate() error {
+	if !a.Consent {
+		return ErrConsentRequired
+	}
+	err := a.Candidate.Normalize()
+	if err != nil {
+		return err
+	}
+	if a.Candidate.Email == "" && a.Candidate.Phone == "" {
+		return ErrContactRequired
+	}
+	return a.Candidate.Validate()
+}
+
+// Apply saves the application: a new candidate with consents, the resume
+// and a card in the first stage of the vacancy. Known candidates are never
+// changed by the public, ones sharing contacts with the applicant are only
+// reported as duplicates.
+func (c *Careers) Apply(ctx context.Context, app *Application) (*Result, error) {
+	err := app.Validate()
+	if err != nil {
+		return nil, err
+	}
+	_, err = c.Vacancy(ctx, app.VacancyID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate := app.Candidate
+	candidate.ID = uuid.Nil
+	candidate.Anonymized = nil
+	err = c.fitDictionaries(ctx, &candidate)
+	if err != nil {
+		return nil, err
+	}
+	err = c.candidate.Create(ctx, &candidate)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{CandidateID: candidate.ID}
+
+	now := c.now()
+	purposes := []entities.ConsentPurpose{entities.ConsentPurposeRecruitment}
+	if app.TalentPool {
+		purposes = append(purposes, entities.ConsentPurposeTalentPool)
+	}
+	for _, purpose := range purposes {
+		err = c.consent.Create(ctx, &entities.Consent{
+			CandidateID: candidate.ID,
+			Purpose:     purpose,
+			Source:      Source,
+			Given:       now,
+			Expires:     now.Add(c.ConsentTerm),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if app.Resume != nil {
+		resume := *app.Resume
+		resume.Draft = app.Candidate
+		err = c.resume.Create(ctx, &resume, app.ResumeData)
+		if err == nil {
+			err = resume.Confirm(candidate.ID)
+		}
+		if err == nil {
+			err = c.resume.Update(ctx, &resume)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	card := &entities.Card{
+		VacancyID:   app.VacancyID,
+		CandidateID: candidate.ID,
+		Stage:       entities.CardStageNew,
+		Source:      Source,
+	}
+	err = c.card.Create(ctx, card)
+	if err != nil {
+		return nil, err
+	}
+	result.CardID = card.ID
+
+	if app.Message != "" {
+		err = c.card.AddComment(ctx, card.ID, &entities.Comment{Author: Source, Text: app.Message})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	found, err := c.candidate.FindByContacts(ctx, candidate.Phone, candidate.Email)
+	if err != nil {
+		return nil, err
+	}
+	result.Duplicates = entities.FindDuplicates(&candidate, found)
+	return result, nil
+}
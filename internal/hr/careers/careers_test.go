@@ -0,0 +1,136 @@
+package careers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/internal/hr/repos/memory"
+)
+
+func TestCareers_Vacancies(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.New()
+	c := New(mem.Repos())
+
+	active := entities.Vacancy{
+		Title:  "Go developer",
+		Status: entities.VacancyStatusActive,
+		Budget: 300000,
+		Skills: []entities.Skill{{Title: "Go", Important: true}},
+		Salary: entities.SalaryRange{Min: 200000, Max: 250000},
+	}
+	require.NoError(t, mem.Vacancy.Create(ctx, &active))
+	draft := entities.Vacancy{Title: "Draft", Status: entities.VacancyStatusDraft}
+	require.NoError(t, mem.Vacancy.Create(ctx, &draft))
+
+	vacancies, err := c.Vacancies(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []Vacancy{{
+		ID:           active.ID,
+		Title:        "Go developer",
+		Skills:       []string{"Go"},
+		Duties:       []string{},
+		Requirements: []string{},
+		Created:      active.Created,
+	}}, vacancies)
+
+	active.Salary.Public = true
+	require.NoError(t, mem.Vacancy.Update(ctx, &active))
+	vacancy, err := c.Vacancy(ctx, active.ID)
+	require.NoError(t, err)
+	require.Equal(t, &active.Salary, vacancy.Salary)
+
+	_, err = c.Vacancy(ctx, draft.ID)
+	require.ErrorIs(t, err, repos.ErrVacancyNotFound)
+}
+
+func TestCareers_Apply(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.New()
+	c := New(mem.Repos())
+
+	vacancy := entities.Vacancy{Title: "Go developer", Status: entities.VacancyStatusActive}
+	require.NoError(t, mem.Vacancy.Create(ctx, &vacancy))
+
+	app := Application{
+		VacancyID:  vacancy.ID,
+		Candidate:  entities.Candidate{Name: " Иван  Иванов ", Email: "Ivan@Example.com", Skills: []string{"Go"}},
+		Message:    "Hello",
+		Consent:    true,
+		TalentPool: true,
+		Resume:     &entities.Resume{Filename: "cv.txt", ContentType: "text/plain", Size: 2, Text: "Go"},
+		ResumeData: []byte("Go"),
+	}
+	result, err := c.Apply(ctx, &app)
+	require.NoError(t, err)
+	require.Empty(t, result.Duplicates)
+
+	candidate, err := mem.Candidate.GetByID(ctx, result.CandidateID)
+	require.NoError(t, err)
+	require.Equal(t, "Иван Иванов", candidate.Name)
+	require.Equal(t, "ivan@example.com", candidate.Email)
+
+	card, err := mem.Card.GetByID(ctx, result.CardID)
+	require.NoError(t, err)
+	require.Equal(t, entities.CardStageNew, card.Stage)
+	require.Equal(t, Source, card.Source)
+	require.Equal(t, "Hello", card.Comments[0].Text)
+
+	consents, err := mem.Consent.List(ctx, candidate.ID)
+	require.NoError(t, err)
+	require.Len(t, consents, 2)
+	require.Equal(t, Source, consents[0].Source)
+	require.True(t, consents[0].Active(c.now()))
+
+	resumes, err := mem.Resume.List(ctx, candidate.ID)
+	require.NoError(t, err)
+	require.Len(t, resumes, 1)
+	require.Equal(t, entities.ResumeStatusConfirmed, resumes[0].Status)
+
+	// Known contacts make another candidate, the known one stays untouched
+	// for a recruiter to merge.
+	again := Application{
+		VacancyID: vacancy.ID,
+		Candidate: entities.Candidate{Name: "Ivan", Email: "ivan@example.com"},
+		Message:   "It is me",
+		Consent:   true,
+	}
+	result2, err := c.Apply(ctx, &again)
+	require.NoError(t, err)
+	require.NotEqual(t, result.CandidateID, result2.CandidateID)
+	require.NotEqual(t, result.CardID, result2.CardID)
+	require.Equal(t, []entities.Duplicate{{
+		CandidateID: result.CandidateID,
+		Name:        "Иван Иванов",
+		Reasons:     []entities.DuplicateReason{entities.DuplicateReasonEmail},
+	}}, result2.Duplicates)
+
+	kept, err := mem.Candidate.GetByID(ctx, result.CandidateID)
+	require.NoError(t, err)
+	require.Equal(t, candidate, kept)
+	card, err = mem.Card.GetByID(ctx, result.CardID)
+	require.NoError(t, err)
+	require.Len(t, card.Comments, 1)
+	consents, err = mem.Consent.List(ctx, result.CandidateID)
+	require.NoError(t, err)
+	require.Len(t, consents, 2)
+
+	invalid := []struct {
+		app  Application
+		want error
+	}{
+		{Application{VacancyID: vacancy.ID, Candidate: entities.Candidate{Name: "A", Email: "a@b.c"}}, ErrConsentRequired},
+		{Application{VacancyID: vacancy.ID, Candidate: entities.Candidate{Name: "A"}, Consent: true}, ErrContactRequired},
+		{Application{VacancyID: vacancy.ID, Candidate: entities.Candidate{Email: "a@b.c"}, Consent: true}, entities.ErrCandidateNameRequired},
+		{Application{VacancyID: uuid.New(), Candidate: entities.Candidate{Name: "A", Email: "a@b.c"}, Consent: true}, repos.ErrVacancyNotFound},
+	}
+	for _, tt := range invalid {
+		_, err = c.Apply(ctx, &tt.app)
+		require.ErrorIs(t, err, tt.want)
+	}
+}
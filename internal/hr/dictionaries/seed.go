@@ -0,0 +1,156 @@
+// Package dictionaries fills dictionaries of areas, departments and
+// specializations from YAML seed files.
+package dictionaries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+var ErrDuplicateName = errors.New("name is seeded twice")
+
+// Node is an item of a seed file with the items nested in it. Items without
+// nested ones may be written as plain names.
+type Node struct {
+	Name  string `yaml:"name"`
+	Items []Node `yaml:"items"`
+}
+
+func (n *Node) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		n.Name = value.Value
+		return nil
+	}
+	type node Node
+	return value.Decode((*node)(n))
+}
+
+// Seed holds trees of the dictionaries:
+//
+//	area:
+//	  - name: Московская область
+//	    items: [Москва, Химки]
+//	department:
+//	  - name: IT
+//	    items: [Backend, QA]
+//	specialization: [Go developer, Analyst]
+type Seed map[entities.DictionaryKind][]Node
+
+// Parse reads the seed file.
+func Parse(data []byte) (Seed, error) {
+	var raw map[string][]Node
+	err := yaml.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+	seed := make(Seed, len(raw))
+	for name, nodes := range raw {
+		var kind entities.DictionaryKind
+		err = kind.UnmarshalText([]byte(name))
+		if err == nil && kind == entities.DictionaryKindNone {
+			err = entities.ErrInvalidDictionaryKind
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", err, name)
+		}
+		seed[kind] = nodes
+	}
+	return seed, nil
+}
+
+// Result counts items of the seed.
+type Result struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+}
+
+// Apply creates items of the seed missing in dictionaries. Items already
+// there, compared by entities.DictionaryKey, get the spelling and the parent
+// of the seed. Items missing in the seed are left as they are, so the seed
+// can be applied again after it grows.
+func Apply(ctx context.Context, repo repos.DictionaryRepo, seed Seed) (*Result, error) {
+	result := &Result{}
+	for _, kind := range entities.DictionaryKinds {
+		nodes, ok := seed[kind]
+		if !ok {
+			continue
+		}
+		existing, err := repo.List(ctx, repos.DictionaryFilter{Kind: kind})
+		if err != nil {
+			return result, err
+		}
+		s := seeder{
+			repo:     repo,
+			kind:     kind,
+			result:   result,
+			existing: make(map[string]entities.DictionaryItem, len(existing)),
+			seeded:   make(map[string]bool),
+		}
+		for _, item := range existing {
+			s.existing[entities.DictionaryKey(item.Name)] = item
+		}
+		err = s.apply(ctx, nodes, uuid.Nil)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// seeder applies trees of one dictionary.
+type seeder struct {
+	repo     repos.DictionaryRepo
+	kind     entities.DictionaryKind
+	result   *Result
+	existing map[string]entities.DictionaryItem
+	seeded   map[string]bool
+}
+
+// apply saves the nodes under the parent, parents before their children.
+func (s *seeder) apply(ctx context.Context, nodes []Node, parentID uuid.UUID) error {
+	for _, node := range nodes {
+		item := entities.DictionaryItem{Kind: s.kind, Name: node.Name, ParentID: parentID}
+		item.Normalize()
+		err := item.Validate()
+		if err != nil {
+			return err
+		}
+
+		key := entities.DictionaryKey(item.Name)
+		if s.seeded[key] {
+			return fmt.Errorf("%w: %s %q", ErrDuplicateName, s.kind, item.Name)
+		}
+		s.seeded[key] = true
+
+		old, ok := s.existing[key]
+		switch {
+		case !ok:
+			err = s.repo.Create(ctx, &item)
+			s.result.Created++
+		case old.Name != item.Name || old.ParentID != item.ParentID:
+			item.ID = old.ID
+			err = s.repo.Update(ctx, &item)
+			s.result.Updated++
+		default:
+			item = old
+			s.result.Unchanged++
+		}
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", s.kind, item.Name, err)
+		}
+
+		err = s.apply(ctx, node.Items, item.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
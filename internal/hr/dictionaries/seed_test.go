@@ -0,0 +1,74 @@
+package dictionaries
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/internal/hr/repos/memory"
+)
+
+const seedFile = `
+area:
+  - name: Московская область
+    items: [Москва, Химки]
+department:
+  - name: IT
+    items:
+      - Backend
+      - name: QA
+specialization: [Go developer]
+`
+
+func TestParse(t *testing.T) {
+	seed, err := Parse([]byte(seedFile))
+	require.NoError(t, err)
+	require.Equal(t, []Node{{Name: "Московская область", Items: []Node{{Name: "Москва"}, {Name: "Химки"}}}}, seed[entities.DictionaryKindArea])
+	require.Equal(t, []Node{{Name: "IT", Items: []Node{{Name: "Backend"}, {Name: "QA"}}}}, seed[entities.DictionaryKindDepartment])
+	require.Equal(t, []Node{{Name: "Go developer"}}, seed[entities.DictionaryKindSpecialization])
+
+	_, err = Parse([]byte("city: [Москва]"))
+	require.ErrorIs(t, err, entities.ErrInvalidDictionaryKind)
+	_, err = Parse([]byte("none: [Москва]"))
+	require.ErrorIs(t, err, entities.ErrInvalidDictionaryKind)
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.New()
+	moscow := entities.DictionaryItem{Kind: entities.DictionaryKindArea, Name: "москва"}
+	require.NoError(t, mem.Dictionary.Create(ctx, &moscow))
+	spb := entities.DictionaryItem{Kind: entities.DictionaryKindArea, Name: "Санкт-Петербург"}
+	require.NoError(t, mem.Dictionary.Create(ctx, &spb))
+	vacancy := entities.Vacancy{Title: "Go developer", Area: "москва"}
+	require.NoError(t, mem.Vacancy.Create(ctx, &vacancy))
+
+	seed, err := Parse([]byte(seedFile))
+	require.NoError(t, err)
+	result, err := Apply(ctx, mem.Dictionary, seed)
+	require.NoError(t, err)
+	require.Equal(t, &Result{Created: 6, Updated: 1}, result)
+
+	areas, err := mem.Dictionary.List(ctx, repos.DictionaryFilter{Kind: entities.DictionaryKindArea})
+	require.NoError(t, err)
+	require.Len(t, areas, 4, "items missing in the seed are kept")
+	updated, err := mem.Dictionary.GetByID(ctx, moscow.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Москва", updated.Name)
+	require.NotEqual(t, uuid.Nil, updated.ParentID)
+	stored, err := mem.Vacancy.GetByID(ctx, vacancy.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Москва", stored.Area, "vacancies get the seeded spelling")
+
+	result, err = Apply(ctx, mem.Dictionary, seed)
+	require.NoError(t, err)
+	require.Equal(t, &Result{Unchanged: 7}, result)
+
+	seed[entities.DictionaryKindSpecialization] = []Node{{Name: "Analyst"}, {Name: " analyst"}}
+	_, err = Apply(ctx, mem.Dictionary, seed)
+	require.ErrorIs(t, err, ErrDuplicateName)
+}
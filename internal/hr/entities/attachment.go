@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment is a file kept for a candidate, like a portfolio, a test
+// assignment or a signed document. Attachments of a card belong to its
+// candidate too. Contents are stored once per distinct SHA-256.
+type Attachment struct {
+	ID          uuid.UUID `json:"id"`
+	CandidateID uuid.UUID `json:"candidateID"`
+	// CardID is nil for attachments of the candidate itself.
+	CardID      uuid.UUID `json:"cardID"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	Uploader    string    `json:"uploader"`
+	Created     time.Time `json:"created"`
+}
+
+var (
+	ErrAttachmentEmpty    = errors.New("attachment file is empty")
+	ErrAttachmentFilename = errors.New("attachment filename is required")
+	ErrAttachmentOwner    = errors.New("attachment must belong to a candidate")
+)
+
+func (a *Attachment) Validate() error {
+	switch {
+	case a.CandidateID == uuid.Nil:
+		return ErrAttachmentOwner
+	case a.Filename == "":
+		return ErrAttachmentFilename
+	case a.Size == 0:
+		return ErrAttachmentEmpty
+	}
+	return nil
+}
+
+// BlobKey returns key of the contents in the blob store.
+func (a *Attachment) BlobKey() string {
+	if len(a.SHA256) < 2 {
+		return a.SHA256
+	}
+	return a.SHA256[:2] + "/" + a.SHA256
+}
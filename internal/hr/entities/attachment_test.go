@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachment_Validate(t *testing.T) {
+	test := func(change func(*Attachment), want error) func(*testing.T) {
+		return func(t *testing.T) {
+			attachment := Attachment{
+				CandidateID: uuid.New(),
+				Filename:    "portfolio.pdf",
+				Size:        1024,
+			}
+			change(&attachment)
+			require.Exactly(t, want, attachment.Validate())
+		}
+	}
+
+	t.Run("valid", test(func(*Attachment) {}, nil))
+	t.Run("no candidate", test(func(a *Attachment) { a.CandidateID = uuid.Nil }, ErrAttachmentOwner))
+	t.Run("no filename", test(func(a *Attachment) { a.Filename = "" }, ErrAttachmentFilename))
+	t.Run("empty", test(func(a *Attachment) { a.Size = 0 }, ErrAttachmentEmpty))
+}
+
+func TestAttachment_BlobKey(t *testing.T) {
+	attachment := Attachment{SHA256: "ab12cd"}
+	require.Exactly(t, "ab/ab12cd", attachment.BlobKey())
+}
@@ -148,20 +148,61 @@ type Experience struct {
 }
 
 type Candidate struct {
-	ID             uuid.UUID      `json:"id"`
-	Name           string         `json:"name"`
-	Phone          string         `json:"phone"`
-	Email          string         `json:"email"`
-	Specialization string         `json:"specialization"`
-	Gender         Gender         `json:"gender"`
-	BirthDate      *time.Time     `json:"birthDate"`
-	Area           string         `json:"area"`
-	Salary         uint32         `json:"salary"`
+	ID             uuid.UUID  `json:"id"`
+	Name           string     `json:"name"`
+	Phone          string     `json:"phone"`
+	Email          string     `json:"email"`
+	Specialization string     `json:"specialization"`
+	Gender         Gender     `json:"gender"`
+	BirthDate      *time.Time `json:"birthDate"`
+	Area           string     `json:"area"`
+	// Salary is the net salary the candidate expects.
+	Salary uint32 `json:"salary"`
+	// SalaryCurrency is the ISO 4217 code of the salary, the base currency
+	// of exchange rates if empty.
+	SalaryCurrency string         `json:"salaryCurrency,omitempty"`
+	SalaryPeriod   SalaryPeriod   `json:"salaryPeriod"`
 	EducationLevel EducationLevel `json:"educationLevel"`
 	Education      []Education    `json:"education"`
 	Experience     []Experience   `json:"experience"`
 	Languages      []string       `json:"languages"`
 	Skills         []string       `json:"skills"`
-	Created        time.Time      `json:"created"`
-	Updated        time.Time      `json:"updated"`
+	// Anonymized is when personal data of the candidate was erased.
+	Anonymized *time.Time `json:"anonymized"`
+	Created    time.Time  `json:"created"`
+	Updated    time.Time  `json:"updated"`
+}
+
+var (
+	ErrCandidateNameRequired = errors.New("candidate name is required")
+	ErrCandidateAnonymized   = errors.New("candidate is anonymized")
+)
+
+func (c *Candidate) Validate() error {
+	if c.Name == "" {
+		return ErrCandidateNameRequired
+	}
+	return nil
+}
+
+// AnonymousName replaces names of anonymized candidates.
+const AnonymousName = "Anonymous"
+
+// Anonymize scrubs personal data of the candidate. Area, specialization,
+// salary, education level, languages, skills, years of education and work
+// periods are kept for statistics.
+func (c *Candidate) Anonymize(now time.Time) {
+	c.Name = AnonymousName
+	c.Phone = ""
+	c.Email = ""
+	c.Gender = GenderNone
+	c.BirthDate = nil
+	for i := range c.Education {
+		c.Education[i].Title = ""
+	}
+	for i := range c.Experience {
+		c.Experience[i].Title = ""
+		c.Experience[i].Description = ""
+	}
+	c.Anonymized = &now
 }
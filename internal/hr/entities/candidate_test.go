@@ -2,6 +2,7 @@ package entities
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -147,3 +148,68 @@ func TestEducationLevel_UnmarshalText(t *testing.T) {
 		t.Run(tt.name, test(tt.data, tt.want, tt.wantErr))
 	}
 }
+
+func TestCandidate_Validate(t *testing.T) {
+	test := func(candidate Candidate, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			err := candidate.Validate()
+			require.Exactly(t, wantErr, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		candidate Candidate
+		wantErr   error
+	}{
+		{
+			name:      "valid",
+			candidate: Candidate{Name: "John Doe"},
+			wantErr:   nil,
+		},
+		{
+			name:      "no name",
+			candidate: Candidate{},
+			wantErr:   ErrCandidateNameRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.candidate, tt.wantErr))
+	}
+}
+
+func TestCandidate_Anonymize(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	birth := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2012, 9, 1, 0, 0, 0, 0, time.UTC)
+	candidate := Candidate{
+		Name:           "Иван Иванов",
+		Phone:          "+79991234567",
+		Email:          "ivan@example.com",
+		Specialization: "Backend",
+		Gender:         GenderMale,
+		BirthDate:      &birth,
+		Area:           "Москва",
+		Salary:         200000,
+		EducationLevel: EducationLevelMaster,
+		Education:      []Education{{Title: "МГУ", Year: 2012}},
+		Experience:     []Experience{{Title: "Developer at Acme", Description: "Go", Start: &start}},
+		Languages:      []string{"en"},
+		Skills:         []string{"Go"},
+	}
+	candidate.Anonymize(now)
+
+	require.Exactly(t, Candidate{
+		Name:           AnonymousName,
+		Specialization: "Backend",
+		Area:           "Москва",
+		Salary:         200000,
+		EducationLevel: EducationLevelMaster,
+		Education:      []Education{{Year: 2012}},
+		Experience:     []Experience{{Start: &start}},
+		Languages:      []string{"en"},
+		Skills:         []string{"Go"},
+		Anonymized:     &now,
+	}, candidate)
+}
@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CardStage byte
+
+const (
+	CardStageNone CardStage = iota
+	CardStageNew
+	CardStageScreening
+	CardStageInterview
+	CardStageOffer
+	CardStageHired
+	CardStageRejected
+	// CardStageInbox holds cards created from incoming mail until a
+	// recruiter looks at them.
+	CardStageInbox
+	cardStageCount
+)
+
+var cardStageStrings = []string{
+	"none",
+	"new",
+	"screening",
+	"interview",
+	"offer",
+	"hired",
+	"rejected",
+	"inbox",
+}
+
+func (stage CardStage) String() string {
+	if stage >= cardStageCount {
+		return cardStageStrings[CardStageNone]
+	}
+	return cardStageStrings[stage]
+}
+
+func (stage CardStage) MarshalText() ([]byte, error) {
+	v := stage.String()
+	return []byte(v), nil
+}
+
+var cardStageTexts = map[string]CardStage{
+	"":          CardStageNone,
+	"none":      CardStageNone,
+	"new":       CardStageNew,
+	"screening": CardStageScreening,
+	"interview": CardStageInterview,
+	"offer":     CardStageOffer,
+	"hired":     CardStageHired,
+	"rejected":  CardStageRejected,
+	"inbox":     CardStageInbox,
+}
+
+var ErrInvalidCardStage = errors.New("invalid card stage")
+
+func (stage *CardStage) UnmarshalText(data []byte) error {
+	v, ok := cardStageTexts[string(data)]
+	if !ok {
+		return ErrInvalidCardStage
+	}
+	*stage = v
+	return nil
+}
+
+func (stage *CardStage) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return stage.UnmarshalText([]byte(v))
+	case []byte:
+		return stage.UnmarshalText(v)
+	}
+	return nil
+}
+
+type Comment struct {
+	ID      uuid.UUID `json:"id"`
+	Author  string    `json:"author"`
+	Text    string    `json:"text"`
+	Created time.Time `json:"created"`
+}
+
+// Card binds a candidate to a vacancy and tracks the candidate's progress
+// through the hiring pipeline of that vacancy.
+type Card struct {
+	ID          uuid.UUID `json:"id"`
+	VacancyID   uuid.UUID `json:"vacancyID"`
+	CandidateID uuid.UUID `json:"candidateID"`
+	Stage       CardStage `json:"stage"`
+	// Source tells where the candidate came from, e.g. "hh" or "referral",
+	// for reports on source effectiveness.
+	Source   string    `json:"source,omitempty"`
+	Comments []Comment `json:"comments"`
+	// Feedback aggregates interview scorecards visible to the reader. It
+	// is not stored with the card.
+	Feedback *Feedback `json:"feedback,omitempty"`
+	Created  time.Time `json:"created"`
+	Updated  time.Time `json:"updated"`
+}
+
+var (
+	ErrCardVacancyRequired   = errors.New("card vacancy is required")
+	ErrCardCandidateRequired = errors.New("card candidate is required")
+)
+
+func (c *Card) Validate() error {
+	if c.VacancyID == uuid.Nil {
+		return ErrCardVacancyRequired
+	}
+	if c.CandidateID == uuid.Nil {
+		return ErrCardCandidateRequired
+	}
+	return nil
+}
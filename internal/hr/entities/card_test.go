@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardStage_UnmarshalText(t *testing.T) {
+	test := func(data []byte, want CardStage, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			var stage CardStage
+			err := stage.UnmarshalText(data)
+			require.Exactly(t, wantErr, err)
+			require.Exactly(t, want, stage)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    CardStage
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			data:    []byte(""),
+			want:    CardStageNone,
+			wantErr: nil,
+		},
+		{
+			name:    "new",
+			data:    []byte("new"),
+			want:    CardStageNew,
+			wantErr: nil,
+		},
+		{
+			name:    "screening",
+			data:    []byte("screening"),
+			want:    CardStageScreening,
+			wantErr: nil,
+		},
+		{
+			name:    "interview",
+			data:    []byte("interview"),
+			want:    CardStageInterview,
+			wantErr: nil,
+		},
+		{
+			name:    "offer",
+			data:    []byte("offer"),
+			want:    CardStageOffer,
+			wantErr: nil,
+		},
+		{
+			name:    "hired",
+			data:    []byte("hired"),
+			want:    CardStageHired,
+			wantErr: nil,
+		},
+		{
+			name:    "rejected",
+			data:    []byte("rejected"),
+			want:    CardStageRejected,
+			wantErr: nil,
+		},
+		{
+			name:    "invalid",
+			data:    []byte("foo"),
+			want:    CardStageNone,
+			wantErr: ErrInvalidCardStage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.data, tt.want, tt.wantErr))
+	}
+}
+
+func TestCard_Validate(t *testing.T) {
+	test := func(card Card, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			err := card.Validate()
+			require.Exactly(t, wantErr, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		card    Card
+		wantErr error
+	}{
+		{
+			name:    "valid",
+			card:    Card{VacancyID: uuid.New(), CandidateID: uuid.New()},
+			wantErr: nil,
+		},
+		{
+			name:    "no vacancy",
+			card:    Card{CandidateID: uuid.New()},
+			wantErr: ErrCardVacancyRequired,
+		},
+		{
+			name:    "no candidate",
+			card:    Card{VacancyID: uuid.New()},
+			wantErr: ErrCardCandidateRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.card, tt.wantErr))
+	}
+}
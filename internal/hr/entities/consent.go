@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConsentPurpose is what the candidate allowed to process personal data for.
+type ConsentPurpose byte
+
+const (
+	ConsentPurposeNone ConsentPurpose = iota
+	// ConsentPurposeRecruitment covers the vacancies the candidate applied to.
+	ConsentPurposeRecruitment
+	// ConsentPurposeTalentPool allows offering other vacancies later.
+	ConsentPurposeTalentPool
+	consentPurposeCount
+)
+
+var consentPurposeStrings = []string{
+	"none",
+	"recruitment",
+	"talentPool",
+}
+
+func (purpose ConsentPurpose) String() string {
+	if purpose >= consentPurposeCount {
+		return consentPurposeStrings[ConsentPurposeNone]
+	}
+	return consentPurposeStrings[purpose]
+}
+
+func (purpose ConsentPurpose) MarshalText() ([]byte, error) {
+	v := purpose.String()
+	return []byte(v), nil
+}
+
+var consentPurposeTexts = map[string]ConsentPurpose{
+	"":            ConsentPurposeNone,
+	"none":        ConsentPurposeNone,
+	"recruitment": ConsentPurposeRecruitment,
+	"talentPool":  ConsentPurposeTalentPool,
+}
+
+var ErrInvalidConsentPurpose = errors.New("invalid consent purpose")
+
+func (purpose *ConsentPurpose) UnmarshalText(data []byte) error {
+	v, ok := consentPurposeTexts[string(data)]
+	if !ok {
+		return ErrInvalidConsentPurpose
+	}
+	*purpose = v
+	return nil
+}
+
+func (purpose *ConsentPurpose) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return purpose.UnmarshalText([]byte(v))
+	case []byte:
+		return purpose.UnmarshalText(v)
+	}
+	return nil
+}
+
+// Consent records the candidate agreeing to personal data processing. Once
+// the candidate has no active consent left the data is anonymized.
+type Consent struct {
+	ID          uuid.UUID      `json:"id"`
+	CandidateID uuid.UUID      `json:"candidateID"`
+	Purpose     ConsentPurpose `json:"purpose"`
+	// Source tells how the consent was collected, e.g. "careers site".
+	Source    string     `json:"source"`
+	Given     time.Time  `json:"given"`
+	Expires   time.Time  `json:"expires"`
+	Withdrawn *time.Time `json:"withdrawn"`
+	Created   time.Time  `json:"created"`
+}
+
+var (
+	ErrConsentCandidate = errors.New("consent must belong to a candidate")
+	ErrConsentPurpose   = errors.New("consent purpose is required")
+	ErrConsentGiven     = errors.New("consent date is required")
+	ErrConsentExpiry    = errors.New("consent must expire after it is given")
+	ErrConsentWithdrawn = errors.New("consent is already withdrawn")
+)
+
+func (c *Consent) Validate() error {
+	switch {
+	case c.CandidateID == uuid.Nil:
+		return ErrConsentCandidate
+	case c.Purpose == ConsentPurposeNone:
+		return ErrConsentPurpose
+	case c.Given.IsZero():
+		return ErrConsentGiven
+	case !c.Expires.After(c.Given):
+		return ErrConsentExpiry
+	}
+	return nil
+}
+
+// Active tells whether the consent allows processing at the moment.
+func (c *Consent) Active(now time.Time) bool {
+	return c.Withdrawn == nil && now.Before(c.Expires)
+}
+
+// Withdraw ends the consent at the moment.
+func (c *Consent) Withdraw(now time.Time) error {
+	if c.Withdrawn != nil {
+		return ErrConsentWithdrawn
+	}
+	c.Withdrawn = &now
+	return nil
+}
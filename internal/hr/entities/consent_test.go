@@ -0,0 +1,75 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsent_Validate(t *testing.T) {
+	given := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := Consent{
+		CandidateID: uuid.New(),
+		Purpose:     ConsentPurposeRecruitment,
+		Given:       given,
+		Expires:     given.AddDate(1, 0, 0),
+	}
+
+	test := func(change func(*Consent), wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			consent := valid
+			change(&consent)
+			require.Exactly(t, wantErr, consent.Validate())
+		}
+	}
+
+	tests := []struct {
+		name    string
+		change  func(*Consent)
+		wantErr error
+	}{
+		{
+			name:    "valid",
+			change:  func(c *Consent) {},
+			wantErr: nil,
+		},
+		{
+			name:    "no candidate",
+			change:  func(c *Consent) { c.CandidateID = uuid.Nil },
+			wantErr: ErrConsentCandidate,
+		},
+		{
+			name:    "no purpose",
+			change:  func(c *Consent) { c.Purpose = ConsentPurposeNone },
+			wantErr: ErrConsentPurpose,
+		},
+		{
+			name:    "no date",
+			change:  func(c *Consent) { c.Given = time.Time{} },
+			wantErr: ErrConsentGiven,
+		},
+		{
+			name:    "expires when given",
+			change:  func(c *Consent) { c.Expires = c.Given },
+			wantErr: ErrConsentExpiry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.change, tt.wantErr))
+	}
+}
+
+func TestConsent_Active(t *testing.T) {
+	given := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	consent := Consent{Given: given, Expires: given.AddDate(1, 0, 0)}
+
+	require.True(t, consent.Active(given.AddDate(0, 6, 0)))
+	require.False(t, consent.Active(given.AddDate(1, 0, 0)))
+
+	require.NoError(t, consent.Withdraw(given.AddDate(0, 1, 0)))
+	require.False(t, consent.Active(given.AddDate(0, 6, 0)))
+	require.Exactly(t, ErrConsentWithdrawn, consent.Withdraw(given.AddDate(0, 2, 0)))
+}
@@ -0,0 +1,220 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DictionaryKind byte
+
+const (
+	DictionaryKindNone DictionaryKind = iota
+	DictionaryKindArea
+	DictionaryKindDepartment
+	DictionaryKindSpecialization
+	dictionaryKindCount
+)
+
+var dictionaryKindStrings = []string{
+	"none",
+	"area",
+	"department",
+	"specialization",
+}
+
+func (kind DictionaryKind) String() string {
+	if kind >= dictionaryKindCount {
+		return dictionaryKindStrings[DictionaryKindNone]
+	}
+	return dictionaryKindStrings[kind]
+}
+
+func (kind DictionaryKind) MarshalText() ([]byte, error) {
+	v := kind.String()
+	return []byte(v), nil
+}
+
+var dictionaryKindTexts = map[string]DictionaryKind{
+	"":               DictionaryKindNone,
+	"none":           DictionaryKindNone,
+	"area":           DictionaryKindArea,
+	"department":     DictionaryKindDepartment,
+	"specialization": DictionaryKindSpecialization,
+}
+
+var ErrInvalidDictionaryKind = errors.New("invalid dictionary kind")
+
+func (kind *DictionaryKind) UnmarshalText(data []byte) error {
+	v, ok := dictionaryKindTexts[string(data)]
+	if !ok {
+		return ErrInvalidDictionaryKind
+	}
+	*kind = v
+	return nil
+}
+
+func (kind *DictionaryKind) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return kind.UnmarshalText([]byte(v))
+	case []byte:
+		return kind.UnmarshalText(v)
+	}
+	return nil
+}
+
+// DictionaryKinds are the dictionaries in the order they are listed.
+var DictionaryKinds = []DictionaryKind{
+	DictionaryKindArea,
+	DictionaryKindDepartment,
+	DictionaryKindSpecialization,
+}
+
+// DictionaryItem is a value of a managed dictionary: an area of vacancies
+// and candidates, a department of vacancies or a specialization of
+// candidates. Items form trees, like regions with their cities or divisions
+// with their departments, and any item is a valid value.
+type DictionaryItem struct {
+	ID   uuid.UUID      `json:"id"`
+	Kind DictionaryKind `json:"kind"`
+	Name string         `json:"name"`
+	// ParentID is nil for the roots of the dictionary.
+	ParentID uuid.UUID `json:"parentID"`
+	Created  time.Time `json:"created"`
+	Updated  time.Time `json:"updated"`
+}
+
+var (
+	ErrDictionaryNameRequired = errors.New("dictionary item name is required")
+	ErrDictionaryKindRequired = errors.New("dictionary kind is required")
+	ErrDictionaryParent       = errors.New("dictionary item parent must be another item of the same dictionary")
+	ErrDictionaryLoop         = errors.New("dictionary item cannot be nested in itself")
+	ErrNotInDictionary        = errors.New("not in dictionary")
+)
+
+// Normalize collapses spaces of the name.
+func (item *DictionaryItem) Normalize() {
+	item.Name = strings.Join(strings.Fields(item.Name), " ")
+}
+
+func (item *DictionaryItem) Validate() error {
+	switch {
+	case item.Kind == DictionaryKindNone || item.Kind >= dictionaryKindCount:
+		return ErrDictionaryKindRequired
+	case DictionaryKey(item.Name) == "":
+		return ErrDictionaryNameRequired
+	case item.ParentID != uuid.Nil && item.ParentID == item.ID:
+		return ErrDictionaryLoop
+	}
+	return nil
+}
+
+// DictionaryKey returns the form dictionary names are compared in: lower
+// case with spaces collapsed.
+func DictionaryKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Dictionaries looks values of vacancies and candidates up in dictionaries.
+// A dictionary without items is not enforced, its values stay free text.
+type Dictionaries struct {
+	items map[uuid.UUID]DictionaryItem
+	names map[DictionaryKind]map[string]string
+}
+
+// NewDictionaries indexes the items of all dictionaries.
+func NewDictionaries(items []DictionaryItem) *Dictionaries {
+	d := &Dictionaries{
+		items: make(map[uuid.UUID]DictionaryItem, len(items)),
+		names: make(map[DictionaryKind]map[string]string),
+	}
+	for _, item := range items {
+		d.items[item.ID] = item
+		if d.names[item.Kind] == nil {
+			d.names[item.Kind] = make(map[string]string)
+		}
+		d.names[item.Kind][DictionaryKey(item.Name)] = item.Name
+	}
+	return d
+}
+
+// Name returns the dictionary name of the value. Empty values and values of
+// empty dictionaries are returned as they are, only spaces are collapsed.
+func (d *Dictionaries) Name(kind DictionaryKind, value string) (string, error) {
+	value = strings.Join(strings.Fields(value), " ")
+	names := d.names[kind]
+	if value == "" || len(names) == 0 {
+		return value, nil
+	}
+	name, ok := names[DictionaryKey(value)]
+	if !ok {
+		return "", fmt.Errorf("%s %q: %w", kind, value, ErrNotInDictionary)
+	}
+	return name, nil
+}
+
+// CheckParent fails unless the parent of the item is another item of the
+// same dictionary the item is not an ancestor of.
+func (d *Dictionaries) CheckParent(item *DictionaryItem) error {
+	for id := item.ParentID; id != uuid.Nil; {
+		parent, ok := d.items[id]
+		if !ok || parent.Kind != item.Kind {
+			return ErrDictionaryParent
+		}
+		if parent.ID == item.ID {
+			return ErrDictionaryLoop
+		}
+		id = parent.ParentID
+	}
+	return nil
+}
+
+// NormalizeDictionaries replaces the area and the department of the vacancy
+// with their dictionary names, failing for values missing in dictionaries.
+func (v *Vacancy) NormalizeDictionaries(d *Dictionaries) error {
+	area, err := d.Name(DictionaryKindArea, v.Area)
+	if err != nil {
+		return err
+	}
+	department, err := d.Name(DictionaryKindDepartment, v.Department)
+	if err != nil {
+		return err
+	}
+	v.Area, v.Department = area, department
+	return nil
+}
+
+// NormalizeDictionaries replaces the area and the specialization of the
+// candidate with their dictionary names, failing for values missing in
+// dictionaries.
+func (c *Candidate) NormalizeDictionaries(d *Dictionaries) error {
+	area, err := d.Name(DictionaryKindArea, c.Area)
+	if err != nil {
+		return err
+	}
+	specialization, err := d.Name(DictionaryKindSpecialization, c.Specialization)
+	if err != nil {
+		return err
+	}
+	c.Area, c.Specialization = area, specialization
+	return nil
+}
+
+// FitDictionaries is NormalizeDictionaries for candidates coming from
+// resumes and applications no recruiter has checked yet: values missing in
+// dictionaries are cleared rather than failing.
+func (c *Candidate) FitDictionaries(d *Dictionaries) {
+	var err error
+	c.Area, err = d.Name(DictionaryKindArea, c.Area)
+	if err != nil {
+		c.Area = ""
+	}
+	c.Specialization, err = d.Name(DictionaryKindSpecialization, c.Specialization)
+	if err != nil {
+		c.Specialization = ""
+	}
+}
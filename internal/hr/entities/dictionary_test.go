@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDictionaryItem_Validate(t *testing.T) {
+	item := DictionaryItem{Kind: DictionaryKindArea, Name: "  Москва  и  область "}
+	item.Normalize()
+	require.Equal(t, "Москва и область", item.Name)
+	require.NoError(t, item.Validate())
+
+	require.ErrorIs(t, (&DictionaryItem{Name: "Москва"}).Validate(), ErrDictionaryKindRequired)
+	require.ErrorIs(t, (&DictionaryItem{Kind: DictionaryKindArea, Name: " "}).Validate(), ErrDictionaryNameRequired)
+
+	id := uuid.New()
+	looped := DictionaryItem{ID: id, Kind: DictionaryKindArea, Name: "Москва", ParentID: id}
+	require.ErrorIs(t, looped.Validate(), ErrDictionaryLoop)
+}
+
+func TestDictionaries(t *testing.T) {
+	region := DictionaryItem{ID: uuid.New(), Kind: DictionaryKindArea, Name: "Московская область"}
+	city := DictionaryItem{ID: uuid.New(), Kind: DictionaryKindArea, Name: "Химки", ParentID: region.ID}
+	division := DictionaryItem{ID: uuid.New(), Kind: DictionaryKindDepartment, Name: "IT"}
+	d := NewDictionaries([]DictionaryItem{region, city, division})
+
+	name, err := d.Name(DictionaryKindArea, " химки ")
+	require.NoError(t, err)
+	require.Equal(t, "Химки", name)
+	_, err = d.Name(DictionaryKindArea, "Химкм")
+	require.ErrorIs(t, err, ErrNotInDictionary)
+	require.EqualError(t, err, `area "Химкм": not in dictionary`)
+	name, err = d.Name(DictionaryKindArea, "")
+	require.NoError(t, err)
+	require.Empty(t, name)
+	name, err = d.Name(DictionaryKindSpecialization, " Go  developer")
+	require.NoError(t, err, "empty dictionaries are not enforced")
+	require.Equal(t, "Go developer", name)
+
+	require.NoError(t, d.CheckParent(&DictionaryItem{Kind: DictionaryKindArea, ParentID: city.ID}))
+	require.NoError(t, d.CheckParent(&DictionaryItem{Kind: DictionaryKindArea}))
+	require.ErrorIs(t, d.CheckParent(&DictionaryItem{Kind: DictionaryKindDepartment, ParentID: city.ID}), ErrDictionaryParent)
+	require.ErrorIs(t, d.CheckParent(&DictionaryItem{Kind: DictionaryKindArea, ParentID: uuid.New()}), ErrDictionaryParent)
+	moved := region
+	moved.ParentID = city.ID
+	require.ErrorIs(t, d.CheckParent(&moved), ErrDictionaryLoop)
+
+	vacancy := Vacancy{Area: "химки", Department: "it"}
+	require.NoError(t, vacancy.NormalizeDictionaries(d))
+	require.Equal(t, "Химки", vacancy.Area)
+	require.Equal(t, "IT", vacancy.Department)
+	vacancy.Department = "ИТ"
+	require.ErrorIs(t, vacancy.NormalizeDictionaries(d), ErrNotInDictionary)
+	require.Equal(t, "Химки", vacancy.Area, "nothing changes on failure")
+
+	candidate := Candidate{Area: "Химкм", Specialization: "Go developer"}
+	require.ErrorIs(t, candidate.NormalizeDictionaries(d), ErrNotInDictionary)
+	candidate.FitDictionaries(d)
+	require.Empty(t, candidate.Area)
+	require.Equal(t, "Go developer", candidate.Specialization)
+}
@@ -0,0 +1,320 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidPhone = errors.New("phone must be an international number")
+
+// NormalizePhone brings the phone to E.164 form like "+79991234567".
+// Spaces, dashes, dots and parentheses are dropped. Numbers without a country
+// code are taken as Russian ones: "8 999 123-45-67" and "999 123-45-67" both
+// become "+79991234567".
+func NormalizePhone(phone string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	if phone == "" {
+		return "", nil
+	}
+
+	international := false
+	digits := make([]byte, 0, len(phone))
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits = append(digits, byte(r))
+		case r == '+' && i == 0:
+			international = true
+		case r == ' ', r == '\u00a0', r == '-', r == '.', r == '(', r == ')':
+		default:
+			return "", fmt.Errorf("%w: %q", ErrInvalidPhone, phone)
+		}
+	}
+
+	switch {
+	case international:
+	case len(digits) > 2 && digits[0] == '0' && digits[1] == '0':
+		digits = digits[2:]
+	case len(digits) == 11 && (digits[0] == '8' || digits[0] == '7'):
+		digits[0] = '7'
+	case len(digits) == 10:
+		digits = append([]byte{'7'}, digits...)
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidPhone, phone)
+	}
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPhone, phone)
+	}
+	return "+" + string(digits), nil
+}
+
+// NormalizeEmail trims and lowercases the email.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Normalize brings contacts of the candidate to the form duplicates are
+// detected by and normalizes the currency of the expected salary.
+func (c *Candidate) Normalize() error {
+	phone, err := NormalizePhone(c.Phone)
+	if err != nil {
+		return err
+	}
+	c.Phone = phone
+	c.Email = NormalizeEmail(c.Email)
+	c.Name = strings.Join(strings.Fields(c.Name), " ")
+	currency, err := NormalizeCurrency(c.SalaryCurrency)
+	if err != nil {
+		return err
+	}
+	c.SalaryCurrency = currency
+	return nil
+}
+
+// DuplicateReason tells which data of two candidates matched.
+type DuplicateReason byte
+
+const (
+	DuplicateReasonNone DuplicateReason = iota
+	DuplicateReasonPhone
+	DuplicateReasonEmail
+	DuplicateReasonName
+	duplicateReasonCount
+)
+
+var duplicateReasonStrings = []string{
+	"none",
+	"phone",
+	"email",
+	"name",
+}
+
+func (reason DuplicateReason) String() string {
+	if reason >= duplicateReasonCount {
+		return duplicateReasonStrings[DuplicateReasonNone]
+	}
+	return duplicateReasonStrings[reason]
+}
+
+func (reason DuplicateReason) MarshalText() ([]byte, error) {
+	v := reason.String()
+	return []byte(v), nil
+}
+
+var duplicateReasonTexts = map[string]DuplicateReason{
+	"":      DuplicateReasonNone,
+	"none":  DuplicateReasonNone,
+	"phone": DuplicateReasonPhone,
+	"email": DuplicateReasonEmail,
+	"name":  DuplicateReasonName,
+}
+
+var ErrInvalidDuplicateReason = errors.New("invalid duplicate reason")
+
+func (reason *DuplicateReason) UnmarshalText(data []byte) error {
+	v, ok := duplicateReasonTexts[string(data)]
+	if !ok {
+		return ErrInvalidDuplicateReason
+	}
+	*reason = v
+	return nil
+}
+
+// Duplicate is a candidate which is likely the same person as another one.
+type Duplicate struct {
+	CandidateID uuid.UUID         `json:"candidateID"`
+	Name        string            `json:"name"`
+	Reasons     []DuplicateReason `json:"reasons"`
+}
+
+// nameSimilarity is the least similarity of names of the same person
+// written differently, e.g. with a typo or in another script.
+const nameSimilarity = 0.85
+
+// SimilarNameKeys reports whether the keys made by NameKey likely belong to
+// the same person. Empty keys are similar to nothing.
+func SimilarNameKeys(a, b string) bool {
+	return a != "" && b != "" && similarity(a, b) >= nameSimilarity
+}
+
+// FindDuplicates returns the candidates sharing a phone or an email with the
+// given one or having a similar name. Contacts are compared as normalized,
+// anonymized candidates are skipped.
+func FindDuplicates(candidate *Candidate, candidates []Candidate) []Duplicate {
+	key := NameKey(candidate.Name)
+	var duplicates []Duplicate
+	for i := range candidates {
+		other := &candidates[i]
+		if other.ID == candidate.ID || other.Anonymized != nil {
+			continue
+		}
+		var reasons []DuplicateReason
+		if candidate.Phone != "" && candidate.Phone == other.Phone {
+			reasons = append(reasons, DuplicateReasonPhone)
+		}
+		if candidate.Email != "" && candidate.Email == other.Email {
+			reasons = append(reasons, DuplicateReasonEmail)
+		}
+		if SimilarNameKeys(key, NameKey(other.Name)) {
+			reasons = append(reasons, DuplicateReasonName)
+		}
+		if len(reasons) > 0 {
+			duplicates = append(duplicates, Duplicate{
+				CandidateID: other.ID,
+				Name:        other.Name,
+				Reasons:     reasons,
+			})
+		}
+	}
+	// Matching contacts are stronger evidence than a similar name.
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Reasons[0] < duplicates[j].Reasons[0]
+	})
+	return duplicates
+}
+
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// NameKey makes names comparable regardless of case, script and word order:
+// "Иванов Иван" and "Ivan Ivanov" both become "ivan ivanov".
+func NameKey(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for i, word := range words {
+		var b strings.Builder
+		for _, r := range word {
+			if latin, ok := cyrillicToLatin[r]; ok {
+				b.WriteString(latin)
+				continue
+			}
+			b.WriteRune(r)
+		}
+		words[i] = b.String()
+	}
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// similarity returns 1 for equal strings down to 0 for completely different
+// ones based on the Levenshtein distance.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return 1 - float64(prev[len(rb)])/float64(max(len(ra), len(rb)))
+}
+
+// Merge combines the duplicate into the candidate. Data of the candidate
+// wins, the duplicate only fills the blanks and adds education, experience,
+// languages and skills the candidate lacks.
+func (c *Candidate) Merge(duplicate *Candidate) {
+	if c.Phone == "" {
+		c.Phone = duplicate.Phone
+	}
+	if c.Email == "" {
+		c.Email = duplicate.Email
+	}
+	if c.Specialization == "" {
+		c.Specialization = duplicate.Specialization
+	}
+	if c.Gender == GenderNone {
+		c.Gender = duplicate.Gender
+	}
+	if c.BirthDate == nil {
+		c.BirthDate = duplicate.BirthDate
+	}
+	if c.Area == "" {
+		c.Area = duplicate.Area
+	}
+	if c.Salary == 0 {
+		c.Salary = duplicate.Salary
+		c.SalaryCurrency = duplicate.SalaryCurrency
+		c.SalaryPeriod = duplicate.SalaryPeriod
+	}
+	if c.EducationLevel < duplicate.EducationLevel {
+		c.EducationLevel = duplicate.EducationLevel
+	}
+
+	for _, e := range duplicate.Education {
+		found := false
+		for _, have := range c.Education {
+			if strings.EqualFold(have.Title, e.Title) && have.Year == e.Year {
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.Education = append(c.Education, e)
+		}
+	}
+	for _, e := range duplicate.Experience {
+		found := false
+		for _, have := range c.Experience {
+			if strings.EqualFold(have.Title, e.Title) && sameTime(have.Start, e.Start) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.Experience = append(c.Experience, e)
+		}
+	}
+	c.Languages = union(c.Languages, duplicate.Languages)
+	c.Skills = union(c.Skills, duplicate.Skills)
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// union appends values missing in the list ignoring case.
+func union(list, values []string) []string {
+	for _, v := range values {
+		found := false
+		for _, have := range list {
+			if strings.EqualFold(have, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,148 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	test := func(phone string, want string, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			got, err := NormalizePhone(phone)
+			require.ErrorIs(t, err, wantErr)
+			require.Exactly(t, want, got)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		phone   string
+		want    string
+		wantErr error
+	}{
+		{name: "empty", phone: " ", want: "", wantErr: nil},
+		{name: "e164", phone: "+79991234567", want: "+79991234567", wantErr: nil},
+		{name: "formatted", phone: "+7 (999) 123-45-67", want: "+79991234567", wantErr: nil},
+		{name: "trunk prefix", phone: "8 999 123 45 67", want: "+79991234567", wantErr: nil},
+		{name: "without plus", phone: "7.999.123.45.67", want: "+79991234567", wantErr: nil},
+		{name: "local", phone: "(999) 123-4567", want: "+79991234567", wantErr: nil},
+		{name: "international prefix", phone: "00 49 30 1234567", want: "+49301234567", wantErr: nil},
+		{name: "foreign", phone: "+44 20 7946 0958", want: "+442079460958", wantErr: nil},
+		{name: "letters", phone: "+7 999 CALL-ME", want: "", wantErr: ErrInvalidPhone},
+		{name: "unknown country", phone: "123-45-67", want: "", wantErr: ErrInvalidPhone},
+		{name: "too long", phone: "+7999123456789012", want: "", wantErr: ErrInvalidPhone},
+		{name: "plus inside", phone: "7+9991234567", want: "", wantErr: ErrInvalidPhone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.phone, tt.want, tt.wantErr))
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	existing := []Candidate{
+		{ID: uuid.New(), Name: "Иванов Иван", Phone: "+79991234567"},
+		{ID: uuid.New(), Name: "Petr Petrov", Email: "petrov@example.com"},
+		{ID: uuid.New(), Name: "Мария Сидорова"},
+		{ID: uuid.New(), Name: "Иван Петров", Email: "ivanov@example.com"},
+	}
+
+	test := func(candidate Candidate, want []Duplicate) func(*testing.T) {
+		return func(t *testing.T) {
+			require.NoError(t, candidate.Normalize())
+			require.Exactly(t, want, FindDuplicates(&candidate, existing))
+		}
+	}
+
+	tests := []struct {
+		name      string
+		candidate Candidate
+		want      []Duplicate
+	}{
+		{
+			name:      "phone",
+			candidate: Candidate{Name: "Alex", Phone: "8 (999) 123-45-67"},
+			want: []Duplicate{
+				{CandidateID: existing[0].ID, Name: "Иванов Иван", Reasons: []DuplicateReason{DuplicateReasonPhone}},
+			},
+		},
+		{
+			name:      "email and name in another script",
+			candidate: Candidate{Name: "Пётр Петров", Email: " Petrov@Example.com "},
+			want: []Duplicate{
+				{
+					CandidateID: existing[1].ID,
+					Name:        "Petr Petrov",
+					Reasons:     []DuplicateReason{DuplicateReasonEmail, DuplicateReasonName},
+				},
+			},
+		},
+		{
+			name:      "name with typo and swapped words",
+			candidate: Candidate{Name: "Сидорова  Мариа"},
+			want: []Duplicate{
+				{CandidateID: existing[2].ID, Name: "Мария Сидорова", Reasons: []DuplicateReason{DuplicateReasonName}},
+			},
+		},
+		{
+			name:      "contacts first",
+			candidate: Candidate{Name: "Ivan Ivanov", Email: "ivanov@example.com"},
+			want: []Duplicate{
+				{CandidateID: existing[3].ID, Name: "Иван Петров", Reasons: []DuplicateReason{DuplicateReasonEmail}},
+				{CandidateID: existing[0].ID, Name: "Иванов Иван", Reasons: []DuplicateReason{DuplicateReasonName}},
+			},
+		},
+		{
+			name:      "different",
+			candidate: Candidate{Name: "Иван Сидоров", Phone: "+79990000000"},
+			want:      nil,
+		},
+		{
+			name:      "itself",
+			candidate: existing[0],
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.candidate, tt.want))
+	}
+}
+
+func TestCandidate_Merge(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	candidate := Candidate{
+		Name:           "Иван Иванов",
+		Email:          "ivan@example.com",
+		EducationLevel: EducationLevelBachelor,
+		Education:      []Education{{Title: "МГУ", Year: 2012}},
+		Experience:     []Experience{{Title: "Developer", Start: &start}},
+		Skills:         []string{"Go"},
+	}
+	candidate.Merge(&Candidate{
+		Name:           "Ivan Ivanov",
+		Phone:          "+79991234567",
+		Email:          "ivanov@example.com",
+		Area:           "Москва",
+		EducationLevel: EducationLevelMaster,
+		Education:      []Education{{Title: "мгу", Year: 2012}, {Title: "МГУ", Year: 2014}},
+		Experience:     []Experience{{Title: "developer", Start: &start}, {Title: "Intern"}},
+		Languages:      []string{"en"},
+		Skills:         []string{"go", "PostgreSQL"},
+	})
+
+	require.Exactly(t, Candidate{
+		Name:           "Иван Иванов",
+		Phone:          "+79991234567",
+		Email:          "ivan@example.com",
+		Area:           "Москва",
+		EducationLevel: EducationLevelMaster,
+		Education:      []Education{{Title: "МГУ", Year: 2012}, {Title: "МГУ", Year: 2014}},
+		Experience:     []Experience{{Title: "Developer", Start: &start}, {Title: "Intern"}},
+		Languages:      []string{"en"},
+		Skills:         []string{"Go", "PostgreSQL"},
+	}, candidate)
+}
@@ -0,0 +1,215 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType byte
+
+const (
+	EventTypeNone EventType = iota
+	EventTypeVacancyCreated
+	EventTypeVacancyStatusChanged
+	EventTypeCandidateCreated
+	EventTypeCardCreated
+	EventTypeCardMoved
+	EventTypeCommentAdded
+	EventTypeVacancyUpdated
+	eventTypeCount
+)
+
+var eventTypeStrings = []string{
+	"none",
+	"vacancyCreated",
+	"vacancyStatusChanged",
+	"candidateCreated",
+	"cardCreated",
+	"cardMoved",
+	"commentAdded",
+	"vacancyUpdated",
+}
+
+func (typ EventType) String() string {
+	if typ >= eventTypeCount {
+		return eventTypeStrings[EventTypeNone]
+	}
+	return eventTypeStrings[typ]
+}
+
+func (typ EventType) MarshalText() ([]byte, error) {
+	v := typ.String()
+	return []byte(v), nil
+}
+
+var eventTypeTexts = map[string]EventType{
+	"":                     EventTypeNone,
+	"none":                 EventTypeNone,
+	"vacancyCreated":       EventTypeVacancyCreated,
+	"vacancyStatusChanged": EventTypeVacancyStatusChanged,
+	"candidateCreated":     EventTypeCandidateCreated,
+	"cardCreated":          EventTypeCardCreated,
+	"cardMoved":            EventTypeCardMoved,
+	"commentAdded":         EventTypeCommentAdded,
+	"vacancyUpdated":       EventTypeVacancyUpdated,
+}
+
+var ErrInvalidEventType = errors.New("invalid event type")
+
+func (typ *EventType) UnmarshalText(data []byte) error {
+	v, ok := eventTypeTexts[string(data)]
+	if !ok {
+		return ErrInvalidEventType
+	}
+	*typ = v
+	return nil
+}
+
+func (typ *EventType) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return typ.UnmarshalText([]byte(v))
+	case []byte:
+		return typ.UnmarshalText(v)
+	}
+	return nil
+}
+
+// Event is a domain event. Events are stored in the outbox together with
+// the change that caused them and delivered to consumers in Seq order.
+type Event struct {
+	ID          uuid.UUID       `json:"id"`
+	Seq         int64           `json:"seq"`
+	Type        EventType       `json:"type"`
+	AggregateID uuid.UUID       `json:"aggregateID"`
+	Payload     json.RawMessage `json:"payload"`
+	Created     time.Time       `json:"created"`
+}
+
+// VacancyID returns the vacancy the event concerns, or uuid.Nil for events
+// unrelated to any vacancy.
+func (e *Event) VacancyID() uuid.UUID {
+	switch e.Type {
+	case EventTypeVacancyCreated, EventTypeVacancyStatusChanged, EventTypeVacancyUpdated:
+		return e.AggregateID
+	}
+
+	var payload struct {
+		VacancyID uuid.UUID `json:"vacancyID"`
+	}
+	_ = json.Unmarshal(e.Payload, &payload)
+	return payload.VacancyID
+}
+
+// Redact blanks personal data of candidates in the payload: names of
+// created candidates and texts of comments. Other payloads only refer to
+// candidates by ID and stay as they are.
+func (e *Event) Redact() error {
+	var payload EventPayload
+	switch e.Type {
+	case EventTypeCandidateCreated:
+		var created CandidateCreated
+		err := json.Unmarshal(e.Payload, &created)
+		if err != nil {
+			return err
+		}
+		created.Name = ""
+		payload = created
+	case EventTypeCommentAdded:
+		var added CommentAdded
+		err := json.Unmarshal(e.Payload, &added)
+		if err != nil {
+			return err
+		}
+		added.Comment.Text = ""
+		payload = added
+	default:
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	e.Payload = data
+	return nil
+}
+
+// EventPayload is implemented by the payloads of domain events.
+type EventPayload interface {
+	EventType() EventType
+}
+
+// NewEvent creates event of the payload type concerning the given aggregate.
+func NewEvent(aggregateID uuid.UUID, payload EventPayload) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		ID:          uuid.New(),
+		Type:        payload.EventType(),
+		AggregateID: aggregateID,
+		Payload:     data,
+		Created:     time.Now(),
+	}, nil
+}
+
+type VacancyCreated struct {
+	Vacancy Vacancy `json:"vacancy"`
+}
+
+func (VacancyCreated) EventType() EventType { return EventTypeVacancyCreated }
+
+type VacancyStatusChanged struct {
+	VacancyID uuid.UUID     `json:"vacancyID"`
+	From      VacancyStatus `json:"from"`
+	To        VacancyStatus `json:"to"`
+}
+
+func (VacancyStatusChanged) EventType() EventType { return EventTypeVacancyStatusChanged }
+
+// VacancyUpdated follows every update of a vacancy, including the ones
+// changing its status.
+type VacancyUpdated struct {
+	Vacancy Vacancy `json:"vacancy"`
+}
+
+func (VacancyUpdated) EventType() EventType { return EventTypeVacancyUpdated }
+
+type CandidateCreated struct {
+	CandidateID uuid.UUID `json:"candidateID"`
+	Name        string    `json:"name"`
+}
+
+func (CandidateCreated) EventType() EventType { return EventTypeCandidateCreated }
+
+type CardCreated struct {
+	CardID      uuid.UUID `json:"cardID"`
+	VacancyID   uuid.UUID `json:"vacancyID"`
+	CandidateID uuid.UUID `json:"candidateID"`
+	Stage       CardStage `json:"stage"`
+}
+
+func (CardCreated) EventType() EventType { return EventTypeCardCreated }
+
+type CardMoved struct {
+	CardID      uuid.UUID `json:"cardID"`
+	VacancyID   uuid.UUID `json:"vacancyID"`
+	CandidateID uuid.UUID `json:"candidateID"`
+	From        CardStage `json:"from"`
+	To          CardStage `json:"to"`
+}
+
+func (CardMoved) EventType() EventType { return EventTypeCardMoved }
+
+type CommentAdded struct {
+	CardID    uuid.UUID `json:"cardID"`
+	VacancyID uuid.UUID `json:"vacancyID"`
+	Comment   Comment   `json:"comment"`
+}
+
+func (CommentAdded) EventType() EventType { return EventTypeCommentAdded }
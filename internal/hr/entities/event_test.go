@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventType_UnmarshalText(t *testing.T) {
+	test := func(data []byte, want EventType, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			var typ EventType
+			err := typ.UnmarshalText(data)
+			require.Exactly(t, wantErr, err)
+			require.Exactly(t, want, typ)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    EventType
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			data:    []byte(""),
+			want:    EventTypeNone,
+			wantErr: nil,
+		},
+		{
+			name:    "vacancyCreated",
+			data:    []byte("vacancyCreated"),
+			want:    EventTypeVacancyCreated,
+			wantErr: nil,
+		},
+		{
+			name:    "vacancyStatusChanged",
+			data:    []byte("vacancyStatusChanged"),
+			want:    EventTypeVacancyStatusChanged,
+			wantErr: nil,
+		},
+		{
+			name:    "candidateCreated",
+			data:    []byte("candidateCreated"),
+			want:    EventTypeCandidateCreated,
+			wantErr: nil,
+		},
+		{
+			name:    "cardCreated",
+			data:    []byte("cardCreated"),
+			want:    EventTypeCardCreated,
+			wantErr: nil,
+		},
+		{
+			name:    "cardMoved",
+			data:    []byte("cardMoved"),
+			want:    EventTypeCardMoved,
+			wantErr: nil,
+		},
+		{
+			name:    "commentAdded",
+			data:    []byte("commentAdded"),
+			want:    EventTypeCommentAdded,
+			wantErr: nil,
+		},
+		{
+			name:    "vacancyUpdated",
+			data:    []byte("vacancyUpdated"),
+			want:    EventTypeVacancyUpdated,
+			wantErr: nil,
+		},
+		{
+			name:    "invalid",
+			data:    []byte("foo"),
+			want:    EventTypeNone,
+			wantErr: ErrInvalidEventType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.data, tt.want, tt.wantErr))
+	}
+}
+
+func TestNewEvent(t *testing.T) {
+	cardID := uuid.New()
+	event, err := NewEvent(cardID, CardMoved{
+		CardID: cardID,
+		From:   CardStageInterview,
+		To:     CardStageOffer,
+	})
+	require.NoError(t, err)
+	require.Equal(t, EventTypeCardMoved, event.Type)
+	require.Equal(t, cardID, event.AggregateID)
+
+	var payload CardMoved
+	require.NoError(t, json.Unmarshal(event.Payload, &payload))
+	require.Equal(t, CardStageOffer, payload.To)
+}
+
+func TestEvent_VacancyID(t *testing.T) {
+	vacancyID := uuid.New()
+	cardID := uuid.New()
+
+	test := func(aggregateID uuid.UUID, payload EventPayload, want uuid.UUID) func(*testing.T) {
+		return func(t *testing.T) {
+			event, err := NewEvent(aggregateID, payload)
+			require.NoError(t, err)
+			require.Exactly(t, want, event.VacancyID())
+		}
+	}
+
+	tests := []struct {
+		name        string
+		aggregateID uuid.UUID
+		payload     EventPayload
+		want        uuid.UUID
+	}{
+		{
+			name:        "vacancyStatusChanged",
+			aggregateID: vacancyID,
+			payload:     VacancyStatusChanged{VacancyID: vacancyID},
+			want:        vacancyID,
+		},
+		{
+			name:        "cardMoved",
+			aggregateID: cardID,
+			payload:     CardMoved{CardID: cardID, VacancyID: vacancyID},
+			want:        vacancyID,
+		},
+		{
+			name:        "commentAdded",
+			aggregateID: cardID,
+			payload:     CommentAdded{CardID: cardID, VacancyID: vacancyID},
+			want:        vacancyID,
+		},
+		{
+			name:        "vacancyUpdated",
+			aggregateID: vacancyID,
+			payload:     VacancyUpdated{Vacancy: Vacancy{ID: vacancyID}},
+			want:        vacancyID,
+		},
+		{
+			name:        "candidateCreated",
+			aggregateID: uuid.New(),
+			payload:     CandidateCreated{Name: "John Doe"},
+			want:        uuid.Nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.aggregateID, tt.payload, tt.want))
+	}
+}
@@ -0,0 +1,241 @@
+package entities
+
+import (
+	"errors"
+	"net/mail"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InterviewFormat byte
+
+const (
+	InterviewFormatNone InterviewFormat = iota
+	InterviewFormatOnsite
+	InterviewFormatVideo
+	InterviewFormatPhone
+	interviewFormatCount
+)
+
+var interviewFormatStrings = []string{
+	"none",
+	"onsite",
+	"video",
+	"phone",
+}
+
+func (format InterviewFormat) String() string {
+	if format >= interviewFormatCount {
+		return interviewFormatStrings[InterviewFormatNone]
+	}
+	return interviewFormatStrings[format]
+}
+
+func (format InterviewFormat) MarshalText() ([]byte, error) {
+	v := format.String()
+	return []byte(v), nil
+}
+
+var interviewFormatTexts = map[string]InterviewFormat{
+	"":       InterviewFormatNone,
+	"none":   InterviewFormatNone,
+	"onsite": InterviewFormatOnsite,
+	"video":  InterviewFormatVideo,
+	"phone":  InterviewFormatPhone,
+}
+
+var ErrInvalidInterviewFormat = errors.New("invalid interview format")
+
+func (format *InterviewFormat) UnmarshalText(data []byte) error {
+	v, ok := interviewFormatTexts[string(data)]
+	if !ok {
+		return ErrInvalidInterviewFormat
+	}
+	*format = v
+	return nil
+}
+
+func (format *InterviewFormat) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return format.UnmarshalText([]byte(v))
+	case []byte:
+		return format.UnmarshalText(v)
+	}
+	return nil
+}
+
+type InterviewStatus byte
+
+const (
+	InterviewStatusNone InterviewStatus = iota
+	InterviewStatusScheduled
+	InterviewStatusCompleted
+	InterviewStatusCancelled
+	interviewStatusCount
+)
+
+var interviewStatusStrings = []string{
+	"none",
+	"scheduled",
+	"completed",
+	"cancelled",
+}
+
+func (status InterviewStatus) String() string {
+	if status >= interviewStatusCount {
+		return interviewStatusStrings[InterviewStatusNone]
+	}
+	return interviewStatusStrings[status]
+}
+
+func (status InterviewStatus) MarshalText() ([]byte, error) {
+	v := status.String()
+	return []byte(v), nil
+}
+
+var interviewStatusTexts = map[string]InterviewStatus{
+	"":          InterviewStatusNone,
+	"none":      InterviewStatusNone,
+	"scheduled": InterviewStatusScheduled,
+	"completed": InterviewStatusCompleted,
+	"cancelled": InterviewStatusCancelled,
+}
+
+var ErrInvalidInterviewStatus = errors.New("invalid interview status")
+
+func (status *InterviewStatus) UnmarshalText(data []byte) error {
+	v, ok := interviewStatusTexts[string(data)]
+	if !ok {
+		return ErrInvalidInterviewStatus
+	}
+	*status = v
+	return nil
+}
+
+func (status *InterviewStatus) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return status.UnmarshalText([]byte(v))
+	case []byte:
+		return status.UnmarshalText(v)
+	}
+	return nil
+}
+
+// Interview is a meeting of interviewers with the candidate of a card.
+// Interviewers are identified by email, so invites can be sent to them.
+type Interview struct {
+	ID           uuid.UUID       `json:"id"`
+	CardID       uuid.UUID       `json:"cardID"`
+	Interviewers []string        `json:"interviewers"`
+	Start        time.Time       `json:"start"`
+	End          time.Time       `json:"end"`
+	Format       InterviewFormat `json:"format"`
+	Location     string          `json:"location"`
+	Link         string          `json:"link"`
+	Status       InterviewStatus `json:"status"`
+	// Sequence is a revision number of the interview, increased on every
+	// update so calendars replace invites they already have.
+	Sequence int       `json:"sequence"`
+	Created  time.Time `json:"created"`
+	Updated  time.Time `json:"updated"`
+}
+
+var (
+	ErrInterviewCardRequired         = errors.New("interview card is required")
+	ErrInterviewInterviewersRequired = errors.New("interview interviewers are required")
+	ErrInterviewInvalidInterviewer   = errors.New("interviewer must be an email address")
+	ErrInterviewInvalidSlot          = errors.New("interview must end after it starts")
+	ErrInterviewFormatRequired       = errors.New("interview format is required")
+	ErrInterviewLocationRequired     = errors.New("onsite interview location is required")
+	ErrInterviewInvalidLink          = errors.New("video interview link must be absolute http(s) url")
+)
+
+// Validate checks the interview, normalizing emails of interviewers so
+// conflicts are found regardless of their case.
+func (i *Interview) Validate() error {
+	if i.CardID == uuid.Nil {
+		return ErrInterviewCardRequired
+	}
+	if len(i.Interviewers) == 0 {
+		return ErrInterviewInterviewersRequired
+	}
+	for n, v := range i.Interviewers {
+		v = NormalizeEmail(v)
+		addr, err := mail.ParseAddress(v)
+		if err != nil || addr.Address != v {
+			return ErrInterviewInvalidInterviewer
+		}
+		i.Interviewers[n] = v
+	}
+	if i.Start.IsZero() || !i.End.After(i.Start) {
+		return ErrInterviewInvalidSlot
+	}
+
+	switch i.Format {
+	case InterviewFormatNone:
+		return ErrInterviewFormatRequired
+	case InterviewFormatOnsite:
+		if i.Location == "" {
+			return ErrInterviewLocationRequired
+		}
+	case InterviewFormatVideo:
+		u, err := url.Parse(i.Link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrInterviewInvalidLink
+		}
+	}
+	return nil
+}
+
+// Conflicts reports whether the interviews overlap in time and share an
+// interviewer. Cancelled interviews conflict with nothing.
+func (i *Interview) Conflicts(other *Interview) bool {
+	if i.ID == other.ID ||
+		i.Status == InterviewStatusCancelled ||
+		other.Status == InterviewStatusCancelled {
+		return false
+	}
+	if !i.Start.Before(other.End) || !other.Start.Before(i.End) {
+		return false
+	}
+	for _, a := range i.Interviewers {
+		for _, b := range other.Interviewers {
+			if NormalizeEmail(a) == NormalizeEmail(b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Rescheduled reports whether the interview differs from its previous
+// revision in status, time, place or interviewers, so invites sent before
+// are outdated.
+func (i *Interview) Rescheduled(prev *Interview) bool {
+	if i.Status != prev.Status ||
+		!i.Start.Equal(prev.Start) ||
+		!i.End.Equal(prev.End) ||
+		i.Format != prev.Format ||
+		i.Location != prev.Location ||
+		i.Link != prev.Link ||
+		len(i.Interviewers) != len(prev.Interviewers) {
+		return true
+	}
+	for _, a := range i.Interviewers {
+		found := false
+		for _, b := range prev.Interviewers {
+			if NormalizeEmail(a) == NormalizeEmail(b) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return true
+		}
+	}
+	return false
+}
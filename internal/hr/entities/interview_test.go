@@ -0,0 +1,346 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterviewFormat_UnmarshalText(t *testing.T) {
+	test := func(data []byte, want InterviewFormat, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			var format InterviewFormat
+			err := format.UnmarshalText(data)
+			require.Exactly(t, wantErr, err)
+			require.Exactly(t, want, format)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    InterviewFormat
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			data:    []byte(""),
+			want:    InterviewFormatNone,
+			wantErr: nil,
+		},
+		{
+			name:    "onsite",
+			data:    []byte("onsite"),
+			want:    InterviewFormatOnsite,
+			wantErr: nil,
+		},
+		{
+			name:    "video",
+			data:    []byte("video"),
+			want:    InterviewFormatVideo,
+			wantErr: nil,
+		},
+		{
+			name:    "phone",
+			data:    []byte("phone"),
+			want:    InterviewFormatPhone,
+			wantErr: nil,
+		},
+		{
+			name:    "invalid",
+			data:    []byte("foo"),
+			want:    InterviewFormatNone,
+			wantErr: ErrInvalidInterviewFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.data, tt.want, tt.wantErr))
+	}
+}
+
+func TestInterviewStatus_UnmarshalText(t *testing.T) {
+	test := func(data []byte, want InterviewStatus, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			var status InterviewStatus
+			err := status.UnmarshalText(data)
+			require.Exactly(t, wantErr, err)
+			require.Exactly(t, want, status)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    InterviewStatus
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			data:    []byte(""),
+			want:    InterviewStatusNone,
+			wantErr: nil,
+		},
+		{
+			name:    "scheduled",
+			data:    []byte("scheduled"),
+			want:    InterviewStatusScheduled,
+			wantErr: nil,
+		},
+		{
+			name:    "completed",
+			data:    []byte("completed"),
+			want:    InterviewStatusCompleted,
+			wantErr: nil,
+		},
+		{
+			name:    "cancelled",
+			data:    []byte("cancelled"),
+			want:    InterviewStatusCancelled,
+			wantErr: nil,
+		},
+		{
+			name:    "invalid",
+			data:    []byte("foo"),
+			want:    InterviewStatusNone,
+			wantErr: ErrInvalidInterviewStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.data, tt.want, tt.wantErr))
+	}
+}
+
+func TestInterview_Validate(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	valid := func() Interview {
+		return Interview{
+			CardID:       uuid.New(),
+			Interviewers: []string{"lead@example.com"},
+			Start:        start,
+			End:          start.Add(time.Hour),
+			Format:       InterviewFormatVideo,
+			Link:         "https://meet.example.com/abc",
+		}
+	}
+
+	test := func(interview Interview, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			err := interview.Validate()
+			require.Exactly(t, wantErr, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		interview func() Interview
+		wantErr   error
+	}{
+		{
+			name:      "valid",
+			interview: valid,
+			wantErr:   nil,
+		},
+		{
+			name: "no card",
+			interview: func() Interview {
+				i := valid()
+				i.CardID = uuid.Nil
+				return i
+			},
+			wantErr: ErrInterviewCardRequired,
+		},
+		{
+			name: "no interviewers",
+			interview: func() Interview {
+				i := valid()
+				i.Interviewers = nil
+				return i
+			},
+			wantErr: ErrInterviewInterviewersRequired,
+		},
+		{
+			name: "interviewer case",
+			interview: func() Interview {
+				i := valid()
+				i.Interviewers = []string{" Lead@Example.com"}
+				return i
+			},
+			wantErr: nil,
+		},
+		{
+			name: "interviewer name",
+			interview: func() Interview {
+				i := valid()
+				i.Interviewers = []string{"John <john@example.com>"}
+				return i
+			},
+			wantErr: ErrInterviewInvalidInterviewer,
+		},
+		{
+			name: "empty slot",
+			interview: func() Interview {
+				i := valid()
+				i.End = i.Start
+				return i
+			},
+			wantErr: ErrInterviewInvalidSlot,
+		},
+		{
+			name: "no format",
+			interview: func() Interview {
+				i := valid()
+				i.Format = InterviewFormatNone
+				return i
+			},
+			wantErr: ErrInterviewFormatRequired,
+		},
+		{
+			name: "onsite without location",
+			interview: func() Interview {
+				i := valid()
+				i.Format = InterviewFormatOnsite
+				return i
+			},
+			wantErr: ErrInterviewLocationRequired,
+		},
+		{
+			name: "video without link",
+			interview: func() Interview {
+				i := valid()
+				i.Link = ""
+				return i
+			},
+			wantErr: ErrInterviewInvalidLink,
+		},
+		{
+			name: "phone",
+			interview: func() Interview {
+				i := valid()
+				i.Format = InterviewFormatPhone
+				i.Link = ""
+				return i
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.interview(), tt.wantErr))
+	}
+}
+
+func TestInterview_Conflicts(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	interview := Interview{
+		ID:           uuid.New(),
+		Interviewers: []string{"lead@example.com", "dev@example.com"},
+		Start:        start,
+		End:          start.Add(time.Hour),
+		Status:       InterviewStatusScheduled,
+	}
+
+	test := func(other Interview, want bool) func(*testing.T) {
+		return func(t *testing.T) {
+			require.Exactly(t, want, interview.Conflicts(&other))
+			require.Exactly(t, want, other.Conflicts(&interview))
+		}
+	}
+
+	tests := []struct {
+		name  string
+		other Interview
+		want  bool
+	}{
+		{
+			name: "overlap",
+			other: Interview{
+				ID:           uuid.New(),
+				Interviewers: []string{"dev@example.com"},
+				Start:        start.Add(30 * time.Minute),
+				End:          start.Add(90 * time.Minute),
+			},
+			want: true,
+		},
+		{
+			name: "adjacent",
+			other: Interview{
+				ID:           uuid.New(),
+				Interviewers: []string{"dev@example.com"},
+				Start:        start.Add(time.Hour),
+				End:          start.Add(2 * time.Hour),
+			},
+			want: false,
+		},
+		{
+			name: "interviewer case",
+			other: Interview{
+				ID:           uuid.New(),
+				Interviewers: []string{"Dev@Example.com"},
+				Start:        start,
+				End:          start.Add(time.Hour),
+			},
+			want: true,
+		},
+		{
+			name: "other interviewers",
+			other: Interview{
+				ID:           uuid.New(),
+				Interviewers: []string{"hr@example.com"},
+				Start:        start,
+				End:          start.Add(time.Hour),
+			},
+			want: false,
+		},
+		{
+			name: "cancelled",
+			other: Interview{
+				ID:           uuid.New(),
+				Interviewers: []string{"dev@example.com"},
+				Start:        start,
+				End:          start.Add(time.Hour),
+				Status:       InterviewStatusCancelled,
+			},
+			want: false,
+		},
+		{
+			name:  "itself",
+			other: interview,
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.other, tt.want))
+	}
+}
+
+func TestInterview_Rescheduled(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	prev := Interview{
+		Interviewers: []string{"lead@example.com", "dev@example.com"},
+		Start:        start,
+		End:          start.Add(time.Hour),
+		Format:       InterviewFormatOnsite,
+		Location:     "Room 1",
+		Status:       InterviewStatusScheduled,
+	}
+
+	interview := prev
+	interview.Interviewers = []string{"dev@example.com", "Lead@example.com"}
+	require.False(t, interview.Rescheduled(&prev), "same interviewers")
+	interview.Start = start.In(time.FixedZone("MSK", 3*60*60))
+	require.False(t, interview.Rescheduled(&prev), "same time")
+
+	interview.Location = "Room 2"
+	require.True(t, interview.Rescheduled(&prev))
+	interview = prev
+	interview.Interviewers = []string{"lead@example.com", "hr@example.com"}
+	require.True(t, interview.Rescheduled(&prev))
+	interview = prev
+	interview.End = start.Add(2 * time.Hour)
+	require.True(t, interview.Rescheduled(&prev))
+}
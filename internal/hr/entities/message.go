@@ -0,0 +1,199 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MessageDirection tells whether the candidate wrote the message or
+// received it.
+type MessageDirection byte
+
+const (
+	MessageDirectionNone MessageDirection = iota
+	MessageDirectionInbound
+	MessageDirectionOutbound
+	messageDirectionCount
+)
+
+var messageDirectionStrings = []string{
+	"none",
+	"inbound",
+	"outbound",
+}
+
+func (direction MessageDirection) String() string {
+	if direction >= messageDirectionCount {
+		return messageDirectionStrings[MessageDirectionNone]
+	}
+	return messageDirectionStrings[direction]
+}
+
+func (direction MessageDirection) MarshalText() ([]byte, error) {
+	v := direction.String()
+	return []byte(v), nil
+}
+
+var messageDirectionTexts = map[string]MessageDirection{
+	"":         MessageDirectionNone,
+	"none":     MessageDirectionNone,
+	"inbound":  MessageDirectionInbound,
+	"outbound": MessageDirectionOutbound,
+}
+
+var ErrInvalidMessageDirection = errors.New("invalid message direction")
+
+func (direction *MessageDirection) UnmarshalText(data []byte) error {
+	v, ok := messageDirectionTexts[string(data)]
+	if !ok {
+		return ErrInvalidMessageDirection
+	}
+	*direction = v
+	return nil
+}
+
+func (direction *MessageDirection) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return direction.UnmarshalText([]byte(v))
+	case []byte:
+		return direction.UnmarshalText(v)
+	}
+	return nil
+}
+
+// MessageStatus tells where the message is on its way.
+type MessageStatus byte
+
+const (
+	MessageStatusNone MessageStatus = iota
+	// MessageStatusReceived marks inbound messages.
+	MessageStatusReceived
+	// MessageStatusPending messages wait in the queue to be sent.
+	MessageStatusPending
+	MessageStatusSent
+	// MessageStatusDead messages failed every attempt.
+	MessageStatusDead
+	messageStatusCount
+)
+
+var messageStatusStrings = []string{
+	"none",
+	"received",
+	"pending",
+	"sent",
+	"dead",
+}
+
+func (status MessageStatus) String() string {
+	if status >= messageStatusCount {
+		return messageStatusStrings[MessageStatusNone]
+	}
+	return messageStatusStrings[status]
+}
+
+func (status MessageStatus) MarshalText() ([]byte, error) {
+	v := status.String()
+	return []byte(v), nil
+}
+
+var messageStatusTexts = map[string]MessageStatus{
+	"":         MessageStatusNone,
+	"none":     MessageStatusNone,
+	"received": MessageStatusReceived,
+	"pending":  MessageStatusPending,
+	"sent":     MessageStatusSent,
+	"dead":     MessageStatusDead,
+}
+
+var ErrInvalidMessageStatus = errors.New("invalid message status")
+
+func (status *MessageStatus) UnmarshalText(data []byte) error {
+	v, ok := messageStatusTexts[string(data)]
+	if !ok {
+		return ErrInvalidMessageStatus
+	}
+	*status = v
+	return nil
+}
+
+func (status *MessageStatus) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return status.UnmarshalText([]byte(v))
+	case []byte:
+		return status.UnmarshalText(v)
+	}
+	return nil
+}
+
+// MessageFile is a file sent with the message, e.g. an interview invite.
+// Contents are only kept until the message is sent.
+type MessageFile struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Data        []byte `json:"-"`
+}
+
+// Message is an email in the communication log of the candidate. Outbound
+// messages wait in the log as in a queue until they are sent.
+type Message struct {
+	ID uuid.UUID `json:"id"`
+	// MessageID is the Message-ID header, it tells the same mail apart when
+	// it is received again.
+	MessageID   string           `json:"messageID"`
+	Direction   MessageDirection `json:"direction"`
+	CandidateID uuid.UUID        `json:"candidateID"`
+	// CardID is nil for messages not related to a vacancy.
+	CardID  uuid.UUID `json:"cardID"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	Text    string    `json:"text"`
+	// Template is the notification the outbound message was made of.
+	Template string        `json:"template"`
+	Files    []MessageFile `json:"files"`
+	Status   MessageStatus `json:"status"`
+	Attempts int           `json:"attempts"`
+	// NextAttempt is when the pending message is due.
+	NextAttempt time.Time `json:"nextAttempt"`
+	LastError   string    `json:"lastError"`
+	// Sent is the Date header of the message, when it is sent for outbound
+	// ones.
+	Sent    time.Time `json:"sent"`
+	Created time.Time `json:"created"`
+}
+
+var (
+	ErrMessageID        = errors.New("message id is required")
+	ErrMessageDirection = errors.New("message direction is required")
+	ErrMessageCandidate = errors.New("message must belong to a candidate")
+)
+
+func (m *Message) Validate() error {
+	switch {
+	case m.MessageID == "":
+		return ErrMessageID
+	case m.Direction == MessageDirectionNone:
+		return ErrMessageDirection
+	case m.CandidateID == uuid.Nil:
+		return ErrMessageCandidate
+	}
+	return nil
+}
+
+// Erase clears personal data of the message. The Message-ID stays, so the
+// same mail is not taken in again. Pending messages are not sent anymore.
+func (m *Message) Erase() {
+	m.From = ""
+	m.To = ""
+	m.Subject = ""
+	m.Text = ""
+	m.Files = nil
+	if m.Status == MessageStatusPending {
+		m.Status = MessageStatusDead
+		m.LastError = "erased"
+	}
+}
@@ -0,0 +1,318 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OfferStatus byte
+
+const (
+	OfferStatusNone OfferStatus = iota
+	OfferStatusPending
+	OfferStatusApproved
+	OfferStatusRejected
+	OfferStatusAccepted
+	OfferStatusDeclined
+	OfferStatusWithdrawn
+	offerStatusCount
+)
+
+var offerStatusStrings = []string{
+	"none",
+	"pending",
+	"approved",
+	"rejected",
+	"accepted",
+	"declined",
+	"withdrawn",
+}
+
+func (status OfferStatus) String() string {
+	if status >= offerStatusCount {
+		return offerStatusStrings[OfferStatusNone]
+	}
+	return offerStatusStrings[status]
+}
+
+func (status OfferStatus) MarshalText() ([]byte, error) {
+	v := status.String()
+	return []byte(v), nil
+}
+
+var offerStatusTexts = map[string]OfferStatus{
+	"":          OfferStatusNone,
+	"none":      OfferStatusNone,
+	"pending":   OfferStatusPending,
+	"approved":  OfferStatusApproved,
+	"rejected":  OfferStatusRejected,
+	"accepted":  OfferStatusAccepted,
+	"declined":  OfferStatusDeclined,
+	"withdrawn": OfferStatusWithdrawn,
+}
+
+var ErrInvalidOfferStatus = errors.New("invalid offer status")
+
+func (status *OfferStatus) UnmarshalText(data []byte) error {
+	v, ok := offerStatusTexts[string(data)]
+	if !ok {
+		return ErrInvalidOfferStatus
+	}
+	*status = v
+	return nil
+}
+
+func (status *OfferStatus) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return status.UnmarshalText([]byte(v))
+	case []byte:
+		return status.UnmarshalText(v)
+	}
+	return nil
+}
+
+type Decision byte
+
+const (
+	DecisionNone Decision = iota
+	DecisionApproved
+	DecisionRejected
+	decisionCount
+)
+
+var decisionStrings = []string{
+	"none",
+	"approved",
+	"rejected",
+}
+
+func (d Decision) String() string {
+	if d >= decisionCount {
+		return decisionStrings[DecisionNone]
+	}
+	return decisionStrings[d]
+}
+
+func (d Decision) MarshalText() ([]byte, error) {
+	v := d.String()
+	return []byte(v), nil
+}
+
+var decisionTexts = map[string]Decision{
+	"":         DecisionNone,
+	"none":     DecisionNone,
+	"approved": DecisionApproved,
+	"rejected": DecisionRejected,
+}
+
+var ErrInvalidDecision = errors.New("invalid decision")
+
+func (d *Decision) UnmarshalText(data []byte) error {
+	v, ok := decisionTexts[string(data)]
+	if !ok {
+		return ErrInvalidDecision
+	}
+	*d = v
+	return nil
+}
+
+// Approval is one step of an approval chain of offers and requisitions,
+// e.g. the hiring manager, then the HR director, then finance.
+type Approval struct {
+	Role     string     `json:"role"`
+	Approver string     `json:"approver"`
+	Decision Decision   `json:"decision"`
+	Comment  string     `json:"comment,omitempty"`
+	Decided  *time.Time `json:"decided,omitempty"`
+}
+
+// resetApprovals clears decisions of the chain to start it over.
+func resetApprovals(approvals []Approval) {
+	for i := range approvals {
+		approvals[i].Decision = DecisionNone
+		approvals[i].Comment = ""
+		approvals[i].Decided = nil
+	}
+}
+
+// undecided returns the first approval of the chain without a decision, nil
+// if all are decided.
+func undecided(approvals []Approval) *Approval {
+	for i := range approvals {
+		if approvals[i].Decision == DecisionNone {
+			return &approvals[i]
+		}
+	}
+	return nil
+}
+
+// Offer warnings.
+const (
+	// OfferBelowExpectation warns the salary is lower than the candidate
+	// expects.
+	OfferBelowExpectation = "belowExpectation"
+)
+
+// Offer is a job offer made to the candidate of a card in the offer stage.
+// It is sent once every approver of the chain agrees to it in order.
+type Offer struct {
+	ID        uuid.UUID   `json:"id"`
+	CardID    uuid.UUID   `json:"cardID"`
+	Position  string      `json:"position"`
+	Salary    uint32      `json:"salary"`
+	StartDate time.Time   `json:"startDate"`
+	Expires   time.Time   `json:"expires"`
+	Status    OfferStatus `json:"status"`
+	Approvals []Approval  `json:"approvals"`
+	Warnings  []string    `json:"warnings"`
+	Created   time.Time   `json:"created"`
+	Updated   time.Time   `json:"updated"`
+}
+
+var (
+	ErrOfferPositionRequired  = errors.New("offer position is required")
+	ErrOfferSalaryRequired    = errors.New("offer salary is required")
+	ErrOfferStartRequired     = errors.New("offer start date is required")
+	ErrOfferExpiresRequired   = errors.New("offer expiry is required")
+	ErrOfferApproverRequired  = errors.New("offer approval must name its approver")
+	ErrOfferApprovalsRequired = errors.New("offer approval chain can not be empty")
+	ErrOfferOverBudget        = errors.New("offer salary exceeds the vacancy budget")
+	ErrOfferOverSalary        = errors.New("offer salary exceeds the vacancy salary range")
+	ErrOfferInvalidTransition = errors.New("offer cannot change from its current status")
+	ErrOfferNotApprover       = errors.New("user is not the next approver of the offer")
+	ErrOfferExpired           = errors.New("offer is expired")
+)
+
+func (o *Offer) Validate() error {
+	if o.Position == "" {
+		return ErrOfferPositionRequired
+	}
+	if o.Salary == 0 {
+		return ErrOfferSalaryRequired
+	}
+	if o.StartDate.IsZero() {
+		return ErrOfferStartRequired
+	}
+	if o.Expires.IsZero() {
+		return ErrOfferExpiresRequired
+	}
+	for _, approval := range o.Approvals {
+		if approval.Approver == "" {
+			return ErrOfferApproverRequired
+		}
+	}
+	return nil
+}
+
+// OverrideApprovals replaces the approval chain configured on the server.
+// The chain can not be empty, so the offer is never sent unapproved.
+func (o *Offer) OverrideApprovals(chain []Approval) error {
+	if len(chain) == 0 {
+		return ErrOfferApprovalsRequired
+	}
+	o.Approvals = chain
+	return nil
+}
+
+// CheckSalary checks the salary, net monthly in the base currency of the
+// rates, against the budget and the salary range of the vacancy and the
+// salary the candidate expects, zero meaning no limit. Exceeding the budget
+// or the range is an error, so is a range in an unknown currency. Offering
+// less than expected is recorded as a warning.
+func (o *Offer) CheckSalary(v *Vacancy, rates *ExchangeRates, expected uint32) error {
+	o.Warnings = []string{}
+	if v.Budget > 0 && o.Salary > v.Budget {
+		return ErrOfferOverBudget
+	}
+	if !rates.Fits(&v.Salary, float64(o.Salary)) {
+		return ErrOfferOverSalary
+	}
+	if o.Salary < expected {
+		o.Warnings = append(o.Warnings, OfferBelowExpectation)
+	}
+	return nil
+}
+
+// Submit starts the approval chain. An offer without approvals is approved
+// right away.
+func (o *Offer) Submit() {
+	resetApprovals(o.Approvals)
+	o.Status = OfferStatusPending
+	if len(o.Approvals) == 0 {
+		o.Status = OfferStatusApproved
+	}
+}
+
+// NextApproval returns the approval waiting for a decision, nil if there is
+// none.
+func (o *Offer) NextApproval() *Approval {
+	if o.Status != OfferStatusPending {
+		return nil
+	}
+	return undecided(o.Approvals)
+}
+
+// Decide records decision of the approver whose turn it is. The offer is
+// approved after the last approval and rejected after any rejection.
+func (o *Offer) Decide(approver string, decision Decision, comment string, now time.Time) error {
+	if decision == DecisionNone {
+		return ErrInvalidDecision
+	}
+	next := o.NextApproval()
+	if next == nil {
+		return ErrOfferInvalidTransition
+	}
+	if next.Approver != approver {
+		return ErrOfferNotApprover
+	}
+
+	next.Decision = decision
+	next.Comment = comment
+	next.Decided = &now
+	switch {
+	case decision == DecisionRejected:
+		o.Status = OfferStatusRejected
+	case o.NextApproval() == nil:
+		o.Status = OfferStatusApproved
+	}
+	return nil
+}
+
+// Expired reports whether the offer can no longer be accepted.
+func (o *Offer) Expired(now time.Time) bool {
+	return !o.Expires.IsZero() && now.After(o.Expires)
+}
+
+// Accept records the candidate accepted the approved offer before it
+// expired.
+func (o *Offer) Accept(now time.Time) error {
+	if o.Status != OfferStatusApproved {
+		return ErrOfferInvalidTransition
+	}
+	if o.Expired(now) {
+		return ErrOfferExpired
+	}
+	o.Status = OfferStatusAccepted
+	return nil
+}
+
+// Decline records the candidate declined the approved offer.
+func (o *Offer) Decline() error {
+	if o.Status != OfferStatusApproved {
+		return ErrOfferInvalidTransition
+	}
+	o.Status = OfferStatusDeclined
+	return nil
+}
+
+// Withdraw cancels the offer which is not answered by the candidate yet.
+func (o *Offer) Withdraw() error {
+	if o.Status != OfferStatusPending && o.Status != OfferStatusApproved {
+		return ErrOfferInvalidTransition
+	}
+	o.Status = OfferStatusWithdrawn
+	return nil
+}
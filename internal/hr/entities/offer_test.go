@@ -0,0 +1,229 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfferStatus_UnmarshalText(t *testing.T) {
+	test := func(data []byte, want OfferStatus, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			var status OfferStatus
+			err := status.UnmarshalText(data)
+			require.Exactly(t, wantErr, err)
+			require.Exactly(t, want, status)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    OfferStatus
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			data:    []byte(""),
+			want:    OfferStatusNone,
+			wantErr: nil,
+		},
+		{
+			name:    "pending",
+			data:    []byte("pending"),
+			want:    OfferStatusPending,
+			wantErr: nil,
+		},
+		{
+			name:    "approved",
+			data:    []byte("approved"),
+			want:    OfferStatusApproved,
+			wantErr: nil,
+		},
+		{
+			name:    "withdrawn",
+			data:    []byte("withdrawn"),
+			want:    OfferStatusWithdrawn,
+			wantErr: nil,
+		},
+		{
+			name:    "invalid",
+			data:    []byte("sent"),
+			want:    OfferStatusNone,
+			wantErr: ErrInvalidOfferStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.data, tt.want, tt.wantErr))
+	}
+}
+
+func TestOffer_CheckSalary(t *testing.T) {
+	rates := &ExchangeRates{Base: "RUB", Rates: map[string]float64{"USD": 90}, Tax: 13}
+	test := func(salary uint32, vacancy Vacancy, expected uint32, want []string, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			offer := Offer{Salary: salary}
+			err := offer.CheckSalary(&vacancy, rates, expected)
+			require.Exactly(t, wantErr, err)
+			if wantErr == nil {
+				require.Exactly(t, want, offer.Warnings)
+			}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		salary   uint32
+		vacancy  Vacancy
+		expected uint32
+		want     []string
+		wantErr  error
+	}{
+		{
+			name:     "within",
+			salary:   200000,
+			vacancy:  Vacancy{Budget: 250000, Salary: SalaryRange{Min: 150000, Max: 250000}},
+			expected: 180000,
+			want:     []string{},
+			wantErr:  nil,
+		},
+		{
+			name:     "no limits",
+			salary:   200000,
+			vacancy:  Vacancy{},
+			expected: 0,
+			want:     []string{},
+			wantErr:  nil,
+		},
+		{
+			name:     "over budget",
+			salary:   300000,
+			vacancy:  Vacancy{Budget: 250000},
+			expected: 0,
+			wantErr:  ErrOfferOverBudget,
+		},
+		{
+			name:     "over salary range",
+			salary:   230000,
+			vacancy:  Vacancy{Budget: 250000, Salary: SalaryRange{Max: 220000}},
+			expected: 0,
+			wantErr:  ErrOfferOverSalary,
+		},
+		{
+			name:     "within salary range in another currency",
+			salary:   200000,
+			vacancy:  Vacancy{Salary: SalaryRange{Max: 2500, Currency: "USD"}},
+			expected: 0,
+			want:     []string{},
+			wantErr:  nil,
+		},
+		{
+			name:     "over gross salary range",
+			salary:   200000,
+			vacancy:  Vacancy{Salary: SalaryRange{Max: 220000, Gross: true}},
+			expected: 0,
+			wantErr:  ErrOfferOverSalary,
+		},
+		{
+			name:     "salary range in unknown currency",
+			salary:   200000,
+			vacancy:  Vacancy{Salary: SalaryRange{Max: 2500, Currency: "EUR"}},
+			expected: 0,
+			wantErr:  ErrOfferOverSalary,
+		},
+		{
+			name:     "below expectation",
+			salary:   200000,
+			vacancy:  Vacancy{Budget: 250000},
+			expected: 220000,
+			want:     []string{OfferBelowExpectation},
+			wantErr:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.salary, tt.vacancy, tt.expected, tt.want, tt.wantErr))
+	}
+}
+
+func TestOffer_Decide(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	offer := Offer{
+		Approvals: []Approval{
+			{Role: "hiring manager", Approver: "manager@example.com"},
+			{Role: "finance", Approver: "finance@example.com"},
+		},
+	}
+	offer.Submit()
+	require.Exactly(t, OfferStatusPending, offer.Status)
+
+	err := offer.Decide("finance@example.com", DecisionApproved, "", now)
+	require.Exactly(t, ErrOfferNotApprover, err)
+
+	err = offer.Decide("manager@example.com", DecisionApproved, "ok", now)
+	require.NoError(t, err)
+	require.Exactly(t, OfferStatusPending, offer.Status)
+	require.Exactly(t, "finance@example.com", offer.NextApproval().Approver)
+
+	err = offer.Accept(now)
+	require.Exactly(t, ErrOfferInvalidTransition, err)
+
+	err = offer.Decide("finance@example.com", DecisionApproved, "", now)
+	require.NoError(t, err)
+	require.Exactly(t, OfferStatusApproved, offer.Status)
+	require.Nil(t, offer.NextApproval())
+
+	err = offer.Decide("finance@example.com", DecisionApproved, "", now)
+	require.Exactly(t, ErrOfferInvalidTransition, err)
+
+	offer.Submit()
+	err = offer.Decide("manager@example.com", DecisionRejected, "too much", now)
+	require.NoError(t, err)
+	require.Exactly(t, OfferStatusRejected, offer.Status)
+	require.Exactly(t, ErrOfferInvalidTransition, offer.Withdraw())
+}
+
+func TestOffer_Accept(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	test := func(expires time.Time, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			offer := Offer{Expires: expires}
+			offer.Submit()
+			err := offer.Accept(now)
+			require.Exactly(t, wantErr, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		expires time.Time
+		wantErr error
+	}{
+		{
+			name:    "valid",
+			expires: now.Add(time.Hour),
+			wantErr: nil,
+		},
+		{
+			name:    "expired",
+			expires: now.Add(-time.Hour),
+			wantErr: ErrOfferExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.expires, tt.wantErr))
+	}
+}
+
+func TestOffer_OverrideApprovals(t *testing.T) {
+	offer := Offer{Approvals: []Approval{{Role: "finance", Approver: "cfo@example.com"}}}
+	require.Exactly(t, ErrOfferApprovalsRequired, offer.OverrideApprovals([]Approval{}))
+	require.Len(t, offer.Approvals, 1, "the configured chain is kept")
+
+	chain := []Approval{{Role: "ceo", Approver: "ceo@example.com"}}
+	require.NoError(t, offer.OverrideApprovals(chain))
+	require.Exactly(t, chain, offer.Approvals)
+}
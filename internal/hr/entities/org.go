@@ -0,0 +1,190 @@
+package entities
+
+import (
+	"errors"
+	"net/mail"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Department is a node of the org structure: an item of the department
+// dictionary with the managers responsible for it. Departments nest in their
+// parents the way dictionary items do.
+type Department struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	ParentID uuid.UUID `json:"parentID"`
+	// Managers are emails of the heads of the department.
+	Managers []string `json:"managers"`
+}
+
+var (
+	ErrInvalidManager = errors.New("manager must be an email address")
+	ErrInvalidOwner   = errors.New("hiring manager and recruiter must be email addresses")
+)
+
+// NormalizeManagers brings emails of managers to the form users are compared
+// in, dropping empty and repeated ones.
+func NormalizeManagers(managers []string) ([]string, error) {
+	result := make([]string, 0, len(managers))
+	seen := make(map[string]bool, len(managers))
+	for _, manager := range managers {
+		manager = NormalizeEmail(manager)
+		if manager == "" || seen[manager] {
+			continue
+		}
+		if !isEmail(manager) {
+			return nil, ErrInvalidManager
+		}
+		seen[manager] = true
+		result = append(result, manager)
+	}
+	return result, nil
+}
+
+func isEmail(v string) bool {
+	addr, err := mail.ParseAddress(v)
+	return err == nil && addr.Address == v
+}
+
+// NormalizeOwners brings emails of the hiring manager and the recruiter of
+// the vacancy to the form users are compared in.
+func (v *Vacancy) NormalizeOwners() error {
+	v.HiringManager = NormalizeEmail(v.HiringManager)
+	v.Recruiter = NormalizeEmail(v.Recruiter)
+	if v.HiringManager != "" && !isEmail(v.HiringManager) {
+		return ErrInvalidOwner
+	}
+	if v.Recruiter != "" && !isEmail(v.Recruiter) {
+		return ErrInvalidOwner
+	}
+	return nil
+}
+
+// Owns reports whether the user is the hiring manager or the recruiter of
+// the vacancy.
+func (v *Vacancy) Owns(user string) bool {
+	user = NormalizeEmail(user)
+	return user != "" && (v.HiringManager == user || v.Recruiter == user)
+}
+
+// OrgChart is the org structure: departments of the department dictionary
+// with their managers. Managers of a department manage all departments
+// nested in it.
+type OrgChart struct {
+	departments map[uuid.UUID]*Department
+	names       map[string]uuid.UUID
+}
+
+// NewOrgChart builds the chart of the dictionary items and managers of
+// departments by their IDs. Items of other dictionaries are skipped.
+func NewOrgChart(items []DictionaryItem, managers map[uuid.UUID][]string) *OrgChart {
+	o := &OrgChart{
+		departments: make(map[uuid.UUID]*Department),
+		names:       make(map[string]uuid.UUID),
+	}
+	for _, item := range items {
+		if item.Kind != DictionaryKindDepartment {
+			continue
+		}
+		o.departments[item.ID] = &Department{
+			ID:       item.ID,
+			Name:     item.Name,
+			ParentID: item.ParentID,
+			Managers: append([]string{}, managers[item.ID]...),
+		}
+		o.names[DictionaryKey(item.Name)] = item.ID
+	}
+	return o
+}
+
+// Departments returns all departments ordered by name.
+func (o *OrgChart) Departments() []Department {
+	departments := make([]Department, 0, len(o.departments))
+	for _, d := range o.departments {
+		departments = append(departments, *d)
+	}
+	sort.Slice(departments, func(i, j int) bool {
+		return departments[i].Name < departments[j].Name
+	})
+	return departments
+}
+
+// Department returns the department of the given ID.
+func (o *OrgChart) Department(id uuid.UUID) (Department, bool) {
+	d, ok := o.departments[id]
+	if !ok {
+		return Department{}, false
+	}
+	return *d, true
+}
+
+// Lookup returns the department a vacancy names.
+func (o *OrgChart) Lookup(name string) (Department, bool) {
+	id, ok := o.names[DictionaryKey(name)]
+	if !ok {
+		return Department{}, false
+	}
+	return o.Department(id)
+}
+
+// Chain returns the department followed by its parents up to the root.
+func (o *OrgChart) Chain(id uuid.UUID) []Department {
+	var chain []Department
+	seen := make(map[uuid.UUID]bool)
+	for d := o.departments[id]; d != nil && !seen[d.ID]; d = o.departments[d.ParentID] {
+		seen[d.ID] = true
+		chain = append(chain, *d)
+	}
+	return chain
+}
+
+// Managers returns managers of the department, ones of its nearest parent
+// having any if the department has none.
+func (o *OrgChart) Managers(id uuid.UUID) []string {
+	for _, d := range o.Chain(id) {
+		if len(d.Managers) > 0 {
+			return d.Managers
+		}
+	}
+	return nil
+}
+
+// Manages reports whether the user manages the department or any of its
+// parents.
+func (o *OrgChart) Manages(user string, id uuid.UUID) bool {
+	user = NormalizeEmail(user)
+	if user == "" {
+		return false
+	}
+	for _, d := range o.Chain(id) {
+		for _, manager := range d.Managers {
+			if manager == user {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ManagesVacancy reports whether the user manages the department of the
+// vacancy.
+func (o *OrgChart) ManagesVacancy(user string, v *Vacancy) bool {
+	d, ok := o.Lookup(v.Department)
+	return ok && o.Manages(user, d.ID)
+}
+
+// HiringManager returns the manager vacancies of the department get when
+// created without a hiring manager, empty if the department has none.
+func (o *OrgChart) HiringManager(department string) string {
+	d, ok := o.Lookup(department)
+	if !ok {
+		return ""
+	}
+	managers := o.Managers(d.ID)
+	if len(managers) == 0 {
+		return ""
+	}
+	return managers[0]
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeManagers(t *testing.T) {
+	managers, err := NormalizeManagers([]string{" Boss@Example.com", "", "boss@example.com", "cto@example.com"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"boss@example.com", "cto@example.com"}, managers)
+
+	_, err = NormalizeManagers([]string{"boss"})
+	require.ErrorIs(t, err, ErrInvalidManager)
+}
+
+func TestVacancy_NormalizeOwners(t *testing.T) {
+	v := Vacancy{HiringManager: " Boss@Example.com ", Recruiter: "HR@example.com"}
+	require.NoError(t, v.NormalizeOwners())
+	require.Equal(t, "boss@example.com", v.HiringManager)
+	require.Equal(t, "hr@example.com", v.Recruiter)
+	require.True(t, v.Owns("BOSS@example.com"))
+	require.True(t, v.Owns("hr@example.com"))
+	require.False(t, v.Owns(""))
+
+	v.Recruiter = "hr"
+	require.ErrorIs(t, v.NormalizeOwners(), ErrInvalidOwner)
+}
+
+func TestOrgChart(t *testing.T) {
+	it := DictionaryItem{ID: uuid.New(), Kind: DictionaryKindDepartment, Name: "IT"}
+	backend := DictionaryItem{ID: uuid.New(), Kind: DictionaryKindDepartment, Name: "Backend", ParentID: it.ID}
+	payments := DictionaryItem{ID: uuid.New(), Kind: DictionaryKindDepartment, Name: "Payments", ParentID: backend.ID}
+	sales := DictionaryItem{ID: uuid.New(), Kind: DictionaryKindDepartment, Name: "Sales"}
+	area := DictionaryItem{ID: uuid.New(), Kind: DictionaryKindArea, Name: "Москва"}
+
+	org := NewOrgChart(
+		[]DictionaryItem{it, backend, payments, sales, area},
+		map[uuid.UUID][]string{
+			it.ID:      {"cto@example.com"},
+			backend.ID: {"lead@example.com", "deputy@example.com"},
+		},
+	)
+
+	departments := org.Departments()
+	require.Len(t, departments, 4, "items of other dictionaries are skipped")
+	require.Equal(t, "Backend", departments[0].Name)
+
+	chain := org.Chain(payments.ID)
+	require.Len(t, chain, 3)
+	require.Equal(t, []uuid.UUID{payments.ID, backend.ID, it.ID}, []uuid.UUID{chain[0].ID, chain[1].ID, chain[2].ID})
+
+	require.Equal(t, []string{"lead@example.com", "deputy@example.com"}, org.Managers(payments.ID), "nearest parent with managers")
+	require.Empty(t, org.Managers(sales.ID))
+	require.Equal(t, "lead@example.com", org.HiringManager("payments"))
+	require.Equal(t, "cto@example.com", org.HiringManager("IT"))
+	require.Empty(t, org.HiringManager("Marketing"))
+
+	require.True(t, org.Manages("CTO@example.com", payments.ID), "parents manage nested departments")
+	require.True(t, org.Manages("deputy@example.com", backend.ID))
+	require.False(t, org.Manages("lead@example.com", it.ID))
+	require.False(t, org.Manages("cto@example.com", sales.ID))
+
+	require.True(t, org.ManagesVacancy("cto@example.com", &Vacancy{Department: "Payments"}))
+	require.False(t, org.ManagesVacancy("cto@example.com", &Vacancy{Department: "Marketing"}))
+}
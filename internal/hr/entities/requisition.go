@@ -0,0 +1,221 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RequisitionStatus byte
+
+const (
+	RequisitionStatusNone RequisitionStatus = iota
+	RequisitionStatusPending
+	RequisitionStatusApproved
+	RequisitionStatusRejected
+	RequisitionStatusWithdrawn
+	requisitionStatusCount
+)
+
+var requisitionStatusStrings = []string{
+	"none",
+	"pending",
+	"approved",
+	"rejected",
+	"withdrawn",
+}
+
+func (status RequisitionStatus) String() string {
+	if status >= requisitionStatusCount {
+		return requisitionStatusStrings[RequisitionStatusNone]
+	}
+	return requisitionStatusStrings[status]
+}
+
+func (status RequisitionStatus) MarshalText() ([]byte, error) {
+	v := status.String()
+	return []byte(v), nil
+}
+
+var requisitionStatusTexts = map[string]RequisitionStatus{
+	"":          RequisitionStatusNone,
+	"none":      RequisitionStatusNone,
+	"pending":   RequisitionStatusPending,
+	"approved":  RequisitionStatusApproved,
+	"rejected":  RequisitionStatusRejected,
+	"withdrawn": RequisitionStatusWithdrawn,
+}
+
+var ErrInvalidRequisitionStatus = errors.New("invalid requisition status")
+
+func (status *RequisitionStatus) UnmarshalText(data []byte) error {
+	v, ok := requisitionStatusTexts[string(data)]
+	if !ok {
+		return ErrInvalidRequisitionStatus
+	}
+	*status = v
+	return nil
+}
+
+func (status *RequisitionStatus) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return status.UnmarshalText([]byte(v))
+	case []byte:
+		return status.UnmarshalText(v)
+	}
+	return nil
+}
+
+// Requisition is a request for headcount. Vacancies opened by it become
+// active only once every approver of the chain agrees to it in order, and
+// only while hires made on them leave some of the positions open.
+type Requisition struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	Department string    `json:"department,omitempty"`
+	// Positions is the headcount requested.
+	Positions uint32 `json:"positions"`
+	// Budget is the monthly payroll of all positions, zero if not limited.
+	Budget uint32 `json:"budget,omitempty"`
+	// SalaryMin and SalaryMax bound the monthly salary of one position,
+	// zero SalaryMax if not limited.
+	SalaryMin     uint32            `json:"salaryMin,omitempty"`
+	SalaryMax     uint32            `json:"salaryMax,omitempty"`
+	Justification string            `json:"justification"`
+	Requester     string            `json:"requester"`
+	Status        RequisitionStatus `json:"status"`
+	Approvals     []Approval        `json:"approvals"`
+	Created       time.Time         `json:"created"`
+	Updated       time.Time         `json:"updated"`
+}
+
+var (
+	ErrRequisitionTitleRequired         = errors.New("requisition title is required")
+	ErrRequisitionPositionsRequired     = errors.New("requisition must request at least one position")
+	ErrRequisitionJustificationRequired = errors.New("requisition justification is required")
+	ErrRequisitionSalaryBand            = errors.New("requisition salary minimum exceeds the maximum")
+	ErrRequisitionOverBudget            = errors.New("requisition positions at the minimum salary exceed the budget")
+	ErrRequisitionApproverRequired      = errors.New("requisition approval must name its approver")
+	ErrRequisitionInvalidTransition     = errors.New("requisition cannot change from its current status")
+	ErrRequisitionNotApprover           = errors.New("user is not the next approver of the requisition")
+	ErrRequisitionRequired              = errors.New("vacancy needs an approved requisition to become active")
+	ErrRequisitionNotApproved           = errors.New("requisition of the vacancy is not approved")
+	ErrHeadcountFilled                  = errors.New("all positions of the requisition are filled")
+	ErrVacancyOverBand                  = errors.New("vacancy budget or salary exceeds the requisition salary band")
+)
+
+func (r *Requisition) Validate() error {
+	switch {
+	case r.Title == "":
+		return ErrRequisitionTitleRequired
+	case r.Positions == 0:
+		return ErrRequisitionPositionsRequired
+	case r.Justification == "":
+		return ErrRequisitionJustificationRequired
+	case r.SalaryMax > 0 && r.SalaryMin > r.SalaryMax:
+		return ErrRequisitionSalaryBand
+	case r.Budget > 0 && uint64(r.SalaryMin)*uint64(r.Positions) > uint64(r.Budget):
+		return ErrRequisitionOverBudget
+	}
+	for _, approval := range r.Approvals {
+		if approval.Approver == "" {
+			return ErrRequisitionApproverRequired
+		}
+	}
+	return nil
+}
+
+// Submit starts the approval chain. A requisition without approvals is
+// approved right away.
+func (r *Requisition) Submit() {
+	resetApprovals(r.Approvals)
+	r.Status = RequisitionStatusPending
+	if len(r.Approvals) == 0 {
+		r.Status = RequisitionStatusApproved
+	}
+}
+
+// NextApproval returns the approval waiting for a decision, nil if there is
+// none.
+func (r *Requisition) NextApproval() *Approval {
+	if r.Status != RequisitionStatusPending {
+		return nil
+	}
+	return undecided(r.Approvals)
+}
+
+// Decide records decision of the approver whose turn it is. The requisition
+// is approved after the last approval and rejected after any rejection.
+func (r *Requisition) Decide(approver string, decision Decision, comment string, now time.Time) error {
+	if decision == DecisionNone {
+		return ErrInvalidDecision
+	}
+	next := r.NextApproval()
+	if next == nil {
+		return ErrRequisitionInvalidTransition
+	}
+	if next.Approver != approver {
+		return ErrRequisitionNotApprover
+	}
+
+	next.Decision = decision
+	next.Comment = comment
+	next.Decided = &now
+	switch {
+	case decision == DecisionRejected:
+		r.Status = RequisitionStatusRejected
+	case r.NextApproval() == nil:
+		r.Status = RequisitionStatusApproved
+	}
+	return nil
+}
+
+// Withdraw cancels the requisition, vacancies can no longer be opened by
+// it.
+func (r *Requisition) Withdraw() error {
+	if r.Status != RequisitionStatusPending && r.Status != RequisitionStatusApproved {
+		return ErrRequisitionInvalidTransition
+	}
+	r.Status = RequisitionStatusWithdrawn
+	return nil
+}
+
+// CheckOpening fails unless a vacancy may be opened by the requisition
+// with the number of positions already filled.
+func (r *Requisition) CheckOpening(filled int) error {
+	if r.Status != RequisitionStatusApproved {
+		return ErrRequisitionNotApproved
+	}
+	if filled >= int(r.Positions) {
+		return ErrHeadcountFilled
+	}
+	return nil
+}
+
+// FitVacancy gives the vacancy opened by the requisition its department and
+// the top of its salary band as the budget unless the vacancy has them. A
+// budget or a salary range maximum above the band fails, the range is
+// converted with the rates and fails if its currency is unknown.
+func (r *Requisition) FitVacancy(v *Vacancy, rates *ExchangeRates) error {
+	if v.Department == "" {
+		v.Department = r.Department
+	}
+	if v.Budget == 0 {
+		v.Budget = r.SalaryMax
+	}
+	if r.SalaryMax == 0 {
+		return nil
+	}
+	if v.Budget > r.SalaryMax {
+		return ErrVacancyOverBand
+	}
+	if v.Salary.Max > 0 {
+		max, ok := rates.Monthly(v.Salary.Max, v.Salary.Currency, v.Salary.Period, v.Salary.Gross)
+		if !ok || max > float64(r.SalaryMax) {
+			return ErrVacancyOverBand
+		}
+	}
+	return nil
+}
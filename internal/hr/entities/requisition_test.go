@@ -0,0 +1,133 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequisition_Validate(t *testing.T) {
+	valid := func() Requisition {
+		return Requisition{
+			Title:         "Go developer",
+			Positions:     2,
+			Budget:        500000,
+			SalaryMin:     200000,
+			SalaryMax:     250000,
+			Justification: "New payments team",
+		}
+	}
+	test := func(change func(*Requisition), wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			r := valid()
+			change(&r)
+			require.Exactly(t, wantErr, r.Validate())
+		}
+	}
+
+	tests := []struct {
+		name    string
+		change  func(*Requisition)
+		wantErr error
+	}{
+		{
+			name:    "valid",
+			change:  func(r *Requisition) {},
+			wantErr: nil,
+		},
+		{
+			name:    "no limits",
+			change:  func(r *Requisition) { r.Budget, r.SalaryMin, r.SalaryMax = 0, 0, 0 },
+			wantErr: nil,
+		},
+		{
+			name:    "no positions",
+			change:  func(r *Requisition) { r.Positions = 0 },
+			wantErr: ErrRequisitionPositionsRequired,
+		},
+		{
+			name:    "no justification",
+			change:  func(r *Requisition) { r.Justification = "" },
+			wantErr: ErrRequisitionJustificationRequired,
+		},
+		{
+			name:    "inverted band",
+			change:  func(r *Requisition) { r.SalaryMin = 300000 },
+			wantErr: ErrRequisitionSalaryBand,
+		},
+		{
+			name:    "over budget",
+			change:  func(r *Requisition) { r.Positions = 3 },
+			wantErr: ErrRequisitionOverBudget,
+		},
+		{
+			name:    "no approver",
+			change:  func(r *Requisition) { r.Approvals = []Approval{{Role: "finance"}} },
+			wantErr: ErrRequisitionApproverRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.change, tt.wantErr))
+	}
+}
+
+func TestRequisition_Decide(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	r := Requisition{
+		Positions: 2,
+		Approvals: []Approval{
+			{Role: "hr director", Approver: "hrd@example.com"},
+			{Role: "finance", Approver: "cfo@example.com"},
+		},
+	}
+	r.Submit()
+	require.Exactly(t, RequisitionStatusPending, r.Status)
+	require.Exactly(t, ErrRequisitionNotApproved, r.CheckOpening(0))
+
+	require.Exactly(t, ErrRequisitionNotApprover, r.Decide("cfo@example.com", DecisionApproved, "", now))
+	require.NoError(t, r.Decide("hrd@example.com", DecisionApproved, "", now))
+	require.Exactly(t, "cfo@example.com", r.NextApproval().Approver)
+	require.NoError(t, r.Decide("cfo@example.com", DecisionApproved, "", now))
+	require.Exactly(t, RequisitionStatusApproved, r.Status)
+	require.Exactly(t, ErrRequisitionInvalidTransition, r.Decide("cfo@example.com", DecisionApproved, "", now))
+
+	require.NoError(t, r.CheckOpening(1))
+	require.Exactly(t, ErrHeadcountFilled, r.CheckOpening(2))
+
+	require.NoError(t, r.Withdraw())
+	require.Exactly(t, ErrRequisitionNotApproved, r.CheckOpening(0))
+
+	r.Submit()
+	require.NoError(t, r.Decide("hrd@example.com", DecisionRejected, "no budget", now))
+	require.Exactly(t, RequisitionStatusRejected, r.Status)
+	require.Exactly(t, ErrRequisitionInvalidTransition, r.Withdraw())
+}
+
+func TestRequisition_FitVacancy(t *testing.T) {
+	r := Requisition{Department: "IT", SalaryMax: 250000}
+	rates := &ExchangeRates{Base: "RUB", Rates: map[string]float64{"USD": 90}, Tax: 13}
+
+	v := Vacancy{}
+	require.NoError(t, r.FitVacancy(&v, rates))
+	require.Exactly(t, "IT", v.Department)
+	require.Exactly(t, uint32(250000), v.Budget)
+
+	v = Vacancy{Department: "Backend", Budget: 200000}
+	require.NoError(t, r.FitVacancy(&v, rates))
+	require.Exactly(t, "Backend", v.Department)
+	require.Exactly(t, uint32(200000), v.Budget)
+
+	v = Vacancy{Budget: 300000}
+	require.Exactly(t, ErrVacancyOverBand, r.FitVacancy(&v, rates))
+
+	v = Vacancy{Salary: SalaryRange{Max: 2500, Currency: "USD"}}
+	require.NoError(t, r.FitVacancy(&v, rates))
+
+	v = Vacancy{Salary: SalaryRange{Max: 3000, Currency: "USD"}}
+	require.Exactly(t, ErrVacancyOverBand, r.FitVacancy(&v, rates))
+
+	v = Vacancy{Salary: SalaryRange{Max: 2500, Currency: "EUR"}}
+	require.Exactly(t, ErrVacancyOverBand, r.FitVacancy(&v, rates), "unknown currency")
+}
@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ResumeStatus byte
+
+const (
+	ResumeStatusNone ResumeStatus = iota
+	ResumeStatusDraft
+	ResumeStatusConfirmed
+	resumeStatusCount
+)
+
+var resumeStatusStrings = []string{
+	"none",
+	"draft",
+	"confirmed",
+}
+
+func (status ResumeStatus) String() string {
+	if status >= resumeStatusCount {
+		return resumeStatusStrings[ResumeStatusNone]
+	}
+	return resumeStatusStrings[status]
+}
+
+func (status ResumeStatus) MarshalText() ([]byte, error) {
+	v := status.String()
+	return []byte(v), nil
+}
+
+var resumeStatusTexts = map[string]ResumeStatus{
+	"":          ResumeStatusNone,
+	"none":      ResumeStatusNone,
+	"draft":     ResumeStatusDraft,
+	"confirmed": ResumeStatusConfirmed,
+}
+
+var ErrInvalidResumeStatus = errors.New("invalid resume status")
+
+func (status *ResumeStatus) UnmarshalText(data []byte) error {
+	v, ok := resumeStatusTexts[string(data)]
+	if !ok {
+		return ErrInvalidResumeStatus
+	}
+	*status = v
+	return nil
+}
+
+func (status *ResumeStatus) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return status.UnmarshalText([]byte(v))
+	case []byte:
+		return status.UnmarshalText(v)
+	}
+	return nil
+}
+
+// Resume is an uploaded resume file with the text extracted from it and the
+// candidate guessed from the text. The draft becomes a candidate once a
+// recruiter reviews and confirms it.
+type Resume struct {
+	ID          uuid.UUID    `json:"id"`
+	Filename    string       `json:"filename"`
+	ContentType string       `json:"contentType"`
+	Size        int64        `json:"size"`
+	Text        string       `json:"text"`
+	Draft       Candidate    `json:"draft"`
+	CandidateID uuid.UUID    `json:"candidateID"`
+	Status      ResumeStatus `json:"status"`
+	Created     time.Time    `json:"created"`
+	Updated     time.Time    `json:"updated"`
+}
+
+var (
+	ErrResumeEmpty     = errors.New("resume file is empty")
+	ErrResumeConfirmed = errors.New("resume is already confirmed")
+)
+
+func (r *Resume) Validate() error {
+	if r.Size == 0 {
+		return ErrResumeEmpty
+	}
+	return nil
+}
+
+// Confirm links the resume to the candidate created from its draft.
+func (r *Resume) Confirm(candidateID uuid.UUID) error {
+	if r.Status == ResumeStatusConfirmed {
+		return ErrResumeConfirmed
+	}
+	r.CandidateID = candidateID
+	r.Status = ResumeStatusConfirmed
+	return nil
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumeStatus_UnmarshalText(t *testing.T) {
+	test := func(data []byte, want ResumeStatus, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			var status ResumeStatus
+			err := status.UnmarshalText(data)
+			require.Exactly(t, wantErr, err)
+			require.Exactly(t, want, status)
+		}
+	}
+
+	t.Run("empty", test([]byte(""), ResumeStatusNone, nil))
+	t.Run("draft", test([]byte("draft"), ResumeStatusDraft, nil))
+	t.Run("confirmed", test([]byte("confirmed"), ResumeStatusConfirmed, nil))
+	t.Run("invalid", test([]byte("parsed"), ResumeStatusNone, ErrInvalidResumeStatus))
+}
+
+func TestResume_Confirm(t *testing.T) {
+	resume := Resume{Size: 10, Status: ResumeStatusDraft}
+	require.NoError(t, resume.Validate())
+
+	candidateID := uuid.New()
+	require.NoError(t, resume.Confirm(candidateID))
+	require.Exactly(t, candidateID, resume.CandidateID)
+	require.Exactly(t, ResumeStatusConfirmed, resume.Status)
+
+	require.Exactly(t, ErrResumeConfirmed, resume.Confirm(uuid.New()))
+	require.Exactly(t, candidateID, resume.CandidateID)
+
+	require.Exactly(t, ErrResumeEmpty, (&Resume{}).Validate())
+}
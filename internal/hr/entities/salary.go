@@ -0,0 +1,243 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+)
+
+type SalaryPeriod byte
+
+const (
+	SalaryPeriodNone SalaryPeriod = iota
+	SalaryPeriodMonth
+	SalaryPeriodYear
+	salaryPeriodCount
+)
+
+var salaryPeriodStrings = []string{
+	"none",
+	"month",
+	"year",
+}
+
+func (period SalaryPeriod) String() string {
+	if period >= salaryPeriodCount {
+		return salaryPeriodStrings[SalaryPeriodNone]
+	}
+	return salaryPeriodStrings[period]
+}
+
+func (period SalaryPeriod) MarshalText() ([]byte, error) {
+	v := period.String()
+	return []byte(v), nil
+}
+
+var salaryPeriodTexts = map[string]SalaryPeriod{
+	"":      SalaryPeriodNone,
+	"none":  SalaryPeriodNone,
+	"month": SalaryPeriodMonth,
+	"year":  SalaryPeriodYear,
+}
+
+var ErrInvalidSalaryPeriod = errors.New("invalid salary period")
+
+func (period *SalaryPeriod) UnmarshalText(data []byte) error {
+	v, ok := salaryPeriodTexts[string(data)]
+	if !ok {
+		return ErrInvalidSalaryPeriod
+	}
+	*period = v
+	return nil
+}
+
+func (period *SalaryPeriod) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return period.UnmarshalText([]byte(v))
+	case []byte:
+		return period.UnmarshalText(v)
+	}
+	return nil
+}
+
+// months returns the number of months in the period, salaries without a
+// period are monthly.
+func (period SalaryPeriod) months() float64 {
+	if period == SalaryPeriodYear {
+		return 12
+	}
+	return 1
+}
+
+var (
+	ErrInvalidCurrency = errors.New("currency must be a three-letter ISO 4217 code")
+	ErrUnknownCurrency = errors.New("currency is missing from the exchange rates")
+	ErrSalaryRange     = errors.New("salary minimum exceeds the maximum")
+	ErrInvalidRate     = errors.New("exchange rate must be positive")
+	ErrInvalidTax      = errors.New("income tax must be a percent below 100")
+)
+
+// NormalizeCurrency returns the upper case ISO 4217 code of the currency,
+// the legacy RUR becoming RUB. Empty currency stays empty.
+func NormalizeCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return "", nil
+	}
+	if len(currency) != 3 {
+		return "", ErrInvalidCurrency
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return "", ErrInvalidCurrency
+		}
+	}
+	if currency == "RUR" {
+		return "RUB", nil
+	}
+	return currency, nil
+}
+
+// SalaryRange is the salary offered for a vacancy. Unlike the budget it may
+// be shown to candidates.
+type SalaryRange struct {
+	Min uint32 `json:"min,omitempty"`
+	// Max is zero if not limited.
+	Max uint32 `json:"max,omitempty"`
+	// Currency is the ISO 4217 code, the base currency of exchange rates if
+	// empty.
+	Currency string `json:"currency,omitempty"`
+	// Gross is true if income tax is yet to be withheld from the amounts.
+	Gross  bool         `json:"gross"`
+	Period SalaryPeriod `json:"period"`
+	// Public shows the salary on the careers site and in job feeds.
+	Public bool `json:"public"`
+}
+
+// Empty reports whether the range has no amounts.
+func (s *SalaryRange) Empty() bool {
+	return s.Min == 0 && s.Max == 0
+}
+
+// Normalize normalizes the currency code and checks the range.
+func (s *SalaryRange) Normalize() error {
+	currency, err := NormalizeCurrency(s.Currency)
+	if err != nil {
+		return err
+	}
+	s.Currency = currency
+	if s.Max > 0 && s.Min > s.Max {
+		return ErrSalaryRange
+	}
+	return nil
+}
+
+// ExchangeRates is the local table salaries in different currencies,
+// periods and with or without tax are compared with. Amounts are converted
+// to net monthly ones in the base currency.
+type ExchangeRates struct {
+	// Base is the currency of amounts without one, RUB if empty.
+	Base string `yaml:"base"`
+	// Rates are prices of a unit of other currencies in the base one.
+	Rates map[string]float64 `yaml:"rates"`
+	// Tax is the income tax in percent withheld from gross amounts.
+	Tax float64 `yaml:"tax"`
+}
+
+// DefaultExchangeRates compare salaries in rubles only with the personal
+// income tax of 13%.
+func DefaultExchangeRates() *ExchangeRates {
+	return &ExchangeRates{Base: "RUB", Tax: 13}
+}
+
+// Normalize normalizes currency codes and checks rates and the tax.
+func (r *ExchangeRates) Normalize() error {
+	base, err := NormalizeCurrency(r.Base)
+	if err != nil {
+		return err
+	}
+	if base == "" {
+		base = "RUB"
+	}
+	rates := make(map[string]float64, len(r.Rates))
+	for currency, rate := range r.Rates {
+		currency, err = NormalizeCurrency(currency)
+		if err != nil {
+			return err
+		}
+		if currency == "" || !(rate > 0) {
+			return ErrInvalidRate
+		}
+		rates[currency] = rate
+	}
+	if r.Tax < 0 || r.Tax >= 100 {
+		return ErrInvalidTax
+	}
+	r.Base, r.Rates = base, rates
+	return nil
+}
+
+// Known reports whether amounts in the currency can be converted.
+func (r *ExchangeRates) Known(currency string) bool {
+	_, ok := r.rate(currency)
+	return ok
+}
+
+// CheckCurrency fails with ErrUnknownCurrency unless amounts in the
+// normalized currency can be converted.
+func (r *ExchangeRates) CheckCurrency(currency string) error {
+	if !r.Known(currency) {
+		return ErrUnknownCurrency
+	}
+	return nil
+}
+
+func (r *ExchangeRates) rate(currency string) (float64, bool) {
+	if currency == "" || currency == r.Base {
+		return 1, true
+	}
+	rate, ok := r.Rates[currency]
+	return rate, ok
+}
+
+// Monthly converts the amount paid per period to the net monthly amount in
+// the base currency, ok is false if the currency is unknown.
+func (r *ExchangeRates) Monthly(amount uint32, currency string, period SalaryPeriod, gross bool) (float64, bool) {
+	rate, ok := r.rate(currency)
+	if !ok {
+		return 0, false
+	}
+	v := float64(amount) * rate / period.months()
+	if gross {
+		v *= 1 - r.Tax/100
+	}
+	return v, true
+}
+
+// Expected returns the net monthly salary the candidate expects in the base
+// currency, zero if the candidate expects none. Candidates state net
+// salaries.
+func (r *ExchangeRates) Expected(c *Candidate) (float64, bool) {
+	if c.Salary == 0 {
+		return 0, true
+	}
+	return r.Monthly(c.Salary, c.SalaryCurrency, c.SalaryPeriod, false)
+}
+
+// Fits reports whether the range pays the net monthly salary in the base
+// currency. Empty ranges and ranges without a maximum pay any salary, ones
+// in unknown currencies pay none.
+func (r *ExchangeRates) Fits(s *SalaryRange, expected float64) bool {
+	if s.Max == 0 {
+		return true
+	}
+	max, ok := r.Monthly(s.Max, s.Currency, s.Period, s.Gross)
+	return ok && expected <= max
+}
+
+// Matches reports whether the vacancy pays the salary the candidate
+// expects. Candidates expecting salaries in unknown currencies match none.
+func (r *ExchangeRates) Matches(v *Vacancy, c *Candidate) bool {
+	expected, ok := r.Expected(c)
+	return ok && r.Fits(&v.Salary, expected)
+}
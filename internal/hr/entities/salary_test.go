@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCurrency(t *testing.T) {
+	test := func(currency, want string, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			got, err := NormalizeCurrency(currency)
+			require.Exactly(t, wantErr, err)
+			require.Exactly(t, want, got)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		currency string
+		want     string
+		wantErr  error
+	}{
+		{name: "empty", currency: " ", want: "", wantErr: nil},
+		{name: "lower case", currency: " usd ", want: "USD", wantErr: nil},
+		{name: "legacy ruble", currency: "RUR", want: "RUB", wantErr: nil},
+		{name: "symbol", currency: "$", want: "", wantErr: ErrInvalidCurrency},
+		{name: "digits", currency: "840", want: "", wantErr: ErrInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.currency, tt.want, tt.wantErr))
+	}
+}
+
+func TestSalaryRange_Normalize(t *testing.T) {
+	s := SalaryRange{Min: 1000, Max: 2000, Currency: "eur"}
+	require.NoError(t, s.Normalize())
+	require.Exactly(t, "EUR", s.Currency)
+
+	s = SalaryRange{Min: 1000}
+	require.NoError(t, s.Normalize(), "no maximum")
+
+	s = SalaryRange{Min: 3000, Max: 2000}
+	require.Exactly(t, ErrSalaryRange, s.Normalize())
+}
+
+func TestExchangeRates_Normalize(t *testing.T) {
+	r := ExchangeRates{Rates: map[string]float64{"usd": 90}, Tax: 13}
+	require.NoError(t, r.Normalize())
+	require.Exactly(t, "RUB", r.Base)
+	require.Exactly(t, map[string]float64{"USD": 90}, r.Rates)
+
+	r = ExchangeRates{Rates: map[string]float64{"USD": 0}}
+	require.Exactly(t, ErrInvalidRate, r.Normalize())
+
+	r = ExchangeRates{Tax: 100}
+	require.Exactly(t, ErrInvalidTax, r.Normalize())
+}
+
+func TestExchangeRates_Matches(t *testing.T) {
+	rates := ExchangeRates{Base: "RUB", Rates: map[string]float64{"USD": 100}, Tax: 20}
+
+	monthly, ok := rates.Monthly(12000, "USD", SalaryPeriodYear, true)
+	require.True(t, ok)
+	require.InDelta(t, 80000, monthly, 0.001)
+	_, ok = rates.Monthly(1000, "EUR", SalaryPeriodMonth, false)
+	require.False(t, ok)
+
+	vacancy := Vacancy{Salary: SalaryRange{Min: 1500, Max: 2500, Currency: "USD", Gross: true}}
+	test := func(candidate Candidate, want bool) func(*testing.T) {
+		return func(t *testing.T) {
+			require.Exactly(t, want, rates.Matches(&vacancy, &candidate))
+		}
+	}
+
+	tests := []struct {
+		name      string
+		candidate Candidate
+		want      bool
+	}{
+		{
+			name:      "no expectation",
+			candidate: Candidate{},
+			want:      true,
+		},
+		{
+			name:      "net maximum in rubles",
+			candidate: Candidate{Salary: 200000},
+			want:      true,
+		},
+		{
+			name:      "above net maximum",
+			candidate: Candidate{Salary: 2400, SalaryCurrency: "USD", SalaryPeriod: SalaryPeriodMonth},
+			want:      false,
+		},
+		{
+			name:      "yearly",
+			candidate: Candidate{Salary: 2400000, SalaryPeriod: SalaryPeriodYear},
+			want:      true,
+		},
+		{
+			name:      "unknown currency",
+			candidate: Candidate{Salary: 1000, SalaryCurrency: "EUR"},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.candidate, tt.want))
+	}
+
+	require.True(t, rates.Fits(&SalaryRange{Min: 1000, Currency: "EUR"}, 1e9), "no maximum")
+	require.False(t, rates.Fits(&SalaryRange{Max: 1000, Currency: "EUR"}, 0), "unknown currency")
+}
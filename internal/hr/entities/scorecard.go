@@ -0,0 +1,181 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Recommendation byte
+
+const (
+	RecommendationNone Recommendation = iota
+	RecommendationStrongNo
+	RecommendationNo
+	RecommendationYes
+	RecommendationStrongYes
+	recommendationCount
+)
+
+var recommendationStrings = []string{
+	"none",
+	"strongNo",
+	"no",
+	"yes",
+	"strongYes",
+}
+
+func (r Recommendation) String() string {
+	if r >= recommendationCount {
+		return recommendationStrings[RecommendationNone]
+	}
+	return recommendationStrings[r]
+}
+
+func (r Recommendation) MarshalText() ([]byte, error) {
+	v := r.String()
+	return []byte(v), nil
+}
+
+var recommendationTexts = map[string]Recommendation{
+	"":          RecommendationNone,
+	"none":      RecommendationNone,
+	"strongNo":  RecommendationStrongNo,
+	"no":        RecommendationNo,
+	"yes":       RecommendationYes,
+	"strongYes": RecommendationStrongYes,
+}
+
+var ErrInvalidRecommendation = errors.New("invalid recommendation")
+
+func (r *Recommendation) UnmarshalText(data []byte) error {
+	v, ok := recommendationTexts[string(data)]
+	if !ok {
+		return ErrInvalidRecommendation
+	}
+	*r = v
+	return nil
+}
+
+func (r *Recommendation) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return r.UnmarshalText([]byte(v))
+	case []byte:
+		return r.UnmarshalText(v)
+	}
+	return nil
+}
+
+// Scores of skill ratings.
+const (
+	MinScore = 1
+	MaxScore = 5
+)
+
+// Rating is a score of one vacancy skill.
+type Rating struct {
+	Skill string `json:"skill"`
+	Score int    `json:"score"`
+}
+
+// Scorecard is a feedback of one interviewer on one interview.
+type Scorecard struct {
+	ID             uuid.UUID      `json:"id"`
+	InterviewID    uuid.UUID      `json:"interviewID"`
+	CardID         uuid.UUID      `json:"cardID"`
+	Interviewer    string         `json:"interviewer"`
+	Ratings        []Rating       `json:"ratings"`
+	Recommendation Recommendation `json:"recommendation"`
+	Notes          string         `json:"notes"`
+	Created        time.Time      `json:"created"`
+	Updated        time.Time      `json:"updated"`
+}
+
+var (
+	ErrScorecardInterviewerRequired    = errors.New("scorecard interviewer is required")
+	ErrScorecardRecommendationRequired = errors.New("scorecard recommendation is required")
+	ErrScorecardInvalidScore           = errors.New("scorecard score must be from 1 to 5")
+	ErrScorecardUnknownSkill           = errors.New("scorecard rates skill the vacancy does not require")
+	ErrScorecardDuplicateSkill         = errors.New("scorecard rates skill more than once")
+	ErrScorecardSkillNotRated          = errors.New("scorecard must rate every vacancy skill")
+)
+
+// Validate checks the scorecard rates every of the given vacancy skills once.
+func (s *Scorecard) Validate(skills []Skill) error {
+	if s.Interviewer == "" {
+		return ErrScorecardInterviewerRequired
+	}
+	if s.Recommendation == RecommendationNone {
+		return ErrScorecardRecommendationRequired
+	}
+
+	required := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		required[skill.Title] = true
+	}
+	rated := make(map[string]bool, len(s.Ratings))
+	for _, rating := range s.Ratings {
+		if rating.Score < MinScore || rating.Score > MaxScore {
+			return ErrScorecardInvalidScore
+		}
+		if !required[rating.Skill] {
+			return ErrScorecardUnknownSkill
+		}
+		if rated[rating.Skill] {
+			return ErrScorecardDuplicateSkill
+		}
+		rated[rating.Skill] = true
+	}
+	if len(rated) != len(required) {
+		return ErrScorecardSkillNotRated
+	}
+	return nil
+}
+
+// SkillScore is an average score of a skill over several scorecards.
+type SkillScore struct {
+	Skill   string  `json:"skill"`
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
+
+// Feedback is the aggregate of scorecards on a card.
+type Feedback struct {
+	Scorecards      int                    `json:"scorecards"`
+	Hidden          int                    `json:"hidden"`
+	Skills          []SkillScore           `json:"skills"`
+	Recommendations map[Recommendation]int `json:"recommendations"`
+}
+
+// Aggregate summarizes the scorecards. Skills are listed in order of their
+// first appearance.
+func Aggregate(scorecards []Scorecard) Feedback {
+	feedback := Feedback{
+		Scorecards:      len(scorecards),
+		Skills:          []SkillScore{},
+		Recommendations: make(map[Recommendation]int),
+	}
+
+	index := make(map[string]int)
+	totals := []int{}
+	for _, scorecard := range scorecards {
+		feedback.Recommendations[scorecard.Recommendation]++
+		for _, rating := range scorecard.Ratings {
+			i, ok := index[rating.Skill]
+			if !ok {
+				i = len(feedback.Skills)
+				index[rating.Skill] = i
+				feedback.Skills = append(feedback.Skills, SkillScore{Skill: rating.Skill})
+				totals = append(totals, 0)
+			}
+			feedback.Skills[i].Count++
+			totals[i] += rating.Score
+		}
+	}
+	for i := range feedback.Skills {
+		feedback.Skills[i].Average = float64(totals[i]) / float64(feedback.Skills[i].Count)
+	}
+	return feedback
+}
@@ -0,0 +1,197 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecommendation_UnmarshalText(t *testing.T) {
+	test := func(data []byte, want Recommendation, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			var r Recommendation
+			err := r.UnmarshalText(data)
+			require.Exactly(t, wantErr, err)
+			require.Exactly(t, want, r)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    Recommendation
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			data:    []byte(""),
+			want:    RecommendationNone,
+			wantErr: nil,
+		},
+		{
+			name:    "strongNo",
+			data:    []byte("strongNo"),
+			want:    RecommendationStrongNo,
+			wantErr: nil,
+		},
+		{
+			name:    "no",
+			data:    []byte("no"),
+			want:    RecommendationNo,
+			wantErr: nil,
+		},
+		{
+			name:    "yes",
+			data:    []byte("yes"),
+			want:    RecommendationYes,
+			wantErr: nil,
+		},
+		{
+			name:    "strongYes",
+			data:    []byte("strongYes"),
+			want:    RecommendationStrongYes,
+			wantErr: nil,
+		},
+		{
+			name:    "invalid",
+			data:    []byte("maybe"),
+			want:    RecommendationNone,
+			wantErr: ErrInvalidRecommendation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.data, tt.want, tt.wantErr))
+	}
+}
+
+func TestScorecard_Validate(t *testing.T) {
+	skills := []Skill{{Title: "Go", Important: true}, {Title: "SQL"}}
+	valid := func() Scorecard {
+		return Scorecard{
+			Interviewer:    "lead@example.com",
+			Ratings:        []Rating{{Skill: "Go", Score: 5}, {Skill: "SQL", Score: 3}},
+			Recommendation: RecommendationYes,
+		}
+	}
+
+	test := func(scorecard Scorecard, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			err := scorecard.Validate(skills)
+			require.Exactly(t, wantErr, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		scorecard func() Scorecard
+		wantErr   error
+	}{
+		{
+			name:      "valid",
+			scorecard: valid,
+			wantErr:   nil,
+		},
+		{
+			name: "no interviewer",
+			scorecard: func() Scorecard {
+				s := valid()
+				s.Interviewer = ""
+				return s
+			},
+			wantErr: ErrScorecardInterviewerRequired,
+		},
+		{
+			name: "no recommendation",
+			scorecard: func() Scorecard {
+				s := valid()
+				s.Recommendation = RecommendationNone
+				return s
+			},
+			wantErr: ErrScorecardRecommendationRequired,
+		},
+		{
+			name: "score too high",
+			scorecard: func() Scorecard {
+				s := valid()
+				s.Ratings[0].Score = 6
+				return s
+			},
+			wantErr: ErrScorecardInvalidScore,
+		},
+		{
+			name: "zero score",
+			scorecard: func() Scorecard {
+				s := valid()
+				s.Ratings[1].Score = 0
+				return s
+			},
+			wantErr: ErrScorecardInvalidScore,
+		},
+		{
+			name: "unknown skill",
+			scorecard: func() Scorecard {
+				s := valid()
+				s.Ratings = append(s.Ratings, Rating{Skill: "Rust", Score: 4})
+				return s
+			},
+			wantErr: ErrScorecardUnknownSkill,
+		},
+		{
+			name: "duplicate skill",
+			scorecard: func() Scorecard {
+				s := valid()
+				s.Ratings[1].Skill = "Go"
+				return s
+			},
+			wantErr: ErrScorecardDuplicateSkill,
+		},
+		{
+			name: "skill not rated",
+			scorecard: func() Scorecard {
+				s := valid()
+				s.Ratings = s.Ratings[:1]
+				return s
+			},
+			wantErr: ErrScorecardSkillNotRated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.scorecard(), tt.wantErr))
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	feedback := Aggregate([]Scorecard{
+		{
+			Ratings:        []Rating{{Skill: "Go", Score: 5}, {Skill: "SQL", Score: 2}},
+			Recommendation: RecommendationYes,
+		},
+		{
+			Ratings:        []Rating{{Skill: "Go", Score: 4}},
+			Recommendation: RecommendationYes,
+		},
+		{
+			Ratings:        []Rating{{Skill: "SQL", Score: 5}, {Skill: "Go", Score: 3}},
+			Recommendation: RecommendationNo,
+		},
+	})
+
+	require.Exactly(t, Feedback{
+		Scorecards: 3,
+		Skills: []SkillScore{
+			{Skill: "Go", Average: 4, Count: 3},
+			{Skill: "SQL", Average: 3.5, Count: 2},
+		},
+		Recommendations: map[Recommendation]int{
+			RecommendationYes: 2,
+			RecommendationNo:  1,
+		},
+	}, feedback)
+
+	require.Exactly(t, Feedback{
+		Skills:          []SkillScore{},
+		Recommendations: map[Recommendation]int{},
+	}, Aggregate(nil))
+}
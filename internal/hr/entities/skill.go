@@ -0,0 +1,187 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// CatalogSkill is a skill of the managed catalog. Skills of vacancies and
+// candidates are normalized to catalog names, synonyms are the other ways
+// people write the same skill.
+type CatalogSkill struct {
+	ID uuid.UUID `json:"id"`
+	// Name is the canonical name vacancies and candidates get.
+	Name     string    `json:"name"`
+	Synonyms []string  `json:"synonyms"`
+	Category string    `json:"category"`
+	Created  time.Time `json:"created"`
+	Updated  time.Time `json:"updated"`
+}
+
+var ErrSkillNameRequired = errors.New("skill name is required")
+
+// Normalize collapses spaces and drops synonyms which are only other
+// spellings of the name or of another synonym.
+func (s *CatalogSkill) Normalize() {
+	s.Name = strings.Join(strings.Fields(s.Name), " ")
+	s.Category = strings.Join(strings.Fields(s.Category), " ")
+
+	seen := map[string]bool{SkillKey(s.Name): true}
+	synonyms := make([]string, 0, len(s.Synonyms))
+	for _, synonym := range s.Synonyms {
+		synonym = strings.Join(strings.Fields(synonym), " ")
+		key := SkillKey(synonym)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		synonyms = append(synonyms, synonym)
+	}
+	s.Synonyms = synonyms
+}
+
+func (s *CatalogSkill) Validate() error {
+	if SkillKey(s.Name) == "" {
+		return ErrSkillNameRequired
+	}
+	return nil
+}
+
+// Keys returns keys of the name and the synonyms.
+func (s *CatalogSkill) Keys() []string {
+	keys := []string{SkillKey(s.Name)}
+	for _, synonym := range s.Synonyms {
+		keys = append(keys, SkillKey(synonym))
+	}
+	return keys
+}
+
+// Absorb makes the other skill a synonym of this one, along with its
+// synonyms. The category is taken if this skill has none.
+func (s *CatalogSkill) Absorb(other *CatalogSkill) {
+	s.Synonyms = append(s.Synonyms, other.Name)
+	s.Synonyms = append(s.Synonyms, other.Synonyms...)
+	if s.Category == "" {
+		s.Category = other.Category
+	}
+	s.Normalize()
+}
+
+// SkillKey returns the form skills are compared in: lower case without
+// spaces, dots, dashes and underscores, so "Go lang", "golang" and "GoLang"
+// are the same while "C++" and "C#" are not.
+func SkillKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '.' || r == '-' || r == '_' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// SkillIndex finds catalog names of skills.
+type SkillIndex struct {
+	names map[string]string
+	terms []string
+}
+
+// NewSkillIndex indexes names and synonyms of the skills.
+func NewSkillIndex(skills []CatalogSkill) *SkillIndex {
+	index := &SkillIndex{names: make(map[string]string)}
+	for _, skill := range skills {
+		index.add(skill.Name, skill.Name)
+		for _, synonym := range skill.Synonyms {
+			index.add(synonym, skill.Name)
+		}
+	}
+	return index
+}
+
+func (index *SkillIndex) add(term, name string) {
+	key := SkillKey(term)
+	if _, ok := index.names[key]; ok || key == "" {
+		return
+	}
+	index.names[key] = name
+	index.terms = append(index.terms, term)
+}
+
+// Name returns the catalog name of the skill. Skills missing in the catalog
+// are returned as they are, only spaces are collapsed.
+func (index *SkillIndex) Name(skill string) string {
+	if name, ok := index.names[SkillKey(skill)]; ok {
+		return name
+	}
+	return strings.Join(strings.Fields(skill), " ")
+}
+
+// Names returns catalog names of the skills in the same order. Empty skills
+// and the ones with the same name as an earlier one are dropped.
+func (index *SkillIndex) Names(skills []string) []string {
+	if skills == nil {
+		return nil
+	}
+	names := make([]string, 0, len(skills))
+	seen := make(map[string]bool)
+	for _, skill := range skills {
+		name := index.Name(skill)
+		key := SkillKey(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// Dictionary returns names and synonyms of the catalog followed by skills of
+// the vacancies missing in it, the words resumes are searched for.
+func (index *SkillIndex) Dictionary(vacancies []Vacancy) []string {
+	terms := append([]string(nil), index.terms...)
+	seen := make(map[string]bool)
+	for _, vacancy := range vacancies {
+		for _, skill := range vacancy.Skills {
+			key := SkillKey(skill.Title)
+			if _, ok := index.names[key]; ok || seen[key] || key == "" {
+				continue
+			}
+			seen[key] = true
+			terms = append(terms, skill.Title)
+		}
+	}
+	return terms
+}
+
+// NormalizeSkills replaces skills of the vacancy with their catalog names.
+// A skill written several ways is kept once, important if any of them is.
+func (v *Vacancy) NormalizeSkills(index *SkillIndex) {
+	if v.Skills == nil {
+		return
+	}
+	skills := make([]Skill, 0, len(v.Skills))
+	seen := make(map[string]int)
+	for _, skill := range v.Skills {
+		name := index.Name(skill.Title)
+		key := SkillKey(name)
+		if key == "" {
+			continue
+		}
+		if i, ok := seen[key]; ok {
+			skills[i].Important = skills[i].Important || skill.Important
+			continue
+		}
+		seen[key] = len(skills)
+		skills = append(skills, Skill{Title: name, Important: skill.Important})
+	}
+	v.Skills = skills
+}
+
+// NormalizeSkills replaces skills of the candidate with their catalog names.
+func (c *Candidate) NormalizeSkills(index *SkillIndex) {
+	c.Skills = index.Names(c.Skills)
+}
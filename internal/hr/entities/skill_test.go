@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkillKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Go", want: "go"},
+		{name: "Go lang", want: "golang"},
+		{name: "GoLang", want: "golang"},
+		{name: "Node.js", want: "nodejs"},
+		{name: "CI/CD", want: "ci/cd"},
+		{name: "C++", want: "c++"},
+		{name: "C#", want: "c#"},
+		{name: "PL_SQL", want: "plsql"},
+		{name: "Постгрес", want: "постгрес"},
+		{name: " - ", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, SkillKey(tt.name))
+		})
+	}
+}
+
+func TestCatalogSkill_Absorb(t *testing.T) {
+	skill := CatalogSkill{Name: " Go ", Synonyms: []string{"golang", "Go  lang", ""}}
+	skill.Normalize()
+	require.Equal(t, "Go", skill.Name)
+	require.Equal(t, []string{"golang"}, skill.Synonyms)
+	require.NoError(t, skill.Validate())
+
+	skill.Absorb(&CatalogSkill{Name: "Go-lang", Synonyms: []string{"Go"}, Category: "Languages"})
+	require.Equal(t, []string{"golang"}, skill.Synonyms, "other spellings are dropped")
+	require.Equal(t, "Languages", skill.Category)
+
+	skill.Absorb(&CatalogSkill{Name: "Goroutines", Category: "Concurrency"})
+	require.Equal(t, []string{"golang", "Goroutines"}, skill.Synonyms)
+	require.Equal(t, "Languages", skill.Category)
+
+	require.ErrorIs(t, (&CatalogSkill{Name: " . "}).Validate(), ErrSkillNameRequired)
+}
+
+func TestSkillIndex(t *testing.T) {
+	index := NewSkillIndex([]CatalogSkill{
+		{Name: "Go", Synonyms: []string{"Golang"}},
+		{Name: "PostgreSQL", Synonyms: []string{"Postgres", "golang"}},
+	})
+	require.Equal(t, "Go", index.Name("go lang"))
+	require.Equal(t, "PostgreSQL", index.Name("POSTGRES"))
+	require.Equal(t, "Kafka Streams", index.Name(" Kafka  Streams"))
+	require.Equal(t, []string{"Go", "Kafka", "PostgreSQL"}, index.Names([]string{"golang", "Kafka", "Go", " ", "postgres"}))
+	require.Nil(t, index.Names(nil))
+
+	vacancies := []Vacancy{{Skills: []Skill{{Title: "golang"}, {Title: "Kafka"}, {Title: "kafka"}}}}
+	require.Equal(t, []string{"Go", "Golang", "PostgreSQL", "Postgres", "Kafka"}, index.Dictionary(vacancies))
+
+	vacancy := Vacancy{Skills: []Skill{
+		{Title: "golang"},
+		{Title: "Kafka", Important: true},
+		{Title: "Go", Important: true},
+		{Title: ""},
+	}}
+	vacancy.NormalizeSkills(index)
+	require.Equal(t, []Skill{{Title: "Go", Important: true}, {Title: "Kafka", Important: true}}, vacancy.Skills)
+
+	candidate := Candidate{Skills: []string{"Postgres", "PostgreSQL"}}
+	candidate.NormalizeSkills(index)
+	require.Equal(t, []string{"PostgreSQL"}, candidate.Skills)
+}
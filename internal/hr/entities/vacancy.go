@@ -71,20 +71,33 @@ type Skill struct {
 }
 
 type Vacancy struct {
-	ID           uuid.UUID     `json:"id"`
-	TemplateID   uuid.UUID     `json:"templateID"`
-	Title        string        `json:"title"`
-	Status       VacancyStatus `json:"status"`
-	Area         string        `json:"area,omitempty"`
-	Department   string        `json:"department,omitempty"`
-	Skills       []Skill       `json:"skills"`
-	Duties       []string      `json:"duties"`
-	Requirements []string      `json:"requirements"`
-	Experience   uint32        `json:"experience"`
-	Created      time.Time     `json:"created"`
-	Updated      time.Time     `json:"updated"`
+	ID         uuid.UUID `json:"id"`
+	TemplateID uuid.UUID `json:"templateID"`
+	// RequisitionID is the approved request for headcount the vacancy is
+	// opened by, uuid.Nil if none.
+	RequisitionID uuid.UUID     `json:"requisitionID"`
+	Title         string        `json:"title"`
+	Status        VacancyStatus `json:"status"`
+	Area          string        `json:"area,omitempty"`
+	Department    string        `json:"department,omitempty"`
+	// HiringManager is email of the manager the position is opened for.
+	HiringManager string `json:"hiringManager,omitempty"`
+	// Recruiter is email of the recruiter filling the position.
+	Recruiter    string   `json:"recruiter,omitempty"`
+	Skills       []Skill  `json:"skills"`
+	Duties       []string `json:"duties"`
+	Requirements []string `json:"requirements"`
+	Experience   uint32   `json:"experience"`
+	// Budget is the highest monthly salary approved for the position, zero
+	// if not limited.
+	Budget uint32 `json:"budget,omitempty"`
+	// Salary is the range offered to candidates.
+	Salary  SalaryRange `json:"salary"`
+	Created time.Time   `json:"created"`
+	Updated time.Time   `json:"updated"`
 }
 
+// Validate checks the salary range, normalizing its currency.
 func (v *Vacancy) Validate() error {
-	return nil
+	return v.Salary.Normalize()
 }
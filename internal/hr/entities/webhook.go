@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is a subscription of an external system to domain events.
+type Webhook struct {
+	ID      uuid.UUID   `json:"id"`
+	URL     string      `json:"url"`
+	Events  []EventType `json:"events"`
+	Secret  string      `json:"secret"`
+	Active  bool        `json:"active"`
+	Created time.Time   `json:"created"`
+	Updated time.Time   `json:"updated"`
+}
+
+const webhookMinSecretLength = 16
+
+var (
+	ErrWebhookInvalidURL     = errors.New("webhook url must be absolute http(s) url")
+	ErrWebhookEventsRequired = errors.New("webhook events are required")
+	ErrWebhookInvalidEvent   = errors.New("webhook event type is invalid")
+	ErrWebhookSecretTooShort = errors.New("webhook secret must be at least 16 characters")
+)
+
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrWebhookInvalidURL
+	}
+	if len(w.Events) == 0 {
+		return ErrWebhookEventsRequired
+	}
+	for _, typ := range w.Events {
+		if typ == EventTypeNone {
+			return ErrWebhookInvalidEvent
+		}
+	}
+	if len(w.Secret) < webhookMinSecretLength {
+		return ErrWebhookSecretTooShort
+	}
+	return nil
+}
+
+// Subscribed reports whether the webhook wants events of the given type.
+func (w *Webhook) Subscribed(typ EventType) bool {
+	if !w.Active {
+		return false
+	}
+	for _, v := range w.Events {
+		if v == typ {
+			return true
+		}
+	}
+	return false
+}
+
+type DeliveryStatus byte
+
+const (
+	DeliveryStatusNone DeliveryStatus = iota
+	DeliveryStatusPending
+	DeliveryStatusDelivered
+	DeliveryStatusDead
+	deliveryStatusCount
+)
+
+var deliveryStatusStrings = []string{
+	"none",
+	"pending",
+	"delivered",
+	"dead",
+}
+
+func (status DeliveryStatus) String() string {
+	if status >= deliveryStatusCount {
+		return deliveryStatusStrings[DeliveryStatusNone]
+	}
+	return deliveryStatusStrings[status]
+}
+
+func (status DeliveryStatus) MarshalText() ([]byte, error) {
+	v := status.String()
+	return []byte(v), nil
+}
+
+var deliveryStatusTexts = map[string]DeliveryStatus{
+	"":          DeliveryStatusNone,
+	"none":      DeliveryStatusNone,
+	"pending":   DeliveryStatusPending,
+	"delivered": DeliveryStatusDelivered,
+	"dead":      DeliveryStatusDead,
+}
+
+var ErrInvalidDeliveryStatus = errors.New("invalid delivery status")
+
+func (status *DeliveryStatus) UnmarshalText(data []byte) error {
+	v, ok := deliveryStatusTexts[string(data)]
+	if !ok {
+		return ErrInvalidDeliveryStatus
+	}
+	*status = v
+	return nil
+}
+
+func (status *DeliveryStatus) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return status.UnmarshalText([]byte(v))
+	case []byte:
+		return status.UnmarshalText(v)
+	}
+	return nil
+}
+
+// Delivery is an attempt to pass one event to one webhook. It doubles as
+// the delivery log entry.
+type Delivery struct {
+	ID           uuid.UUID       `json:"id"`
+	WebhookID    uuid.UUID       `json:"webhookID"`
+	EventID      uuid.UUID       `json:"eventID"`
+	EventType    EventType       `json:"eventType"`
+	Payload      json.RawMessage `json:"payload"`
+	Status       DeliveryStatus  `json:"status"`
+	Attempts     int             `json:"attempts"`
+	NextAttempt  time.Time       `json:"nextAttempt"`
+	ResponseCode int             `json:"responseCode"`
+	LastError    string          `json:"lastError"`
+	Created      time.Time       `json:"created"`
+	Updated      time.Time       `json:"updated"`
+}
@@ -0,0 +1,138 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliveryStatus_UnmarshalText(t *testing.T) {
+	test := func(data []byte, want DeliveryStatus, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			var status DeliveryStatus
+			err := status.UnmarshalText(data)
+			require.Exactly(t, wantErr, err)
+			require.Exactly(t, want, status)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    DeliveryStatus
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			data:    []byte(""),
+			want:    DeliveryStatusNone,
+			wantErr: nil,
+		},
+		{
+			name:    "pending",
+			data:    []byte("pending"),
+			want:    DeliveryStatusPending,
+			wantErr: nil,
+		},
+		{
+			name:    "delivered",
+			data:    []byte("delivered"),
+			want:    DeliveryStatusDelivered,
+			wantErr: nil,
+		},
+		{
+			name:    "dead",
+			data:    []byte("dead"),
+			want:    DeliveryStatusDead,
+			wantErr: nil,
+		},
+		{
+			name:    "invalid",
+			data:    []byte("foo"),
+			want:    DeliveryStatusNone,
+			wantErr: ErrInvalidDeliveryStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.data, tt.want, tt.wantErr))
+	}
+}
+
+func TestWebhook_Validate(t *testing.T) {
+	valid := func() Webhook {
+		return Webhook{
+			URL:    "https://bot.example.com/hr",
+			Events: []EventType{EventTypeCardMoved},
+			Secret: "0123456789abcdef",
+		}
+	}
+
+	test := func(webhook Webhook, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			err := webhook.Validate()
+			require.Exactly(t, wantErr, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		webhook func() Webhook
+		wantErr error
+	}{
+		{
+			name:    "valid",
+			webhook: valid,
+			wantErr: nil,
+		},
+		{
+			name: "relative url",
+			webhook: func() Webhook {
+				w := valid()
+				w.URL = "/hr"
+				return w
+			},
+			wantErr: ErrWebhookInvalidURL,
+		},
+		{
+			name: "ftp url",
+			webhook: func() Webhook {
+				w := valid()
+				w.URL = "ftp://example.com/hr"
+				return w
+			},
+			wantErr: ErrWebhookInvalidURL,
+		},
+		{
+			name: "no events",
+			webhook: func() Webhook {
+				w := valid()
+				w.Events = nil
+				return w
+			},
+			wantErr: ErrWebhookEventsRequired,
+		},
+		{
+			name: "none event",
+			webhook: func() Webhook {
+				w := valid()
+				w.Events = []EventType{EventTypeNone}
+				return w
+			},
+			wantErr: ErrWebhookInvalidEvent,
+		},
+		{
+			name: "short secret",
+			webhook: func() Webhook {
+				w := valid()
+				w.Secret = "secret"
+				return w
+			},
+			wantErr: ErrWebhookSecretTooShort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.webhook(), tt.wantErr))
+	}
+}
@@ -0,0 +1,169 @@
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+// Hub broadcasts new outbox events to live subscribers, e.g. browsers
+// watching a board. Unlike Relay it keeps no positions: subscribers only get
+// events written while they are connected and resume by sequence number.
+//
+// The hub reads the outbox when notified about new events and, as a
+// fallback, with the given interval, so notifications may be lost.
+type Hub struct {
+	outbox   repos.OutboxRepo
+	interval time.Duration
+	batch    int
+	buffer   int
+
+	wake chan struct{}
+
+	mu          sync.Mutex
+	last        int64
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives events matching its filter. The channel is closed
+// when the subscription is cancelled or the subscriber lags too far behind;
+// in the latter case the subscriber should resume from the last event seen.
+type Subscription struct {
+	C      <-chan entities.Event
+	c      chan entities.Event
+	filter func(*entities.Event) bool
+}
+
+// NewHub creates hub reading the outbox.
+func NewHub(outbox repos.OutboxRepo, interval time.Duration) *Hub {
+	return &Hub{
+		outbox:      outbox,
+		interval:    interval,
+		batch:       100,
+		buffer:      64,
+		wake:        make(chan struct{}, 1),
+		last:        -1,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Notify tells the hub there are new events in the outbox. It never blocks.
+func (hub *Hub) Notify() {
+	select {
+	case hub.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run broadcasts events until the context is done.
+func (hub *Hub) Run(ctx context.Context) error {
+	ticker := time.NewTicker(hub.interval)
+	defer ticker.Stop()
+
+	for {
+		err := hub.Poll(ctx)
+		if err != nil {
+			log.Printf("[error] [events] hub: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			hub.close()
+			return ctx.Err()
+		case <-ticker.C:
+		case <-hub.wake:
+		}
+	}
+}
+
+// Poll broadcasts events written since the previous poll. The first poll
+// only remembers the outbox position.
+func (hub *Hub) Poll(ctx context.Context) error {
+	hub.mu.Lock()
+	last := hub.last
+	hub.mu.Unlock()
+
+	if last < 0 {
+		seq, err := hub.outbox.Last(ctx)
+		if err != nil {
+			return err
+		}
+		hub.mu.Lock()
+		hub.last = seq
+		hub.mu.Unlock()
+		return nil
+	}
+
+	for {
+		events, err := hub.outbox.List(ctx, last, hub.batch)
+		if err != nil {
+			return err
+		}
+		for i := range events {
+			hub.broadcast(&events[i])
+			last = events[i].Seq
+		}
+
+		hub.mu.Lock()
+		hub.last = last
+		hub.mu.Unlock()
+
+		if len(events) < hub.batch {
+			return nil
+		}
+	}
+}
+
+func (hub *Hub) broadcast(event *entities.Event) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	for sub := range hub.subscribers {
+		if !sub.filter(event) {
+			continue
+		}
+		select {
+		case sub.c <- *event:
+		default:
+			delete(hub.subscribers, sub)
+			close(sub.c)
+		}
+	}
+}
+
+// Subscribe creates subscription to events matching the filter.
+func (hub *Hub) Subscribe(filter func(*entities.Event) bool) *Subscription {
+	c := make(chan entities.Event, hub.buffer)
+	sub := &Subscription{C: c, c: c, filter: filter}
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	hub.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe cancels the subscription. It is safe to call it more than once.
+func (hub *Hub) Unsubscribe(sub *Subscription) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	if _, ok := hub.subscribers[sub]; ok {
+		delete(hub.subscribers, sub)
+		close(sub.c)
+	}
+}
+
+func (hub *Hub) close() {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	for sub := range hub.subscribers {
+		delete(hub.subscribers, sub)
+		close(sub.c)
+	}
+}
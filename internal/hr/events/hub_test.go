@@ -0,0 +1,50 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos/memory"
+)
+
+func TestHub_Poll(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.New()
+
+	require.NoError(t, mem.Candidate.Create(ctx, &entities.Candidate{Name: "Old"}))
+
+	hub := NewHub(mem.Outbox, 0)
+	hub.buffer = 2
+	require.NoError(t, hub.Poll(ctx))
+
+	all := hub.Subscribe(func(*entities.Event) bool { return true })
+	vacancies := hub.Subscribe(func(event *entities.Event) bool {
+		return event.Type == entities.EventTypeVacancyCreated
+	})
+
+	require.NoError(t, mem.Candidate.Create(ctx, &entities.Candidate{Name: "John Doe"}))
+	require.NoError(t, mem.Vacancy.Create(ctx, &entities.Vacancy{Title: "Go developer"}))
+	require.NoError(t, hub.Poll(ctx))
+
+	require.Equal(t, entities.EventTypeCandidateCreated, (<-all.C).Type, "events before the first poll are skipped")
+	require.Equal(t, entities.EventTypeVacancyCreated, (<-all.C).Type)
+	require.Equal(t, entities.EventTypeVacancyCreated, (<-vacancies.C).Type)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, mem.Candidate.Create(ctx, &entities.Candidate{Name: "John Doe"}))
+	}
+	require.NoError(t, hub.Poll(ctx))
+
+	<-all.C
+	<-all.C
+	_, ok := <-all.C
+	require.False(t, ok, "lagging subscriber must be dropped")
+
+	hub.Unsubscribe(vacancies)
+	hub.Unsubscribe(vacancies)
+	_, ok = <-vacancies.C
+	require.False(t, ok)
+}
@@ -0,0 +1,112 @@
+// Package events delivers domain events from the outbox to consumers.
+package events
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+// Handler consumes domain events. Delivery is at-least-once, so handlers
+// must tolerate events they have already seen, e.g. by event ID.
+type Handler interface {
+	Handle(context.Context, entities.Event) error
+}
+
+// HandlerFunc adapts an ordinary function to Handler.
+type HandlerFunc func(context.Context, entities.Event) error
+
+func (fn HandlerFunc) Handle(ctx context.Context, event entities.Event) error {
+	return fn(ctx, event)
+}
+
+// Relay polls the outbox and passes new events to subscribed consumers.
+// Every consumer receives events in order and has its own position, so a
+// failing consumer is retried without affecting the others.
+type Relay struct {
+	outbox   repos.OutboxRepo
+	interval time.Duration
+	batch    int
+
+	mu        sync.Mutex
+	consumers map[string]Handler
+}
+
+// NewRelay creates relay polling the outbox with the given interval.
+func NewRelay(outbox repos.OutboxRepo, interval time.Duration) *Relay {
+	return &Relay{
+		outbox:    outbox,
+		interval:  interval,
+		batch:     100,
+		consumers: make(map[string]Handler),
+	}
+}
+
+// Subscribe registers handler under the consumer name. The name identifies
+// consumer position in the outbox and must be stable across restarts.
+func (relay *Relay) Subscribe(consumer string, handler Handler) {
+	relay.mu.Lock()
+	defer relay.mu.Unlock()
+
+	relay.consumers[consumer] = handler
+}
+
+// Run delivers events until the context is done.
+func (relay *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(relay.interval)
+	defer ticker.Stop()
+
+	for {
+		relay.Poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll delivers all pending events once.
+func (relay *Relay) Poll(ctx context.Context) {
+	relay.mu.Lock()
+	names := make([]string, 0, len(relay.consumers))
+	for name := range relay.consumers {
+		names = append(names, name)
+	}
+	relay.mu.Unlock()
+	sort.Strings(names)
+
+	for _, name := range names {
+		relay.mu.Lock()
+		handler := relay.consumers[name]
+		relay.mu.Unlock()
+
+		for ctx.Err() == nil {
+			n, err := relay.outbox.Consume(ctx, name, relay.batch, handler.Handle)
+			if err != nil {
+				log.Printf("[error] [events] consumer %s: %s", name, err)
+				break
+			}
+			if n < relay.batch {
+				break
+			}
+		}
+	}
+}
+
+// Log is a handler writing events to the log.
+var Log = HandlerFunc(func(ctx context.Context, event entities.Event) error {
+	log.Printf(
+		"[info] [events] %s %s %s",
+		event.Type,
+		event.AggregateID,
+		event.Payload,
+	)
+	return nil
+})
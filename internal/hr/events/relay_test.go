@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos/memory"
+)
+
+func TestRelay_Poll(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.New()
+
+	vacancy := entities.Vacancy{Title: "Go developer", Status: entities.VacancyStatusDraft}
+	require.NoError(t, mem.Vacancy.Create(ctx, &vacancy))
+	vacancy.Status = entities.VacancyStatusActive
+	require.NoError(t, mem.Vacancy.Update(ctx, &vacancy))
+
+	candidate := entities.Candidate{Name: "John Doe"}
+	require.NoError(t, mem.Candidate.Create(ctx, &candidate))
+
+	card := entities.Card{VacancyID: vacancy.ID, CandidateID: candidate.ID}
+	require.NoError(t, mem.Card.Create(ctx, &card))
+	_, err := mem.Card.Move(ctx, card.ID, entities.CardStageOffer)
+	require.NoError(t, err)
+
+	want := []entities.EventType{
+		entities.EventTypeVacancyCreated,
+		entities.EventTypeVacancyUpdated,
+		entities.EventTypeVacancyStatusChanged,
+		entities.EventTypeCandidateCreated,
+		entities.EventTypeCardCreated,
+		entities.EventTypeCardMoved,
+	}
+
+	relay := NewRelay(mem.Outbox, 0)
+
+	var got []entities.EventType
+	relay.Subscribe("ok", HandlerFunc(func(ctx context.Context, event entities.Event) error {
+		got = append(got, event.Type)
+		return nil
+	}))
+
+	fail := true
+	var retried []entities.EventType
+	relay.Subscribe("flaky", HandlerFunc(func(ctx context.Context, event entities.Event) error {
+		if event.Type == entities.EventTypeCandidateCreated && fail {
+			fail = false
+			return errors.New("unavailable")
+		}
+		retried = append(retried, event.Type)
+		return nil
+	}))
+
+	relay.Poll(ctx)
+	require.Equal(t, want, got)
+	require.Equal(t, want[:3], retried)
+
+	relay.Poll(ctx)
+	require.Equal(t, want, got, "events must be delivered once to healthy consumer")
+	require.Equal(t, want, retried, "failed event must be redelivered")
+}
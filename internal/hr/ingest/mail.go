@@ -0,0 +1,316 @@
+// Package ingest takes applications sent by email into the system. A message
+// becomes a candidate with the attachments, matched to a vacancy by the
+// subject and put into the inbox stage until a recruiter looks at it.
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/internal/hr/resumes"
+	"gpb.ru/hr/pkg/mailbox"
+	"gpb.ru/hr/pkg/textract"
+)
+
+// Source marks cards, comments and attachments of emailed applications.
+const Source = "email"
+
+// Result tells what the message was saved as.
+type Result struct {
+	MessageID   string
+	CandidateID uuid.UUID
+	// CardID and VacancyID are nil if the subject matched no vacancy.
+	CardID    uuid.UUID
+	VacancyID uuid.UUID
+	// Existing is true if the sender matched a known candidate.
+	Existing bool
+	// Duplicate is true if the message was taken in before, nothing is
+	// saved then.
+	Duplicate   bool
+	Attachments int
+}
+
+// Mail takes in emailed applications.
+type Mail struct {
+	vacancy    repos.VacancyRepo
+	candidate  repos.CandidateRepo
+	card       repos.CardRepo
+	attachment repos.AttachmentRepo
+	message    repos.MessageRepo
+	skill      repos.SkillRepo
+	dictionary repos.DictionaryRepo
+	blobs      repos.BlobStore
+
+	now func() time.Time
+}
+
+// NewMail creates ingestion keeping attachment contents in the blob store.
+func NewMail(r repos.Repos, blobs repos.BlobStore) *Mail {
+	return &Mail{
+		vacancy:    r.Vacancy,
+		candidate:  r.Candidate,
+		card:       r.Card,
+		attachment: r.Attachment,
+		message:    r.Message,
+		skill:      r.Skill,
+		dictionary: r.Dictionary,
+		blobs:      blobs,
+		now:        time.Now,
+	}
+}
+
+// Ingest saves the message. The sender becomes a new candidate or is added
+// to the known one with the same contacts, the first attachment looking
+// like a resume fills the blanks. If the subject names an active vacancy
+// the candidate gets a card there in the inbox stage, an existing card
+// keeps its stage. A message with a known Message-ID is skipped.
+func (m *Mail) Ingest(ctx context.Context, msg *mailbox.Message) (*Result, error) {
+	result := &Result{MessageID: msg.ID}
+	seen, err := m.message.GetByMessageID(ctx, msg.ID)
+	if err == nil {
+		result.Duplicate = true
+		result.CandidateID = seen.CandidateID
+		result.CardID = seen.CardID
+		return result, nil
+	}
+	if !errors.Is(err, repos.ErrMessageNotFound) {
+		return nil, err
+	}
+
+	vacancies, err := m.vacancy.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vacancy := matchVacancy(msg.Subject, vacancies)
+
+	catalog, err := m.skill.List(ctx, repos.SkillFilter{})
+	if err != nil {
+		return nil, err
+	}
+	skills := entities.NewSkillIndex(catalog)
+	items, err := m.dictionary.List(ctx, repos.DictionaryFilter{})
+	if err != nil {
+		return nil, err
+	}
+	dicts := entities.NewDictionaries(items)
+
+	applicant := applicantOf(msg, skills.Dictionary(vacancies))
+	applicant.NormalizeSkills(skills)
+	applicant.FitDictionaries(dicts)
+	err = applicant.Normalize()
+	if err != nil {
+		// A malformed phone guessed from the resume is not worth losing
+		// the application.
+		applicant.Phone = ""
+		err = applicant.Normalize()
+	}
+	if err == nil {
+		err = applicant.Validate()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	candidate, err := m.findCandidate(ctx, &applicant)
+	if err != nil {
+		return nil, err
+	}
+	if candidate != nil {
+		result.Existing = true
+		candidate.Merge(&applicant)
+		candidate.NormalizeSkills(skills)
+		candidate.FitDictionaries(dicts)
+		err = m.candidate.Update(ctx, candidate)
+	} else {
+		candidate = &applicant
+		err = m.candidate.Create(ctx, candidate)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.CandidateID = candidate.ID
+
+	if vacancy != nil {
+		result.VacancyID = vacancy.ID
+		card, err := m.findCard(ctx, candidate.ID, vacancy.ID)
+		if err != nil {
+			return nil, err
+		}
+		if card == nil {
+			card = &entities.Card{
+				VacancyID:   vacancy.ID,
+				CandidateID: candidate.ID,
+				Stage:       entities.CardStageInbox,
+				Source:      Source,
+			}
+			err = m.card.Create(ctx, card)
+			if err != nil {
+				return nil, err
+			}
+		}
+		result.CardID = card.ID
+
+		if msg.Text != "" {
+			err = m.card.AddComment(ctx, card.ID, &entities.Comment{Author: Source, Text: msg.Text})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, file := range msg.Attachments {
+		err = m.attach(ctx, &file, candidate.ID, result.CardID)
+		if err != nil {
+			return nil, err
+		}
+		result.Attachments++
+	}
+
+	// The message goes last, so a failed one is taken in again next time.
+	to := make([]string, len(msg.To))
+	for i, address := range msg.To {
+		to[i] = address.Address
+	}
+	sent := msg.Date
+	if sent.IsZero() {
+		sent = m.now()
+	}
+	err = m.message.Create(ctx, &entities.Message{
+		MessageID:   msg.ID,
+		Direction:   entities.MessageDirectionInbound,
+		Status:      entities.MessageStatusReceived,
+		CandidateID: candidate.ID,
+		CardID:      result.CardID,
+		From:        msg.From.Address,
+		To:          strings.Join(to, ", "),
+		Subject:     msg.Subject,
+		Text:        msg.Text,
+		Sent:        sent,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applicantOf makes the candidate of the sender. Name and email of the
+// sender win over the ones guessed from the resume.
+func applicantOf(msg *mailbox.Message, skills []string) entities.Candidate {
+	var applicant entities.Candidate
+	for _, file := range msg.Attachments {
+		text, _, err := textract.Extract(file.Data)
+		if err == nil && strings.TrimSpace(text) != "" {
+			applicant = resumes.FromText(text, skills)
+			break
+		}
+	}
+
+	applicant.Email = msg.From.Address
+	if name := strings.TrimSpace(msg.From.Name); name != "" {
+		applicant.Name = name
+	}
+	if applicant.Name == "" {
+		applicant.Name = msg.From.Address
+	}
+	return applicant
+}
+
+// attach stores the file unless a blob with the same hash is already there.
+func (m *Mail) attach(ctx context.Context, file *mailbox.Attachment, candidateID, cardID uuid.UUID) error {
+	hash := sha256.Sum256(file.Data)
+	attachment := entities.Attachment{
+		CandidateID: candidateID,
+		CardID:      cardID,
+		Filename:    file.Filename,
+		ContentType: contentType(file),
+		Size:        int64(len(file.Data)),
+		SHA256:      hex.EncodeToString(hash[:]),
+		Uploader:    Source,
+	}
+	err := attachment.Validate()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, file.Filename)
+	}
+
+	key := attachment.BlobKey()
+	exists, err := m.blobs.Exists(ctx, key)
+	if err == nil && !exists {
+		err = m.blobs.Put(ctx, key, bytes.NewReader(file.Data), attachment.Size)
+	}
+	if err != nil {
+		return err
+	}
+	return m.attachment.Create(ctx, &attachment)
+}
+
+// contentType detects the type by the contents like uploads do, the one
+// the mail client claimed is kept only if the contents tell nothing.
+func contentType(file *mailbox.Attachment) string {
+	if detected := textract.Detect(file.Data); detected != "" {
+		return detected
+	}
+	detected := http.DetectContentType(file.Data)
+	if detected == "application/octet-stream" && file.ContentType != "" {
+		return file.ContentType
+	}
+	return detected
+}
+
+// matchVacancy returns the active vacancy the subject names, the longest
+// title wins when several match. It returns nil if there is none.
+func matchVacancy(subject string, vacancies []entities.Vacancy) *entities.Vacancy {
+	subject = strings.ToLower(strings.Join(strings.Fields(subject), " "))
+	var found *entities.Vacancy
+	for i := range vacancies {
+		v := &vacancies[i]
+		title := strings.ToLower(strings.Join(strings.Fields(v.Title), " "))
+		if v.Status != entities.VacancyStatusActive || title == "" || !strings.Contains(subject, title) {
+			continue
+		}
+		if found == nil || len(v.Title) > len(found.Title) {
+			found = v
+		}
+	}
+	return found
+}
+
+// findCandidate returns the candidate with the contacts, nil if there is
+// none. Anonymized candidates are not reused.
+func (m *Mail) findCandidate(ctx context.Context, applicant *entities.Candidate) (*entities.Candidate, error) {
+	found, err := m.candidate.FindByContacts(ctx, applicant.Phone, applicant.Email)
+	if err != nil {
+		return nil, err
+	}
+	for i := range found {
+		if found[i].Anonymized == nil {
+			return &found[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// findCard returns the card of the candidate on the vacancy, nil if there
+// is none.
+func (m *Mail) findCard(ctx context.Context, candidateID, vacancyID uuid.UUID) (*entities.Card, error) {
+	cards, err := m.card.ListByCandidate(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cards {
+		if cards[i].VacancyID == vacancyID {
+			return &cards[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,117 @@
+package ingest
+
+import (
+	"context"
+	"net/mail"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos/memory"
+	"gpb.ru/hr/pkg/blob"
+	"gpb.ru/hr/pkg/mailbox"
+)
+
+func TestMail_Ingest(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.New()
+	blobs, err := blob.NewFS(t.TempDir())
+	require.NoError(t, err)
+	m := NewMail(mem.Repos(), blobs)
+
+	golang := entities.Vacancy{
+		Title:  "Go developer",
+		Status: entities.VacancyStatusActive,
+		Skills: []entities.Skill{{Title: "PostgreSQL"}},
+	}
+	require.NoError(t, mem.Vacancy.Create(ctx, &golang))
+	senior := entities.Vacancy{Title: "Senior Go developer", Status: entities.VacancyStatusActive}
+	require.NoError(t, mem.Vacancy.Create(ctx, &senior))
+	closed := entities.Vacancy{Title: "Senior Go developer (old)", Status: entities.VacancyStatusInactive}
+	require.NoError(t, mem.Vacancy.Create(ctx, &closed))
+
+	msg := &mailbox.Message{
+		ID:      "<1@example.com>",
+		From:    mail.Address{Name: "Иван Иванов", Address: "Ivan@Example.com"},
+		To:      []mail.Address{{Address: "hr@example.com"}},
+		Subject: "Отклик: senior  Go developer (old)",
+		Text:    "Здравствуйте!",
+		Attachments: []mailbox.Attachment{{
+			Filename:    "cv.txt",
+			ContentType: "application/octet-stream",
+			Data:        []byte("Пётр Петров\n+7 999 123-45-67\nGo, PostgreSQL"),
+		}},
+	}
+	result, err := m.Ingest(ctx, msg)
+	require.NoError(t, err)
+	require.False(t, result.Existing)
+	require.False(t, result.Duplicate)
+	require.Equal(t, senior.ID, result.VacancyID, "the longest active title wins")
+	require.Equal(t, 1, result.Attachments)
+
+	candidate, err := mem.Candidate.GetByID(ctx, result.CandidateID)
+	require.NoError(t, err)
+	require.Equal(t, "Иван Иванов", candidate.Name, "the sender name wins over the resume")
+	require.Equal(t, "ivan@example.com", candidate.Email)
+	require.Equal(t, "+79991234567", candidate.Phone)
+	require.Equal(t, []string{"PostgreSQL"}, candidate.Skills)
+
+	card, err := mem.Card.GetByID(ctx, result.CardID)
+	require.NoError(t, err)
+	require.Equal(t, entities.CardStageInbox, card.Stage)
+	require.Equal(t, Source, card.Source)
+	require.Equal(t, "Здравствуйте!", card.Comments[0].Text)
+
+	attachments, err := mem.Attachment.List(ctx, candidate.ID, uuid.Nil)
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	require.Equal(t, card.ID, attachments[0].CardID)
+	require.Equal(t, "text/plain; charset=utf-8", attachments[0].ContentType)
+	exists, err := blobs.Exists(ctx, attachments[0].BlobKey())
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	messages, err := mem.Message.List(ctx, candidate.ID)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, entities.MessageDirectionInbound, messages[0].Direction)
+	require.Equal(t, "hr@example.com", messages[0].To)
+
+	again, err := m.Ingest(ctx, msg)
+	require.NoError(t, err)
+	require.True(t, again.Duplicate)
+	require.Equal(t, result.CardID, again.CardID)
+	attachments, err = mem.Attachment.List(ctx, candidate.ID, uuid.Nil)
+	require.NoError(t, err)
+	require.Len(t, attachments, 1, "duplicates are not saved")
+
+	_, err = mem.Card.Move(ctx, card.ID, entities.CardStageScreening)
+	require.NoError(t, err)
+	followUp := &mailbox.Message{
+		ID:      "<2@example.com>",
+		From:    mail.Address{Address: "ivan@example.com"},
+		Subject: "Re: Senior Go developer",
+	}
+	result2, err := m.Ingest(ctx, followUp)
+	require.NoError(t, err)
+	require.True(t, result2.Existing)
+	require.Equal(t, result.CandidateID, result2.CandidateID)
+	require.Equal(t, result.CardID, result2.CardID)
+	card, err = mem.Card.GetByID(ctx, result.CardID)
+	require.NoError(t, err)
+	require.Equal(t, entities.CardStageScreening, card.Stage, "existing cards keep the stage")
+
+	unmatched := &mailbox.Message{
+		ID:      "<3@example.com>",
+		From:    mail.Address{Address: "petr@example.com"},
+		Subject: "Резюме",
+	}
+	result3, err := m.Ingest(ctx, unmatched)
+	require.NoError(t, err)
+	require.Equal(t, uuid.Nil, result3.CardID)
+	candidate, err = mem.Candidate.GetByID(ctx, result3.CandidateID)
+	require.NoError(t, err)
+	require.Equal(t, "petr@example.com", candidate.Name)
+}
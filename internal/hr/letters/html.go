@@ -0,0 +1,30 @@
+package letters
+
+import (
+	"html/template"
+	"io"
+	"strings"
+)
+
+var page = template.Must(template.New("page").Funcs(template.FuncMap{
+	"lines": func(s string) []string { return strings.Split(s, "\n") },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 40em; margin: 3em auto; line-height: 1.5; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Paragraphs}}<p>{{range $i, $line := lines .}}{{if $i}}<br>{{end}}{{$line}}{{end}}</p>
+{{end}}</body>
+</html>
+`))
+
+// WriteHTML writes the document as an HTML page.
+func WriteHTML(w io.Writer, doc Document) error {
+	return page.Execute(w, doc)
+}
@@ -0,0 +1,101 @@
+// Package letters renders offer letters from a text template to HTML and
+// PDF documents without external tools.
+//
+// The template is a text/template. Its output is split into paragraphs on
+// blank lines, the first paragraph being the title of the document.
+package letters
+
+import (
+	_ "embed" // embeds default letter template
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+//go:embed letter.tmpl
+var DefaultTemplate string
+
+// Letter is the data the template is executed with.
+type Letter struct {
+	Offer     entities.Offer
+	Candidate entities.Candidate
+	Vacancy   entities.Vacancy
+}
+
+// Document is a rendered letter.
+type Document struct {
+	Title      string
+	Paragraphs []string
+}
+
+// Template renders letters.
+type Template struct {
+	tmpl *template.Template
+}
+
+var funcs = template.FuncMap{
+	"date":  formatDate,
+	"money": formatMoney,
+}
+
+// Parse parses the letter template.
+func Parse(text string) (*Template, error) {
+	tmpl, err := template.New("letter").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+// Must panics if err is not nil, it is meant for templates known to be
+// valid like DefaultTemplate.
+func Must(t *Template, err error) *Template {
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Render executes the template for the letter.
+func (t *Template) Render(letter Letter) (Document, error) {
+	var b strings.Builder
+	err := t.tmpl.Execute(&b, letter)
+	if err != nil {
+		return Document{}, err
+	}
+
+	text := strings.ReplaceAll(b.String(), "\r\n", "\n")
+	var doc Document
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		if doc.Title == "" {
+			doc.Title = paragraph
+			continue
+		}
+		doc.Paragraphs = append(doc.Paragraphs, paragraph)
+	}
+	return doc, nil
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("02.01.2006")
+}
+
+// formatMoney groups digits by thousands with non-breaking spaces.
+func formatMoney(v uint32) string {
+	digits := strconv.FormatUint(uint64(v), 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteRune('\u00a0')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
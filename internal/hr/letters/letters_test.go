@@ -0,0 +1,84 @@
+package letters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+func testLetter() Letter {
+	return Letter{
+		Offer: entities.Offer{
+			Position:  "Go разработчик",
+			Salary:    250000,
+			StartDate: time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+			Expires:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		},
+		Candidate: entities.Candidate{Name: "Иван <Петров>"},
+		Vacancy:   entities.Vacancy{Department: "Платформа"},
+	}
+}
+
+func TestTemplate_Render(t *testing.T) {
+	tmpl, err := Parse(DefaultTemplate)
+	require.NoError(t, err)
+
+	doc, err := tmpl.Render(testLetter())
+	require.NoError(t, err)
+	require.Exactly(t, "Предложение о работе", doc.Title)
+	require.Len(t, doc.Paragraphs, 5)
+	require.Exactly(t, "Уважаемый(ая) Иван <Петров>!", doc.Paragraphs[0])
+	require.Contains(t, doc.Paragraphs[1], "«Go разработчик» в подразделении «Платформа».")
+	require.Contains(t, doc.Paragraphs[2], "250\u00a0000 руб.")
+	require.Contains(t, doc.Paragraphs[2], "02.11.2026")
+	require.Contains(t, doc.Paragraphs[3], "20.10.2026")
+}
+
+func TestFormatMoney(t *testing.T) {
+	test := func(v uint32, want string) func(*testing.T) {
+		return func(t *testing.T) {
+			require.Exactly(t, want, formatMoney(v))
+		}
+	}
+
+	tests := []struct {
+		name string
+		v    uint32
+		want string
+	}{
+		{name: "zero", v: 0, want: "0"},
+		{name: "hundreds", v: 999, want: "999"},
+		{name: "thousands", v: 1000, want: "1\u00a0000"},
+		{name: "millions", v: 1250000, want: "1\u00a0250\u00a0000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.v, tt.want))
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var b bytes.Buffer
+	err := WriteHTML(&b, Document{Title: "Offer", Paragraphs: []string{"<b>Dear</b>\nsecond"}})
+	require.NoError(t, err)
+	require.Contains(t, b.String(), "<h1>Offer</h1>")
+	require.Contains(t, b.String(), "<p>&lt;b&gt;Dear&lt;/b&gt;<br>second</p>")
+}
+
+func TestWritePDF(t *testing.T) {
+	tmpl, err := Parse(DefaultTemplate)
+	require.NoError(t, err)
+	doc, err := tmpl.Render(testLetter())
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	err = WritePDF(&b, doc)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(b.String(), "%PDF-"))
+	require.True(t, strings.HasSuffix(strings.TrimSpace(b.String()), "%%EOF"))
+}
@@ -0,0 +1,34 @@
+package letters
+
+import (
+	"io"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// Go fonts cover Latin and Cyrillic, so letters need no system fonts.
+const font = "go"
+
+// WritePDF writes the document as an A4 PDF.
+func WritePDF(w io.Writer, doc Document) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(font, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(font, "B", gobold.TTF)
+	pdf.SetMargins(25, 25, 25)
+	pdf.SetTitle(doc.Title, true)
+	pdf.AddPage()
+
+	pdf.SetFont(font, "B", 16)
+	pdf.MultiCell(0, 8, doc.Title, "", "C", false)
+	pdf.Ln(6)
+
+	pdf.SetFont(font, "", 12)
+	for _, paragraph := range doc.Paragraphs {
+		pdf.MultiCell(0, 6, paragraph, "", "L", false)
+		pdf.Ln(4)
+	}
+
+	return pdf.Output(w)
+}
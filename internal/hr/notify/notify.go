@@ -0,0 +1,327 @@
+// Package notify emails candidates and interviewers about what happens to
+// applications.
+//
+// The Notifier renders localized templates into outbound messages of the
+// candidate communication log, which doubles as a persistent queue. The
+// Sender picks pending messages up, sends them over SMTP and retries
+// failures with exponential backoff until the message is sent or goes dead.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/calendar"
+	"gpb.ru/hr/internal/hr/careers"
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/ingest"
+	"gpb.ru/hr/internal/hr/letters"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+// Notifier queues notifications. Every notification has a Message-ID made of
+// what it is about, so notifying twice about the same thing is harmless.
+type Notifier struct {
+	candidate repos.CandidateRepo
+	vacancy   repos.VacancyRepo
+	card      repos.CardRepo
+	interview repos.InterviewRepo
+	message   repos.MessageRepo
+	templates *Templates
+
+	// From is the sender address.
+	From    string
+	Company string
+	// Locale is used for interviewers and for candidates whose languages
+	// tell nothing.
+	Locale string
+	// Sources are the card sources acknowledged on application, cards added
+	// by recruiters are not.
+	Sources []string
+	// Reminder is how long before an interview its interviewers are
+	// reminded of it, no reminders if zero.
+	Reminder time.Duration
+	// Interval is a pause between checks of upcoming interviews.
+	Interval time.Duration
+
+	now func() time.Time
+}
+
+// New creates notifier sending from the given address an hour reminders
+// ahead.
+func New(r repos.Repos, templates *Templates, from string) *Notifier {
+	return &Notifier{
+		candidate: r.Candidate,
+		vacancy:   r.Vacancy,
+		card:      r.Card,
+		interview: r.Interview,
+		message:   r.Message,
+		templates: templates,
+		From:      from,
+		Locale:    LocaleRU,
+		Sources:   []string{careers.Source, ingest.Source},
+		Reminder:  time.Hour,
+		Interval:  time.Minute,
+		now:       time.Now,
+	}
+}
+
+// Handle implements events.Handler. Candidates applying themselves are
+// acknowledged, rejected ones are told so.
+func (n *Notifier) Handle(ctx context.Context, event entities.Event) error {
+	switch event.Type {
+	case entities.EventTypeCardCreated:
+		var payload entities.CardCreated
+		err := json.Unmarshal(event.Payload, &payload)
+		if err != nil {
+			return err
+		}
+		app, err := n.application(ctx, payload.CardID)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(n.Sources, app.card.Source) {
+			return nil
+		}
+		return n.toCandidate(ctx, "card-"+payload.CardID.String(), TemplateApplicationReceived, app, Data{}, nil)
+
+	case entities.EventTypeCardMoved:
+		var payload entities.CardMoved
+		err := json.Unmarshal(event.Payload, &payload)
+		if err != nil {
+			return err
+		}
+		if payload.To != entities.CardStageRejected {
+			return nil
+		}
+		app, err := n.application(ctx, payload.CardID)
+		if err != nil {
+			return err
+		}
+		return n.toCandidate(ctx, "event-"+event.ID.String(), TemplateRejection, app, Data{}, nil)
+	}
+	return nil
+}
+
+// InterviewScheduled invites the candidate and the interviewers to the
+// interview, the calendar event goes attached. Every revision of the
+// interview is sent once.
+func (n *Notifier) InterviewScheduled(ctx context.Context, interview *entities.Interview, event calendar.Event) error {
+	app, err := n.application(ctx, interview.CardID)
+	if err != nil {
+		return err
+	}
+
+	var invite bytes.Buffer
+	err = calendar.Write(&invite, calendar.MethodRequest, event)
+	if err != nil {
+		return err
+	}
+	files := []entities.MessageFile{{
+		Filename:    "invite.ics",
+		ContentType: "text/calendar; charset=utf-8; method=" + calendar.MethodRequest,
+		Data:        invite.Bytes(),
+	}}
+
+	key := "interview-" + interview.ID.String() + "-" + strconv.Itoa(interview.Sequence)
+	data := Data{Interview: interview}
+	err = n.toCandidate(ctx, key, TemplateInterviewScheduled, app, data, files)
+	if err != nil {
+		return err
+	}
+	return n.toInterviewers(ctx, key, TemplateInterviewScheduled, app, data, files)
+}
+
+// OfferApproved sends the approved offer to the candidate with the letter
+// attached as PDF.
+func (n *Notifier) OfferApproved(ctx context.Context, offer *entities.Offer, letter letters.Document) error {
+	app, err := n.application(ctx, offer.CardID)
+	if err != nil {
+		return err
+	}
+
+	var pdf bytes.Buffer
+	err = letters.WritePDF(&pdf, letter)
+	if err != nil {
+		return err
+	}
+	files := []entities.MessageFile{{Filename: "offer.pdf", ContentType: "application/pdf", Data: pdf.Bytes()}}
+	return n.toCandidate(ctx, "offer-"+offer.ID.String(), TemplateOffer, app, Data{Offer: offer}, files)
+}
+
+// Run reminds of interviews until the context is done.
+func (n *Notifier) Run(ctx context.Context) error {
+	ticker := time.NewTicker(n.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := n.Remind(ctx)
+		if err != nil {
+			log.Printf("[error] [notify] %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Remind reminds interviewers of scheduled interviews starting within the
+// Reminder duration.
+func (n *Notifier) Remind(ctx context.Context) error {
+	if n.Reminder <= 0 {
+		return nil
+	}
+	now := n.now()
+	interviews, err := n.interview.List(ctx, repos.InterviewFilter{From: now, To: now.Add(n.Reminder)})
+	if err != nil {
+		return err
+	}
+	for i := range interviews {
+		interview := &interviews[i]
+		if interview.Status != entities.InterviewStatusScheduled || interview.Start.Before(now) {
+			continue
+		}
+		app, err := n.application(ctx, interview.CardID)
+		if err != nil {
+			return err
+		}
+		key := "reminder-" + interview.ID.String() + "-" + strconv.Itoa(interview.Sequence)
+		err = n.toInterviewers(ctx, key, TemplateInterviewReminder, app, Data{Interview: interview}, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// application is what a notification is about.
+type application struct {
+	card      *entities.Card
+	candidate *entities.Candidate
+	vacancy   *entities.Vacancy
+}
+
+func (n *Notifier) application(ctx context.Context, cardID uuid.UUID) (*application, error) {
+	card, err := n.card.GetByID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := n.candidate.GetByID(ctx, card.CandidateID)
+	if err != nil {
+		return nil, err
+	}
+	vacancy, err := n.vacancy.GetByID(ctx, card.VacancyID)
+	if err != nil {
+		return nil, err
+	}
+	return &application{card: card, candidate: candidate, vacancy: vacancy}, nil
+}
+
+// toCandidate queues the notification to the candidate unless there is no
+// address to send it to.
+func (n *Notifier) toCandidate(
+	ctx context.Context,
+	key, name string,
+	app *application,
+	data Data,
+	files []entities.MessageFile,
+) error {
+	if app.candidate.Anonymized != nil || app.candidate.Email == "" {
+		return nil
+	}
+	data.Recipient = app.candidate.Name
+	return n.enqueue(ctx, key+"-candidate", name, n.locale(app.candidate), app.candidate.Email, app, data, files)
+}
+
+// toInterviewers queues the notification to every interviewer.
+func (n *Notifier) toInterviewers(
+	ctx context.Context,
+	key, name string,
+	app *application,
+	data Data,
+	files []entities.MessageFile,
+) error {
+	data.Interviewer = true
+	for i, interviewer := range data.Interview.Interviewers {
+		data.Recipient = interviewer
+		err := n.enqueue(ctx, key+"-"+strconv.Itoa(i), name, n.Locale, interviewer, app, data, files)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Notifier) enqueue(
+	ctx context.Context,
+	key, name, locale, to string,
+	app *application,
+	data Data,
+	files []entities.MessageFile,
+) error {
+	data.Candidate = *app.candidate
+	data.Vacancy = *app.vacancy
+	data.Company = n.Company
+	subject, text, err := n.templates.Render(locale, name, data)
+	if err != nil {
+		return err
+	}
+
+	err = n.message.Create(ctx, &entities.Message{
+		MessageID:   n.messageID(key + "-" + name),
+		Direction:   entities.MessageDirectionOutbound,
+		CandidateID: app.candidate.ID,
+		CardID:      app.card.ID,
+		From:        n.From,
+		To:          to,
+		Subject:     subject,
+		Text:        text,
+		Template:    name,
+		Files:       files,
+		Status:      entities.MessageStatusPending,
+		NextAttempt: n.now(),
+	})
+	if errors.Is(err, repos.ErrMessageExists) {
+		return nil
+	}
+	return err
+}
+
+// messageID returns the Message-ID header on the domain of the sender.
+func (n *Notifier) messageID(key string) string {
+	domain := "hr"
+	if _, host, ok := strings.Cut(n.From, "@"); ok {
+		domain = strings.TrimSuffix(host, ">")
+	}
+	return "<" + key + "@" + domain + ">"
+}
+
+// locale guesses the locale of the candidate by the languages of the
+// resume: English speakers not knowing Russian get English messages.
+func (n *Notifier) locale(candidate *entities.Candidate) string {
+	russian, english := false, false
+	for _, language := range candidate.Languages {
+		switch strings.ToLower(strings.TrimSpace(language)) {
+		case "ru", "russian", "русский":
+			russian = true
+		case "en", "english", "английский":
+			english = true
+		}
+	}
+	if english && !russian && n.templates.Has(LocaleEN) {
+		return LocaleEN
+	}
+	return n.Locale
+}
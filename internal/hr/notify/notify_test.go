@@ -0,0 +1,217 @@
+package notify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/calendar"
+	"gpb.ru/hr/internal/hr/careers"
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/letters"
+	"gpb.ru/hr/internal/hr/repos/memory"
+)
+
+func TestTemplates_Render(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	templates := DefaultTemplates(moscow)
+	start := time.Date(2030, 3, 1, 7, 0, 0, 0, time.UTC)
+	data := Data{
+		Candidate: entities.Candidate{Name: "Иван Иванов"},
+		Vacancy:   entities.Vacancy{Title: "Go разработчик"},
+		Interview: &entities.Interview{
+			Interviewers: []string{"lead@example.com"},
+			Start:        start,
+			End:          start.Add(time.Hour),
+			Link:         "https://meet.example.com/abc",
+		},
+		Offer: &entities.Offer{
+			Position:  "Go разработчик",
+			Salary:    250000,
+			StartDate: time.Date(2030, 4, 1, 0, 0, 0, 0, moscow),
+			Expires:   time.Date(2030, 3, 15, 0, 0, 0, 0, moscow),
+		},
+		Recipient: "Иван Иванов",
+		Company:   "ГПБ",
+	}
+
+	names := []string{
+		TemplateApplicationReceived,
+		TemplateInterviewScheduled,
+		TemplateInterviewReminder,
+		TemplateRejection,
+		TemplateOffer,
+	}
+	for _, locale := range []string{LocaleRU, LocaleEN} {
+		for _, name := range names {
+			subject, text, err := templates.Render(locale, name, data)
+			require.NoError(t, err, "%s %s", locale, name)
+			require.NotEmpty(t, subject, "%s %s", locale, name)
+			require.NotContains(t, subject, "\n")
+			require.NotEmpty(t, text, "%s %s", locale, name)
+		}
+	}
+
+	subject, text, err := templates.Render(LocaleRU, TemplateInterviewScheduled, data)
+	require.NoError(t, err)
+	require.Equal(t, "Собеседование 01.03.2030 10:00: Go разработчик", subject)
+	require.Contains(t, text, "Ссылка: https://meet.example.com/abc")
+	require.NotContains(t, text, "Место:")
+
+	_, text, err = templates.Render(LocaleEN, TemplateOffer, data)
+	require.NoError(t, err)
+	require.Contains(t, text, "250 000 RUB")
+	require.Contains(t, text, "Apr 1, 2030")
+	require.Contains(t, text, "at ГПБ")
+
+	_, _, err = templates.Render("de", TemplateOffer, data)
+	require.ErrorIs(t, err, ErrUnknownLocale)
+}
+
+func TestLoadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "en.tmpl"),
+		[]byte(`{{define "rejection.subject"}}About {{.Vacancy.Title}}{{end}}`),
+		0o644,
+	))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "de.tmpl"),
+		[]byte(`{{define "offer.subject"}}Angebot bis {{date .Offer.Expires}}{{end}}{{define "offer.text"}}Hallo{{end}}`),
+		0o644,
+	))
+
+	templates, err := LoadTemplates(dir, time.UTC)
+	require.NoError(t, err)
+	data := Data{
+		Vacancy: entities.Vacancy{Title: "Go developer"},
+		Offer:   &entities.Offer{Expires: time.Date(2030, 3, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	subject, text, err := templates.Render(LocaleEN, TemplateRejection, data)
+	require.NoError(t, err)
+	require.Equal(t, "About Go developer", subject)
+	require.Contains(t, text, "Unfortunately", "texts not redefined stay built in")
+
+	require.True(t, templates.Has("de"))
+	subject, _, err = templates.Render("de", TemplateOffer, data)
+	require.NoError(t, err)
+	require.Equal(t, "Angebot bis 2030-03-15", subject)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ru.tmpl"), []byte(`{{define "x"}}{{.Nope}`), 0o644))
+	_, err = LoadTemplates(dir, time.UTC)
+	require.Error(t, err)
+}
+
+func TestNotifier(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.New()
+	n := New(mem.Repos(), DefaultTemplates(time.UTC), "HR <hr@example.com>")
+	now := time.Date(2030, 3, 1, 9, 30, 0, 0, time.UTC)
+	n.now = func() time.Time { return now }
+
+	vacancy := entities.Vacancy{Title: "Go developer", Status: entities.VacancyStatusActive}
+	require.NoError(t, mem.Vacancy.Create(ctx, &vacancy))
+	john := entities.Candidate{Name: "John Doe", Email: "john@example.com", Languages: []string{"English"}}
+	require.NoError(t, mem.Candidate.Create(ctx, &john))
+	ivan := entities.Candidate{Name: "Иван Иванов", Email: "ivan@example.com"}
+	require.NoError(t, mem.Candidate.Create(ctx, &ivan))
+
+	applied := entities.Card{VacancyID: vacancy.ID, CandidateID: john.ID, Stage: entities.CardStageNew, Source: careers.Source}
+	require.NoError(t, mem.Card.Create(ctx, &applied))
+	added := entities.Card{VacancyID: vacancy.ID, CandidateID: ivan.ID, Stage: entities.CardStageNew}
+	require.NoError(t, mem.Card.Create(ctx, &added))
+	_, err := mem.Card.Move(ctx, added.ID, entities.CardStageRejected)
+	require.NoError(t, err)
+
+	for _, event := range mem.Outbox.Events() {
+		require.NoError(t, n.Handle(ctx, event))
+		require.NoError(t, n.Handle(ctx, event), "notifications must be idempotent")
+	}
+
+	messages, err := mem.Message.List(ctx, john.ID)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, TemplateApplicationReceived, messages[0].Template)
+	require.Equal(t, "We received your application for Go developer", messages[0].Subject)
+	require.Equal(t, "john@example.com", messages[0].To)
+	require.Equal(t, "HR <hr@example.com>", messages[0].From)
+	require.Equal(t, entities.MessageStatusPending, messages[0].Status)
+	require.Equal(t, now, messages[0].NextAttempt)
+	require.Contains(t, messages[0].MessageID, "@example.com>")
+
+	messages, err = mem.Message.List(ctx, ivan.ID)
+	require.NoError(t, err)
+	require.Len(t, messages, 1, "cards added by recruiters are not acknowledged")
+	require.Equal(t, TemplateRejection, messages[0].Template)
+	require.Equal(t, "Ваш отклик на вакансию «Go developer»", messages[0].Subject)
+
+	interview := entities.Interview{
+		CardID:       applied.ID,
+		Interviewers: []string{"lead@example.com", "dev@example.com"},
+		Start:        now.Add(30 * time.Minute),
+		End:          now.Add(90 * time.Minute),
+		Format:       entities.InterviewFormatVideo,
+		Link:         "https://meet.example.com/abc",
+		Status:       entities.InterviewStatusScheduled,
+	}
+	require.NoError(t, mem.Interview.Create(ctx, &interview))
+	event := calendar.Event{UID: interview.ID.String(), Start: interview.Start, End: interview.End}
+	require.NoError(t, n.InterviewScheduled(ctx, &interview, event))
+	require.NoError(t, n.InterviewScheduled(ctx, &interview, event))
+
+	messages, err = mem.Message.List(ctx, john.ID)
+	require.NoError(t, err)
+	require.Len(t, messages, 4, "the candidate and both interviewers are invited once")
+	for _, message := range messages[1:] {
+		require.Equal(t, TemplateInterviewScheduled, message.Template)
+		require.Equal(t, "invite.ics", message.Files[0].Filename)
+		require.Contains(t, string(message.Files[0].Data), "METHOD:REQUEST")
+	}
+
+	require.NoError(t, n.Remind(ctx))
+	require.NoError(t, n.Remind(ctx))
+	messages, err = mem.Message.List(ctx, john.ID)
+	require.NoError(t, err)
+	require.Len(t, messages, 6, "interviewers are reminded once")
+	require.Equal(t, TemplateInterviewReminder, messages[5].Template)
+	require.Equal(t, "Напоминание: собеседование 01.03.2030 10:00", messages[5].Subject, "interviewers get the default locale")
+
+	offer := entities.Offer{
+		CardID:    applied.ID,
+		Position:  "Go developer",
+		Salary:    250000,
+		StartDate: now.AddDate(0, 1, 0),
+		Expires:   now.AddDate(0, 0, 14),
+	}
+	require.NoError(t, mem.Offer.Create(ctx, &offer))
+	letter, err := letters.Must(letters.Parse(letters.DefaultTemplate)).Render(letters.Letter{
+		Offer:     offer,
+		Candidate: john,
+		Vacancy:   vacancy,
+	})
+	require.NoError(t, err)
+	require.NoError(t, n.OfferApproved(ctx, &offer, letter))
+
+	messages, err = mem.Message.List(ctx, john.ID)
+	require.NoError(t, err)
+	require.Len(t, messages, 7)
+	require.Equal(t, TemplateOffer, messages[6].Template)
+	require.Equal(t, "offer.pdf", messages[6].Files[0].Filename)
+	require.Contains(t, messages[6].Text, "250 000")
+
+	anonymized := john
+	anonymized.Anonymize(now)
+	require.NoError(t, mem.Candidate.Update(ctx, &anonymized))
+	_, err = mem.Card.Move(ctx, applied.ID, entities.CardStageRejected)
+	require.NoError(t, err)
+	events := mem.Outbox.Events()
+	require.NoError(t, n.Handle(ctx, events[len(events)-1]))
+	messages, err = mem.Message.List(ctx, john.ID)
+	require.NoError(t, err)
+	require.Len(t, messages, 7, "anonymized candidates get nothing")
+}
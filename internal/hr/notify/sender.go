@@ -0,0 +1,240 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"log"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/pkg/mailbox"
+)
+
+// SMTP is the server messages are sent through. STARTTLS is used whenever
+// the server offers it.
+type SMTP struct {
+	// Addr is host:port of the server.
+	Addr string
+	// Username and Password authenticate with PLAIN, no authentication if
+	// Username is empty.
+	Username string
+	Password string
+}
+
+// Sender sends pending messages over SMTP.
+type Sender struct {
+	message repos.MessageRepo
+	config  SMTP
+
+	// Interval is a pause between polls of due messages.
+	Interval time.Duration
+	// Backoff is a delay before the first retry, doubled on every next one
+	// up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// MaxAttempts is a number of attempts after which the message is
+	// considered dead.
+	MaxAttempts int
+	// Lease is how long a claimed message stays hidden from other senders.
+	Lease time.Duration
+	Batch int
+	// Timeout limits a single attempt.
+	Timeout time.Duration
+
+	now func() time.Time
+}
+
+// NewSender creates sender with default retry policy.
+func NewSender(message repos.MessageRepo, config SMTP) *Sender {
+	return &Sender{
+		message:     message,
+		config:      config,
+		Interval:    time.Second,
+		Backoff:     time.Minute,
+		MaxBackoff:  6 * time.Hour,
+		MaxAttempts: 10,
+		Lease:       time.Minute,
+		Batch:       50,
+		Timeout:     30 * time.Second,
+		now:         time.Now,
+	}
+}
+
+// Run sends messages until the context is done.
+func (s *Sender) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.Send(ctx)
+		if err != nil {
+			log.Printf("[error] [notify] %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Send makes one attempt for every due message.
+func (s *Sender) Send(ctx context.Context) error {
+	messages, err := s.message.Claim(ctx, s.now(), s.Lease, s.Batch)
+	if err != nil {
+		return err
+	}
+
+	for i := range messages {
+		message := &messages[i]
+		s.attempt(ctx, message)
+
+		err = s.message.Update(ctx, message)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Sender) attempt(ctx context.Context, message *entities.Message) {
+	message.Attempts++
+
+	sent := s.now()
+	err := s.send(ctx, message, sent)
+	if err == nil {
+		message.Status = entities.MessageStatusSent
+		message.Sent = sent
+		message.LastError = ""
+		// Attachments are rendered again on demand, the log keeps their
+		// names only.
+		for i := range message.Files {
+			message.Files[i].Data = nil
+		}
+		return
+	}
+
+	message.LastError = err.Error()
+	if message.Attempts >= s.MaxAttempts {
+		message.Status = entities.MessageStatusDead
+		log.Printf(
+			"[error] [notify] message %s is dead after %d attempts: %s",
+			message.MessageID,
+			message.Attempts,
+			err,
+		)
+		return
+	}
+	message.NextAttempt = s.now().Add(s.backoff(message.Attempts))
+}
+
+// backoff returns delay after the given number of failed attempts.
+func (s *Sender) backoff(attempts int) time.Duration {
+	delay := s.Backoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= s.MaxBackoff {
+			return s.MaxBackoff
+		}
+	}
+	return delay
+}
+
+func (s *Sender) send(ctx context.Context, message *entities.Message, date time.Time) error {
+	from, err := mail.ParseAddress(message.From)
+	if err != nil {
+		return err
+	}
+	to, err := mail.ParseAddressList(message.To)
+	if err != nil {
+		return err
+	}
+
+	msg := &mailbox.Message{
+		ID:      message.MessageID,
+		From:    *from,
+		Subject: message.Subject,
+		Date:    date,
+		Text:    message.Text,
+	}
+	for _, address := range to {
+		msg.To = append(msg.To, *address)
+	}
+	for _, file := range message.Files {
+		msg.Attachments = append(msg.Attachments, mailbox.Attachment{
+			Filename:    file.Filename,
+			ContentType: file.ContentType,
+			Data:        file.Data,
+		})
+	}
+	var data bytes.Buffer
+	err = mailbox.Write(&data, msg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.config.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	host, _, err := net.SplitHostPort(s.config.Addr)
+	if err != nil {
+		return err
+	}
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: host})
+		if err != nil {
+			return err
+		}
+	}
+	if s.config.Username != "" {
+		err = client.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, host))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(from.Address)
+	if err != nil {
+		return err
+	}
+	for _, address := range to {
+		err = client.Rcpt(address.Address)
+		if err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data.Bytes())
+	if err != nil {
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,138 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos/memory"
+	"gpb.ru/hr/pkg/mailbox"
+	"gpb.ru/hr/pkg/smtptest"
+)
+
+type senderFixture struct {
+	mem    *memory.Memory
+	server *smtptest.Server
+	sender *Sender
+	now    time.Time
+}
+
+func newSenderFixture(t *testing.T) *senderFixture {
+	server := smtptest.NewServer()
+	t.Cleanup(server.Close)
+
+	f := &senderFixture{mem: memory.New(), server: server, now: time.Now()}
+	f.sender = NewSender(f.mem.Message, SMTP{Addr: server.Addr})
+	f.sender.MaxAttempts = 3
+	f.sender.now = func() time.Time { return f.now }
+
+	require.NoError(t, f.mem.Message.Create(context.Background(), &entities.Message{
+		MessageID:   "<1@example.com>",
+		Direction:   entities.MessageDirectionOutbound,
+		CandidateID: uuid.New(),
+		From:        "HR <hr@example.com>",
+		To:          "john@example.com",
+		Subject:     "Собеседование",
+		Text:        "Приглашение во вложении.",
+		Template:    TemplateInterviewScheduled,
+		Files: []entities.MessageFile{{
+			Filename:    "invite.ics",
+			ContentType: "text/calendar; method=REQUEST",
+			Data:        []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"),
+		}},
+		Status:      entities.MessageStatusPending,
+		NextAttempt: f.now,
+	}))
+	return f
+}
+
+func (f *senderFixture) message(t *testing.T) *entities.Message {
+	message, err := f.mem.Message.GetByMessageID(context.Background(), "<1@example.com>")
+	require.NoError(t, err)
+	return message
+}
+
+func TestSender_Retry(t *testing.T) {
+	ctx := context.Background()
+	f := newSenderFixture(t)
+	f.server.Fail(1)
+
+	require.NoError(t, f.sender.Send(ctx))
+	message := f.message(t)
+	require.Equal(t, entities.MessageStatusPending, message.Status)
+	require.Equal(t, 1, message.Attempts)
+	require.Contains(t, message.LastError, "451")
+	require.Equal(t, f.now.Add(f.sender.Backoff), message.NextAttempt)
+
+	require.NoError(t, f.sender.Send(ctx))
+	require.Empty(t, f.server.Messages(), "message must wait for backoff")
+
+	f.now = f.now.Add(f.sender.Backoff)
+	require.NoError(t, f.sender.Send(ctx))
+	message = f.message(t)
+	require.Equal(t, entities.MessageStatusSent, message.Status)
+	require.Equal(t, 2, message.Attempts)
+	require.Empty(t, message.LastError)
+	require.Equal(t, f.now, message.Sent)
+	require.Equal(t, "invite.ics", message.Files[0].Filename)
+	require.Nil(t, message.Files[0].Data, "sent files are not kept")
+
+	received := f.server.Messages()
+	require.Len(t, received, 1)
+	require.Equal(t, "hr@example.com", received[0].From)
+	require.Equal(t, []string{"john@example.com"}, received[0].To)
+
+	msg, err := mailbox.Parse(received[0].Data)
+	require.NoError(t, err)
+	require.Equal(t, "<1@example.com>", msg.ID)
+	require.Equal(t, "Собеседование", msg.Subject)
+	require.Equal(t, "Приглашение во вложении.", msg.Text)
+	require.Equal(t, "invite.ics", msg.Attachments[0].Filename)
+	require.Equal(t, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", string(msg.Attachments[0].Data))
+
+	require.NoError(t, f.sender.Send(ctx))
+	require.Len(t, f.server.Messages(), 1, "sent messages are not sent again")
+}
+
+func TestSender_Dead(t *testing.T) {
+	ctx := context.Background()
+	f := newSenderFixture(t)
+	f.server.Fail(100)
+
+	for i := 0; i < f.sender.MaxAttempts; i++ {
+		require.NoError(t, f.sender.Send(ctx))
+		f.now = f.now.Add(f.sender.MaxBackoff)
+	}
+	message := f.message(t)
+	require.Equal(t, entities.MessageStatusDead, message.Status)
+	require.Equal(t, f.sender.MaxAttempts, message.Attempts)
+	require.NotEmpty(t, message.LastError)
+	require.NotNil(t, message.Files[0].Data)
+}
+
+func TestSender_Unreachable(t *testing.T) {
+	ctx := context.Background()
+	f := newSenderFixture(t)
+	f.server.Close()
+
+	require.NoError(t, f.sender.Send(ctx))
+	message := f.message(t)
+	require.Equal(t, entities.MessageStatusPending, message.Status)
+	require.NotEmpty(t, message.LastError)
+}
+
+func TestSender_Backoff(t *testing.T) {
+	s := NewSender(nil, SMTP{})
+	s.Backoff = time.Second
+	s.MaxBackoff = 5 * time.Second
+
+	require.Exactly(t, time.Second, s.backoff(1))
+	require.Exactly(t, 2*time.Second, s.backoff(2))
+	require.Exactly(t, 4*time.Second, s.backoff(3))
+	require.Exactly(t, 5*time.Second, s.backoff(4))
+	require.Exactly(t, 5*time.Second, s.backoff(40))
+}
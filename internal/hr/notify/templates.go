@@ -0,0 +1,170 @@
+package notify
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// Locales of the built in templates.
+const (
+	LocaleRU = "ru"
+	LocaleEN = "en"
+)
+
+// Notifications, each has a "<name>.subject" and a "<name>.text" template.
+const (
+	TemplateApplicationReceived = "applicationReceived"
+	TemplateInterviewScheduled  = "interviewScheduled"
+	TemplateInterviewReminder   = "interviewReminder"
+	TemplateRejection           = "rejection"
+	TemplateOffer               = "offer"
+)
+
+var ErrUnknownLocale = errors.New("unknown locale")
+
+//go:embed templates/*.tmpl
+var builtin embed.FS
+
+// Data is what templates are executed with. Interview and Offer are set for
+// the notifications about them only.
+type Data struct {
+	Candidate entities.Candidate
+	Vacancy   entities.Vacancy
+	Interview *entities.Interview
+	Offer     *entities.Offer
+	// Recipient is the name or the address the message is sent to.
+	Recipient string
+	// Interviewer is true if the message goes to an interviewer rather
+	// than the candidate.
+	Interviewer bool
+	Company     string
+}
+
+// Templates renders notifications in every locale.
+type Templates struct {
+	locales  map[string]*template.Template
+	location *time.Location
+}
+
+// DefaultTemplates returns the built in templates showing time in the
+// location.
+func DefaultTemplates(location *time.Location) *Templates {
+	t, err := LoadTemplates("", location)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// LoadTemplates parses the built in templates and the ones of the directory
+// on top of them. The directory has a <locale>.tmpl file per locale, e.g.
+// ru.tmpl, redefining some or all of the notifications or adding a locale.
+func LoadTemplates(dir string, location *time.Location) (*Templates, error) {
+	t := &Templates{locales: make(map[string]*template.Template), location: location}
+	builtins, err := builtin.ReadDir("templates")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range builtins {
+		data, err := builtin.ReadFile("templates/" + file.Name())
+		if err != nil {
+			return nil, err
+		}
+		err = t.parse(strings.TrimSuffix(file.Name(), ".tmpl"), string(data))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if dir == "" {
+		return t, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		err = t.parse(strings.TrimSuffix(filepath.Base(file), ".tmpl"), string(data))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func (t *Templates) parse(locale, text string) error {
+	tmpl, ok := t.locales[locale]
+	if !ok {
+		date, ok := dateLayouts[locale]
+		if !ok {
+			date = "2006-01-02"
+		}
+		tmpl = template.New(locale).Funcs(template.FuncMap{
+			"date":     func(v time.Time) string { return v.In(t.location).Format(date) },
+			"time":     func(v time.Time) string { return v.In(t.location).Format("15:04") },
+			"datetime": func(v time.Time) string { return v.In(t.location).Format(date + " 15:04") },
+			"money":    formatMoney,
+		})
+		t.locales[locale] = tmpl
+	}
+	_, err := tmpl.Parse(text)
+	if err != nil {
+		return fmt.Errorf("%w in %s templates", err, locale)
+	}
+	return nil
+}
+
+// dateLayouts are date formats of locales, ISO 8601 for others.
+var dateLayouts = map[string]string{
+	LocaleRU: "02.01.2006",
+	LocaleEN: "Jan 2, 2006",
+}
+
+// Has tells whether there are templates of the locale.
+func (t *Templates) Has(locale string) bool {
+	_, ok := t.locales[locale]
+	return ok
+}
+
+// Render returns subject and text of the notification.
+func (t *Templates) Render(locale, name string, data Data) (string, string, error) {
+	tmpl, ok := t.locales[locale]
+	if !ok {
+		return "", "", fmt.Errorf("%w: %s", ErrUnknownLocale, locale)
+	}
+	var subject, text strings.Builder
+	err := tmpl.ExecuteTemplate(&subject, name+".subject", data)
+	if err == nil {
+		err = tmpl.ExecuteTemplate(&text, name+".text", data)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return strings.Join(strings.Fields(subject.String()), " "), strings.TrimSpace(text.String()), nil
+}
+
+// formatMoney groups digits by thousands with spaces.
+func formatMoney(v uint32) string {
+	digits := strconv.FormatUint(uint64(v), 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
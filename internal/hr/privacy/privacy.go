@@ -0,0 +1,226 @@
+// Package privacy keeps personal data of candidates only as long as they
+// consent to its processing. It exports everything known about a person and
+// erases personal data on request or once consents expire.
+package privacy
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+// Export is everything stored about a candidate. Attachments are listed
+// without contents, those are downloaded separately.
+type Export struct {
+	Candidate   entities.Candidate    `json:"candidate"`
+	Consents    []entities.Consent    `json:"consents"`
+	Cards       []entities.Card       `json:"cards"`
+	Interviews  []entities.Interview  `json:"interviews"`
+	Scorecards  []entities.Scorecard  `json:"scorecards"`
+	Offers      []entities.Offer      `json:"offers"`
+	Resumes     []entities.Resume     `json:"resumes"`
+	Attachments []entities.Attachment `json:"attachments"`
+	Messages    []entities.Message    `json:"messages"`
+	Exported    time.Time             `json:"exported"`
+}
+
+// Controller exports and erases personal data of candidates.
+type Controller struct {
+	candidate  repos.CandidateRepo
+	card       repos.CardRepo
+	interview  repos.InterviewRepo
+	scorecard  repos.ScorecardRepo
+	offer      repos.OfferRepo
+	resume     repos.ResumeRepo
+	attachment repos.AttachmentRepo
+	consent    repos.ConsentRepo
+	message    repos.MessageRepo
+
+	// Blobs keeps attachment contents, they are left in place if nil.
+	Blobs repos.BlobStore
+	// Interval is a pause between checks of expired consents.
+	Interval time.Duration
+
+	now func() time.Time
+}
+
+// New creates controller checking consents hourly.
+func New(r repos.Repos, blobs repos.BlobStore) *Controller {
+	return &Controller{
+		candidate:  r.Candidate,
+		card:       r.Card,
+		interview:  r.Interview,
+		scorecard:  r.Scorecard,
+		offer:      r.Offer,
+		resume:     r.Resume,
+		attachment: r.Attachment,
+		consent:    r.Consent,
+		message:    r.Message,
+		Blobs:      blobs,
+		Interval:   time.Hour,
+		now:        time.Now,
+	}
+}
+
+// Export collects data of the candidate.
+func (c *Controller) Export(ctx context.Context, candidateID uuid.UUID) (*Export, error) {
+	candidate, err := c.candidate.GetByID(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	export := &Export{Candidate: *candidate, Exported: c.now()}
+
+	export.Consents, err = c.consent.List(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := c.card.ListByCandidate(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	for _, card := range cards {
+		// Listed cards come without comments.
+		full, err := c.card.GetByID(ctx, card.ID)
+		if err != nil {
+			return nil, err
+		}
+		export.Cards = append(export.Cards, *full)
+
+		interviews, err := c.interview.List(ctx, repos.InterviewFilter{CardID: card.ID})
+		if err != nil {
+			return nil, err
+		}
+		export.Interviews = append(export.Interviews, interviews...)
+
+		scorecards, err := c.scorecard.List(ctx, repos.ScorecardFilter{CardID: card.ID})
+		if err != nil {
+			return nil, err
+		}
+		export.Scorecards = append(export.Scorecards, scorecards...)
+
+		offers, err := c.offer.List(ctx, card.ID)
+		if err != nil {
+			return nil, err
+		}
+		export.Offers = append(export.Offers, offers...)
+	}
+	export.Resumes, err = c.resume.List(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	export.Attachments, err = c.attachment.List(ctx, candidateID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	export.Messages, err = c.message.List(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// Erase anonymizes the candidate, deletes its resumes and attachments and
+// clears its messages. Cards, interviews, scorecards and offers stay for
+// statistics, while the candidate repository redacts their events and
+// webhook deliveries along with the candidate. Erasing an anonymized
+// candidate changes nothing.
+func (c *Controller) Erase(ctx context.Context, candidateID uuid.UUID) (*entities.Candidate, error) {
+	candidate, err := c.candidate.GetByID(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate.Anonymized != nil {
+		return candidate, nil
+	}
+
+	resumes, err := c.resume.List(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	for _, resume := range resumes {
+		err = c.resume.Delete(ctx, resume.ID)
+		if err != nil && !errors.Is(err, repos.ErrResumeNotFound) {
+			return nil, err
+		}
+	}
+
+	attachments, err := c.attachment.List(ctx, candidateID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, attachment := range attachments {
+		err = c.deleteAttachment(ctx, &attachment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.message.Erase(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The candidate goes last, so a failed erase is retried as a whole.
+	candidate.Anonymize(c.now())
+	err = c.candidate.Anonymize(ctx, candidate)
+	if err != nil {
+		return nil, err
+	}
+	return candidate, nil
+}
+
+func (c *Controller) deleteAttachment(ctx context.Context, attachment *entities.Attachment) error {
+	err := c.attachment.Delete(ctx, attachment.ID)
+	if err != nil && !errors.Is(err, repos.ErrAttachmentNotFound) {
+		return err
+	}
+	if c.Blobs == nil {
+		return nil
+	}
+	count, err := c.attachment.CountBySHA256(ctx, attachment.SHA256)
+	if err != nil || count > 0 {
+		return err
+	}
+	return c.Blobs.Delete(ctx, attachment.BlobKey())
+}
+
+// Run erases candidates with expired consents until the context is done.
+func (c *Controller) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := c.Sweep(ctx)
+		if err != nil {
+			log.Printf("[error] [privacy] %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep erases every candidate having no active consent left.
+func (c *Controller) Sweep(ctx context.Context) error {
+	ids, err := c.consent.Expired(ctx, c.now())
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		_, err = c.Erase(ctx, id)
+		if err != nil {
+			return err
+		}
+		log.Printf("[info] [privacy] candidate %s anonymized on consent expiry", id)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package privacy
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos/memory"
+	"gpb.ru/hr/pkg/blob"
+)
+
+func TestController(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	mem := memory.New()
+	blobs, err := blob.NewFS(t.TempDir())
+	require.NoError(t, err)
+	controller := New(mem.Repos(), blobs)
+	controller.now = func() time.Time { return now }
+
+	candidate := func(name string, expires time.Time) uuid.UUID {
+		c := entities.Candidate{Name: name, Phone: "+79991234567", Skills: []string{"Go"}}
+		require.NoError(t, mem.Candidate.Create(ctx, &c))
+		require.NoError(t, mem.Consent.Create(ctx, &entities.Consent{
+			CandidateID: c.ID,
+			Purpose:     entities.ConsentPurposeRecruitment,
+			Given:       expires.AddDate(-1, 0, 0),
+			Expires:     expires,
+		}))
+		return c.ID
+	}
+	expired := candidate("Иван Иванов", now.AddDate(0, -1, 0))
+	active := candidate("Пётр Петров", now.AddDate(0, 1, 0))
+	withoutConsent := entities.Candidate{Name: "Legacy"}
+	require.NoError(t, mem.Candidate.Create(ctx, &withoutConsent))
+
+	vacancy := entities.Vacancy{Title: "Go developer"}
+	require.NoError(t, mem.Vacancy.Create(ctx, &vacancy))
+	card := entities.Card{VacancyID: vacancy.ID, CandidateID: expired}
+	require.NoError(t, mem.Card.Create(ctx, &card))
+	require.NoError(t, mem.Card.AddComment(ctx, card.ID, &entities.Comment{Author: "hr", Text: "Strong"}))
+
+	resume := entities.Resume{Filename: "cv.txt", CandidateID: expired, Status: entities.ResumeStatusConfirmed}
+	require.NoError(t, mem.Resume.Create(ctx, &resume, []byte("CV")))
+	attachment := entities.Attachment{
+		CandidateID: expired,
+		Filename:    "passport.txt",
+		Size:        2,
+		SHA256:      strings.Repeat("ab", 32),
+	}
+	require.NoError(t, blobs.Put(ctx, attachment.BlobKey(), strings.NewReader("ID"), 2))
+	require.NoError(t, mem.Attachment.Create(ctx, &attachment))
+	message := entities.Message{
+		MessageID:   "<1@example.com>",
+		Direction:   entities.MessageDirectionInbound,
+		CandidateID: expired,
+		From:        "ivan@example.com",
+		Subject:     "Go developer",
+	}
+	require.NoError(t, mem.Message.Create(ctx, &message))
+
+	export, err := controller.Export(ctx, expired)
+	require.NoError(t, err)
+	require.Equal(t, "Иван Иванов", export.Candidate.Name)
+	require.Len(t, export.Consents, 1)
+	require.Len(t, export.Cards, 1)
+	require.Len(t, export.Cards[0].Comments, 1)
+	require.Len(t, export.Resumes, 1)
+	require.Len(t, export.Attachments, 1)
+	require.Len(t, export.Messages, 1)
+
+	var delivery entities.Delivery
+	for _, event := range mem.Outbox.Events() {
+		if event.Type == entities.EventTypeCommentAdded {
+			delivery = entities.Delivery{
+				WebhookID: uuid.New(),
+				EventID:   event.ID,
+				EventType: event.Type,
+				Payload:   event.Payload,
+				LastError: "400 Bad Request: " + string(event.Payload),
+			}
+		}
+	}
+	require.NoError(t, mem.Delivery.Create(ctx, &delivery))
+
+	require.NoError(t, controller.Sweep(ctx))
+
+	erased, err := mem.Candidate.GetByID(ctx, expired)
+	require.NoError(t, err)
+	require.Equal(t, entities.AnonymousName, erased.Name)
+	require.Empty(t, erased.Phone)
+	require.Equal(t, []string{"Go"}, erased.Skills)
+	require.Equal(t, &now, erased.Anonymized)
+	require.ErrorIs(t, mem.Candidate.Update(ctx, erased), entities.ErrCandidateAnonymized)
+	_, err = mem.Card.GetByID(ctx, card.ID)
+	require.NoError(t, err, "cards stay for statistics")
+	resumes, err := mem.Resume.List(ctx, expired)
+	require.NoError(t, err)
+	require.Empty(t, resumes)
+	erasedMessage, err := mem.Message.GetByMessageID(ctx, message.MessageID)
+	require.NoError(t, err, "message ids stay to skip the mail next time")
+	require.Empty(t, erasedMessage.From)
+	require.Empty(t, erasedMessage.Subject)
+	ok, err := blobs.Exists(ctx, attachment.BlobKey())
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Events and their deliveries keep IDs but lose names and comments.
+	var payloads strings.Builder
+	for _, event := range mem.Outbox.Events() {
+		payloads.Write(event.Payload)
+	}
+	require.NotContains(t, payloads.String(), "Иван Иванов")
+	require.NotContains(t, payloads.String(), "Strong")
+	require.Contains(t, payloads.String(), "Пётр Петров", "events of others stay")
+	require.Contains(t, payloads.String(), card.ID.String())
+	redacted, err := mem.Delivery.GetByID(ctx, delivery.ID)
+	require.NoError(t, err)
+	require.NotContains(t, string(redacted.Payload), "Strong")
+	require.Empty(t, redacted.LastError)
+
+	for _, id := range []uuid.UUID{active, withoutConsent.ID} {
+		kept, err := mem.Candidate.GetByID(ctx, id)
+		require.NoError(t, err)
+		require.Nil(t, kept.Anonymized)
+	}
+	kept, err := mem.Candidate.GetByID(ctx, active)
+	require.NoError(t, err)
+	kept.Anonymized = &now
+	require.NoError(t, mem.Candidate.Update(ctx, kept))
+	kept, err = mem.Candidate.GetByID(ctx, active)
+	require.NoError(t, err)
+	require.Nil(t, kept.Anonymized, "only erasing anonymizes")
+
+	ids, err := mem.Consent.Expired(ctx, now)
+	require.NoError(t, err)
+	require.Empty(t, ids, "anonymized candidates are not swept again")
+}
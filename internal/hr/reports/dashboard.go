@@ -0,0 +1,121 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// Roles a user has in vacancies of the dashboard.
+const (
+	RoleHiringManager     = "hiringManager"
+	RoleRecruiter         = "recruiter"
+	RoleDepartmentManager = "departmentManager"
+)
+
+// DashboardVacancy is an open vacancy of the dashboard with its pipeline.
+type DashboardVacancy struct {
+	VacancyID     uuid.UUID              `json:"vacancyID"`
+	Title         string                 `json:"title"`
+	Department    string                 `json:"department"`
+	Area          string                 `json:"area"`
+	Status        entities.VacancyStatus `json:"status"`
+	HiringManager string                 `json:"hiringManager"`
+	Recruiter     string                 `json:"recruiter"`
+	// Role tells why the vacancy is on the dashboard.
+	Role  string `json:"role"`
+	Cards int    `json:"cards"`
+	// Stages counts cards by their current stage.
+	Stages map[entities.CardStage]int `json:"stages"`
+}
+
+// Dashboard shows a manager open vacancies they own or manage with the
+// number of cards in every stage.
+type Dashboard struct {
+	User      string             `json:"user"`
+	Vacancies []DashboardVacancy `json:"vacancies"`
+	// Stages sums stages of all vacancies.
+	Stages map[entities.CardStage]int `json:"stages"`
+}
+
+// Dashboard returns draft and active vacancies the user is the hiring
+// manager or the recruiter of, and ones of departments the user manages in
+// the org chart.
+func (r *Reports) Dashboard(ctx context.Context, user string, org *entities.OrgChart) (*Dashboard, error) {
+	user = entities.NormalizeEmail(user)
+	vacancies, err := r.vacancy.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &Dashboard{
+		User:      user,
+		Vacancies: []DashboardVacancy{},
+		Stages:    newStages(),
+	}
+	if user == "" {
+		return dashboard, nil
+	}
+	index := make(map[uuid.UUID]int)
+	for i := range vacancies {
+		vacancy := &vacancies[i]
+		if vacancy.Status != entities.VacancyStatusDraft && vacancy.Status != entities.VacancyStatusActive {
+			continue
+		}
+		role := ""
+		switch {
+		case vacancy.HiringManager == user:
+			role = RoleHiringManager
+		case vacancy.Recruiter == user:
+			role = RoleRecruiter
+		case org.ManagesVacancy(user, vacancy):
+			role = RoleDepartmentManager
+		default:
+			continue
+		}
+		index[vacancy.ID] = len(dashboard.Vacancies)
+		dashboard.Vacancies = append(dashboard.Vacancies, DashboardVacancy{
+			VacancyID:     vacancy.ID,
+			Title:         vacancy.Title,
+			Department:    vacancy.Department,
+			Area:          vacancy.Area,
+			Status:        vacancy.Status,
+			HiringManager: vacancy.HiringManager,
+			Recruiter:     vacancy.Recruiter,
+			Role:          role,
+			Stages:        newStages(),
+		})
+	}
+	if len(index) == 0 {
+		return dashboard, nil
+	}
+
+	cards, err := r.card.List(ctx, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, card := range cards {
+		i, ok := index[card.VacancyID]
+		if !ok {
+			continue
+		}
+		dashboard.Vacancies[i].Cards++
+		dashboard.Vacancies[i].Stages[card.Stage]++
+		dashboard.Stages[card.Stage]++
+	}
+	return dashboard, nil
+}
+
+// newStages returns counters of the inbox, the pipeline and rejected
+// stages, so every dashboard has the same keys.
+func newStages() map[entities.CardStage]int {
+	stages := make(map[entities.CardStage]int, len(pipeline)+2)
+	stages[entities.CardStageInbox] = 0
+	for _, stage := range pipeline {
+		stages[stage] = 0
+	}
+	stages[entities.CardStageRejected] = 0
+	return stages
+}
@@ -0,0 +1,149 @@
+package reports
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// FunnelStage tells how many cards got to the stage and how long they spent
+// in it.
+type FunnelStage struct {
+	Stage entities.CardStage `json:"stage"`
+	// Reached counts cards which got to the stage or further.
+	Reached int `json:"reached"`
+	// Rejected counts cards rejected right from the stage.
+	Rejected int `json:"rejected"`
+	// Conversion is the share of cards reached the previous stage which got
+	// to this one.
+	Conversion float64 `json:"conversion"`
+	// AverageDays is the mean time cards spent in the stage before moving
+	// on, cards still in the stage are not counted.
+	AverageDays float64 `json:"averageDays"`
+}
+
+// SourceStats tells how effective a source of candidates is.
+type SourceStats struct {
+	// Source is empty for cards of unknown source.
+	Source     string  `json:"source"`
+	Cards      int     `json:"cards"`
+	Hired      int     `json:"hired"`
+	Conversion float64 `json:"conversion"`
+}
+
+// Funnel follows cards created in the period through the pipeline. Cards
+// which have not left the inbox, or were rejected right there, are left out.
+type Funnel struct {
+	Cards   int           `json:"cards"`
+	Stages  []FunnelStage `json:"stages"`
+	Sources []SourceStats `json:"sources"`
+}
+
+func (r *Reports) Funnel(ctx context.Context, filter Filter) (*Funnel, error) {
+	h, err := r.load(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	funnel := &Funnel{Stages: make([]FunnelStage, len(pipeline))}
+	for i, stage := range pipeline {
+		funnel.Stages[i].Stage = stage
+	}
+	stayed := make([][]float64, len(pipeline))
+	sources := make(map[string]*SourceStats)
+
+	for _, card := range h.cards {
+		visits := h.visits[card.ID]
+		if !filter.period(card.Created) || inboxOnly(visits) {
+			continue
+		}
+		funnel.Cards++
+
+		furthest := -1
+		for j, v := range visits {
+			i := pipelineIndex(v.stage)
+			if i < 0 {
+				continue
+			}
+			furthest = max(furthest, i)
+			if j+1 < len(visits) {
+				stayed[i] = append(stayed[i], days(visits[j+1].at.Sub(v.at)))
+			}
+		}
+		for i := 0; i <= furthest; i++ {
+			funnel.Stages[i].Reached++
+		}
+		if card.Stage == entities.CardStageRejected && furthest >= 0 {
+			funnel.Stages[furthest].Rejected++
+		}
+
+		source := sources[card.Source]
+		if source == nil {
+			source = &SourceStats{Source: card.Source}
+			sources[card.Source] = source
+		}
+		source.Cards++
+		if _, ok := h.hired(card.ID); ok {
+			source.Hired++
+		}
+	}
+
+	for i := range funnel.Stages {
+		stage := &funnel.Stages[i]
+		stage.AverageDays = average(stayed[i])
+		if i == 0 {
+			stage.Conversion = ratio(stage.Reached, funnel.Cards)
+		} else {
+			stage.Conversion = ratio(stage.Reached, funnel.Stages[i-1].Reached)
+		}
+	}
+
+	funnel.Sources = make([]SourceStats, 0, len(sources))
+	for _, source := range sources {
+		source.Conversion = ratio(source.Hired, source.Cards)
+		funnel.Sources = append(funnel.Sources, *source)
+	}
+	sort.Slice(funnel.Sources, func(i, j int) bool {
+		a, b := funnel.Sources[i], funnel.Sources[j]
+		if a.Cards != b.Cards {
+			return a.Cards > b.Cards
+		}
+		return a.Source < b.Source
+	})
+
+	return funnel, nil
+}
+
+// WriteCSV writes stages followed by sources, each with its own header, one
+// empty line apart.
+func (f *Funnel) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	records := [][]string{{"stage", "reached", "rejected", "conversion", "average_days"}}
+	for _, s := range f.Stages {
+		records = append(records, []string{
+			s.Stage.String(),
+			strconv.Itoa(s.Reached),
+			strconv.Itoa(s.Rejected),
+			formatFloat(s.Conversion),
+			formatFloat(s.AverageDays),
+		})
+	}
+	records = append(records, nil, []string{"source", "cards", "hired", "conversion"})
+	for _, s := range f.Sources {
+		records = append(records, []string{
+			s.Source,
+			strconv.Itoa(s.Cards),
+			strconv.Itoa(s.Hired),
+			formatFloat(s.Conversion),
+		})
+	}
+	return out.WriteAll(records)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
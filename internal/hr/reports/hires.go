@@ -0,0 +1,97 @@
+package reports
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Hire is a card which reached the hired stage.
+type Hire struct {
+	CardID      uuid.UUID `json:"cardID"`
+	VacancyID   uuid.UUID `json:"vacancyID"`
+	CandidateID uuid.UUID `json:"candidateID"`
+	Vacancy     string    `json:"vacancy"`
+	Department  string    `json:"department"`
+	Source      string    `json:"source"`
+	Applied     time.Time `json:"applied"`
+	Hired       time.Time `json:"hired"`
+	// Days passed from the card creation to the hire.
+	Days float64 `json:"days"`
+}
+
+// TimeToHire lists hires made in the period.
+type TimeToHire struct {
+	Hires       int     `json:"hires"`
+	AverageDays float64 `json:"averageDays"`
+	MedianDays  float64 `json:"medianDays"`
+	Items       []Hire  `json:"items"`
+}
+
+func (r *Reports) TimeToHire(ctx context.Context, filter Filter) (*TimeToHire, error) {
+	h, err := r.load(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]int, len(h.vacancies))
+	for i := range h.vacancies {
+		byID[h.vacancies[i].ID] = i
+	}
+
+	report := &TimeToHire{Items: []Hire{}}
+	var durations []float64
+	for _, card := range h.cards {
+		hired, ok := h.hired(card.ID)
+		if !ok || !filter.period(hired) {
+			continue
+		}
+		vacancy := &h.vacancies[byID[card.VacancyID]]
+		hire := Hire{
+			CardID:      card.ID,
+			VacancyID:   card.VacancyID,
+			CandidateID: card.CandidateID,
+			Vacancy:     vacancy.Title,
+			Department:  vacancy.Department,
+			Source:      card.Source,
+			Applied:     card.Created,
+			Hired:       hired,
+			Days:        days(hired.Sub(card.Created)),
+		}
+		report.Items = append(report.Items, hire)
+		durations = append(durations, hire.Days)
+	}
+	sort.Slice(report.Items, func(i, j int) bool {
+		return report.Items[i].Hired.Before(report.Items[j].Hired)
+	})
+
+	report.Hires = len(report.Items)
+	report.AverageDays = average(durations)
+	report.MedianDays = median(durations)
+	return report, nil
+}
+
+func (t *TimeToHire) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	records := [][]string{{
+		"card_id", "vacancy_id", "candidate_id", "vacancy", "department",
+		"source", "applied", "hired", "days",
+	}}
+	for _, hire := range t.Items {
+		records = append(records, []string{
+			hire.CardID.String(),
+			hire.VacancyID.String(),
+			hire.CandidateID.String(),
+			hire.Vacancy,
+			hire.Department,
+			hire.Source,
+			hire.Applied.Format(time.RFC3339),
+			hire.Hired.Format(time.RFC3339),
+			formatFloat(hire.Days),
+		})
+	}
+	return out.WriteAll(records)
+}
@@ -0,0 +1,250 @@
+// Package reports builds hiring analytics: funnel conversion between card
+// stages, time spent in stages, time to hire and per vacancy statistics.
+//
+// Stage history is taken from domain events in the outbox, so reports cover
+// everything since the outbox was introduced. Cards without events are taken
+// as created in their current stage.
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+// Filter selects vacancies by department and area and the period reports
+// cover. Empty values do not filter, From is inclusive and To is exclusive.
+type Filter struct {
+	Department string
+	Area       string
+	From       time.Time
+	To         time.Time
+}
+
+func (f *Filter) vacancy(vacancy *entities.Vacancy) bool {
+	return (f.Department == "" || vacancy.Department == f.Department) &&
+		(f.Area == "" || vacancy.Area == f.Area)
+}
+
+func (f *Filter) period(t time.Time) bool {
+	return (f.From.IsZero() || !t.Before(f.From)) && (f.To.IsZero() || t.Before(f.To))
+}
+
+// pipeline is the order of stages cards move through, rejected cards leave
+// it from any stage. Cards made of mail wait in the inbox before entering
+// it, so the inbox is not a part of it.
+var pipeline = []entities.CardStage{
+	entities.CardStageNew,
+	entities.CardStageScreening,
+	entities.CardStageInterview,
+	entities.CardStageOffer,
+	entities.CardStageHired,
+}
+
+func pipelineIndex(stage entities.CardStage) int {
+	for i, s := range pipeline {
+		if s == stage {
+			return i
+		}
+	}
+	return -1
+}
+
+// inboxOnly reports whether the card went from the inbox nowhere but to
+// the rejected stage, so it never entered the pipeline.
+func inboxOnly(visits []visit) bool {
+	inbox := false
+	for _, v := range visits {
+		if pipelineIndex(v.stage) >= 0 {
+			return false
+		}
+		if v.stage == entities.CardStageInbox {
+			inbox = true
+		}
+	}
+	return inbox
+}
+
+// eventsPage is the number of events read from the outbox at once.
+const eventsPage = 1000
+
+// Reports builds reports over vacancies, cards and their history.
+type Reports struct {
+	vacancy repos.VacancyRepo
+	card    repos.CardRepo
+	outbox  repos.OutboxRepo
+	now     func() time.Time
+}
+
+func New(r repos.Repos) *Reports {
+	return &Reports{
+		vacancy: r.Vacancy,
+		card:    r.Card,
+		outbox:  r.Outbox,
+		now:     time.Now,
+	}
+}
+
+// visit is a card entering a stage.
+type visit struct {
+	stage entities.CardStage
+	at    time.Time
+}
+
+// history holds vacancies selected by the filter, their cards and what
+// happened to them.
+type history struct {
+	vacancies []entities.Vacancy
+	cards     []entities.Card
+	visits    map[uuid.UUID][]visit
+	opened    map[uuid.UUID]time.Time
+	closed    map[uuid.UUID]time.Time
+}
+
+func (r *Reports) load(ctx context.Context, filter Filter) (*history, error) {
+	vacancies, err := r.vacancy.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	h := &history{
+		visits: make(map[uuid.UUID][]visit),
+		opened: make(map[uuid.UUID]time.Time),
+		closed: make(map[uuid.UUID]time.Time),
+	}
+	selected := make(map[uuid.UUID]bool)
+	for i := range vacancies {
+		if filter.vacancy(&vacancies[i]) {
+			h.vacancies = append(h.vacancies, vacancies[i])
+			selected[vacancies[i].ID] = true
+		}
+	}
+
+	cards, err := r.card.List(ctx, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, card := range cards {
+		if selected[card.VacancyID] {
+			h.cards = append(h.cards, card)
+		}
+	}
+
+	after := int64(0)
+	for {
+		events, err := r.outbox.List(ctx, after, eventsPage)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) == 0 {
+			break
+		}
+		for i := range events {
+			h.apply(&events[i])
+		}
+		after = events[len(events)-1].Seq
+	}
+
+	for _, card := range h.cards {
+		if len(h.visits[card.ID]) == 0 {
+			h.visits[card.ID] = []visit{{stage: card.Stage, at: card.Created}}
+		}
+	}
+	return h, nil
+}
+
+// apply records the event, events are not validated as they were produced
+// by the repositories.
+func (h *history) apply(event *entities.Event) {
+	switch event.Type {
+	case entities.EventTypeCardCreated:
+		var payload entities.CardCreated
+		if decode(event, &payload) {
+			h.visits[payload.CardID] = append(h.visits[payload.CardID], visit{payload.Stage, event.Created})
+		}
+	case entities.EventTypeCardMoved:
+		var payload entities.CardMoved
+		if decode(event, &payload) {
+			h.visits[payload.CardID] = append(h.visits[payload.CardID], visit{payload.To, event.Created})
+		}
+	case entities.EventTypeVacancyCreated:
+		var payload entities.VacancyCreated
+		if decode(event, &payload) && payload.Vacancy.Status == entities.VacancyStatusActive {
+			h.opened[event.AggregateID] = event.Created
+		}
+	case entities.EventTypeVacancyStatusChanged:
+		var payload entities.VacancyStatusChanged
+		if !decode(event, &payload) {
+			return
+		}
+		switch payload.To {
+		case entities.VacancyStatusActive:
+			if _, ok := h.opened[event.AggregateID]; !ok {
+				h.opened[event.AggregateID] = event.Created
+			}
+			delete(h.closed, event.AggregateID)
+		case entities.VacancyStatusInactive:
+			h.closed[event.AggregateID] = event.Created
+		}
+	}
+}
+
+func decode(event *entities.Event, payload interface{}) bool {
+	return json.Unmarshal(event.Payload, payload) == nil
+}
+
+// hired returns when the card was hired first.
+func (h *history) hired(cardID uuid.UUID) (time.Time, bool) {
+	for _, v := range h.visits[cardID] {
+		if v.stage == entities.CardStageHired {
+			return v.at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func days(d time.Duration) float64 {
+	return round(d.Hours()/24, 1)
+}
+
+func round(v float64, digits int) float64 {
+	p := math.Pow(10, float64(digits))
+	return math.Round(v*p) / p
+}
+
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return round(sum/float64(len(values)), 1)
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return round((sorted[n/2-1]+sorted[n/2])/2, 1)
+}
+
+func ratio(a, b int) float64 {
+	if b == 0 {
+		return 0
+	}
+	return round(float64(a)/float64(b), 3)
+}
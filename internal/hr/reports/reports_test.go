@@ -0,0 +1,258 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type vacancies struct {
+	repos.VacancyRepo
+	items []entities.Vacancy
+}
+
+func (v *vacancies) List(context.Context) ([]entities.Vacancy, error) {
+	return v.items, nil
+}
+
+type cards struct {
+	repos.CardRepo
+	items []entities.Card
+}
+
+func (c *cards) List(context.Context, uuid.UUID) ([]entities.Card, error) {
+	return c.items, nil
+}
+
+type outbox struct {
+	repos.OutboxRepo
+	events []entities.Event
+}
+
+func (o *outbox) List(ctx context.Context, after int64, limit int) ([]entities.Event, error) {
+	var events []entities.Event
+	for _, event := range o.events {
+		if event.Seq > after && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (o *outbox) add(t *testing.T, at time.Time, aggregateID uuid.UUID, payload entities.EventPayload) {
+	event, err := entities.NewEvent(aggregateID, payload)
+	require.NoError(t, err)
+	event.Seq = int64(len(o.events) + 1)
+	event.Created = at
+	o.events = append(o.events, event)
+}
+
+var t0 = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func day(n float64) time.Time {
+	return t0.Add(time.Duration(n * 24 * float64(time.Hour)))
+}
+
+// testReports sets up two vacancies with four cards:
+//
+//	it:    c1 hh       new 1 → screening 3 → interview 5 → offer 8 → hired 11
+//	it:    c2 hh       new 1 → screening 2 → rejected 4
+//	it:    c3 referral new 2
+//	sales: c4 referral new 3 → screening 4 → interview 6 → offer 7 → hired 12
+//
+// IT is opened on day 0, sales is a draft opened on day 2 and closed on 20.
+func testReports(t *testing.T) (*Reports, []uuid.UUID, []uuid.UUID) {
+	it := entities.Vacancy{
+		ID: uuid.New(), Title: "Go developer", Department: "IT", Area: "Москва",
+		Status: entities.VacancyStatusActive, Created: t0,
+	}
+	sales := entities.Vacancy{
+		ID: uuid.New(), Title: "Account manager", Department: "Sales", Area: "Казань",
+		Status: entities.VacancyStatusInactive, Created: t0.Add(time.Hour),
+	}
+	o := &outbox{}
+	o.add(t, it.Created, it.ID, entities.VacancyCreated{Vacancy: it})
+	draft := sales
+	draft.Status = entities.VacancyStatusDraft
+	o.add(t, sales.Created, sales.ID, entities.VacancyCreated{Vacancy: draft})
+	o.add(t, day(2), sales.ID, entities.VacancyStatusChanged{
+		VacancyID: sales.ID, From: entities.VacancyStatusDraft, To: entities.VacancyStatusActive,
+	})
+	o.add(t, day(20), sales.ID, entities.VacancyStatusChanged{
+		VacancyID: sales.ID, From: entities.VacancyStatusActive, To: entities.VacancyStatusInactive,
+	})
+
+	var items []entities.Card
+	card := func(vacancyID uuid.UUID, source string, created float64, moves ...interface{}) {
+		c := entities.Card{
+			ID: uuid.New(), VacancyID: vacancyID, CandidateID: uuid.New(),
+			Stage: entities.CardStageNew, Source: source, Created: day(created),
+		}
+		o.add(t, c.Created, c.ID, entities.CardCreated{
+			CardID: c.ID, VacancyID: vacancyID, CandidateID: c.CandidateID, Stage: c.Stage,
+		})
+		for i := 0; i < len(moves); i += 2 {
+			to := moves[i].(entities.CardStage)
+			o.add(t, day(moves[i+1].(float64)), c.ID, entities.CardMoved{
+				CardID: c.ID, VacancyID: vacancyID, CandidateID: c.CandidateID, From: c.Stage, To: to,
+			})
+			c.Stage = to
+		}
+		items = append(items, c)
+	}
+	card(it.ID, "hh", 1,
+		entities.CardStageScreening, 3.0, entities.CardStageInterview, 5.0,
+		entities.CardStageOffer, 8.0, entities.CardStageHired, 11.0)
+	card(it.ID, "hh", 1,
+		entities.CardStageScreening, 2.0, entities.CardStageRejected, 4.0)
+	card(it.ID, "referral", 2)
+	card(sales.ID, "referral", 3,
+		entities.CardStageScreening, 4.0, entities.CardStageInterview, 6.0,
+		entities.CardStageOffer, 7.0, entities.CardStageHired, 12.0)
+
+	r := &Reports{
+		vacancy: &vacancies{items: []entities.Vacancy{it, sales}},
+		card:    &cards{items: items},
+		outbox:  o,
+		now:     func() time.Time { return day(30) },
+	}
+	cardIDs := []uuid.UUID{items[0].ID, items[1].ID, items[2].ID, items[3].ID}
+	return r, []uuid.UUID{it.ID, sales.ID}, cardIDs
+}
+
+func TestReports_Funnel(t *testing.T) {
+	r, _, _ := testReports(t)
+
+	funnel, err := r.Funnel(context.Background(), Filter{})
+	require.NoError(t, err)
+	require.Exactly(t, 4, funnel.Cards)
+	require.Exactly(t, []FunnelStage{
+		{Stage: entities.CardStageNew, Reached: 4, Conversion: 1, AverageDays: 1.3},
+		{Stage: entities.CardStageScreening, Reached: 3, Rejected: 1, Conversion: 0.75, AverageDays: 2},
+		{Stage: entities.CardStageInterview, Reached: 2, Conversion: 0.667, AverageDays: 2},
+		{Stage: entities.CardStageOffer, Reached: 2, Conversion: 1, AverageDays: 4},
+		{Stage: entities.CardStageHired, Reached: 2, Conversion: 1},
+	}, funnel.Stages)
+	require.Exactly(t, []SourceStats{
+		{Source: "hh", Cards: 2, Hired: 1, Conversion: 0.5},
+		{Source: "referral", Cards: 2, Hired: 1, Conversion: 0.5},
+	}, funnel.Sources)
+
+	var buf bytes.Buffer
+	require.NoError(t, funnel.WriteCSV(&buf))
+	require.Exactly(t, "stage,reached,rejected,conversion,average_days\n"+
+		"new,4,0,1,1.3\n"+
+		"screening,3,1,0.75,2\n"+
+		"interview,2,0,0.667,2\n"+
+		"offer,2,0,1,4\n"+
+		"hired,2,0,1,0\n"+
+		"\n"+
+		"source,cards,hired,conversion\n"+
+		"hh,2,1,0.5\n"+
+		"referral,2,1,0.5\n", buf.String())
+
+	funnel, err = r.Funnel(context.Background(), Filter{Department: "IT", From: day(1), To: day(2)})
+	require.NoError(t, err)
+	require.Exactly(t, 2, funnel.Cards)
+	require.Exactly(t, 1, funnel.Stages[4].Reached)
+}
+
+func TestReports_FunnelInbox(t *testing.T) {
+	r, vacancyIDs, _ := testReports(t)
+	items, o := r.card.(*cards), r.outbox.(*outbox)
+
+	// Mailed applications wait in the inbox, the pipeline starts once one
+	// is moved to new.
+	inbox := func(moves ...entities.CardStage) {
+		c := entities.Card{
+			ID: uuid.New(), VacancyID: vacancyIDs[0], CandidateID: uuid.New(),
+			Stage: entities.CardStageInbox, Source: "mail", Created: day(2),
+		}
+		o.add(t, c.Created, c.ID, entities.CardCreated{
+			CardID: c.ID, VacancyID: c.VacancyID, CandidateID: c.CandidateID, Stage: c.Stage,
+		})
+		for i, to := range moves {
+			o.add(t, day(3+float64(i)), c.ID, entities.CardMoved{
+				CardID: c.ID, VacancyID: c.VacancyID, CandidateID: c.CandidateID, From: c.Stage, To: to,
+			})
+			c.Stage = to
+		}
+		items.items = append(items.items, c)
+	}
+	inbox()
+	inbox(entities.CardStageRejected)
+	inbox(entities.CardStageNew, entities.CardStageScreening)
+
+	funnel, err := r.Funnel(context.Background(), Filter{})
+	require.NoError(t, err)
+	require.Exactly(t, 5, funnel.Cards)
+	require.Exactly(t, []FunnelStage{
+		{Stage: entities.CardStageNew, Reached: 5, Conversion: 1, AverageDays: 1.3},
+		{Stage: entities.CardStageScreening, Reached: 4, Rejected: 1, Conversion: 0.8, AverageDays: 2},
+		{Stage: entities.CardStageInterview, Reached: 2, Conversion: 0.5, AverageDays: 2},
+		{Stage: entities.CardStageOffer, Reached: 2, Conversion: 1, AverageDays: 4},
+		{Stage: entities.CardStageHired, Reached: 2, Conversion: 1},
+	}, funnel.Stages)
+	require.Contains(t, funnel.Sources, SourceStats{Source: "mail", Cards: 1})
+}
+
+func TestReports_TimeToHire(t *testing.T) {
+	r, vacancyIDs, cardIDs := testReports(t)
+
+	report, err := r.TimeToHire(context.Background(), Filter{})
+	require.NoError(t, err)
+	require.Exactly(t, 2, report.Hires)
+	require.Exactly(t, 9.5, report.AverageDays)
+	require.Exactly(t, 9.5, report.MedianDays)
+	require.Len(t, report.Items, 2)
+	require.Exactly(t, cardIDs[0], report.Items[0].CardID)
+	require.Exactly(t, "Go developer", report.Items[0].Vacancy)
+	require.Exactly(t, 10.0, report.Items[0].Days)
+	require.Exactly(t, vacancyIDs[1], report.Items[1].VacancyID)
+	require.Exactly(t, 9.0, report.Items[1].Days)
+
+	report, err = r.TimeToHire(context.Background(), Filter{From: day(11.5)})
+	require.NoError(t, err)
+	require.Exactly(t, 1, report.Hires)
+	require.Exactly(t, cardIDs[3], report.Items[0].CardID)
+
+	report, err = r.TimeToHire(context.Background(), Filter{Area: "Новосибирск"})
+	require.NoError(t, err)
+	require.Exactly(t, 0, report.Hires)
+	require.Empty(t, report.Items)
+}
+
+func TestReports_Vacancies(t *testing.T) {
+	r, vacancyIDs, _ := testReports(t)
+	ptr := func(v float64) *float64 { return &v }
+	at := func(v time.Time) *time.Time { return &v }
+
+	report, err := r.Vacancies(context.Background(), Filter{})
+	require.NoError(t, err)
+	require.Exactly(t, []VacancyStats{
+		{
+			VacancyID: vacancyIDs[1], Title: "Account manager", Department: "Sales", Area: "Казань",
+			Status: entities.VacancyStatusInactive, Created: t0.Add(time.Hour),
+			Opened: at(day(2)), Closed: at(day(20)), Cards: 1, Hired: 1,
+			DaysOpen: ptr(18), TimeToFillDays: ptr(10),
+		},
+		{
+			VacancyID: vacancyIDs[0], Title: "Go developer", Department: "IT", Area: "Москва",
+			Status: entities.VacancyStatusActive, Created: t0,
+			Opened: at(t0), Cards: 3, InProgress: 1, Hired: 1, Rejected: 1,
+			DaysOpen: ptr(30), TimeToFillDays: ptr(11),
+		},
+	}, report.Items)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteCSV(&buf))
+	require.Contains(t, buf.String(), vacancyIDs[0].String()+
+		",Go developer,IT,Москва,active,2024-01-01T00:00:00Z,2024-01-01T00:00:00Z,,3,1,1,1,30,11\n")
+}
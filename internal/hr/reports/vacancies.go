@@ -0,0 +1,150 @@
+package reports
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// VacancyStats sums cards of a vacancy up and tells how long it took to fill.
+type VacancyStats struct {
+	VacancyID  uuid.UUID              `json:"vacancyID"`
+	Title      string                 `json:"title"`
+	Department string                 `json:"department"`
+	Area       string                 `json:"area"`
+	Status     entities.VacancyStatus `json:"status"`
+	Created    time.Time              `json:"created"`
+	// Opened is when the vacancy became active first.
+	Opened *time.Time `json:"opened"`
+	// Closed is when the vacancy became inactive last, nil if it is open.
+	Closed     *time.Time `json:"closed"`
+	Cards      int        `json:"cards"`
+	InProgress int        `json:"inProgress"`
+	Hired      int        `json:"hired"`
+	Rejected   int        `json:"rejected"`
+	// DaysOpen passed from opening to closing or till now.
+	DaysOpen *float64 `json:"daysOpen"`
+	// TimeToFillDays passed from opening to the first hire.
+	TimeToFillDays *float64 `json:"timeToFillDays"`
+}
+
+// Vacancies sums up vacancies created in the period.
+type Vacancies struct {
+	Items []VacancyStats `json:"items"`
+}
+
+func (r *Reports) Vacancies(ctx context.Context, filter Filter) (*Vacancies, error) {
+	h, err := r.load(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[uuid.UUID]*VacancyStats)
+	report := &Vacancies{Items: []VacancyStats{}}
+	for _, vacancy := range h.vacancies {
+		if !filter.period(vacancy.Created) {
+			continue
+		}
+		s := VacancyStats{
+			VacancyID:  vacancy.ID,
+			Title:      vacancy.Title,
+			Department: vacancy.Department,
+			Area:       vacancy.Area,
+			Status:     vacancy.Status,
+			Created:    vacancy.Created,
+		}
+		if opened, ok := h.opened[vacancy.ID]; ok {
+			s.Opened = &opened
+			end := r.now()
+			if closed, ok := h.closed[vacancy.ID]; ok {
+				s.Closed = &closed
+				end = closed
+			}
+			open := days(end.Sub(opened))
+			s.DaysOpen = &open
+		}
+		report.Items = append(report.Items, s)
+	}
+	for i := range report.Items {
+		stats[report.Items[i].VacancyID] = &report.Items[i]
+	}
+
+	for _, card := range h.cards {
+		s := stats[card.VacancyID]
+		if s == nil {
+			continue
+		}
+		s.Cards++
+		switch card.Stage {
+		case entities.CardStageHired:
+			s.Hired++
+		case entities.CardStageRejected:
+			s.Rejected++
+		default:
+			s.InProgress++
+		}
+
+		hired, ok := h.hired(card.ID)
+		if !ok || s.Opened == nil {
+			continue
+		}
+		fill := days(hired.Sub(*s.Opened))
+		if s.TimeToFillDays == nil || fill < *s.TimeToFillDays {
+			s.TimeToFillDays = &fill
+		}
+	}
+
+	sort.Slice(report.Items, func(i, j int) bool {
+		return report.Items[i].Created.After(report.Items[j].Created)
+	})
+	return report, nil
+}
+
+func (v *Vacancies) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	records := [][]string{{
+		"vacancy_id", "title", "department", "area", "status", "created",
+		"opened", "closed", "cards", "in_progress", "hired", "rejected",
+		"days_open", "time_to_fill_days",
+	}}
+	for _, s := range v.Items {
+		records = append(records, []string{
+			s.VacancyID.String(),
+			s.Title,
+			s.Department,
+			s.Area,
+			s.Status.String(),
+			s.Created.Format(time.RFC3339),
+			formatTime(s.Opened),
+			formatTime(s.Closed),
+			strconv.Itoa(s.Cards),
+			strconv.Itoa(s.InProgress),
+			strconv.Itoa(s.Hired),
+			strconv.Itoa(s.Rejected),
+			formatOptional(s.DaysOpen),
+			formatOptional(s.TimeToFillDays),
+		})
+	}
+	return out.WriteAll(records)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatOptional(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return formatFloat(*v)
+}
@@ -0,0 +1,33 @@
+package repos
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// BlobStore keeps file contents by key, see package pkg/blob for the
+// implementations. Get of a missing key fails with blob.ErrNotFound.
+type BlobStore interface {
+	// Put writes the blob of the given size, -1 if unknown to stores which
+	// accept that.
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type AttachmentRepo interface {
+	GetByID(context.Context, uuid.UUID) (*entities.Attachment, error)
+	// List returns attachments of the candidate including the ones of its
+	// cards, or attachments of the card if one is given, ordered by
+	// creation time.
+	List(ctx context.Context, candidateID, cardID uuid.UUID) ([]entities.Attachment, error)
+	Create(context.Context, *entities.Attachment) error
+	Delete(context.Context, uuid.UUID) error
+	// CountBySHA256 returns the number of attachments sharing the contents.
+	CountBySHA256(ctx context.Context, hash string) (int, error)
+}
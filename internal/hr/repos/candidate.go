@@ -12,5 +12,25 @@ type CandidateRepo interface {
 	GetByID(context.Context, uuid.UUID) (*entities.Candidate, error)
 	List(context.Context, uuid.UUID) ([]entities.Candidate, error)
 	Create(context.Context, *entities.Candidate) error
+	// Update saves the candidate unless it is anonymized, which fails with
+	// entities.ErrCandidateAnonymized. The anonymized time is never saved.
 	Update(context.Context, *entities.Candidate) error
+	// Anonymize saves the candidate scrubbed by entities.Candidate.Anonymize
+	// along with the time it was anonymized. Events of the candidate and its
+	// cards in the outbox and their webhook deliveries are redacted with
+	// entities.Event.Redact at the same time.
+	Anonymize(context.Context, *entities.Candidate) error
+	// FindByContacts returns candidates with the given phone or email, both
+	// normalized. Empty values match nothing.
+	FindByContacts(ctx context.Context, phone, email string) ([]entities.Candidate, error)
+	// FindByName returns candidates whose entities.NameKey is similar to
+	// the one of the name, at least all entities.SimilarNameKeys would
+	// accept. Empty keys match nothing.
+	FindByName(ctx context.Context, name string) ([]entities.Candidate, error)
+	// Merge saves the candidate with the duplicate merged in, moves cards,
+	// attachments, resumes and consents of the duplicate to it and replaces
+	// the duplicate with a redirect.
+	Merge(ctx context.Context, candidate *entities.Candidate, duplicateID uuid.UUID) error
+	// Redirect returns ID of the candidate the merged one lives on as.
+	Redirect(context.Context, uuid.UUID) (uuid.UUID, error)
 }
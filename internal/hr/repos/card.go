@@ -0,0 +1,19 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+type CardRepo interface {
+	GetByID(context.Context, uuid.UUID) (*entities.Card, error)
+	List(context.Context, uuid.UUID) ([]entities.Card, error)
+	// ListByCandidate returns cards of the candidate without comments.
+	ListByCandidate(context.Context, uuid.UUID) ([]entities.Card, error)
+	Create(context.Context, *entities.Card) error
+	Move(context.Context, uuid.UUID, entities.CardStage) (*entities.Card, error)
+	AddComment(context.Context, uuid.UUID, *entities.Comment) error
+}
@@ -0,0 +1,21 @@
+package repos
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+type ConsentRepo interface {
+	GetByID(context.Context, uuid.UUID) (*entities.Consent, error)
+	// List returns consents of the candidate ordered by the date given.
+	List(context.Context, uuid.UUID) ([]entities.Consent, error)
+	Create(context.Context, *entities.Consent) error
+	Update(context.Context, *entities.Consent) error
+	// Expired returns candidates not anonymized yet which gave consents but
+	// have none active at the moment.
+	Expired(context.Context, time.Time) ([]uuid.UUID, error)
+}
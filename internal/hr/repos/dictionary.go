@@ -0,0 +1,34 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+type DictionaryFilter struct {
+	// Kind limits items to one dictionary, all of them if none.
+	Kind entities.DictionaryKind
+	// ParentID limits items to the children of the item.
+	ParentID uuid.UUID
+	// Prefix matches the beginning of the name compared by
+	// entities.DictionaryKey.
+	Prefix string
+}
+
+type DictionaryRepo interface {
+	GetByID(context.Context, uuid.UUID) (*entities.DictionaryItem, error)
+	// List returns items matching the filter ordered by kind and name.
+	List(context.Context, DictionaryFilter) ([]entities.DictionaryItem, error)
+	// Create and Update fail with ErrDictionaryExists if the dictionary has
+	// another item of the name.
+	Create(context.Context, *entities.DictionaryItem) error
+	// Update renames values of vacancies and candidates having the old name
+	// of the item.
+	Update(context.Context, *entities.DictionaryItem) error
+	// Delete fails with ErrDictionaryInUse if the item has children or is a
+	// value of a vacancy or a candidate.
+	Delete(context.Context, uuid.UUID) error
+}
@@ -0,0 +1,26 @@
+package repos
+
+import "errors"
+
+var (
+	ErrVacancyNotFound     = errors.New("vacancy not found")
+	ErrCandidateNotFound   = errors.New("candidate not found")
+	ErrCardNotFound        = errors.New("card not found")
+	ErrWebhookNotFound     = errors.New("webhook not found")
+	ErrDeliveryNotFound    = errors.New("delivery not found")
+	ErrInterviewNotFound   = errors.New("interview not found")
+	ErrOfferNotFound       = errors.New("offer not found")
+	ErrResumeNotFound      = errors.New("resume not found")
+	ErrAttachmentNotFound  = errors.New("attachment not found")
+	ErrConsentNotFound     = errors.New("consent not found")
+	ErrMessageNotFound     = errors.New("message not found")
+	ErrSkillNotFound       = errors.New("skill not found")
+	ErrDictionaryNotFound  = errors.New("dictionary item not found")
+	ErrRequisitionNotFound = errors.New("requisition not found")
+
+	ErrMergeConflict    = errors.New("both candidates have cards on the same vacancy")
+	ErrMessageExists    = errors.New("message is already received")
+	ErrSkillExists      = errors.New("skill name or synonym is already in the catalog")
+	ErrDictionaryExists = errors.New("dictionary already has an item of the name")
+	ErrDictionaryInUse  = errors.New("dictionary item has nested items or is in use")
+)
@@ -0,0 +1,32 @@
+package repos
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// InterviewFilter selects interviews. Zero fields match everything, the
+// time range selects interviews overlapping [From, To).
+type InterviewFilter struct {
+	CardID      uuid.UUID
+	Interviewer string
+	From        time.Time
+	To          time.Time
+}
+
+type InterviewRepo interface {
+	GetByID(context.Context, uuid.UUID) (*entities.Interview, error)
+	// List returns interviews matching the filter ordered by start time.
+	List(context.Context, InterviewFilter) ([]entities.Interview, error)
+	Create(context.Context, *entities.Interview) error
+	// Update replaces the interview and increases its sequence.
+	Update(context.Context, *entities.Interview) error
+	// Hold runs fn while holding the interviewers, so interviews checked
+	// and saved by fn are not raced by other holds of any of them. Saving
+	// with the context given to fn makes it a part of the hold.
+	Hold(ctx context.Context, interviewers []string, fn func(context.Context) error) error
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type AttachmentRepo struct {
+	mu          sync.RWMutex
+	attachments map[uuid.UUID]entities.Attachment
+}
+
+func NewAttachmentRepo() *AttachmentRepo {
+	return &AttachmentRepo{attachments: make(map[uuid.UUID]entities.Attachment)}
+}
+
+func (repo *AttachmentRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Attachment, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	attachment, ok := repo.attachments[id]
+	if !ok {
+		return nil, repos.ErrAttachmentNotFound
+	}
+	return &attachment, nil
+}
+
+func (repo *AttachmentRepo) List(
+	ctx context.Context,
+	candidateID uuid.UUID,
+	cardID uuid.UUID,
+) ([]entities.Attachment, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	attachments := make([]entities.Attachment, 0, len(repo.attachments))
+	for _, attachment := range repo.attachments {
+		if cardID != uuid.Nil && attachment.CardID != cardID ||
+			candidateID != uuid.Nil && attachment.CandidateID != candidateID {
+			continue
+		}
+		attachments = append(attachments, attachment)
+	}
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].Created.Before(attachments[j].Created)
+	})
+	return attachments, nil
+}
+
+func (repo *AttachmentRepo) Create(
+	ctx context.Context,
+	attachment *entities.Attachment,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	attachment.ID = uuid.New()
+	attachment.Created = time.Now()
+	repo.attachments[attachment.ID] = *attachment
+	return nil
+}
+
+func (repo *AttachmentRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.attachments[id]; !ok {
+		return repos.ErrAttachmentNotFound
+	}
+	delete(repo.attachments, id)
+	return nil
+}
+
+func (repo *AttachmentRepo) CountBySHA256(ctx context.Context, hash string) (int, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	count := 0
+	for _, attachment := range repo.attachments {
+		if attachment.SHA256 == hash {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// reassign moves attachments of the candidate to another one.
+func (repo *AttachmentRepo) reassign(from, to uuid.UUID) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, attachment := range repo.attachments {
+		if attachment.CandidateID == from {
+			attachment.CandidateID = to
+			repo.attachments[id] = attachment
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type CandidateRepo struct {
+	mu          sync.RWMutex
+	candidates  map[uuid.UUID]entities.Candidate
+	redirects   map[uuid.UUID]uuid.UUID
+	cards       *CardRepo
+	attachments *AttachmentRepo
+	resumes     *ResumeRepo
+	consents    *ConsentRepo
+	messages    *MessageRepo
+	outbox      *OutboxRepo
+	deliveries  *DeliveryRepo
+}
+
+func NewCandidateRepo(outbox *OutboxRepo) *CandidateRepo {
+	return &CandidateRepo{
+		candidates: make(map[uuid.UUID]entities.Candidate),
+		redirects:  make(map[uuid.UUID]uuid.UUID),
+		outbox:     outbox,
+	}
+}
+
+func (repo *CandidateRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Candidate, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	candidate, ok := repo.candidates[id]
+	if !ok {
+		return nil, repos.ErrCandidateNotFound
+	}
+	return &candidate, nil
+}
+
+// List returns all candidates. Filtering by vacancy requires the card repo
+// to be linked with LinkCards.
+func (repo *CandidateRepo) List(
+	ctx context.Context,
+	vacancyID uuid.UUID,
+) ([]entities.Candidate, error) {
+	var filter map[uuid.UUID]bool
+	if vacancyID != uuid.Nil && repo.cards != nil {
+		cards, err := repo.cards.List(ctx, vacancyID)
+		if err != nil {
+			return nil, err
+		}
+		filter = make(map[uuid.UUID]bool, len(cards))
+		for _, card := range cards {
+			filter[card.CandidateID] = true
+		}
+	}
+
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	candidates := make([]entities.Candidate, 0, len(repo.candidates))
+	for _, candidate := range repo.candidates {
+		if filter != nil && !filter[candidate.ID] {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Updated.After(candidates[j].Updated)
+	})
+	return candidates, nil
+}
+
+func (repo *CandidateRepo) Create(
+	ctx context.Context,
+	candidate *entities.Candidate,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	candidate.ID = uuid.New()
+	candidate.Created = time.Now()
+	candidate.Updated = time.Now()
+	repo.candidates[candidate.ID] = *candidate
+	return repo.outbox.publish(candidate.ID, entities.CandidateCreated{
+		CandidateID: candidate.ID,
+		Name:        candidate.Name,
+	})
+}
+
+func (repo *CandidateRepo) Update(
+	ctx context.Context,
+	candidate *entities.Candidate,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	old, ok := repo.candidates[candidate.ID]
+	if !ok {
+		return repos.ErrCandidateNotFound
+	}
+	if old.Anonymized != nil {
+		return entities.ErrCandidateAnonymized
+	}
+	candidate.Anonymized = nil
+	candidate.Created = old.Created
+	candidate.Updated = time.Now()
+	repo.candidates[candidate.ID] = *candidate
+	return nil
+}
+
+func (repo *CandidateRepo) Anonymize(
+	ctx context.Context,
+	candidate *entities.Candidate,
+) error {
+	anonymized := candidate.Anonymized
+	err := repo.Update(ctx, candidate)
+	if err != nil {
+		return err
+	}
+
+	repo.mu.Lock()
+	candidate.Anonymized = anonymized
+	repo.candidates[candidate.ID] = *candidate
+	repo.mu.Unlock()
+
+	return repo.redactEvents(ctx, candidate.ID)
+}
+
+// redactEvents redacts events of the candidate and of its cards along with
+// their deliveries. Cards and deliveries are skipped unless linked with
+// LinkCards and LinkDeliveries.
+func (repo *CandidateRepo) redactEvents(ctx context.Context, candidateID uuid.UUID) error {
+	aggregates := map[uuid.UUID]bool{candidateID: true}
+	if repo.cards != nil {
+		cards, err := repo.cards.ListByCandidate(ctx, candidateID)
+		if err != nil {
+			return err
+		}
+		for _, card := range cards {
+			aggregates[card.ID] = true
+		}
+	}
+
+	events, err := repo.outbox.redact(aggregates)
+	if err != nil {
+		return err
+	}
+	if repo.deliveries != nil {
+		repo.deliveries.redact(events)
+	}
+	return nil
+}
+
+func (repo *CandidateRepo) FindByContacts(
+	ctx context.Context,
+	phone, email string,
+) ([]entities.Candidate, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	var candidates []entities.Candidate
+	for _, candidate := range repo.candidates {
+		if phone != "" && candidate.Phone == phone || email != "" && candidate.Email == email {
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Updated.After(candidates[j].Updated)
+	})
+	return candidates, nil
+}
+
+func (repo *CandidateRepo) FindByName(
+	ctx context.Context,
+	name string,
+) ([]entities.Candidate, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	key := entities.NameKey(name)
+	var candidates []entities.Candidate
+	for _, candidate := range repo.candidates {
+		if entities.SimilarNameKeys(key, entities.NameKey(candidate.Name)) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Updated.After(candidates[j].Updated)
+	})
+	return candidates, nil
+}
+
+// Merge moves cards, attachments, resumes and consents of the duplicate to
+// the candidate. Repos not linked with LinkCards and LinkMerged are skipped.
+func (repo *CandidateRepo) Merge(
+	ctx context.Context,
+	candidate *entities.Candidate,
+	duplicateID uuid.UUID,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	old, ok := repo.candidates[candidate.ID]
+	if !ok {
+		return repos.ErrCandidateNotFound
+	}
+	if _, ok := repo.candidates[duplicateID]; !ok {
+		return repos.ErrCandidateNotFound
+	}
+
+	if repo.cards != nil {
+		err := repo.cards.reassign(duplicateID, candidate.ID)
+		if err != nil {
+			return err
+		}
+	}
+	if repo.attachments != nil {
+		repo.attachments.reassign(duplicateID, candidate.ID)
+	}
+	if repo.resumes != nil {
+		repo.resumes.reassign(duplicateID, candidate.ID)
+	}
+	if repo.consents != nil {
+		repo.consents.reassign(duplicateID, candidate.ID)
+	}
+	if repo.messages != nil {
+		repo.messages.reassign(duplicateID, candidate.ID)
+	}
+
+	candidate.Created = old.Created
+	candidate.Updated = time.Now()
+	repo.candidates[candidate.ID] = *candidate
+	delete(repo.candidates, duplicateID)
+	for id, target := range repo.redirects {
+		if target == duplicateID {
+			repo.redirects[id] = candidate.ID
+		}
+	}
+	repo.redirects[duplicateID] = candidate.ID
+	return nil
+}
+
+func (repo *CandidateRepo) Redirect(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	candidateID, ok := repo.redirects[id]
+	if !ok {
+		return uuid.Nil, repos.ErrCandidateNotFound
+	}
+	return candidateID, nil
+}
+
+// LinkCards makes List able to filter candidates by vacancy.
+func (repo *CandidateRepo) LinkCards(cards *CardRepo) {
+	repo.cards = cards
+}
+
+// LinkMerged makes Merge move attachments, resumes, consents and messages
+// of the duplicate.
+func (repo *CandidateRepo) LinkMerged(
+	attachments *AttachmentRepo,
+	resumes *ResumeRepo,
+	consents *ConsentRepo,
+	messages *MessageRepo,
+) {
+	repo.attachments = attachments
+	repo.resumes = resumes
+	repo.consents = consents
+	repo.messages = messages
+}
+
+// LinkDeliveries makes Anonymize redact webhook deliveries of the events of
+// the candidate.
+func (repo *CandidateRepo) LinkDeliveries(deliveries *DeliveryRepo) {
+	repo.deliveries = deliveries
+}
+
+// renameSkill gives candidates the catalog name of the skill in place of its
+// other spellings.
+func (repo *CandidateRepo) renameSkill(skill *entities.CatalogSkill) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	index := entities.NewSkillIndex([]entities.CatalogSkill{*skill})
+	for id, candidate := range repo.candidates {
+		if !usesSkill(skill, candidate.Skills) {
+			continue
+		}
+		candidate.NormalizeSkills(index)
+		repo.candidates[id] = candidate
+	}
+}
+
+// renameValue gives candidates the new name of the dictionary item.
+func (repo *CandidateRepo) renameValue(kind entities.DictionaryKind, from, to string) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, candidate := range repo.candidates {
+		value := candidateValue(&candidate, kind)
+		if value == nil || entities.DictionaryKey(*value) != entities.DictionaryKey(from) {
+			continue
+		}
+		*value = to
+		repo.candidates[id] = candidate
+	}
+}
+
+// usesValue reports whether any candidate has the dictionary item.
+func (repo *CandidateRepo) usesValue(kind entities.DictionaryKind, name string) bool {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	for _, candidate := range repo.candidates {
+		value := candidateValue(&candidate, kind)
+		if value != nil && entities.DictionaryKey(*value) == entities.DictionaryKey(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type CardRepo struct {
+	mu     sync.RWMutex
+	cards  map[uuid.UUID]entities.Card
+	outbox *OutboxRepo
+}
+
+func NewCardRepo(outbox *OutboxRepo) *CardRepo {
+	return &CardRepo{
+		cards:  make(map[uuid.UUID]entities.Card),
+		outbox: outbox,
+	}
+}
+
+func (repo *CardRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Card, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	card, ok := repo.cards[id]
+	if !ok {
+		return nil, repos.ErrCardNotFound
+	}
+	card.Comments = append([]entities.Comment(nil), card.Comments...)
+	return &card, nil
+}
+
+func (repo *CardRepo) List(
+	ctx context.Context,
+	vacancyID uuid.UUID,
+) ([]entities.Card, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	cards := make([]entities.Card, 0, len(repo.cards))
+	for _, card := range repo.cards {
+		if vacancyID != uuid.Nil && card.VacancyID != vacancyID {
+			continue
+		}
+		card.Comments = nil
+		cards = append(cards, card)
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		return cards[i].Updated.After(cards[j].Updated)
+	})
+	return cards, nil
+}
+
+func (repo *CardRepo) ListByCandidate(
+	ctx context.Context,
+	candidateID uuid.UUID,
+) ([]entities.Card, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	cards := make([]entities.Card, 0)
+	for _, card := range repo.cards {
+		if card.CandidateID != candidateID {
+			continue
+		}
+		card.Comments = nil
+		cards = append(cards, card)
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		return cards[i].Updated.After(cards[j].Updated)
+	})
+	return cards, nil
+}
+
+func (repo *CardRepo) Create(ctx context.Context, card *entities.Card) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	card.ID = uuid.New()
+	if card.Stage == entities.CardStageNone {
+		card.Stage = entities.CardStageNew
+	}
+	card.Created = time.Now()
+	card.Updated = time.Now()
+	repo.cards[card.ID] = *card
+	return repo.outbox.publish(card.ID, entities.CardCreated{
+		CardID:      card.ID,
+		VacancyID:   card.VacancyID,
+		CandidateID: card.CandidateID,
+		Stage:       card.Stage,
+	})
+}
+
+func (repo *CardRepo) Move(
+	ctx context.Context,
+	id uuid.UUID,
+	stage entities.CardStage,
+) (*entities.Card, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	card, ok := repo.cards[id]
+	if !ok {
+		return nil, repos.ErrCardNotFound
+	}
+	from := card.Stage
+	card.Stage = stage
+	card.Updated = time.Now()
+	repo.cards[id] = card
+	card.Comments = append([]entities.Comment(nil), card.Comments...)
+	if from == stage {
+		return &card, nil
+	}
+	err := repo.outbox.publish(card.ID, entities.CardMoved{
+		CardID:      card.ID,
+		VacancyID:   card.VacancyID,
+		CandidateID: card.CandidateID,
+		From:        from,
+		To:          stage,
+	})
+	return &card, err
+}
+
+func (repo *CardRepo) AddComment(
+	ctx context.Context,
+	cardID uuid.UUID,
+	comment *entities.Comment,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	card, ok := repo.cards[cardID]
+	if !ok {
+		return repos.ErrCardNotFound
+	}
+	comment.ID = uuid.New()
+	comment.Created = time.Now()
+	card.Comments = append(card.Comments, *comment)
+	repo.cards[cardID] = card
+	return repo.outbox.publish(card.ID, entities.CommentAdded{
+		CardID:    card.ID,
+		VacancyID: card.VacancyID,
+		Comment:   *comment,
+	})
+}
+
+// reassign moves cards of the candidate to another one unless both have
+// cards on the same vacancy.
+func (repo *CardRepo) reassign(from, to uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	vacancies := make(map[uuid.UUID]bool)
+	for _, card := range repo.cards {
+		if card.CandidateID == to {
+			vacancies[card.VacancyID] = true
+		}
+	}
+	for _, card := range repo.cards {
+		if card.CandidateID == from && vacancies[card.VacancyID] {
+			return repos.ErrMergeConflict
+		}
+	}
+	for id, card := range repo.cards {
+		if card.CandidateID == from {
+			card.CandidateID = to
+			repo.cards[id] = card
+		}
+	}
+	return nil
+}
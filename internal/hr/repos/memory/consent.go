@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type ConsentRepo struct {
+	mu         sync.RWMutex
+	consents   map[uuid.UUID]entities.Consent
+	candidates *CandidateRepo
+}
+
+func NewConsentRepo() *ConsentRepo {
+	return &ConsentRepo{consents: make(map[uuid.UUID]entities.Consent)}
+}
+
+func (repo *ConsentRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Consent, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	consent, ok := repo.consents[id]
+	if !ok {
+		return nil, repos.ErrConsentNotFound
+	}
+	return &consent, nil
+}
+
+func (repo *ConsentRepo) List(
+	ctx context.Context,
+	candidateID uuid.UUID,
+) ([]entities.Consent, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	consents := make([]entities.Consent, 0)
+	for _, consent := range repo.consents {
+		if consent.CandidateID == candidateID {
+			consents = append(consents, consent)
+		}
+	}
+	sort.Slice(consents, func(i, j int) bool {
+		return consents[i].Given.Before(consents[j].Given)
+	})
+	return consents, nil
+}
+
+func (repo *ConsentRepo) Create(
+	ctx context.Context,
+	consent *entities.Consent,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	consent.ID = uuid.New()
+	consent.Created = time.Now()
+	repo.consents[consent.ID] = *consent
+	return nil
+}
+
+func (repo *ConsentRepo) Update(
+	ctx context.Context,
+	consent *entities.Consent,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored, ok := repo.consents[consent.ID]
+	if !ok {
+		return repos.ErrConsentNotFound
+	}
+	consent.CandidateID = stored.CandidateID
+	consent.Created = stored.Created
+	repo.consents[consent.ID] = *consent
+	return nil
+}
+
+// Expired skips anonymized candidates only if the candidate repo is linked
+// with LinkCandidates.
+func (repo *ConsentRepo) Expired(
+	ctx context.Context,
+	now time.Time,
+) ([]uuid.UUID, error) {
+	repo.mu.RLock()
+	active := make(map[uuid.UUID]bool)
+	for _, consent := range repo.consents {
+		active[consent.CandidateID] = active[consent.CandidateID] || consent.Active(now)
+	}
+	repo.mu.RUnlock()
+
+	ids := make([]uuid.UUID, 0)
+	for id, ok := range active {
+		if ok {
+			continue
+		}
+		if repo.candidates != nil {
+			candidate, err := repo.candidates.GetByID(ctx, id)
+			if err != nil || candidate.Anonymized != nil {
+				continue
+			}
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// LinkCandidates makes Expired skip anonymized candidates.
+func (repo *ConsentRepo) LinkCandidates(candidates *CandidateRepo) {
+	repo.candidates = candidates
+}
+
+// reassign moves consents of the candidate to another one.
+func (repo *ConsentRepo) reassign(from, to uuid.UUID) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, consent := range repo.consents {
+		if consent.CandidateID == from {
+			consent.CandidateID = to
+			repo.consents[id] = consent
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type DictionaryRepo struct {
+	mu         sync.RWMutex
+	items      map[uuid.UUID]entities.DictionaryItem
+	vacancies  *VacancyRepo
+	candidates *CandidateRepo
+}
+
+func NewDictionaryRepo() *DictionaryRepo {
+	return &DictionaryRepo{items: make(map[uuid.UUID]entities.DictionaryItem)}
+}
+
+func (repo *DictionaryRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.DictionaryItem, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	item, ok := repo.items[id]
+	if !ok {
+		return nil, repos.ErrDictionaryNotFound
+	}
+	return &item, nil
+}
+
+func (repo *DictionaryRepo) List(
+	ctx context.Context,
+	filter repos.DictionaryFilter,
+) ([]entities.DictionaryItem, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	prefix := entities.DictionaryKey(filter.Prefix)
+	items := make([]entities.DictionaryItem, 0)
+	for _, item := range repo.items {
+		if filter.Kind != entities.DictionaryKindNone && item.Kind != filter.Kind {
+			continue
+		}
+		if filter.ParentID != uuid.Nil && item.ParentID != filter.ParentID {
+			continue
+		}
+		if !strings.HasPrefix(entities.DictionaryKey(item.Name), prefix) {
+			continue
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Kind != items[j].Kind {
+			return items[i].Kind < items[j].Kind
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items, nil
+}
+
+func (repo *DictionaryRepo) Create(
+	ctx context.Context,
+	item *entities.DictionaryItem,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.taken(item) {
+		return repos.ErrDictionaryExists
+	}
+	item.ID = uuid.New()
+	item.Created = time.Now()
+	item.Updated = time.Now()
+	repo.items[item.ID] = *item
+	return nil
+}
+
+func (repo *DictionaryRepo) Update(
+	ctx context.Context,
+	item *entities.DictionaryItem,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	old, ok := repo.items[item.ID]
+	if !ok {
+		return repos.ErrDictionaryNotFound
+	}
+	if repo.taken(item) {
+		return repos.ErrDictionaryExists
+	}
+	item.Created = old.Created
+	item.Updated = time.Now()
+	repo.items[item.ID] = *item
+
+	if old.Name != item.Name && repo.vacancies != nil {
+		repo.vacancies.renameValue(item.Kind, old.Name, item.Name)
+	}
+	if old.Name != item.Name && repo.candidates != nil {
+		repo.candidates.renameValue(item.Kind, old.Name, item.Name)
+	}
+	return nil
+}
+
+// taken reports whether another item of the dictionary has the name of the
+// item.
+func (repo *DictionaryRepo) taken(item *entities.DictionaryItem) bool {
+	key := entities.DictionaryKey(item.Name)
+	for id, other := range repo.items {
+		if id != item.ID && other.Kind == item.Kind && entities.DictionaryKey(other.Name) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (repo *DictionaryRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	item, ok := repo.items[id]
+	if !ok {
+		return repos.ErrDictionaryNotFound
+	}
+	for _, other := range repo.items {
+		if other.ParentID == id {
+			return repos.ErrDictionaryInUse
+		}
+	}
+	if repo.vacancies != nil && repo.vacancies.usesValue(item.Kind, item.Name) {
+		return repos.ErrDictionaryInUse
+	}
+	if repo.candidates != nil && repo.candidates.usesValue(item.Kind, item.Name) {
+		return repos.ErrDictionaryInUse
+	}
+	delete(repo.items, id)
+	return nil
+}
+
+// LinkUses makes Update rename values of vacancies and candidates and
+// Delete keep the items they use.
+func (repo *DictionaryRepo) LinkUses(vacancies *VacancyRepo, candidates *CandidateRepo) {
+	repo.vacancies = vacancies
+	repo.candidates = candidates
+}
+
+// vacancyValue returns the field of the vacancy holding values of the
+// dictionary, nil if there is none.
+func vacancyValue(vacancy *entities.Vacancy, kind entities.DictionaryKind) *string {
+	switch kind {
+	case entities.DictionaryKindArea:
+		return &vacancy.Area
+	case entities.DictionaryKindDepartment:
+		return &vacancy.Department
+	}
+	return nil
+}
+
+// candidateValue returns the field of the candidate holding values of the
+// dictionary, nil if there is none.
+func candidateValue(candidate *entities.Candidate, kind entities.DictionaryKind) *string {
+	switch kind {
+	case entities.DictionaryKindArea:
+		return &candidate.Area
+	case entities.DictionaryKindSpecialization:
+		return &candidate.Specialization
+	}
+	return nil
+}
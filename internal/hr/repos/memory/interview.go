@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type InterviewRepo struct {
+	mu sync.RWMutex
+	// hold serializes holds of all interviewers.
+	hold       sync.Mutex
+	interviews map[uuid.UUID]entities.Interview
+}
+
+func NewInterviewRepo() *InterviewRepo {
+	return &InterviewRepo{interviews: make(map[uuid.UUID]entities.Interview)}
+}
+
+func (repo *InterviewRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Interview, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	interview, ok := repo.interviews[id]
+	if !ok {
+		return nil, repos.ErrInterviewNotFound
+	}
+	interview.Interviewers = append([]string(nil), interview.Interviewers...)
+	return &interview, nil
+}
+
+func (repo *InterviewRepo) List(
+	ctx context.Context,
+	filter repos.InterviewFilter,
+) ([]entities.Interview, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	interviews := make([]entities.Interview, 0, len(repo.interviews))
+	for _, interview := range repo.interviews {
+		if filter.CardID != uuid.Nil && interview.CardID != filter.CardID {
+			continue
+		}
+		if filter.Interviewer != "" && !contains(interview.Interviewers, filter.Interviewer) {
+			continue
+		}
+		if !filter.From.IsZero() && !interview.End.After(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && !interview.Start.Before(filter.To) {
+			continue
+		}
+		interview.Interviewers = append([]string(nil), interview.Interviewers...)
+		interviews = append(interviews, interview)
+	}
+	sort.Slice(interviews, func(i, j int) bool {
+		return interviews[i].Start.Before(interviews[j].Start)
+	})
+	return interviews, nil
+}
+
+func (repo *InterviewRepo) Create(
+	ctx context.Context,
+	interview *entities.Interview,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	interview.ID = uuid.New()
+	interview.Sequence = 0
+	interview.Created = time.Now()
+	interview.Updated = time.Now()
+	interview.Interviewers = append([]string(nil), interview.Interviewers...)
+	repo.interviews[interview.ID] = *interview
+	return nil
+}
+
+func (repo *InterviewRepo) Update(
+	ctx context.Context,
+	interview *entities.Interview,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	prev, ok := repo.interviews[interview.ID]
+	if !ok {
+		return repos.ErrInterviewNotFound
+	}
+	interview.CardID = prev.CardID
+	interview.Sequence = prev.Sequence + 1
+	interview.Created = prev.Created
+	interview.Updated = time.Now()
+	interview.Interviewers = append([]string(nil), interview.Interviewers...)
+	repo.interviews[interview.ID] = *interview
+	return nil
+}
+
+func (repo *InterviewRepo) Hold(
+	ctx context.Context,
+	interviewers []string,
+	fn func(context.Context) error,
+) error {
+	repo.hold.Lock()
+	defer repo.hold.Unlock()
+
+	return fn(ctx)
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
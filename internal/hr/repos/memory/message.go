@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type MessageRepo struct {
+	mu       sync.RWMutex
+	messages map[string]entities.Message
+}
+
+func NewMessageRepo() *MessageRepo {
+	return &MessageRepo{messages: make(map[string]entities.Message)}
+}
+
+func (repo *MessageRepo) GetByMessageID(
+	ctx context.Context,
+	messageID string,
+) (*entities.Message, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	message, ok := repo.messages[messageID]
+	if !ok {
+		return nil, repos.ErrMessageNotFound
+	}
+	return &message, nil
+}
+
+func (repo *MessageRepo) List(
+	ctx context.Context,
+	candidateID uuid.UUID,
+) ([]entities.Message, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	messages := make([]entities.Message, 0)
+	for _, message := range repo.messages {
+		if message.CandidateID == candidateID {
+			messages = append(messages, message)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Created.Before(messages[j].Created)
+	})
+	return messages, nil
+}
+
+func (repo *MessageRepo) Create(
+	ctx context.Context,
+	message *entities.Message,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.messages[message.MessageID]; ok {
+		return repos.ErrMessageExists
+	}
+	message.ID = uuid.New()
+	message.Created = time.Now()
+	repo.messages[message.MessageID] = *message
+	return nil
+}
+
+func (repo *MessageRepo) Update(
+	ctx context.Context,
+	message *entities.Message,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored, ok := repo.messages[message.MessageID]
+	if !ok || stored.ID != message.ID {
+		return repos.ErrMessageNotFound
+	}
+	repo.messages[message.MessageID] = *message
+	return nil
+}
+
+func (repo *MessageRepo) Claim(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit int,
+) ([]entities.Message, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	messages := make([]entities.Message, 0, limit)
+	for _, message := range repo.messages {
+		if message.Status != entities.MessageStatusPending || message.NextAttempt.After(now) {
+			continue
+		}
+		messages = append(messages, message)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].NextAttempt.Before(messages[j].NextAttempt)
+	})
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+	for i := range messages {
+		messages[i].NextAttempt = now.Add(lease)
+		repo.messages[messages[i].MessageID] = messages[i]
+	}
+	return messages, nil
+}
+
+func (repo *MessageRepo) Erase(ctx context.Context, candidateID uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, message := range repo.messages {
+		if message.CandidateID == candidateID {
+			message.Erase()
+			repo.messages[id] = message
+		}
+	}
+	return nil
+}
+
+// reassign moves messages of the candidate to another one.
+func (repo *MessageRepo) reassign(from, to uuid.UUID) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, message := range repo.messages {
+		if message.CandidateID == from {
+			message.CandidateID = to
+			repo.messages[id] = message
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type OfferRepo struct {
+	mu     sync.RWMutex
+	offers map[uuid.UUID]entities.Offer
+}
+
+func NewOfferRepo() *OfferRepo {
+	return &OfferRepo{offers: make(map[uuid.UUID]entities.Offer)}
+}
+
+func copyOffer(offer entities.Offer) entities.Offer {
+	offer.Approvals = append([]entities.Approval(nil), offer.Approvals...)
+	offer.Warnings = append([]string{}, offer.Warnings...)
+	return offer
+}
+
+func (repo *OfferRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Offer, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	offer, ok := repo.offers[id]
+	if !ok {
+		return nil, repos.ErrOfferNotFound
+	}
+	offer = copyOffer(offer)
+	return &offer, nil
+}
+
+func (repo *OfferRepo) List(
+	ctx context.Context,
+	cardID uuid.UUID,
+) ([]entities.Offer, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	offers := make([]entities.Offer, 0, len(repo.offers))
+	for _, offer := range repo.offers {
+		if cardID != uuid.Nil && offer.CardID != cardID {
+			continue
+		}
+		offers = append(offers, copyOffer(offer))
+	}
+	sort.Slice(offers, func(i, j int) bool {
+		return offers[i].Created.Before(offers[j].Created)
+	})
+	return offers, nil
+}
+
+func (repo *OfferRepo) Create(
+	ctx context.Context,
+	offer *entities.Offer,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	offer.ID = uuid.New()
+	offer.Created = time.Now()
+	offer.Updated = time.Now()
+	repo.offers[offer.ID] = copyOffer(*offer)
+	return nil
+}
+
+func (repo *OfferRepo) Update(
+	ctx context.Context,
+	offer *entities.Offer,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored, ok := repo.offers[offer.ID]
+	if !ok {
+		return repos.ErrOfferNotFound
+	}
+	offer.Created = stored.Created
+	offer.Updated = time.Now()
+	repo.offers[offer.ID] = copyOffer(*offer)
+	return nil
+}
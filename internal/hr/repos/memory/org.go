@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type OrgRepo struct {
+	mu       sync.RWMutex
+	managers map[uuid.UUID][]string
+}
+
+func NewOrgRepo() *OrgRepo {
+	return &OrgRepo{managers: make(map[uuid.UUID][]string)}
+}
+
+func (repo *OrgRepo) Managers(ctx context.Context) (map[uuid.UUID][]string, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	managers := make(map[uuid.UUID][]string, len(repo.managers))
+	for id, emails := range repo.managers {
+		managers[id] = append([]string{}, emails...)
+	}
+	return managers, nil
+}
+
+func (repo *OrgRepo) SetManagers(
+	ctx context.Context,
+	departmentID uuid.UUID,
+	managers []string,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if len(managers) == 0 {
+		delete(repo.managers, departmentID)
+		return nil
+	}
+	repo.managers[departmentID] = append([]string{}, managers...)
+	return nil
+}
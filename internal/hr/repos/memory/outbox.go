@@ -0,0 +1,152 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+type OutboxRepo struct {
+	mu        sync.Mutex
+	events    []entities.Event
+	positions map[string]int64
+	consuming map[string]bool
+}
+
+func NewOutboxRepo() *OutboxRepo {
+	return &OutboxRepo{
+		positions: make(map[string]int64),
+		consuming: make(map[string]bool),
+	}
+}
+
+// publish appends events of the given payloads to the outbox. It is a no-op
+// for repositories created without outbox.
+func (repo *OutboxRepo) publish(
+	aggregateID uuid.UUID,
+	payloads ...entities.EventPayload,
+) error {
+	if repo == nil {
+		return nil
+	}
+
+	events := make([]entities.Event, 0, len(payloads))
+	for _, payload := range payloads {
+		event, err := entities.NewEvent(aggregateID, payload)
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, event := range events {
+		event.Seq = int64(len(repo.events) + 1)
+		repo.events = append(repo.events, event)
+	}
+	return nil
+}
+
+// redact redacts events of the given aggregates and returns them. It is a
+// no-op for repositories created without outbox.
+func (repo *OutboxRepo) redact(aggregates map[uuid.UUID]bool) ([]entities.Event, error) {
+	if repo == nil {
+		return nil, nil
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var redacted []entities.Event
+	for i, event := range repo.events {
+		if !aggregates[event.AggregateID] {
+			continue
+		}
+		err := event.Redact()
+		if err != nil {
+			return nil, err
+		}
+		repo.events[i] = event
+		redacted = append(redacted, event)
+	}
+	return redacted, nil
+}
+
+// Events returns all events written to the outbox.
+func (repo *OutboxRepo) Events() []entities.Event {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return append([]entities.Event(nil), repo.events...)
+}
+
+func (repo *OutboxRepo) Consume(
+	ctx context.Context,
+	consumer string,
+	limit int,
+	fn func(context.Context, entities.Event) error,
+) (int, error) {
+	repo.mu.Lock()
+	if repo.consuming[consumer] {
+		repo.mu.Unlock()
+		return 0, nil
+	}
+	repo.consuming[consumer] = true
+	position := repo.positions[consumer]
+	end := position + int64(limit)
+	if end > int64(len(repo.events)) {
+		end = int64(len(repo.events))
+	}
+	events := append([]entities.Event(nil), repo.events[position:end]...)
+	repo.mu.Unlock()
+
+	n := 0
+	var err error
+	for _, event := range events {
+		err = fn(ctx, event)
+		if err != nil {
+			break
+		}
+		n++
+	}
+
+	repo.mu.Lock()
+	repo.positions[consumer] = position + int64(n)
+	repo.consuming[consumer] = false
+	repo.mu.Unlock()
+
+	return n, err
+}
+
+func (repo *OutboxRepo) List(
+	ctx context.Context,
+	after int64,
+	limit int,
+) ([]entities.Event, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if after < 0 {
+		after = 0
+	}
+	if after > int64(len(repo.events)) {
+		return nil, nil
+	}
+	end := after + int64(limit)
+	if end > int64(len(repo.events)) {
+		end = int64(len(repo.events))
+	}
+	return append([]entities.Event(nil), repo.events[after:end]...), nil
+}
+
+func (repo *OutboxRepo) Last(ctx context.Context) (int64, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return int64(len(repo.events)), nil
+}
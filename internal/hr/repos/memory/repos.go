@@ -0,0 +1,79 @@
+// Package memory implements hr repositories on top of in-process maps. It is
+// meant for tests and local experiments where postgres is not available.
+package memory
+
+import "gpb.ru/hr/internal/hr/repos"
+
+type Memory struct {
+	Candidate   *CandidateRepo
+	Vacancy     *VacancyRepo
+	Card        *CardRepo
+	Outbox      *OutboxRepo
+	Webhook     *WebhookRepo
+	Delivery    *DeliveryRepo
+	Interview   *InterviewRepo
+	Scorecard   *ScorecardRepo
+	Offer       *OfferRepo
+	Resume      *ResumeRepo
+	Attachment  *AttachmentRepo
+	Consent     *ConsentRepo
+	Message     *MessageRepo
+	Skill       *SkillRepo
+	Dictionary  *DictionaryRepo
+	Org         *OrgRepo
+	Requisition *RequisitionRepo
+}
+
+func New() *Memory {
+	outbox := NewOutboxRepo()
+	mem := &Memory{
+		Candidate:   NewCandidateRepo(outbox),
+		Vacancy:     NewVacancyRepo(outbox),
+		Card:        NewCardRepo(outbox),
+		Outbox:      outbox,
+		Webhook:     NewWebhookRepo(),
+		Delivery:    NewDeliveryRepo(),
+		Interview:   NewInterviewRepo(),
+		Scorecard:   NewScorecardRepo(),
+		Offer:       NewOfferRepo(),
+		Resume:      NewResumeRepo(),
+		Attachment:  NewAttachmentRepo(),
+		Consent:     NewConsentRepo(),
+		Message:     NewMessageRepo(),
+		Skill:       NewSkillRepo(),
+		Dictionary:  NewDictionaryRepo(),
+		Org:         NewOrgRepo(),
+		Requisition: NewRequisitionRepo(),
+	}
+	mem.Candidate.LinkCards(mem.Card)
+	mem.Candidate.LinkMerged(mem.Attachment, mem.Resume, mem.Consent, mem.Message)
+	mem.Candidate.LinkDeliveries(mem.Delivery)
+	mem.Consent.LinkCandidates(mem.Candidate)
+	mem.Skill.LinkUses(mem.Vacancy, mem.Candidate)
+	mem.Dictionary.LinkUses(mem.Vacancy, mem.Candidate)
+	mem.Requisition.LinkHires(mem.Vacancy, mem.Card)
+	return mem
+}
+
+// Repos returns the repositories as a set services are built on.
+func (mem *Memory) Repos() repos.Repos {
+	return repos.Repos{
+		Candidate:   mem.Candidate,
+		Vacancy:     mem.Vacancy,
+		Card:        mem.Card,
+		Outbox:      mem.Outbox,
+		Webhook:     mem.Webhook,
+		Delivery:    mem.Delivery,
+		Interview:   mem.Interview,
+		Scorecard:   mem.Scorecard,
+		Offer:       mem.Offer,
+		Resume:      mem.Resume,
+		Attachment:  mem.Attachment,
+		Consent:     mem.Consent,
+		Message:     mem.Message,
+		Skill:       mem.Skill,
+		Dictionary:  mem.Dictionary,
+		Org:         mem.Org,
+		Requisition: mem.Requisition,
+	}
+}
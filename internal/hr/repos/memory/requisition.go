@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type RequisitionRepo struct {
+	mu           sync.RWMutex
+	requisitions map[uuid.UUID]entities.Requisition
+	// hold serializes holds of all requisitions.
+	hold      sync.Mutex
+	vacancies *VacancyRepo
+	cards     *CardRepo
+}
+
+func NewRequisitionRepo() *RequisitionRepo {
+	return &RequisitionRepo{requisitions: make(map[uuid.UUID]entities.Requisition)}
+}
+
+func copyRequisition(r entities.Requisition) entities.Requisition {
+	r.Approvals = append([]entities.Approval(nil), r.Approvals...)
+	return r
+}
+
+func (repo *RequisitionRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Requisition, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	r, ok := repo.requisitions[id]
+	if !ok {
+		return nil, repos.ErrRequisitionNotFound
+	}
+	r = copyRequisition(r)
+	return &r, nil
+}
+
+func (repo *RequisitionRepo) List(
+	ctx context.Context,
+	filter repos.RequisitionFilter,
+) ([]entities.Requisition, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	requisitions := make([]entities.Requisition, 0, len(repo.requisitions))
+	for _, r := range repo.requisitions {
+		if filter.Status != entities.RequisitionStatusNone && r.Status != filter.Status {
+			continue
+		}
+		if filter.Requester != "" && r.Requester != filter.Requester {
+			continue
+		}
+		requisitions = append(requisitions, copyRequisition(r))
+	}
+	sort.Slice(requisitions, func(i, j int) bool {
+		return requisitions[i].Created.After(requisitions[j].Created)
+	})
+	return requisitions, nil
+}
+
+func (repo *RequisitionRepo) Create(
+	ctx context.Context,
+	r *entities.Requisition,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	r.ID = uuid.New()
+	r.Created = time.Now()
+	r.Updated = time.Now()
+	repo.requisitions[r.ID] = copyRequisition(*r)
+	return nil
+}
+
+func (repo *RequisitionRepo) Update(
+	ctx context.Context,
+	r *entities.Requisition,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored, ok := repo.requisitions[r.ID]
+	if !ok {
+		return repos.ErrRequisitionNotFound
+	}
+	r.Created = stored.Created
+	r.Updated = time.Now()
+	repo.requisitions[r.ID] = copyRequisition(*r)
+	return nil
+}
+
+// LinkHires makes Filled and Hired count cards of the vacancies.
+func (repo *RequisitionRepo) LinkHires(vacancies *VacancyRepo, cards *CardRepo) {
+	repo.vacancies = vacancies
+	repo.cards = cards
+}
+
+func (repo *RequisitionRepo) Filled(ctx context.Context) (map[uuid.UUID]int, error) {
+	filled := make(map[uuid.UUID]int)
+	if repo.vacancies == nil || repo.cards == nil {
+		return filled, nil
+	}
+	requisitions := make(map[uuid.UUID]uuid.UUID)
+	repo.vacancies.mu.RLock()
+	for _, vacancy := range repo.vacancies.vacancies {
+		if vacancy.RequisitionID != uuid.Nil {
+			requisitions[vacancy.ID] = vacancy.RequisitionID
+		}
+	}
+	repo.vacancies.mu.RUnlock()
+
+	repo.cards.mu.RLock()
+	defer repo.cards.mu.RUnlock()
+	for _, card := range repo.cards.cards {
+		id, ok := requisitions[card.VacancyID]
+		if ok && card.Stage == entities.CardStageHired {
+			filled[id]++
+		}
+	}
+	return filled, nil
+}
+
+func (repo *RequisitionRepo) Hired(ctx context.Context, id uuid.UUID) (int, error) {
+	filled, err := repo.Filled(ctx)
+	return filled[id], err
+}
+
+func (repo *RequisitionRepo) Hold(
+	ctx context.Context,
+	id uuid.UUID,
+	fn func(context.Context) error,
+) error {
+	repo.hold.Lock()
+	defer repo.hold.Unlock()
+
+	_, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return fn(ctx)
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type ResumeRepo struct {
+	mu      sync.RWMutex
+	resumes map[uuid.UUID]entities.Resume
+	data    map[uuid.UUID][]byte
+}
+
+func NewResumeRepo() *ResumeRepo {
+	return &ResumeRepo{
+		resumes: make(map[uuid.UUID]entities.Resume),
+		data:    make(map[uuid.UUID][]byte),
+	}
+}
+
+func (repo *ResumeRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Resume, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	resume, ok := repo.resumes[id]
+	if !ok {
+		return nil, repos.ErrResumeNotFound
+	}
+	return &resume, nil
+}
+
+func (repo *ResumeRepo) Data(
+	ctx context.Context,
+	id uuid.UUID,
+) ([]byte, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	data, ok := repo.data[id]
+	if !ok {
+		return nil, repos.ErrResumeNotFound
+	}
+	return data, nil
+}
+
+func (repo *ResumeRepo) Create(
+	ctx context.Context,
+	resume *entities.Resume,
+	data []byte,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	resume.ID = uuid.New()
+	resume.Created = time.Now()
+	resume.Updated = time.Now()
+	repo.resumes[resume.ID] = *resume
+	repo.data[resume.ID] = append([]byte(nil), data...)
+	return nil
+}
+
+func (repo *ResumeRepo) Update(
+	ctx context.Context,
+	resume *entities.Resume,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored, ok := repo.resumes[resume.ID]
+	if !ok {
+		return repos.ErrResumeNotFound
+	}
+	resume.Created = stored.Created
+	resume.Updated = time.Now()
+	repo.resumes[resume.ID] = *resume
+	return nil
+}
+
+func (repo *ResumeRepo) List(
+	ctx context.Context,
+	candidateID uuid.UUID,
+) ([]entities.Resume, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	resumes := make([]entities.Resume, 0)
+	for _, resume := range repo.resumes {
+		if resume.CandidateID == candidateID {
+			resumes = append(resumes, resume)
+		}
+	}
+	sort.Slice(resumes, func(i, j int) bool {
+		return resumes[i].Created.Before(resumes[j].Created)
+	})
+	return resumes, nil
+}
+
+func (repo *ResumeRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.resumes[id]; !ok {
+		return repos.ErrResumeNotFound
+	}
+	delete(repo.resumes, id)
+	delete(repo.data, id)
+	return nil
+}
+
+// reassign moves resumes of the candidate to another one.
+func (repo *ResumeRepo) reassign(from, to uuid.UUID) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, resume := range repo.resumes {
+		if resume.CandidateID == from {
+			resume.CandidateID = to
+			repo.resumes[id] = resume
+		}
+	}
+}
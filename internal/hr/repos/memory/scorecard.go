@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type ScorecardRepo struct {
+	mu         sync.RWMutex
+	scorecards map[uuid.UUID]entities.Scorecard
+}
+
+func NewScorecardRepo() *ScorecardRepo {
+	return &ScorecardRepo{scorecards: make(map[uuid.UUID]entities.Scorecard)}
+}
+
+func (repo *ScorecardRepo) List(
+	ctx context.Context,
+	filter repos.ScorecardFilter,
+) ([]entities.Scorecard, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	scorecards := make([]entities.Scorecard, 0, len(repo.scorecards))
+	for _, scorecard := range repo.scorecards {
+		if filter.InterviewID != uuid.Nil && scorecard.InterviewID != filter.InterviewID {
+			continue
+		}
+		if filter.CardID != uuid.Nil && scorecard.CardID != filter.CardID {
+			continue
+		}
+		scorecard.Ratings = append([]entities.Rating(nil), scorecard.Ratings...)
+		scorecards = append(scorecards, scorecard)
+	}
+	sort.Slice(scorecards, func(i, j int) bool {
+		return scorecards[i].Created.Before(scorecards[j].Created)
+	})
+	return scorecards, nil
+}
+
+func (repo *ScorecardRepo) Save(
+	ctx context.Context,
+	scorecard *entities.Scorecard,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	scorecard.ID = uuid.New()
+	scorecard.Created = time.Now()
+	for _, v := range repo.scorecards {
+		if v.InterviewID == scorecard.InterviewID && v.Interviewer == scorecard.Interviewer {
+			scorecard.ID = v.ID
+			scorecard.Created = v.Created
+		}
+	}
+	scorecard.Updated = time.Now()
+	scorecard.Ratings = append([]entities.Rating(nil), scorecard.Ratings...)
+	repo.scorecards[scorecard.ID] = *scorecard
+	return nil
+}
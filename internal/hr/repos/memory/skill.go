@@ -0,0 +1,201 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type SkillRepo struct {
+	mu         sync.RWMutex
+	skills     map[uuid.UUID]entities.CatalogSkill
+	vacancies  *VacancyRepo
+	candidates *CandidateRepo
+}
+
+func NewSkillRepo() *SkillRepo {
+	return &SkillRepo{skills: make(map[uuid.UUID]entities.CatalogSkill)}
+}
+
+func (repo *SkillRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.CatalogSkill, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	skill, ok := repo.skills[id]
+	if !ok {
+		return nil, repos.ErrSkillNotFound
+	}
+	return &skill, nil
+}
+
+func (repo *SkillRepo) List(
+	ctx context.Context,
+	filter repos.SkillFilter,
+) ([]entities.CatalogSkill, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	prefix := entities.SkillKey(filter.Prefix)
+	skills := make([]entities.CatalogSkill, 0)
+	for _, skill := range repo.skills {
+		if filter.Category != "" && skill.Category != filter.Category {
+			continue
+		}
+		if prefix != "" && !hasKeyPrefix(&skill, prefix) {
+			continue
+		}
+		skills = append(skills, skill)
+	}
+	sort.Slice(skills, func(i, j int) bool {
+		return skills[i].Name < skills[j].Name
+	})
+	return skills, nil
+}
+
+func hasKeyPrefix(skill *entities.CatalogSkill, prefix string) bool {
+	for _, key := range skill.Keys() {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (repo *SkillRepo) Create(
+	ctx context.Context,
+	skill *entities.CatalogSkill,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.taken(skill) {
+		return repos.ErrSkillExists
+	}
+	skill.ID = uuid.New()
+	skill.Created = time.Now()
+	skill.Updated = time.Now()
+	repo.skills[skill.ID] = *skill
+	return nil
+}
+
+func (repo *SkillRepo) Update(
+	ctx context.Context,
+	skill *entities.CatalogSkill,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	old, ok := repo.skills[skill.ID]
+	if !ok {
+		return repos.ErrSkillNotFound
+	}
+	if repo.taken(skill, skill.ID) {
+		return repos.ErrSkillExists
+	}
+	skill.Created = old.Created
+	skill.Updated = time.Now()
+	repo.skills[skill.ID] = *skill
+	return nil
+}
+
+// taken reports whether a name or a synonym of the skill belongs to another
+// skill, the ignored ones aside.
+func (repo *SkillRepo) taken(skill *entities.CatalogSkill, ignore ...uuid.UUID) bool {
+	keys := make(map[string]bool)
+	for _, key := range skill.Keys() {
+		keys[key] = true
+	}
+	for id, other := range repo.skills {
+		if containsID(ignore, id) {
+			continue
+		}
+		for _, key := range other.Keys() {
+			if keys[key] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (repo *SkillRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.skills[id]; !ok {
+		return repos.ErrSkillNotFound
+	}
+	delete(repo.skills, id)
+	return nil
+}
+
+func (repo *SkillRepo) Merge(
+	ctx context.Context,
+	skill *entities.CatalogSkill,
+	duplicateID uuid.UUID,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	old, ok := repo.skills[skill.ID]
+	if !ok {
+		return repos.ErrSkillNotFound
+	}
+	if _, ok := repo.skills[duplicateID]; !ok {
+		return repos.ErrSkillNotFound
+	}
+	if repo.taken(skill, skill.ID, duplicateID) {
+		return repos.ErrSkillExists
+	}
+	delete(repo.skills, duplicateID)
+	skill.Created = old.Created
+	skill.Updated = time.Now()
+	repo.skills[skill.ID] = *skill
+
+	if repo.vacancies != nil {
+		repo.vacancies.renameSkill(skill)
+	}
+	if repo.candidates != nil {
+		repo.candidates.renameSkill(skill)
+	}
+	return nil
+}
+
+// LinkUses makes Merge rename skills of vacancies and candidates.
+func (repo *SkillRepo) LinkUses(vacancies *VacancyRepo, candidates *CandidateRepo) {
+	repo.vacancies = vacancies
+	repo.candidates = candidates
+}
+
+// usesSkill reports whether any of the titles is a name or a synonym of the
+// skill.
+func usesSkill(skill *entities.CatalogSkill, titles []string) bool {
+	keys := skill.Keys()
+	for _, title := range titles {
+		for _, key := range keys {
+			if entities.SkillKey(title) == key {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type VacancyRepo struct {
+	mu        sync.RWMutex
+	vacancies map[uuid.UUID]entities.Vacancy
+	outbox    *OutboxRepo
+}
+
+func NewVacancyRepo(outbox *OutboxRepo) *VacancyRepo {
+	return &VacancyRepo{
+		vacancies: make(map[uuid.UUID]entities.Vacancy),
+		outbox:    outbox,
+	}
+}
+
+func (repo *VacancyRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Vacancy, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	vacancy, ok := repo.vacancies[id]
+	if !ok {
+		return nil, repos.ErrVacancyNotFound
+	}
+	return &vacancy, nil
+}
+
+func (repo *VacancyRepo) List(ctx context.Context) ([]entities.Vacancy, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	vacancies := make([]entities.Vacancy, 0, len(repo.vacancies))
+	for _, vacancy := range repo.vacancies {
+		vacancies = append(vacancies, vacancy)
+	}
+	sort.Slice(vacancies, func(i, j int) bool {
+		return vacancies[i].Updated.After(vacancies[j].Updated)
+	})
+	return vacancies, nil
+}
+
+func (repo *VacancyRepo) Create(
+	ctx context.Context,
+	vacancy *entities.Vacancy,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	vacancy.ID = uuid.New()
+	vacancy.Created = time.Now()
+	vacancy.Updated = time.Now()
+	repo.vacancies[vacancy.ID] = *vacancy
+	return repo.outbox.publish(vacancy.ID, entities.VacancyCreated{Vacancy: *vacancy})
+}
+
+func (repo *VacancyRepo) Update(
+	ctx context.Context,
+	vacancy *entities.Vacancy,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	old, ok := repo.vacancies[vacancy.ID]
+	if !ok {
+		return repos.ErrVacancyNotFound
+	}
+	vacancy.Created = old.Created
+	vacancy.Updated = time.Now()
+	repo.vacancies[vacancy.ID] = *vacancy
+	payloads := []entities.EventPayload{entities.VacancyUpdated{Vacancy: *vacancy}}
+	if old.Status != vacancy.Status {
+		payloads = append(payloads, entities.VacancyStatusChanged{
+			VacancyID: vacancy.ID,
+			From:      old.Status,
+			To:        vacancy.Status,
+		})
+	}
+	return repo.outbox.publish(vacancy.ID, payloads...)
+}
+
+// renameSkill gives vacancies the catalog name of the skill in place of its
+// other spellings.
+func (repo *VacancyRepo) renameSkill(skill *entities.CatalogSkill) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	index := entities.NewSkillIndex([]entities.CatalogSkill{*skill})
+	for id, vacancy := range repo.vacancies {
+		titles := make([]string, 0, len(vacancy.Skills))
+		for _, s := range vacancy.Skills {
+			titles = append(titles, s.Title)
+		}
+		if !usesSkill(skill, titles) {
+			continue
+		}
+		vacancy.Skills = append([]entities.Skill(nil), vacancy.Skills...)
+		vacancy.NormalizeSkills(index)
+		repo.vacancies[id] = vacancy
+	}
+}
+
+// renameValue gives vacancies the new name of the dictionary item.
+func (repo *VacancyRepo) renameValue(kind entities.DictionaryKind, from, to string) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, vacancy := range repo.vacancies {
+		value := vacancyValue(&vacancy, kind)
+		if value == nil || entities.DictionaryKey(*value) != entities.DictionaryKey(from) {
+			continue
+		}
+		*value = to
+		repo.vacancies[id] = vacancy
+	}
+}
+
+// usesValue reports whether any vacancy has the dictionary item.
+func (repo *VacancyRepo) usesValue(kind entities.DictionaryKind, name string) bool {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	for _, vacancy := range repo.vacancies {
+		value := vacancyValue(&vacancy, kind)
+		if value != nil && entities.DictionaryKey(*value) == entities.DictionaryKey(name) {
+			return true
+		}
+	}
+	return false
+}
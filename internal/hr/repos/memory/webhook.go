@@ -0,0 +1,228 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type WebhookRepo struct {
+	mu       sync.RWMutex
+	webhooks map[uuid.UUID]entities.Webhook
+}
+
+func NewWebhookRepo() *WebhookRepo {
+	return &WebhookRepo{webhooks: make(map[uuid.UUID]entities.Webhook)}
+}
+
+func (repo *WebhookRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Webhook, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	webhook, ok := repo.webhooks[id]
+	if !ok {
+		return nil, repos.ErrWebhookNotFound
+	}
+	webhook.Events = append([]entities.EventType(nil), webhook.Events...)
+	return &webhook, nil
+}
+
+func (repo *WebhookRepo) List(ctx context.Context) ([]entities.Webhook, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	webhooks := make([]entities.Webhook, 0, len(repo.webhooks))
+	for _, webhook := range repo.webhooks {
+		webhook.Events = append([]entities.EventType(nil), webhook.Events...)
+		webhooks = append(webhooks, webhook)
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].Created.Before(webhooks[j].Created)
+	})
+	return webhooks, nil
+}
+
+func (repo *WebhookRepo) Create(
+	ctx context.Context,
+	webhook *entities.Webhook,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	webhook.ID = uuid.New()
+	webhook.Created = time.Now()
+	webhook.Updated = time.Now()
+	repo.webhooks[webhook.ID] = *webhook
+	return nil
+}
+
+func (repo *WebhookRepo) Update(
+	ctx context.Context,
+	webhook *entities.Webhook,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	prev, ok := repo.webhooks[webhook.ID]
+	if !ok {
+		return repos.ErrWebhookNotFound
+	}
+	webhook.Created = prev.Created
+	webhook.Updated = time.Now()
+	repo.webhooks[webhook.ID] = *webhook
+	return nil
+}
+
+func (repo *WebhookRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.webhooks[id]; !ok {
+		return repos.ErrWebhookNotFound
+	}
+	delete(repo.webhooks, id)
+	return nil
+}
+
+type DeliveryRepo struct {
+	mu         sync.RWMutex
+	deliveries map[uuid.UUID]entities.Delivery
+}
+
+func NewDeliveryRepo() *DeliveryRepo {
+	return &DeliveryRepo{deliveries: make(map[uuid.UUID]entities.Delivery)}
+}
+
+func (repo *DeliveryRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Delivery, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	delivery, ok := repo.deliveries[id]
+	if !ok {
+		return nil, repos.ErrDeliveryNotFound
+	}
+	return &delivery, nil
+}
+
+func (repo *DeliveryRepo) List(
+	ctx context.Context,
+	webhookID uuid.UUID,
+) ([]entities.Delivery, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	deliveries := make([]entities.Delivery, 0, len(repo.deliveries))
+	for _, delivery := range repo.deliveries {
+		if delivery.WebhookID != webhookID {
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].Created.After(deliveries[j].Created)
+	})
+	return deliveries, nil
+}
+
+func (repo *DeliveryRepo) Create(
+	ctx context.Context,
+	delivery *entities.Delivery,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, v := range repo.deliveries {
+		if v.WebhookID == delivery.WebhookID && v.EventID == delivery.EventID {
+			return nil
+		}
+	}
+	delivery.ID = uuid.New()
+	delivery.Created = time.Now()
+	delivery.Updated = time.Now()
+	repo.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (repo *DeliveryRepo) Update(
+	ctx context.Context,
+	delivery *entities.Delivery,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	prev, ok := repo.deliveries[delivery.ID]
+	if !ok {
+		return repos.ErrDeliveryNotFound
+	}
+	prev.Status = delivery.Status
+	prev.Attempts = delivery.Attempts
+	prev.NextAttempt = delivery.NextAttempt
+	prev.ResponseCode = delivery.ResponseCode
+	prev.LastError = delivery.LastError
+	prev.Updated = time.Now()
+	delivery.Updated = prev.Updated
+	repo.deliveries[delivery.ID] = prev
+	return nil
+}
+
+// redact replaces payloads of deliveries of the events with the redacted
+// ones and clears their errors.
+func (repo *DeliveryRepo) redact(events []entities.Event) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	payloads := make(map[uuid.UUID][]byte, len(events))
+	for _, event := range events {
+		payloads[event.ID] = event.Payload
+	}
+	for id, delivery := range repo.deliveries {
+		payload, ok := payloads[delivery.EventID]
+		if !ok {
+			continue
+		}
+		delivery.Payload = payload
+		delivery.LastError = ""
+		repo.deliveries[id] = delivery
+	}
+}
+
+func (repo *DeliveryRepo) Claim(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit int,
+) ([]entities.Delivery, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	deliveries := make([]entities.Delivery, 0, limit)
+	for _, delivery := range repo.deliveries {
+		if delivery.Status != entities.DeliveryStatusPending || delivery.NextAttempt.After(now) {
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].NextAttempt.Before(deliveries[j].NextAttempt)
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	for i := range deliveries {
+		deliveries[i].NextAttempt = now.Add(lease)
+		repo.deliveries[deliveries[i].ID] = deliveries[i]
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,27 @@
+package repos
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+type MessageRepo interface {
+	// GetByMessageID returns the message by its Message-ID header.
+	GetByMessageID(context.Context, string) (*entities.Message, error)
+	// List returns messages of the candidate ordered by creation time.
+	List(context.Context, uuid.UUID) ([]entities.Message, error)
+	// Create fails with ErrMessageExists if a message with the same
+	// Message-ID is there.
+	Create(context.Context, *entities.Message) error
+	// Update saves delivery state of the message.
+	Update(context.Context, *entities.Message) error
+	// Claim returns pending messages due at the given time and postpones
+	// their next attempt by lease, so concurrent senders skip them.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entities.Message, error)
+	// Erase clears personal data of messages of the candidate.
+	Erase(context.Context, uuid.UUID) error
+}
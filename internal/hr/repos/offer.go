@@ -0,0 +1,18 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+type OfferRepo interface {
+	GetByID(context.Context, uuid.UUID) (*entities.Offer, error)
+	// List returns offers of the card, or all offers for uuid.Nil, ordered
+	// by creation time.
+	List(context.Context, uuid.UUID) ([]entities.Offer, error)
+	Create(context.Context, *entities.Offer) error
+	Update(context.Context, *entities.Offer) error
+}
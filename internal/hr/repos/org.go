@@ -0,0 +1,16 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// OrgRepo keeps managers of departments, the departments themselves are
+// items of the department dictionary.
+type OrgRepo interface {
+	// Managers returns emails of managers by IDs of their departments.
+	Managers(context.Context) (map[uuid.UUID][]string, error)
+	// SetManagers replaces managers of the department, none removes them.
+	SetManagers(ctx context.Context, departmentID uuid.UUID, managers []string) error
+}
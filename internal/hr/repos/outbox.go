@@ -0,0 +1,26 @@
+package repos
+
+import (
+	"context"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// OutboxRepo gives consumers access to domain events written by other
+// repositories. Every consumer has its own position in the outbox.
+type OutboxRepo interface {
+	// Consume passes up to limit events following the consumer position to
+	// fn in order. The position is advanced past every event fn accepted,
+	// consumption stops at the first error which is returned together with
+	// the number of accepted events.
+	Consume(
+		ctx context.Context,
+		consumer string,
+		limit int,
+		fn func(context.Context, entities.Event) error,
+	) (int, error)
+	// List returns up to limit events following the given sequence number.
+	List(ctx context.Context, after int64, limit int) ([]entities.Event, error)
+	// Last returns sequence number of the last event, zero if there is none.
+	Last(context.Context) (int64, error)
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type AttachmentRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAttachmentRepo(pool *pgxpool.Pool) *AttachmentRepo {
+	return &AttachmentRepo{db: pool}
+}
+
+const attachmentColumns = `
+	id, candidate_id, card_id, filename, content_type, size, sha256,
+	uploader, created
+`
+
+func scanAttachment(row pgx.Row, attachment *entities.Attachment) error {
+	var cardID string
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.CandidateID,
+		&cardID,
+		&attachment.Filename,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.SHA256,
+		&attachment.Uploader,
+		&attachment.Created,
+	)
+	if err != nil || cardID == "" {
+		return err
+	}
+	attachment.CardID, err = uuid.Parse(cardID)
+	return err
+}
+
+func (repo *AttachmentRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Attachment, error) {
+	var attachment entities.Attachment
+	err := scanAttachment(
+		repo.db.QueryRow(
+			ctx,
+			`SELECT `+attachmentColumns+` FROM attachment.attachment WHERE id = $1`,
+			id.String(),
+		),
+		&attachment,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (repo *AttachmentRepo) List(
+	ctx context.Context,
+	candidateID uuid.UUID,
+	cardID uuid.UUID,
+) ([]entities.Attachment, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`
+			SELECT `+attachmentColumns+` FROM attachment.attachment
+			WHERE ($1 = '' OR candidate_id = $1) AND ($2 = '' OR card_id = $2)
+			ORDER BY created
+		`,
+		optionalID(candidateID),
+		optionalID(cardID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := make([]entities.Attachment, 0, 10)
+	for rows.Next() {
+		attachment := entities.Attachment{}
+		err = scanAttachment(rows, &attachment)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+func (repo *AttachmentRepo) Create(
+	ctx context.Context,
+	attachment *entities.Attachment,
+) error {
+	attachment.ID = uuid.New()
+	attachment.Created = time.Now()
+
+	_, err := repo.db.Exec(
+		ctx,
+		`INSERT INTO attachment.attachment (`+attachmentColumns+`) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9)`,
+		attachment.ID,
+		attachment.CandidateID,
+		optionalID(attachment.CardID),
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.SHA256,
+		attachment.Uploader,
+		attachment.Created,
+	)
+	return err
+}
+
+func (repo *AttachmentRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := repo.db.Exec(
+		ctx,
+		`DELETE FROM attachment.attachment WHERE id = $1`,
+		id.String(),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+func (repo *AttachmentRepo) CountBySHA256(ctx context.Context, hash string) (int, error) {
+	var count int
+	err := repo.db.QueryRow(
+		ctx,
+		`SELECT count(*) FROM attachment.attachment WHERE sha256 = $1`,
+		hash,
+	).Scan(&count)
+	return count, err
+}
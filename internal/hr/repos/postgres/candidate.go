@@ -0,0 +1,612 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/pkg/keyring"
+)
+
+// CandidateRepo keeps phone, email and birth date of candidates encrypted
+// with the keyring and looks contacts up by their blind indexes.
+type CandidateRepo struct {
+	db   *pgxpool.Pool
+	keys *keyring.Keyring
+}
+
+func NewCandidateRepo(pool *pgxpool.Pool, keys *keyring.Keyring) *CandidateRepo {
+	return &CandidateRepo{db: pool, keys: keys}
+}
+
+const candidateColumns = `
+	c.id, c.name, c.phone, c.email, c.specialization, c.gender,
+	c.birth_date, c.area, c.salary, c.education_level, c.education,
+	c.experience, c.languages, c.skills, c.anonymized, c.created, c.updated,
+	c.salary_currency, c.salary_period
+`
+
+// Names of encrypted fields, they are authenticated along with the values.
+const (
+	phoneField     = "phone"
+	emailField     = "email"
+	birthDateField = "birth_date"
+)
+
+func (repo *CandidateRepo) scan(row pgx.Row, candidate *entities.Candidate) error {
+	var birthDate *string
+	err := row.Scan(
+		&candidate.ID,
+		&candidate.Name,
+		&candidate.Phone,
+		&candidate.Email,
+		&candidate.Specialization,
+		&candidate.Gender,
+		&birthDate,
+		&candidate.Area,
+		&candidate.Salary,
+		&candidate.EducationLevel,
+		&candidate.Education,
+		&candidate.Experience,
+		&candidate.Languages,
+		&candidate.Skills,
+		&candidate.Anonymized,
+		&candidate.Created,
+		&candidate.Updated,
+		&candidate.SalaryCurrency,
+		&candidate.SalaryPeriod,
+	)
+	if err != nil {
+		return err
+	}
+
+	candidate.Phone, err = repo.keys.Open(phoneField, candidate.Phone)
+	if err != nil {
+		return err
+	}
+	candidate.Email, err = repo.keys.Open(emailField, candidate.Email)
+	if err != nil {
+		return err
+	}
+	candidate.BirthDate = nil
+	if birthDate != nil {
+		value, err := repo.keys.Open(birthDateField, *birthDate)
+		if err != nil {
+			return err
+		}
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return err
+		}
+		candidate.BirthDate = &date
+	}
+	return nil
+}
+
+// sealed holds encrypted fields of a candidate as stored.
+type sealed struct {
+	phone      string
+	email      string
+	birthDate  *string
+	phoneIndex string
+	emailIndex string
+}
+
+func (repo *CandidateRepo) seal(candidate *entities.Candidate) (sealed, error) {
+	var s sealed
+	var err error
+	s.phone, err = repo.keys.Seal(phoneField, candidate.Phone)
+	if err != nil {
+		return s, err
+	}
+	s.email, err = repo.keys.Seal(emailField, candidate.Email)
+	if err != nil {
+		return s, err
+	}
+	if candidate.BirthDate != nil {
+		birthDate, err := repo.keys.Seal(birthDateField, candidate.BirthDate.Format(time.DateOnly))
+		if err != nil {
+			return s, err
+		}
+		s.birthDate = &birthDate
+	}
+	s.phoneIndex = repo.keys.Index(phoneField, candidate.Phone)
+	s.emailIndex = repo.keys.Index(emailField, candidate.Email)
+	return s, nil
+}
+
+func (repo *CandidateRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Candidate, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`SELECT `+candidateColumns+` FROM candidate.candidate c WHERE c.id = $1`,
+		id.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, repos.ErrCandidateNotFound
+	}
+
+	var candidate entities.Candidate
+	err = repo.scan(rows, &candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &candidate, nil
+}
+
+// List returns candidates having a card on the given vacancy or all
+// candidates when vacancy is not specified.
+func (repo *CandidateRepo) List(
+	ctx context.Context,
+	vacancyID uuid.UUID,
+) ([]entities.Candidate, error) {
+	query := `SELECT ` + candidateColumns + ` FROM candidate.candidate c
+		ORDER BY c.updated DESC`
+	args := []interface{}{}
+	if vacancyID != uuid.Nil {
+		query = `SELECT ` + candidateColumns + ` FROM candidate.candidate c
+			JOIN card.card k ON k.candidate_id = c.id
+			WHERE k.vacancy_id = $1
+			ORDER BY c.updated DESC`
+		args = append(args, vacancyID.String())
+	}
+
+	rows, err := repo.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := make([]entities.Candidate, 0, 1000)
+	for rows.Next() {
+		candidate := entities.Candidate{}
+		err = repo.scan(rows, &candidate)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, rows.Err()
+}
+
+func (repo *CandidateRepo) Create(
+	ctx context.Context,
+	candidate *entities.Candidate,
+) error {
+	tx, err := connFrom(ctx, repo.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	sealed, err := repo.seal(candidate)
+	if err != nil {
+		return err
+	}
+
+	candidate.ID = uuid.New()
+	candidate.Created = time.Now()
+	candidate.Updated = time.Now()
+
+	_, err = tx.Exec(
+		ctx,
+		`
+			INSERT INTO candidate.candidate (
+				id, name, phone, email, specialization, gender,
+				birth_date, area, salary, education_level, education,
+				experience, languages, skills, created, updated,
+				phone_index, email_index, salary_currency, salary_period, name_key
+			) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21)
+		`,
+		candidate.ID,
+		candidate.Name,
+		sealed.phone,
+		sealed.email,
+		candidate.Specialization,
+		candidate.Gender.String(),
+		sealed.birthDate,
+		candidate.Area,
+		candidate.Salary,
+		candidate.EducationLevel.String(),
+		candidate.Education,
+		candidate.Experience,
+		candidate.Languages,
+		candidate.Skills,
+		candidate.Created,
+		candidate.Updated,
+		sealed.phoneIndex,
+		sealed.emailIndex,
+		candidate.SalaryCurrency,
+		candidate.SalaryPeriod.String(),
+		entities.NameKey(candidate.Name),
+	)
+	if err != nil {
+		return err
+	}
+
+	events, err := newEvents(candidate.ID, entities.CandidateCreated{
+		CandidateID: candidate.ID,
+		Name:        candidate.Name,
+	})
+	if err != nil {
+		return err
+	}
+	err = insertEvents(ctx, tx, events...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (repo *CandidateRepo) Update(
+	ctx context.Context,
+	candidate *entities.Candidate,
+) error {
+	return repo.update(ctx, repo.db, candidate)
+}
+
+// Anonymize saves the scrubbed candidate and its anonymized time in one
+// transaction.
+func (repo *CandidateRepo) Anonymize(
+	ctx context.Context,
+	candidate *entities.Candidate,
+) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	anonymized := candidate.Anonymized
+	err = repo.update(ctx, tx, candidate)
+	if err != nil {
+		return err
+	}
+	candidate.Anonymized = anonymized
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE candidate.candidate SET anonymized = $2 WHERE id = $1`,
+		candidate.ID,
+		candidate.Anonymized,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = redactEvents(ctx, tx, candidate.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// execer is implemented by both pool and transaction.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+func (repo *CandidateRepo) update(ctx context.Context, db execer, candidate *entities.Candidate) error {
+	sealed, err := repo.seal(candidate)
+	if err != nil {
+		return err
+	}
+	candidate.Updated = time.Now()
+
+	tag, err := db.Exec(
+		ctx,
+		`
+			UPDATE candidate.candidate SET
+				name = $2,
+				phone = $3,
+				email = $4,
+				specialization = $5,
+				gender = $6,
+				birth_date = $7,
+				area = $8,
+				salary = $9,
+				education_level = $10,
+				education = $11,
+				experience = $12,
+				languages = $13,
+				skills = $14,
+				updated = $15,
+				phone_index = $16,
+				email_index = $17,
+				salary_currency = $18,
+				salary_period = $19,
+				name_key = $20
+			WHERE id = $1 AND anonymized IS NULL
+		`,
+		candidate.ID,
+		candidate.Name,
+		sealed.phone,
+		sealed.email,
+		candidate.Specialization,
+		candidate.Gender.String(),
+		sealed.birthDate,
+		candidate.Area,
+		candidate.Salary,
+		candidate.EducationLevel.String(),
+		candidate.Education,
+		candidate.Experience,
+		candidate.Languages,
+		candidate.Skills,
+		candidate.Updated,
+		sealed.phoneIndex,
+		sealed.emailIndex,
+		candidate.SalaryCurrency,
+		candidate.SalaryPeriod.String(),
+		entities.NameKey(candidate.Name),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return candidateMissing(ctx, db, candidate.ID)
+	}
+	candidate.Anonymized = nil
+
+	return nil
+}
+
+// candidateMissing tells why the candidate was not updated: it is either
+// gone or anonymized.
+func candidateMissing(ctx context.Context, db execer, id uuid.UUID) error {
+	var anonymized bool
+	err := db.QueryRow(
+		ctx,
+		`SELECT anonymized IS NOT NULL FROM candidate.candidate WHERE id = $1`,
+		id,
+	).Scan(&anonymized)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return repos.ErrCandidateNotFound
+	case err != nil:
+		return err
+	case anonymized:
+		return entities.ErrCandidateAnonymized
+	}
+	return repos.ErrCandidateNotFound
+}
+
+// Merge moves everything referring to the duplicate to the candidate within
+// one transaction. Redirects to the duplicate are moved along, so a chain of
+// merges resolves in one step.
+func (repo *CandidateRepo) Merge(
+	ctx context.Context,
+	candidate *entities.Candidate,
+	duplicateID uuid.UUID,
+) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var conflicts int
+	err = tx.QueryRow(
+		ctx,
+		`
+			SELECT COUNT(*) FROM card.card a
+			JOIN card.card b ON b.vacancy_id = a.vacancy_id
+			WHERE a.candidate_id = $1 AND b.candidate_id = $2
+		`,
+		candidate.ID.String(),
+		duplicateID.String(),
+	).Scan(&conflicts)
+	if err != nil {
+		return err
+	}
+	if conflicts > 0 {
+		return repos.ErrMergeConflict
+	}
+
+	err = repo.update(ctx, tx, candidate)
+	if err != nil {
+		return err
+	}
+
+	for _, query := range []string{
+		`UPDATE card.card SET candidate_id = $2 WHERE candidate_id = $1`,
+		`UPDATE attachment.attachment SET candidate_id = $2 WHERE candidate_id = $1`,
+		`UPDATE resume.resume SET candidate_id = $2 WHERE candidate_id = $1`,
+		`UPDATE consent.consent SET candidate_id = $2 WHERE candidate_id = $1`,
+		`UPDATE mail.message SET candidate_id = $2 WHERE candidate_id = $1`,
+		`UPDATE candidate.redirect SET candidate_id = $2 WHERE candidate_id = $1`,
+	} {
+		_, err = tx.Exec(ctx, query, duplicateID.String(), candidate.ID.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	tag, err := tx.Exec(
+		ctx,
+		`DELETE FROM candidate.candidate WHERE id = $1`,
+		duplicateID.String(),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrCandidateNotFound
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO candidate.redirect (id, candidate_id, created) VALUES ($1, $2, $3)`,
+		duplicateID.String(),
+		candidate.ID.String(),
+		time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (repo *CandidateRepo) Redirect(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	var candidateID uuid.UUID
+	err := repo.db.QueryRow(
+		ctx,
+		`SELECT candidate_id FROM candidate.redirect WHERE id = $1`,
+		id.String(),
+	).Scan(&candidateID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, repos.ErrCandidateNotFound
+	}
+	return candidateID, err
+}
+
+// FindByContacts looks the normalized phone and email up by their blind
+// indexes.
+func (repo *CandidateRepo) FindByContacts(
+	ctx context.Context,
+	phone, email string,
+) ([]entities.Candidate, error) {
+	return repo.find(
+		ctx,
+		`WHERE (c.phone_index = $1 AND $1 <> '') OR (c.email_index = $2 AND $2 <> '')`,
+		repo.keys.Index(phoneField, phone),
+		repo.keys.Index(emailField, email),
+	)
+}
+
+// FindByName looks the name up by trigrams of its key kept next to it. The
+// trigram match is looser than entities.SimilarNameKeys, so typos are found
+// and compared precisely later.
+func (repo *CandidateRepo) FindByName(
+	ctx context.Context,
+	name string,
+) ([]entities.Candidate, error) {
+	return repo.find(ctx, `WHERE c.name_key % $1 AND $1 <> ''`, entities.NameKey(name))
+}
+
+// find returns candidates matching the where clause, recently updated
+// first.
+func (repo *CandidateRepo) find(
+	ctx context.Context,
+	where string,
+	args ...interface{},
+) ([]entities.Candidate, error) {
+	rows, err := connFrom(ctx, repo.db).Query(
+		ctx,
+		`SELECT `+candidateColumns+` FROM candidate.candidate c `+where+` ORDER BY c.updated DESC`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []entities.Candidate
+	for rows.Next() {
+		candidate := entities.Candidate{}
+		err = repo.scan(rows, &candidate)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, rows.Err()
+}
+
+// Rotate seals fields of all candidates again with the primary key and
+// rebuilds their blind indexes, which also encrypts rows stored before the
+// keyring was set up. It returns the number of candidates rewritten.
+func (repo *CandidateRepo) Rotate(ctx context.Context) (int, error) {
+	return rotate(ctx, repo.rotateBatch)
+}
+
+// rotateBatch rotates candidates following the given ID and returns the last
+// ID seen, empty when there are no more candidates.
+func (repo *CandidateRepo) rotateBatch(ctx context.Context, after string) (int, string, error) {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(
+		ctx,
+		`SELECT `+candidateColumns+` FROM candidate.candidate c
+			WHERE c.id > $1 ORDER BY c.id LIMIT $2 FOR UPDATE`,
+		after,
+		rotateBatch,
+	)
+	if err != nil {
+		return 0, "", err
+	}
+	var candidates []entities.Candidate
+	for rows.Next() {
+		candidate := entities.Candidate{}
+		err = repo.scan(rows, &candidate)
+		if err != nil {
+			rows.Close()
+			return 0, "", err
+		}
+		candidates = append(candidates, candidate)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, "", err
+	}
+	if len(candidates) == 0 {
+		return 0, "", nil
+	}
+
+	for i := range candidates {
+		sealed, err := repo.seal(&candidates[i])
+		if err != nil {
+			return 0, "", err
+		}
+		_, err = tx.Exec(
+			ctx,
+			`
+				UPDATE candidate.candidate SET
+					phone = $2,
+					email = $3,
+					birth_date = $4,
+					phone_index = $5,
+					email_index = $6
+				WHERE id = $1
+			`,
+			candidates[i].ID.String(),
+			sealed.phone,
+			sealed.email,
+			sealed.birthDate,
+			sealed.phoneIndex,
+			sealed.emailIndex,
+		)
+		if err != nil {
+			return 0, "", err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	return len(candidates), candidates[len(candidates)-1].ID.String(), nil
+}
@@ -0,0 +1,329 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type CardRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCardRepo(pool *pgxpool.Pool) *CardRepo {
+	return &CardRepo{db: pool}
+}
+
+const cardColumns = `id, vacancy_id, candidate_id, stage, source, created, updated`
+
+func scanCard(row pgx.Row, card *entities.Card) error {
+	return row.Scan(
+		&card.ID,
+		&card.VacancyID,
+		&card.CandidateID,
+		&card.Stage,
+		&card.Source,
+		&card.Created,
+		&card.Updated,
+	)
+}
+
+func (repo *CardRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Card, error) {
+	db := connFrom(ctx, repo.db)
+	cardRows, err := db.Query(
+		ctx,
+		`SELECT `+cardColumns+` FROM card.card WHERE id = $1`,
+		id.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cardRows.Close()
+
+	if !cardRows.Next() {
+		return nil, repos.ErrCardNotFound
+	}
+
+	var card entities.Card
+	err = scanCard(cardRows, &card)
+	if err != nil {
+		return nil, err
+	}
+	cardRows.Close()
+
+	commentRows, err := db.Query(
+		ctx,
+		`
+			SELECT id, author, text, created FROM card.comment
+			WHERE card_id = $1
+			ORDER BY created
+		`,
+		id.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer commentRows.Close()
+
+	for commentRows.Next() {
+		comment := entities.Comment{}
+		err = commentRows.Scan(
+			&comment.ID,
+			&comment.Author,
+			&comment.Text,
+			&comment.Created,
+		)
+		if err != nil {
+			return nil, err
+		}
+		card.Comments = append(card.Comments, comment)
+	}
+
+	return &card, commentRows.Err()
+}
+
+// List returns cards of the given vacancy or all cards when vacancy is not
+// specified. Comments are not loaded.
+func (repo *CardRepo) List(
+	ctx context.Context,
+	vacancyID uuid.UUID,
+) ([]entities.Card, error) {
+	query := `SELECT ` + cardColumns + ` FROM card.card ORDER BY updated DESC`
+	args := []interface{}{}
+	if vacancyID != uuid.Nil {
+		query = `SELECT ` + cardColumns + ` FROM card.card
+			WHERE vacancy_id = $1
+			ORDER BY updated DESC`
+		args = append(args, vacancyID.String())
+	}
+
+	rows, err := repo.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cards := make([]entities.Card, 0, 1000)
+	for rows.Next() {
+		card := entities.Card{}
+		err = scanCard(rows, &card)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+
+	return cards, rows.Err()
+}
+
+func (repo *CardRepo) ListByCandidate(
+	ctx context.Context,
+	candidateID uuid.UUID,
+) ([]entities.Card, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`SELECT `+cardColumns+` FROM card.card
+			WHERE candidate_id = $1
+			ORDER BY updated DESC`,
+		candidateID.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cards := make([]entities.Card, 0)
+	for rows.Next() {
+		card := entities.Card{}
+		err = scanCard(rows, &card)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+
+	return cards, rows.Err()
+}
+
+func (repo *CardRepo) Create(ctx context.Context, card *entities.Card) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	card.ID = uuid.New()
+	if card.Stage == entities.CardStageNone {
+		card.Stage = entities.CardStageNew
+	}
+	card.Created = time.Now()
+	card.Updated = time.Now()
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO card.card (`+cardColumns+`) VALUES($1,$2,$3,$4,$5,$6,$7)`,
+		card.ID,
+		card.VacancyID,
+		card.CandidateID,
+		card.Stage.String(),
+		card.Source,
+		card.Created,
+		card.Updated,
+	)
+	if err != nil {
+		return err
+	}
+
+	events, err := newEvents(card.ID, entities.CardCreated{
+		CardID:      card.ID,
+		VacancyID:   card.VacancyID,
+		CandidateID: card.CandidateID,
+		Stage:       card.Stage,
+	})
+	if err != nil {
+		return err
+	}
+	err = insertEvents(ctx, tx, events...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// lock selects the card for update within the transaction.
+func (repo *CardRepo) lock(
+	ctx context.Context,
+	tx pgx.Tx,
+	id uuid.UUID,
+) (*entities.Card, error) {
+	var card entities.Card
+	err := scanCard(
+		tx.QueryRow(
+			ctx,
+			`SELECT `+cardColumns+` FROM card.card WHERE id = $1 FOR UPDATE`,
+			id.String(),
+		),
+		&card,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrCardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+func (repo *CardRepo) Move(
+	ctx context.Context,
+	id uuid.UUID,
+	stage entities.CardStage,
+) (*entities.Card, error) {
+	tx, err := connFrom(ctx, repo.db).Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	card, err := repo.lock(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE card.card SET stage = $2, updated = $3 WHERE id = $1`,
+		id.String(),
+		stage.String(),
+		time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if card.Stage != stage {
+		events, err := newEvents(card.ID, entities.CardMoved{
+			CardID:      card.ID,
+			VacancyID:   card.VacancyID,
+			CandidateID: card.CandidateID,
+			From:        card.Stage,
+			To:          stage,
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = insertEvents(ctx, tx, events...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.GetByID(ctx, id)
+}
+
+func (repo *CardRepo) AddComment(
+	ctx context.Context,
+	cardID uuid.UUID,
+	comment *entities.Comment,
+) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	card, err := repo.lock(ctx, tx, cardID)
+	if err != nil {
+		return err
+	}
+
+	comment.ID = uuid.New()
+	comment.Created = time.Now()
+
+	_, err = tx.Exec(
+		ctx,
+		`
+			INSERT INTO card.comment (id, card_id, author, text, created)
+			VALUES($1,$2,$3,$4,$5)
+		`,
+		comment.ID,
+		cardID.String(),
+		comment.Author,
+		comment.Text,
+		comment.Created,
+	)
+	if err != nil {
+		return err
+	}
+
+	events, err := newEvents(card.ID, entities.CommentAdded{
+		CardID:    card.ID,
+		VacancyID: card.VacancyID,
+		Comment:   *comment,
+	})
+	if err != nil {
+		return err
+	}
+	err = insertEvents(ctx, tx, events...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type ConsentRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewConsentRepo(pool *pgxpool.Pool) *ConsentRepo {
+	return &ConsentRepo{db: pool}
+}
+
+const consentColumns = `
+	id, candidate_id, purpose, source, given, expires, withdrawn, created
+`
+
+func scanConsent(row pgx.Row, consent *entities.Consent) error {
+	return row.Scan(
+		&consent.ID,
+		&consent.CandidateID,
+		&consent.Purpose,
+		&consent.Source,
+		&consent.Given,
+		&consent.Expires,
+		&consent.Withdrawn,
+		&consent.Created,
+	)
+}
+
+func (repo *ConsentRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Consent, error) {
+	var consent entities.Consent
+	err := scanConsent(
+		repo.db.QueryRow(
+			ctx,
+			`SELECT `+consentColumns+` FROM consent.consent WHERE id = $1`,
+			id.String(),
+		),
+		&consent,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrConsentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &consent, nil
+}
+
+func (repo *ConsentRepo) List(
+	ctx context.Context,
+	candidateID uuid.UUID,
+) ([]entities.Consent, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`SELECT `+consentColumns+` FROM consent.consent
+			WHERE candidate_id = $1
+			ORDER BY given`,
+		candidateID.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	consents := make([]entities.Consent, 0)
+	for rows.Next() {
+		var consent entities.Consent
+		err = scanConsent(rows, &consent)
+		if err != nil {
+			return nil, err
+		}
+		consents = append(consents, consent)
+	}
+	return consents, rows.Err()
+}
+
+func (repo *ConsentRepo) Create(
+	ctx context.Context,
+	consent *entities.Consent,
+) error {
+	consent.ID = uuid.New()
+	consent.Created = time.Now()
+
+	_, err := repo.db.Exec(
+		ctx,
+		`INSERT INTO consent.consent (`+consentColumns+`) VALUES($1,$2,$3,$4,$5,$6,$7,$8)`,
+		consent.ID,
+		consent.CandidateID,
+		consent.Purpose.String(),
+		consent.Source,
+		consent.Given,
+		consent.Expires,
+		consent.Withdrawn,
+		consent.Created,
+	)
+	return err
+}
+
+func (repo *ConsentRepo) Update(
+	ctx context.Context,
+	consent *entities.Consent,
+) error {
+	tag, err := repo.db.Exec(
+		ctx,
+		`
+			UPDATE consent.consent SET
+				purpose = $2,
+				source = $3,
+				given = $4,
+				expires = $5,
+				withdrawn = $6
+			WHERE id = $1
+		`,
+		consent.ID,
+		consent.Purpose.String(),
+		consent.Source,
+		consent.Given,
+		consent.Expires,
+		consent.Withdrawn,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrConsentNotFound
+	}
+	return nil
+}
+
+func (repo *ConsentRepo) Expired(
+	ctx context.Context,
+	now time.Time,
+) ([]uuid.UUID, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`
+			SELECT c.id FROM candidate.candidate c
+			WHERE c.anonymized IS NULL
+			AND EXISTS (SELECT 1 FROM consent.consent k WHERE k.candidate_id = c.id)
+			AND NOT EXISTS (
+				SELECT 1 FROM consent.consent k
+				WHERE k.candidate_id = c.id AND k.withdrawn IS NULL AND k.expires > $1
+			)
+		`,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,274 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type DictionaryRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewDictionaryRepo(pool *pgxpool.Pool) *DictionaryRepo {
+	return &DictionaryRepo{db: pool}
+}
+
+const dictionaryColumns = `id, kind, name, coalesce(parent_id, ''), created, updated`
+
+// dictionaryUse is a column holding values of a dictionary.
+type dictionaryUse struct {
+	table  string
+	column string
+}
+
+var dictionaryUses = map[entities.DictionaryKind][]dictionaryUse{
+	entities.DictionaryKindArea: {
+		{table: "vacancy.vacancy", column: "area"},
+		{table: "candidate.candidate", column: "area"},
+	},
+	entities.DictionaryKindDepartment: {
+		{table: "vacancy.vacancy", column: "department"},
+	},
+	entities.DictionaryKindSpecialization: {
+		{table: "candidate.candidate", column: "specialization"},
+	},
+}
+
+func scanDictionaryItem(row pgx.Row, item *entities.DictionaryItem) error {
+	var parentID string
+	err := row.Scan(
+		&item.ID,
+		&item.Kind,
+		&item.Name,
+		&parentID,
+		&item.Created,
+		&item.Updated,
+	)
+	if err != nil || parentID == "" {
+		return err
+	}
+	item.ParentID, err = uuid.Parse(parentID)
+	return err
+}
+
+func (repo *DictionaryRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.DictionaryItem, error) {
+	var item entities.DictionaryItem
+	err := scanDictionaryItem(
+		repo.db.QueryRow(
+			ctx,
+			`SELECT `+dictionaryColumns+` FROM dictionary.item WHERE id = $1`,
+			id.String(),
+		),
+		&item,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrDictionaryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (repo *DictionaryRepo) List(
+	ctx context.Context,
+	filter repos.DictionaryFilter,
+) ([]entities.DictionaryItem, error) {
+	kind := ""
+	if filter.Kind != entities.DictionaryKindNone {
+		kind = filter.Kind.String()
+	}
+	prefix := entities.DictionaryKey(filter.Prefix)
+	prefix = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+	rows, err := repo.db.Query(
+		ctx,
+		`
+			SELECT `+dictionaryColumns+` FROM dictionary.item
+			WHERE ($1 = '' OR kind::TEXT = $1)
+				AND ($2 = '' OR parent_id = $2)
+				AND lower(name) LIKE $3 || '%'
+			ORDER BY kind, name
+		`,
+		kind,
+		optionalID(filter.ParentID),
+		prefix,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]entities.DictionaryItem, 0)
+	for rows.Next() {
+		var item entities.DictionaryItem
+		err = scanDictionaryItem(rows, &item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (repo *DictionaryRepo) Create(
+	ctx context.Context,
+	item *entities.DictionaryItem,
+) error {
+	item.ID = uuid.New()
+	item.Created = time.Now()
+	item.Updated = time.Now()
+	tag, err := repo.db.Exec(
+		ctx,
+		`
+			INSERT INTO dictionary.item (id, kind, name, parent_id, created, updated)
+			VALUES($1,$2,$3,NULLIF($4, ''),$5,$6)
+			ON CONFLICT DO NOTHING
+		`,
+		item.ID.String(),
+		item.Kind.String(),
+		item.Name,
+		optionalID(item.ParentID),
+		item.Created,
+		item.Updated,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrDictionaryExists
+	}
+	return nil
+}
+
+func (repo *DictionaryRepo) Update(
+	ctx context.Context,
+	item *entities.DictionaryItem,
+) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var old string
+	err = tx.QueryRow(
+		ctx,
+		`SELECT name FROM dictionary.item WHERE id = $1 FOR UPDATE`,
+		item.ID.String(),
+	).Scan(&old)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repos.ErrDictionaryNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var taken bool
+	err = tx.QueryRow(
+		ctx,
+		`
+			SELECT EXISTS (
+				SELECT 1 FROM dictionary.item
+				WHERE kind::TEXT = $1 AND lower(name) = lower($2) AND id <> $3
+			)
+		`,
+		item.Kind.String(),
+		item.Name,
+		item.ID.String(),
+	).Scan(&taken)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return repos.ErrDictionaryExists
+	}
+
+	item.Updated = time.Now()
+	err = tx.QueryRow(
+		ctx,
+		`
+			UPDATE dictionary.item SET
+				name = $2,
+				parent_id = NULLIF($3, ''),
+				updated = $4
+			WHERE id = $1
+			RETURNING created
+		`,
+		item.ID.String(),
+		item.Name,
+		optionalID(item.ParentID),
+		item.Updated,
+	).Scan(&item.Created)
+	if err != nil {
+		return err
+	}
+
+	if old != item.Name {
+		for _, use := range dictionaryUses[item.Kind] {
+			_, err = tx.Exec(
+				ctx,
+				`UPDATE `+use.table+` SET `+use.column+` = $2 WHERE lower(`+use.column+`) = lower($1)`,
+				old,
+				item.Name,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (repo *DictionaryRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	item, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	var used bool
+	err = tx.QueryRow(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM dictionary.item WHERE parent_id = $1)`,
+		id.String(),
+	).Scan(&used)
+	for _, use := range dictionaryUses[item.Kind] {
+		if err != nil || used {
+			break
+		}
+		err = tx.QueryRow(
+			ctx,
+			`SELECT EXISTS (SELECT 1 FROM `+use.table+` WHERE lower(`+use.column+`) = lower($1))`,
+			item.Name,
+		).Scan(&used)
+	}
+	if err != nil {
+		return err
+	}
+	if used {
+		return repos.ErrDictionaryInUse
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM dictionary.item WHERE id = $1`, id.String())
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
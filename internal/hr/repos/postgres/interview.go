@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type InterviewRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewInterviewRepo(pool *pgxpool.Pool) *InterviewRepo {
+	return &InterviewRepo{db: pool}
+}
+
+const interviewColumns = `
+	id, card_id, interviewers, start_at, end_at, format, location, link,
+	status, sequence, created, updated
+`
+
+func scanInterview(row pgx.Row, interview *entities.Interview) error {
+	return row.Scan(
+		&interview.ID,
+		&interview.CardID,
+		&interview.Interviewers,
+		&interview.Start,
+		&interview.End,
+		&interview.Format,
+		&interview.Location,
+		&interview.Link,
+		&interview.Status,
+		&interview.Sequence,
+		&interview.Created,
+		&interview.Updated,
+	)
+}
+
+func (repo *InterviewRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Interview, error) {
+	var interview entities.Interview
+	err := scanInterview(
+		connFrom(ctx, repo.db).QueryRow(
+			ctx,
+			`SELECT `+interviewColumns+` FROM interview.interview WHERE id = $1`,
+			id.String(),
+		),
+		&interview,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrInterviewNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &interview, nil
+}
+
+func (repo *InterviewRepo) List(
+	ctx context.Context,
+	filter repos.InterviewFilter,
+) ([]entities.Interview, error) {
+	where := []string{"TRUE"}
+	args := []interface{}{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	if filter.CardID != uuid.Nil {
+		where = append(where, "card_id = "+arg(filter.CardID.String()))
+	}
+	if filter.Interviewer != "" {
+		where = append(where, arg(filter.Interviewer)+" = ANY(interviewers)")
+	}
+	if !filter.From.IsZero() {
+		where = append(where, "end_at > "+arg(filter.From))
+	}
+	if !filter.To.IsZero() {
+		where = append(where, "start_at < "+arg(filter.To))
+	}
+
+	rows, err := connFrom(ctx, repo.db).Query(
+		ctx,
+		`
+			SELECT `+interviewColumns+` FROM interview.interview
+			WHERE `+strings.Join(where, " AND ")+`
+			ORDER BY start_at
+			LIMIT 1000
+		`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	interviews := make([]entities.Interview, 0, 100)
+	for rows.Next() {
+		interview := entities.Interview{}
+		err = scanInterview(rows, &interview)
+		if err != nil {
+			return nil, err
+		}
+		interviews = append(interviews, interview)
+	}
+
+	return interviews, rows.Err()
+}
+
+func (repo *InterviewRepo) Create(
+	ctx context.Context,
+	interview *entities.Interview,
+) error {
+	interview.ID = uuid.New()
+	interview.Sequence = 0
+	interview.Created = time.Now()
+	interview.Updated = time.Now()
+
+	_, err := connFrom(ctx, repo.db).Exec(
+		ctx,
+		`
+			INSERT INTO interview.interview (`+interviewColumns+`)
+			VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+		`,
+		interview.ID,
+		interview.CardID,
+		interview.Interviewers,
+		interview.Start,
+		interview.End,
+		interview.Format.String(),
+		interview.Location,
+		interview.Link,
+		interview.Status.String(),
+		interview.Sequence,
+		interview.Created,
+		interview.Updated,
+	)
+	return err
+}
+
+func (repo *InterviewRepo) Update(
+	ctx context.Context,
+	interview *entities.Interview,
+) error {
+	interview.Updated = time.Now()
+
+	err := connFrom(ctx, repo.db).QueryRow(
+		ctx,
+		`
+			UPDATE interview.interview SET
+				interviewers = $2,
+				start_at = $3,
+				end_at = $4,
+				format = $5,
+				location = $6,
+				link = $7,
+				status = $8,
+				sequence = sequence + 1,
+				updated = $9
+			WHERE id = $1
+			RETURNING card_id, sequence, created
+		`,
+		interview.ID,
+		interview.Interviewers,
+		interview.Start,
+		interview.End,
+		interview.Format.String(),
+		interview.Location,
+		interview.Link,
+		interview.Status.String(),
+		interview.Updated,
+	).Scan(&interview.CardID, &interview.Sequence, &interview.Created)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repos.ErrInterviewNotFound
+	}
+	return err
+}
+
+// Hold takes transaction level advisory locks of the interviewers and runs
+// fn in the same transaction, which repositories called with the context
+// given to fn join. Locks are taken in order, so holds do not deadlock.
+func (repo *InterviewRepo) Hold(
+	ctx context.Context,
+	interviewers []string,
+	fn func(context.Context) error,
+) error {
+	tx, err := connFrom(ctx, repo.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	keys := make([]string, 0, len(interviewers))
+	for _, interviewer := range interviewers {
+		keys = append(keys, entities.NormalizeEmail(interviewer))
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		_, err = tx.Exec(
+			ctx,
+			`SELECT pg_advisory_xact_lock(hashtext('interview.interviewer'), hashtext($1))`,
+			key,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = fn(context.WithValue(ctx, txKey{}, tx))
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,394 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/pkg/keyring"
+)
+
+// MessageRepo keeps addresses, subjects and texts of messages encrypted with
+// the keyring.
+type MessageRepo struct {
+	db   *pgxpool.Pool
+	keys *keyring.Keyring
+}
+
+func NewMessageRepo(pool *pgxpool.Pool, keys *keyring.Keyring) *MessageRepo {
+	return &MessageRepo{db: pool, keys: keys}
+}
+
+const messageColumns = `
+	id, message_id, direction, candidate_id, card_id, sender, recipient,
+	subject, text, template, files, status, attempts, next_attempt,
+	last_error, sent, created
+`
+
+// Names of encrypted message fields.
+const (
+	messageSenderField    = "message_sender"
+	messageRecipientField = "message_recipient"
+	messageSubjectField   = "message_subject"
+	messageTextField      = "message_text"
+)
+
+// messageFile keeps contents of the file, which are not shown in the API.
+type messageFile struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Data        []byte `json:"data"`
+}
+
+func marshalFiles(files []entities.MessageFile) ([]byte, error) {
+	stored := make([]messageFile, len(files))
+	for i, file := range files {
+		stored[i] = messageFile(file)
+	}
+	return json.Marshal(stored)
+}
+
+func (repo *MessageRepo) scan(row pgx.Row, message *entities.Message) error {
+	var cardID string
+	var files []byte
+	err := row.Scan(
+		&message.ID,
+		&message.MessageID,
+		&message.Direction,
+		&message.CandidateID,
+		&cardID,
+		&message.From,
+		&message.To,
+		&message.Subject,
+		&message.Text,
+		&message.Template,
+		&files,
+		&message.Status,
+		&message.Attempts,
+		&message.NextAttempt,
+		&message.LastError,
+		&message.Sent,
+		&message.Created,
+	)
+	if err != nil {
+		return err
+	}
+	message.From, err = repo.keys.Open(messageSenderField, message.From)
+	if err != nil {
+		return err
+	}
+	message.To, err = repo.keys.Open(messageRecipientField, message.To)
+	if err != nil {
+		return err
+	}
+	message.Subject, err = repo.keys.Open(messageSubjectField, message.Subject)
+	if err != nil {
+		return err
+	}
+	message.Text, err = repo.keys.Open(messageTextField, message.Text)
+	if err != nil {
+		return err
+	}
+
+	var stored []messageFile
+	err = json.Unmarshal(files, &stored)
+	if err != nil {
+		return err
+	}
+	message.Files = make([]entities.MessageFile, len(stored))
+	for i, file := range stored {
+		message.Files[i] = entities.MessageFile(file)
+	}
+
+	if cardID == "" {
+		return nil
+	}
+	message.CardID, err = uuid.Parse(cardID)
+	return err
+}
+
+func (repo *MessageRepo) scanAll(rows pgx.Rows) ([]entities.Message, error) {
+	defer rows.Close()
+
+	messages := make([]entities.Message, 0)
+	for rows.Next() {
+		var message entities.Message
+		err := repo.scan(rows, &message)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+// sealedMessage holds encrypted fields of a message as stored.
+type sealedMessage struct {
+	from    string
+	to      string
+	subject string
+	text    string
+}
+
+func (repo *MessageRepo) seal(message *entities.Message) (sealedMessage, error) {
+	var s sealedMessage
+	var err error
+	s.from, err = repo.keys.Seal(messageSenderField, message.From)
+	if err != nil {
+		return s, err
+	}
+	s.to, err = repo.keys.Seal(messageRecipientField, message.To)
+	if err != nil {
+		return s, err
+	}
+	s.subject, err = repo.keys.Seal(messageSubjectField, message.Subject)
+	if err != nil {
+		return s, err
+	}
+	s.text, err = repo.keys.Seal(messageTextField, message.Text)
+	return s, err
+}
+
+func (repo *MessageRepo) GetByMessageID(
+	ctx context.Context,
+	messageID string,
+) (*entities.Message, error) {
+	var message entities.Message
+	err := repo.scan(
+		repo.db.QueryRow(
+			ctx,
+			`SELECT `+messageColumns+` FROM mail.message WHERE message_id = $1`,
+			messageID,
+		),
+		&message,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (repo *MessageRepo) List(
+	ctx context.Context,
+	candidateID uuid.UUID,
+) ([]entities.Message, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`SELECT `+messageColumns+` FROM mail.message
+			WHERE candidate_id = $1
+			ORDER BY created`,
+		candidateID.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return repo.scanAll(rows)
+}
+
+func (repo *MessageRepo) Create(
+	ctx context.Context,
+	message *entities.Message,
+) error {
+	files, err := marshalFiles(message.Files)
+	if err != nil {
+		return err
+	}
+	sealed, err := repo.seal(message)
+	if err != nil {
+		return err
+	}
+	message.ID = uuid.New()
+	message.Created = time.Now()
+
+	tag, err := repo.db.Exec(
+		ctx,
+		`
+			INSERT INTO mail.message (`+messageColumns+`)
+			VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17)
+			ON CONFLICT (message_id) DO NOTHING
+		`,
+		message.ID,
+		message.MessageID,
+		message.Direction.String(),
+		message.CandidateID,
+		optionalID(message.CardID),
+		sealed.from,
+		sealed.to,
+		sealed.subject,
+		sealed.text,
+		message.Template,
+		files,
+		message.Status.String(),
+		message.Attempts,
+		message.NextAttempt,
+		message.LastError,
+		message.Sent,
+		message.Created,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrMessageExists
+	}
+	return nil
+}
+
+func (repo *MessageRepo) Update(
+	ctx context.Context,
+	message *entities.Message,
+) error {
+	files, err := marshalFiles(message.Files)
+	if err != nil {
+		return err
+	}
+
+	tag, err := repo.db.Exec(
+		ctx,
+		`
+			UPDATE mail.message SET
+				files = $2,
+				status = $3,
+				attempts = $4,
+				next_attempt = $5,
+				last_error = $6,
+				sent = $7
+			WHERE id = $1
+		`,
+		message.ID,
+		files,
+		message.Status.String(),
+		message.Attempts,
+		message.NextAttempt,
+		message.LastError,
+		message.Sent,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrMessageNotFound
+	}
+	return nil
+}
+
+func (repo *MessageRepo) Claim(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit int,
+) ([]entities.Message, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`
+			UPDATE mail.message SET next_attempt = $2
+			WHERE id IN (
+				SELECT id FROM mail.message
+				WHERE status = 'pending' AND next_attempt <= $1
+				ORDER BY next_attempt
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING `+messageColumns,
+		now,
+		now.Add(lease),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return repo.scanAll(rows)
+}
+
+func (repo *MessageRepo) Erase(ctx context.Context, candidateID uuid.UUID) error {
+	_, err := repo.db.Exec(
+		ctx,
+		`
+			UPDATE mail.message SET
+				sender = '',
+				recipient = '',
+				subject = '',
+				text = '',
+				files = '[]',
+				status = CASE WHEN status = 'pending' THEN 'dead' ELSE status END,
+				last_error = CASE WHEN status = 'pending' THEN 'erased' ELSE last_error END
+			WHERE candidate_id = $1
+		`,
+		candidateID.String(),
+	)
+	return err
+}
+
+// Rotate seals addresses, subjects and texts of all messages again with the
+// primary key. It returns the number of messages rewritten.
+func (repo *MessageRepo) Rotate(ctx context.Context) (int, error) {
+	return rotate(ctx, repo.rotateBatch)
+}
+
+func (repo *MessageRepo) rotateBatch(ctx context.Context, after string) (int, string, error) {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(
+		ctx,
+		`SELECT `+messageColumns+` FROM mail.message
+			WHERE id > $1 ORDER BY id LIMIT $2 FOR UPDATE`,
+		after,
+		rotateBatch,
+	)
+	if err != nil {
+		return 0, "", err
+	}
+	messages, err := repo.scanAll(rows)
+	if err != nil {
+		return 0, "", err
+	}
+	if len(messages) == 0 {
+		return 0, "", nil
+	}
+
+	for i := range messages {
+		sealed, err := repo.seal(&messages[i])
+		if err != nil {
+			return 0, "", err
+		}
+		_, err = tx.Exec(
+			ctx,
+			`
+				UPDATE mail.message SET
+					sender = $2,
+					recipient = $3,
+					subject = $4,
+					text = $5
+				WHERE id = $1
+			`,
+			messages[i].ID,
+			sealed.from,
+			sealed.to,
+			sealed.subject,
+			sealed.text,
+		)
+		if err != nil {
+			return 0, "", err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	return len(messages), messages[len(messages)-1].ID.String(), nil
+}
@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type OfferRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewOfferRepo(pool *pgxpool.Pool) *OfferRepo {
+	return &OfferRepo{db: pool}
+}
+
+const offerColumns = `
+	id, card_id, position, salary, start_date, expires, status, approvals,
+	warnings, created, updated
+`
+
+func scanOffer(row pgx.Row, offer *entities.Offer) error {
+	var approvals []byte
+	err := row.Scan(
+		&offer.ID,
+		&offer.CardID,
+		&offer.Position,
+		&offer.Salary,
+		&offer.StartDate,
+		&offer.Expires,
+		&offer.Status,
+		&approvals,
+		&offer.Warnings,
+		&offer.Created,
+		&offer.Updated,
+	)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(approvals, &offer.Approvals)
+}
+
+func (repo *OfferRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Offer, error) {
+	var offer entities.Offer
+	err := scanOffer(
+		repo.db.QueryRow(
+			ctx,
+			`SELECT `+offerColumns+` FROM offer.offer WHERE id = $1`,
+			id.String(),
+		),
+		&offer,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrOfferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &offer, nil
+}
+
+func (repo *OfferRepo) List(
+	ctx context.Context,
+	cardID uuid.UUID,
+) ([]entities.Offer, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`
+			SELECT `+offerColumns+` FROM offer.offer
+			WHERE $1 = '' OR card_id = $1
+			ORDER BY created
+		`,
+		optionalID(cardID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	offers := make([]entities.Offer, 0, 10)
+	for rows.Next() {
+		offer := entities.Offer{}
+		err = scanOffer(rows, &offer)
+		if err != nil {
+			return nil, err
+		}
+		offers = append(offers, offer)
+	}
+
+	return offers, rows.Err()
+}
+
+func (repo *OfferRepo) Create(
+	ctx context.Context,
+	offer *entities.Offer,
+) error {
+	approvals, err := json.Marshal(offer.Approvals)
+	if err != nil {
+		return err
+	}
+
+	offer.ID = uuid.New()
+	offer.Created = time.Now()
+	offer.Updated = time.Now()
+
+	_, err = repo.db.Exec(
+		ctx,
+		`INSERT INTO offer.offer (`+offerColumns+`) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`,
+		offer.ID,
+		offer.CardID,
+		offer.Position,
+		offer.Salary,
+		offer.StartDate,
+		offer.Expires,
+		offer.Status.String(),
+		approvals,
+		offer.Warnings,
+		offer.Created,
+		offer.Updated,
+	)
+	return err
+}
+
+func (repo *OfferRepo) Update(
+	ctx context.Context,
+	offer *entities.Offer,
+) error {
+	approvals, err := json.Marshal(offer.Approvals)
+	if err != nil {
+		return err
+	}
+
+	offer.Updated = time.Now()
+
+	tag, err := repo.db.Exec(
+		ctx,
+		`
+			UPDATE offer.offer SET
+				position = $2,
+				salary = $3,
+				start_date = $4,
+				expires = $5,
+				status = $6,
+				approvals = $7,
+				warnings = $8,
+				updated = $9
+			WHERE id = $1
+		`,
+		offer.ID,
+		offer.Position,
+		offer.Salary,
+		offer.StartDate,
+		offer.Expires,
+		offer.Status.String(),
+		approvals,
+		offer.Warnings,
+		offer.Updated,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrOfferNotFound
+	}
+	return nil
+}
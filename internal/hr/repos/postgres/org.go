@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type OrgRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewOrgRepo(pool *pgxpool.Pool) *OrgRepo {
+	return &OrgRepo{db: pool}
+}
+
+func (repo *OrgRepo) Managers(ctx context.Context) (map[uuid.UUID][]string, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`SELECT department_id, email FROM org.manager ORDER BY department_id, position`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	managers := make(map[uuid.UUID][]string)
+	for rows.Next() {
+		var id uuid.UUID
+		var email string
+		err = rows.Scan(&id, &email)
+		if err != nil {
+			return nil, err
+		}
+		managers[id] = append(managers[id], email)
+	}
+	return managers, rows.Err()
+}
+
+func (repo *OrgRepo) SetManagers(
+	ctx context.Context,
+	departmentID uuid.UUID,
+	managers []string,
+) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `DELETE FROM org.manager WHERE department_id = $1`, departmentID.String())
+	if err != nil {
+		return err
+	}
+	for i, email := range managers {
+		_, err = tx.Exec(
+			ctx,
+			`INSERT INTO org.manager (department_id, email, position) VALUES($1,$2,$3)`,
+			departmentID.String(),
+			email,
+			i,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
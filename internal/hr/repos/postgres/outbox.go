@@ -0,0 +1,324 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+type OutboxRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepo(pool *pgxpool.Pool) *OutboxRepo {
+	return &OutboxRepo{db: pool}
+}
+
+// outboxLock serializes writers of the outbox, so events become visible in
+// the order of their sequence numbers and consumers never skip one.
+const outboxLock = 0x68720001
+
+// insertEvents writes events to the outbox within the given transaction.
+func insertEvents(ctx context.Context, tx pgx.Tx, events ...entities.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, outboxLock)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		_, err = tx.Exec(
+			ctx,
+			`
+				INSERT INTO outbox.event (id, type, aggregate_id, payload, created)
+				VALUES($1,$2,$3,$4,$5)
+			`,
+			event.ID,
+			event.Type.String(),
+			event.AggregateID,
+			[]byte(event.Payload),
+			event.Created,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// redactEvents blanks personal data in the events of the candidate and of
+// its cards, along with the webhook deliveries of those events, within the
+// given transaction. Errors of failed deliveries may quote the payload, so
+// they are cleared as well.
+func redactEvents(ctx context.Context, tx pgx.Tx, candidateID uuid.UUID) error {
+	rows, err := tx.Query(
+		ctx,
+		`
+			SELECT seq, id, type, aggregate_id, payload, created
+			FROM outbox.event
+			WHERE aggregate_id = $1
+			OR aggregate_id IN (SELECT id FROM card.card WHERE candidate_id = $1)
+			ORDER BY seq
+		`,
+		candidateID.String(),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var events []entities.Event
+	for rows.Next() {
+		event := entities.Event{}
+		var payload []byte
+		err = rows.Scan(
+			&event.Seq,
+			&event.ID,
+			&event.Type,
+			&event.AggregateID,
+			&payload,
+			&event.Created,
+		)
+		if err != nil {
+			return err
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+	rows.Close()
+
+	for _, event := range events {
+		err = event.Redact()
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE outbox.event SET payload = $2 WHERE seq = $1`,
+			event.Seq,
+			[]byte(event.Payload),
+		)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE webhook.delivery SET payload = $2, last_error = '' WHERE event_id = $1`,
+			event.ID.String(),
+			[]byte(event.Payload),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newEvents builds events of the given payloads concerning one aggregate.
+func newEvents(
+	aggregateID uuid.UUID,
+	payloads ...entities.EventPayload,
+) ([]entities.Event, error) {
+	events := make([]entities.Event, 0, len(payloads))
+	for _, payload := range payloads {
+		event, err := entities.NewEvent(aggregateID, payload)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Consume implements repos.OutboxRepo. The consumer position is locked for
+// the time of consumption, so several replicas never deliver the same batch
+// concurrently.
+func (repo *OutboxRepo) Consume(
+	ctx context.Context,
+	consumer string,
+	limit int,
+	fn func(context.Context, entities.Event) error,
+) (int, error) {
+	_, err := repo.db.Exec(
+		ctx,
+		`
+			INSERT INTO outbox.consumer (name, position, updated)
+			VALUES($1, 0, $2)
+			ON CONFLICT (name) DO NOTHING
+		`,
+		consumer,
+		time.Now(),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var position int64
+	err = tx.QueryRow(
+		ctx,
+		`
+			SELECT position FROM outbox.consumer
+			WHERE name = $1
+			FOR UPDATE SKIP LOCKED
+		`,
+		consumer,
+	).Scan(&position)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	events, err := after(ctx, tx, position, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	var fnErr error
+	for _, event := range events {
+		fnErr = fn(ctx, event)
+		if fnErr != nil {
+			break
+		}
+		position = event.Seq
+		n++
+	}
+
+	if n > 0 {
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE outbox.consumer SET position = $2, updated = $3 WHERE name = $1`,
+			consumer,
+			position,
+			time.Now(),
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return n, fnErr
+}
+
+// List implements repos.OutboxRepo.
+func (repo *OutboxRepo) List(
+	ctx context.Context,
+	position int64,
+	limit int,
+) ([]entities.Event, error) {
+	return after(ctx, repo.db, position, limit)
+}
+
+// Last implements repos.OutboxRepo.
+func (repo *OutboxRepo) Last(ctx context.Context) (int64, error) {
+	var seq int64
+	err := repo.db.QueryRow(
+		ctx,
+		`SELECT COALESCE(MAX(seq), 0) FROM outbox.event`,
+	).Scan(&seq)
+	return seq, err
+}
+
+// querier is implemented by both pool and transaction.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+func after(
+	ctx context.Context,
+	db querier,
+	position int64,
+	limit int,
+) ([]entities.Event, error) {
+	rows, err := db.Query(
+		ctx,
+		`
+			SELECT seq, id, type, aggregate_id, payload, created
+			FROM outbox.event
+			WHERE seq > $1
+			ORDER BY seq
+			LIMIT $2
+		`,
+		position,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]entities.Event, 0, limit)
+	for rows.Next() {
+		event := entities.Event{}
+		var payload []byte
+		err = rows.Scan(
+			&event.Seq,
+			&event.ID,
+			&event.Type,
+			&event.AggregateID,
+			&payload,
+			&event.Created,
+		)
+		if err != nil {
+			return nil, err
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// outboxChannel is notified by a trigger on every insert into the outbox.
+const outboxChannel = "outbox_event"
+
+// ListenOutbox calls fn whenever new events are committed to the outbox by
+// any replica. It holds one connection of the pool and returns when the
+// context is done or the connection fails.
+func (pg *Postgres) ListenOutbox(ctx context.Context, fn func()) error {
+	conn, err := pg.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `LISTEN `+outboxChannel)
+	if err != nil {
+		return err
+	}
+
+	for {
+		_, err = conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		fn()
+	}
+}
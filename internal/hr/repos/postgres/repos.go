@@ -5,18 +5,25 @@ import (
 	"log"
 	"time"
 
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 
 	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/pkg/keyring"
 )
 
 type Postgres struct {
+	repos.Repos
 	pool      *pgxpool.Pool
-	Candidate repos.CandidateRepo
-	Vacancy   repos.VacancyRepo
+	candidate *CandidateRepo
+	resume    *ResumeRepo
+	message   *MessageRepo
 }
 
-func New(uri string) (*Postgres, error) {
+// New connects to the database. Candidate contacts, resumes and mail are
+// encrypted with the keyring, nil keeps them as is.
+func New(uri string, keys *keyring.Keyring) (*Postgres, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	config, err := pgxpool.ParseConfig(uri)
@@ -35,12 +42,104 @@ func New(uri string) (*Postgres, error) {
 		return nil, err
 	}
 
+	candidate := NewCandidateRepo(pool, keys)
+	resume := NewResumeRepo(pool, keys)
+	message := NewMessageRepo(pool, keys)
 	return &Postgres{
-		pool:    pool,
-		Vacancy: NewVacancyRepo(pool),
+		pool:      pool,
+		candidate: candidate,
+		resume:    resume,
+		message:   message,
+		Repos: repos.Repos{
+			Candidate:   candidate,
+			Vacancy:     NewVacancyRepo(pool),
+			Card:        NewCardRepo(pool),
+			Outbox:      NewOutboxRepo(pool),
+			Webhook:     NewWebhookRepo(pool),
+			Delivery:    NewDeliveryRepo(pool),
+			Interview:   NewInterviewRepo(pool),
+			Scorecard:   NewScorecardRepo(pool),
+			Offer:       NewOfferRepo(pool),
+			Resume:      resume,
+			Attachment:  NewAttachmentRepo(pool),
+			Consent:     NewConsentRepo(pool),
+			Message:     message,
+			Skill:       NewSkillRepo(pool),
+			Dictionary:  NewDictionaryRepo(pool),
+			Org:         NewOrgRepo(pool),
+			Requisition: NewRequisitionRepo(pool),
+		},
 	}, nil
 }
 
+// RotateKeys re-encrypts candidates, their resumes and mail with the primary
+// key of the keyring. It returns the number of rows rewritten.
+func (pg *Postgres) RotateKeys(ctx context.Context) (int, error) {
+	rotated := 0
+	for _, rotate := range []func(context.Context) (int, error){
+		pg.candidate.Rotate,
+		pg.resume.Rotate,
+		pg.message.Rotate,
+	} {
+		n, err := rotate(ctx)
+		rotated += n
+		if err != nil {
+			return rotated, err
+		}
+	}
+	return rotated, nil
+}
+
+// rotateBatch is the number of rows re-encrypted in one transaction.
+const rotateBatch = 100
+
+// rotate calls batch with the last ID it returned until there are no more
+// rows and returns the number of rows rewritten.
+func rotate(
+	ctx context.Context,
+	batch func(ctx context.Context, after string) (int, string, error),
+) (int, error) {
+	rotated := 0
+	last := ""
+	for {
+		n, next, err := batch(ctx, last)
+		rotated += n
+		if err != nil || next == "" {
+			return rotated, err
+		}
+		last = next
+	}
+}
+
+// reseal seals the stored value of the field again with the primary key.
+func reseal(keys *keyring.Keyring, field, value string) (string, error) {
+	value, err := keys.Open(field, value)
+	if err != nil {
+		return "", err
+	}
+	return keys.Seal(field, value)
+}
+
+// txKey keys the transaction RequisitionRepo.Hold runs in.
+type txKey struct{}
+
+// conn is implemented by both pool and transaction.
+type conn interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// connFrom returns the transaction the context is held in, the pool
+// otherwise. Transactions begun on it are nested in the held one.
+func connFrom(ctx context.Context, pool *pgxpool.Pool) conn {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
 func (pg *Postgres) Close(ctx context.Context) error {
 	done := make(chan struct{})
 	go func() {
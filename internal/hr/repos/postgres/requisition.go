@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type RequisitionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewRequisitionRepo(pool *pgxpool.Pool) *RequisitionRepo {
+	return &RequisitionRepo{db: pool}
+}
+
+const requisitionColumns = `
+	id, title, department, positions, budget, salary_min, salary_max,
+	justification, requester, status, approvals, created, updated
+`
+
+func scanRequisition(row pgx.Row, r *entities.Requisition) error {
+	var approvals []byte
+	err := row.Scan(
+		&r.ID,
+		&r.Title,
+		&r.Department,
+		&r.Positions,
+		&r.Budget,
+		&r.SalaryMin,
+		&r.SalaryMax,
+		&r.Justification,
+		&r.Requester,
+		&r.Status,
+		&approvals,
+		&r.Created,
+		&r.Updated,
+	)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(approvals, &r.Approvals)
+}
+
+func (repo *RequisitionRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Requisition, error) {
+	var r entities.Requisition
+	err := scanRequisition(
+		connFrom(ctx, repo.db).QueryRow(
+			ctx,
+			`SELECT `+requisitionColumns+` FROM requisition.requisition WHERE id = $1`,
+			id.String(),
+		),
+		&r,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrRequisitionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (repo *RequisitionRepo) List(
+	ctx context.Context,
+	filter repos.RequisitionFilter,
+) ([]entities.Requisition, error) {
+	status := ""
+	if filter.Status != entities.RequisitionStatusNone {
+		status = filter.Status.String()
+	}
+	rows, err := repo.db.Query(
+		ctx,
+		`
+			SELECT `+requisitionColumns+` FROM requisition.requisition
+			WHERE ($1 = '' OR status::TEXT = $1)
+				AND ($2 = '' OR requester = $2)
+			ORDER BY created DESC
+		`,
+		status,
+		filter.Requester,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requisitions := make([]entities.Requisition, 0, 10)
+	for rows.Next() {
+		r := entities.Requisition{}
+		err = scanRequisition(rows, &r)
+		if err != nil {
+			return nil, err
+		}
+		requisitions = append(requisitions, r)
+	}
+	return requisitions, rows.Err()
+}
+
+func (repo *RequisitionRepo) Create(
+	ctx context.Context,
+	r *entities.Requisition,
+) error {
+	approvals, err := json.Marshal(r.Approvals)
+	if err != nil {
+		return err
+	}
+
+	r.ID = uuid.New()
+	r.Created = time.Now()
+	r.Updated = time.Now()
+
+	_, err = repo.db.Exec(
+		ctx,
+		`INSERT INTO requisition.requisition (`+requisitionColumns+`) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`,
+		r.ID,
+		r.Title,
+		r.Department,
+		r.Positions,
+		r.Budget,
+		r.SalaryMin,
+		r.SalaryMax,
+		r.Justification,
+		r.Requester,
+		r.Status.String(),
+		approvals,
+		r.Created,
+		r.Updated,
+	)
+	return err
+}
+
+func (repo *RequisitionRepo) Update(
+	ctx context.Context,
+	r *entities.Requisition,
+) error {
+	approvals, err := json.Marshal(r.Approvals)
+	if err != nil {
+		return err
+	}
+
+	r.Updated = time.Now()
+
+	err = repo.db.QueryRow(
+		ctx,
+		`
+			UPDATE requisition.requisition SET
+				title = $2,
+				department = $3,
+				positions = $4,
+				budget = $5,
+				salary_min = $6,
+				salary_max = $7,
+				justification = $8,
+				status = $9,
+				approvals = $10,
+				updated = $11
+			WHERE id = $1
+			RETURNING created
+		`,
+		r.ID,
+		r.Title,
+		r.Department,
+		r.Positions,
+		r.Budget,
+		r.SalaryMin,
+		r.SalaryMax,
+		r.Justification,
+		r.Status.String(),
+		approvals,
+		r.Updated,
+	).Scan(&r.Created)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repos.ErrRequisitionNotFound
+	}
+	return err
+}
+
+// Filled counts hired cards of vacancies by the requisitions the vacancies
+// are opened by.
+func (repo *RequisitionRepo) Filled(ctx context.Context) (map[uuid.UUID]int, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`
+			SELECT v.requisition_id, count(*) FROM card.card c
+			JOIN vacancy.vacancy v ON v.id = c.vacancy_id
+			WHERE c.stage = 'hired' AND v.requisition_id <> $1
+			GROUP BY v.requisition_id
+		`,
+		uuid.Nil.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	filled := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var id uuid.UUID
+		var n int
+		err = rows.Scan(&id, &n)
+		if err != nil {
+			return nil, err
+		}
+		filled[id] = n
+	}
+	return filled, rows.Err()
+}
+
+func (repo *RequisitionRepo) Hired(ctx context.Context, id uuid.UUID) (int, error) {
+	var n int
+	err := connFrom(ctx, repo.db).QueryRow(
+		ctx,
+		`
+			SELECT count(*) FROM card.card c
+			JOIN vacancy.vacancy v ON v.id = c.vacancy_id
+			WHERE v.requisition_id = $1 AND c.stage = 'hired'
+		`,
+		id.String(),
+	).Scan(&n)
+	return n, err
+}
+
+// Hold locks the requisition row for update and runs fn in the same
+// transaction, which repositories called with the context given to fn join.
+func (repo *RequisitionRepo) Hold(
+	ctx context.Context,
+	id uuid.UUID,
+	fn func(context.Context) error,
+) error {
+	tx, err := connFrom(ctx, repo.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var locked string
+	err = tx.QueryRow(
+		ctx,
+		`SELECT id FROM requisition.requisition WHERE id = $1 FOR UPDATE`,
+		id.String(),
+	).Scan(&locked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repos.ErrRequisitionNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	err = fn(context.WithValue(ctx, txKey{}, tx))
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,321 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/pkg/keyring"
+)
+
+// ResumeRepo keeps the original file, extracted text and draft of resumes
+// encrypted with the keyring.
+type ResumeRepo struct {
+	db   *pgxpool.Pool
+	keys *keyring.Keyring
+}
+
+func NewResumeRepo(pool *pgxpool.Pool, keys *keyring.Keyring) *ResumeRepo {
+	return &ResumeRepo{db: pool, keys: keys}
+}
+
+const resumeColumns = `
+	id, filename, content_type, size, text, draft, candidate_id, status,
+	created, updated
+`
+
+// Names of encrypted resume fields.
+const (
+	resumeTextField  = "resume_text"
+	resumeDraftField = "resume_draft"
+	resumeDataField  = "resume_data"
+)
+
+func (repo *ResumeRepo) scan(row pgx.Row, resume *entities.Resume) error {
+	var draft string
+	var candidateID string
+	err := row.Scan(
+		&resume.ID,
+		&resume.Filename,
+		&resume.ContentType,
+		&resume.Size,
+		&resume.Text,
+		&draft,
+		&candidateID,
+		&resume.Status,
+		&resume.Created,
+		&resume.Updated,
+	)
+	if err != nil {
+		return err
+	}
+	if candidateID != "" {
+		resume.CandidateID, err = uuid.Parse(candidateID)
+		if err != nil {
+			return err
+		}
+	}
+	resume.Text, err = repo.keys.Open(resumeTextField, resume.Text)
+	if err != nil {
+		return err
+	}
+	draft, err = repo.keys.Open(resumeDraftField, draft)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(draft), &resume.Draft)
+}
+
+// sealDraft encodes the draft as stored.
+func (repo *ResumeRepo) sealDraft(resume *entities.Resume) (string, error) {
+	draft, err := json.Marshal(resume.Draft)
+	if err != nil {
+		return "", err
+	}
+	return repo.keys.Seal(resumeDraftField, string(draft))
+}
+
+func (repo *ResumeRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Resume, error) {
+	var resume entities.Resume
+	err := repo.scan(
+		repo.db.QueryRow(
+			ctx,
+			`SELECT `+resumeColumns+` FROM resume.resume WHERE id = $1`,
+			id.String(),
+		),
+		&resume,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrResumeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resume, nil
+}
+
+func (repo *ResumeRepo) Data(
+	ctx context.Context,
+	id uuid.UUID,
+) ([]byte, error) {
+	var data []byte
+	err := repo.db.QueryRow(
+		ctx,
+		`SELECT data FROM resume.resume WHERE id = $1`,
+		id.String(),
+	).Scan(&data)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrResumeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	opened, err := repo.keys.Open(resumeDataField, string(data))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(opened), nil
+}
+
+func (repo *ResumeRepo) Create(
+	ctx context.Context,
+	resume *entities.Resume,
+	data []byte,
+) error {
+	text, err := repo.keys.Seal(resumeTextField, resume.Text)
+	if err != nil {
+		return err
+	}
+	draft, err := repo.sealDraft(resume)
+	if err != nil {
+		return err
+	}
+	sealedData, err := repo.keys.Seal(resumeDataField, string(data))
+	if err != nil {
+		return err
+	}
+
+	resume.ID = uuid.New()
+	resume.Created = time.Now()
+	resume.Updated = time.Now()
+
+	_, err = repo.db.Exec(
+		ctx,
+		`INSERT INTO resume.resume (`+resumeColumns+`, data) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`,
+		resume.ID,
+		resume.Filename,
+		resume.ContentType,
+		resume.Size,
+		text,
+		draft,
+		optionalID(resume.CandidateID),
+		resume.Status.String(),
+		resume.Created,
+		resume.Updated,
+		[]byte(sealedData),
+	)
+	return err
+}
+
+func (repo *ResumeRepo) Update(
+	ctx context.Context,
+	resume *entities.Resume,
+) error {
+	draft, err := repo.sealDraft(resume)
+	if err != nil {
+		return err
+	}
+
+	resume.Updated = time.Now()
+
+	tag, err := repo.db.Exec(
+		ctx,
+		`
+			UPDATE resume.resume SET
+				draft = $2,
+				candidate_id = $3,
+				status = $4,
+				updated = $5
+			WHERE id = $1
+		`,
+		resume.ID,
+		draft,
+		optionalID(resume.CandidateID),
+		resume.Status.String(),
+		resume.Updated,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrResumeNotFound
+	}
+	return nil
+}
+
+func (repo *ResumeRepo) List(
+	ctx context.Context,
+	candidateID uuid.UUID,
+) ([]entities.Resume, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`SELECT `+resumeColumns+` FROM resume.resume
+			WHERE candidate_id = $1
+			ORDER BY created`,
+		candidateID.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resumes := make([]entities.Resume, 0)
+	for rows.Next() {
+		var resume entities.Resume
+		err = repo.scan(rows, &resume)
+		if err != nil {
+			return nil, err
+		}
+		resumes = append(resumes, resume)
+	}
+	return resumes, rows.Err()
+}
+
+func (repo *ResumeRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := repo.db.Exec(ctx, `DELETE FROM resume.resume WHERE id = $1`, id.String())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrResumeNotFound
+	}
+	return nil
+}
+
+// Rotate seals text, drafts and files of all resumes again with the primary
+// key. It returns the number of resumes rewritten.
+func (repo *ResumeRepo) Rotate(ctx context.Context) (int, error) {
+	return rotate(ctx, repo.rotateBatch)
+}
+
+func (repo *ResumeRepo) rotateBatch(ctx context.Context, after string) (int, string, error) {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(
+		ctx,
+		`SELECT id, text, draft, data FROM resume.resume
+			WHERE id > $1 ORDER BY id LIMIT $2 FOR UPDATE`,
+		after,
+		rotateBatch,
+	)
+	if err != nil {
+		return 0, "", err
+	}
+	type stored struct {
+		id, text, draft string
+		data            []byte
+	}
+	var resumes []stored
+	for rows.Next() {
+		var r stored
+		err = rows.Scan(&r.id, &r.text, &r.draft, &r.data)
+		if err != nil {
+			rows.Close()
+			return 0, "", err
+		}
+		resumes = append(resumes, r)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, "", err
+	}
+	if len(resumes) == 0 {
+		return 0, "", nil
+	}
+
+	for _, r := range resumes {
+		text, err := reseal(repo.keys, resumeTextField, r.text)
+		if err != nil {
+			return 0, "", err
+		}
+		draft, err := reseal(repo.keys, resumeDraftField, r.draft)
+		if err != nil {
+			return 0, "", err
+		}
+		data, err := reseal(repo.keys, resumeDataField, string(r.data))
+		if err != nil {
+			return 0, "", err
+		}
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE resume.resume SET text = $2, draft = $3, data = $4 WHERE id = $1`,
+			r.id,
+			text,
+			draft,
+			[]byte(data),
+		)
+		if err != nil {
+			return 0, "", err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	return len(resumes), resumes[len(resumes)-1].id, nil
+}
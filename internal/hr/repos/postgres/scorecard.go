@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type ScorecardRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewScorecardRepo(pool *pgxpool.Pool) *ScorecardRepo {
+	return &ScorecardRepo{db: pool}
+}
+
+const scorecardColumns = `
+	id, interview_id, card_id, interviewer, ratings, recommendation, notes,
+	created, updated
+`
+
+func (repo *ScorecardRepo) List(
+	ctx context.Context,
+	filter repos.ScorecardFilter,
+) ([]entities.Scorecard, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`
+			SELECT `+scorecardColumns+` FROM scorecard.scorecard
+			WHERE ($1 = '' OR interview_id = $1) AND ($2 = '' OR card_id = $2)
+			ORDER BY created
+		`,
+		optionalID(filter.InterviewID),
+		optionalID(filter.CardID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scorecards := make([]entities.Scorecard, 0, 10)
+	for rows.Next() {
+		scorecard := entities.Scorecard{}
+		var ratings []byte
+		err = rows.Scan(
+			&scorecard.ID,
+			&scorecard.InterviewID,
+			&scorecard.CardID,
+			&scorecard.Interviewer,
+			&ratings,
+			&scorecard.Recommendation,
+			&scorecard.Notes,
+			&scorecard.Created,
+			&scorecard.Updated,
+		)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(ratings, &scorecard.Ratings)
+		if err != nil {
+			return nil, err
+		}
+		scorecards = append(scorecards, scorecard)
+	}
+
+	return scorecards, rows.Err()
+}
+
+func (repo *ScorecardRepo) Save(
+	ctx context.Context,
+	scorecard *entities.Scorecard,
+) error {
+	ratings, err := json.Marshal(scorecard.Ratings)
+	if err != nil {
+		return err
+	}
+
+	scorecard.ID = uuid.New()
+	scorecard.Created = time.Now()
+	scorecard.Updated = time.Now()
+
+	return repo.db.QueryRow(
+		ctx,
+		`
+			INSERT INTO scorecard.scorecard (`+scorecardColumns+`)
+			VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9)
+			ON CONFLICT (interview_id, interviewer) DO UPDATE SET
+				ratings = EXCLUDED.ratings,
+				recommendation = EXCLUDED.recommendation,
+				notes = EXCLUDED.notes,
+				updated = EXCLUDED.updated
+			RETURNING id, created
+		`,
+		scorecard.ID,
+		scorecard.InterviewID,
+		scorecard.CardID,
+		scorecard.Interviewer,
+		ratings,
+		scorecard.Recommendation.String(),
+		scorecard.Notes,
+		scorecard.Created,
+		scorecard.Updated,
+	).Scan(&scorecard.ID, &scorecard.Created)
+}
+
+// optionalID formats the id as stored in text columns, empty for uuid.Nil.
+func optionalID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
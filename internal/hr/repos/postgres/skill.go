@@ -0,0 +1,365 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type SkillRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSkillRepo(pool *pgxpool.Pool) *SkillRepo {
+	return &SkillRepo{db: pool}
+}
+
+const skillColumns = `id, name, synonyms, category, created, updated`
+
+// skillKey is entities.SkillKey of the column in SQL.
+func skillKey(column string) string {
+	return fmt.Sprintf(`lower(regexp_replace(%s, '[[:space:]._-]', '', 'g'))`, column)
+}
+
+func scanSkill(row pgx.Row, skill *entities.CatalogSkill) error {
+	return row.Scan(
+		&skill.ID,
+		&skill.Name,
+		&skill.Synonyms,
+		&skill.Category,
+		&skill.Created,
+		&skill.Updated,
+	)
+}
+
+func (repo *SkillRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.CatalogSkill, error) {
+	var skill entities.CatalogSkill
+	err := scanSkill(
+		repo.db.QueryRow(
+			ctx,
+			`SELECT `+skillColumns+` FROM skill.skill WHERE id = $1`,
+			id.String(),
+		),
+		&skill,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrSkillNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &skill, nil
+}
+
+func (repo *SkillRepo) List(
+	ctx context.Context,
+	filter repos.SkillFilter,
+) ([]entities.CatalogSkill, error) {
+	prefix := entities.SkillKey(filter.Prefix)
+	prefix = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+	rows, err := repo.db.Query(
+		ctx,
+		`
+			SELECT `+skillColumns+` FROM skill.skill
+			WHERE ($1 = '' OR id IN (SELECT skill_id FROM skill.term WHERE key LIKE $1 || '%'))
+				AND ($2 = '' OR category = $2)
+			ORDER BY name
+		`,
+		prefix,
+		filter.Category,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := make([]entities.CatalogSkill, 0)
+	for rows.Next() {
+		var skill entities.CatalogSkill
+		err = scanSkill(rows, &skill)
+		if err != nil {
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+	return skills, rows.Err()
+}
+
+func (repo *SkillRepo) Create(
+	ctx context.Context,
+	skill *entities.CatalogSkill,
+) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	skill.ID = uuid.New()
+	skill.Created = time.Now()
+	skill.Updated = time.Now()
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO skill.skill (`+skillColumns+`) VALUES($1,$2,$3,$4,$5,$6)`,
+		skill.ID.String(),
+		skill.Name,
+		skill.Synonyms,
+		skill.Category,
+		skill.Created,
+		skill.Updated,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = insertTerms(ctx, tx, skill)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (repo *SkillRepo) Update(
+	ctx context.Context,
+	skill *entities.CatalogSkill,
+) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = updateSkill(ctx, tx, skill)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func updateSkill(ctx context.Context, tx pgx.Tx, skill *entities.CatalogSkill) error {
+	skill.Updated = time.Now()
+	err := tx.QueryRow(
+		ctx,
+		`
+			UPDATE skill.skill SET
+				name = $2,
+				synonyms = $3,
+				category = $4,
+				updated = $5
+			WHERE id = $1
+			RETURNING created
+		`,
+		skill.ID.String(),
+		skill.Name,
+		skill.Synonyms,
+		skill.Category,
+		skill.Updated,
+	).Scan(&skill.Created)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repos.ErrSkillNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM skill.term WHERE skill_id = $1`, skill.ID.String())
+	if err != nil {
+		return err
+	}
+	return insertTerms(ctx, tx, skill)
+}
+
+// insertTerms indexes the name and the synonyms of the skill, failing if
+// another skill has any of them.
+func insertTerms(ctx context.Context, tx pgx.Tx, skill *entities.CatalogSkill) error {
+	seen := make(map[string]bool)
+	for _, key := range skill.Keys() {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		tag, err := tx.Exec(
+			ctx,
+			`INSERT INTO skill.term (key, skill_id) VALUES($1,$2) ON CONFLICT (key) DO NOTHING`,
+			key,
+			skill.ID.String(),
+		)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return repos.ErrSkillExists
+		}
+	}
+	return nil
+}
+
+func (repo *SkillRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := repo.db.Exec(
+		ctx,
+		`DELETE FROM skill.skill WHERE id = $1`,
+		id.String(),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrSkillNotFound
+	}
+	return nil
+}
+
+func (repo *SkillRepo) Merge(
+	ctx context.Context,
+	skill *entities.CatalogSkill,
+	duplicateID uuid.UUID,
+) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Terms of the duplicate go with it, so the skill can take them.
+	tag, err := tx.Exec(
+		ctx,
+		`DELETE FROM skill.skill WHERE id = $1`,
+		duplicateID.String(),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrSkillNotFound
+	}
+
+	err = updateSkill(ctx, tx, skill)
+	if err != nil {
+		return err
+	}
+	err = renameVacancySkills(ctx, tx, skill)
+	if err != nil {
+		return err
+	}
+	err = renameCandidateSkills(ctx, tx, skill)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// renameVacancySkills replaces other spellings of the skill in vacancies
+// with its name. A vacancy having several of them keeps one, important if
+// any of them is.
+func renameVacancySkills(ctx context.Context, tx pgx.Tx, skill *entities.CatalogSkill) error {
+	keys := skill.Keys()
+	rows, err := tx.Query(
+		ctx,
+		`
+			SELECT vacancy_id, bool_or(important) FROM vacancy.skill
+			WHERE `+skillKey("title")+` = ANY($1)
+			GROUP BY vacancy_id
+		`,
+		keys,
+	)
+	if err != nil {
+		return err
+	}
+	important := make(map[string]bool)
+	for rows.Next() {
+		var vacancyID string
+		var value bool
+		err = rows.Scan(&vacancyID, &value)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		important[vacancyID] = value
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`DELETE FROM vacancy.skill WHERE `+skillKey("title")+` = ANY($1)`,
+		keys,
+	)
+	if err != nil {
+		return err
+	}
+	for vacancyID, value := range important {
+		_, err = tx.Exec(
+			ctx,
+			`INSERT INTO vacancy.skill VALUES($1,$2,$3)`,
+			vacancyID,
+			skill.Name,
+			value,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameCandidateSkills replaces other spellings of the skill in candidates
+// with its name.
+func renameCandidateSkills(ctx context.Context, tx pgx.Tx, skill *entities.CatalogSkill) error {
+	rows, err := tx.Query(
+		ctx,
+		`
+			SELECT id, skills FROM candidate.candidate
+			WHERE EXISTS (
+				SELECT 1 FROM unnest(skills) s WHERE `+skillKey("s")+` = ANY($1)
+			)
+		`,
+		skill.Keys(),
+	)
+	if err != nil {
+		return err
+	}
+	skills := make(map[string][]string)
+	for rows.Next() {
+		var id string
+		var values []string
+		err = rows.Scan(&id, &values)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		skills[id] = values
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+
+	index := entities.NewSkillIndex([]entities.CatalogSkill{*skill})
+	for id, values := range skills {
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE candidate.candidate SET skills = $2 WHERE id = $1`,
+			id,
+			index.Names(values),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
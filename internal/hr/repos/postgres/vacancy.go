@@ -8,9 +8,11 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 
 	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
 )
 
 type VacancyRepo struct {
@@ -21,28 +23,15 @@ func NewVacancyRepo(pool *pgxpool.Pool) *VacancyRepo {
 	return &VacancyRepo{db: pool}
 }
 
-var ErrVacancyNotFound = errors.New("vacancy not found")
+const vacancyColumns = `
+	id, template_id, title, status, area, department, duties, requirements,
+	experience, budget, created, updated, hiring_manager, recruiter,
+	requisition_id, salary_min, salary_max, salary_currency, salary_gross,
+	salary_period, salary_public
+`
 
-func (repo *VacancyRepo) GetByID(
-	ctx context.Context,
-	id uuid.UUID,
-) (*entities.Vacancy, error) {
-	vacancyRows, err := repo.db.Query(
-		ctx,
-		`SELECT * FROM vacancy.vacancy WHERE id = $1`,
-		id.String(),
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer vacancyRows.Close()
-
-	if !vacancyRows.Next() {
-		return nil, ErrVacancyNotFound
-	}
-
-	var vacancy entities.Vacancy
-	err = vacancyRows.Scan(
+func scanVacancy(row pgx.Row, vacancy *entities.Vacancy) error {
+	return row.Scan(
 		&vacancy.ID,
 		&vacancy.TemplateID,
 		&vacancy.Title,
@@ -52,14 +41,43 @@ func (repo *VacancyRepo) GetByID(
 		&vacancy.Duties,
 		&vacancy.Requirements,
 		&vacancy.Experience,
+		&vacancy.Budget,
 		&vacancy.Created,
 		&vacancy.Updated,
+		&vacancy.HiringManager,
+		&vacancy.Recruiter,
+		&vacancy.RequisitionID,
+		&vacancy.Salary.Min,
+		&vacancy.Salary.Max,
+		&vacancy.Salary.Currency,
+		&vacancy.Salary.Gross,
+		&vacancy.Salary.Period,
+		&vacancy.Salary.Public,
+	)
+}
+
+func (repo *VacancyRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Vacancy, error) {
+	db := connFrom(ctx, repo.db)
+	var vacancy entities.Vacancy
+	err := scanVacancy(
+		db.QueryRow(
+			ctx,
+			`SELECT `+vacancyColumns+` FROM vacancy.vacancy WHERE id = $1`,
+			id.String(),
+		),
+		&vacancy,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrVacancyNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	skillRows, err := repo.db.Query(
+	skillRows, err := db.Query(
 		ctx,
 		`SELECT * FROM vacancy.skill WHERE vacancy_id = $1`,
 		id,
@@ -89,7 +107,7 @@ func (repo *VacancyRepo) Create(
 	ctx context.Context,
 	vacancy *entities.Vacancy,
 ) error {
-	tx, err := repo.db.Begin(ctx)
+	tx, err := connFrom(ctx, repo.db).Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -100,7 +118,7 @@ func (repo *VacancyRepo) Create(
 
 	_, err = tx.Exec(
 		ctx,
-		`INSERT INTO vacancy.vacancy VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`,
+		`INSERT INTO vacancy.vacancy (`+vacancyColumns+`) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21)`,
 		vacancy.ID,
 		vacancy.TemplateID,
 		vacancy.Title,
@@ -110,8 +128,18 @@ func (repo *VacancyRepo) Create(
 		vacancy.Duties,
 		vacancy.Requirements,
 		vacancy.Experience,
+		vacancy.Budget,
 		vacancy.Created,
 		vacancy.Updated,
+		vacancy.HiringManager,
+		vacancy.Recruiter,
+		vacancy.RequisitionID,
+		vacancy.Salary.Min,
+		vacancy.Salary.Max,
+		vacancy.Salary.Currency,
+		vacancy.Salary.Gross,
+		vacancy.Salary.Period.String(),
+		vacancy.Salary.Public,
 	)
 	if err != nil {
 		tx.Rollback(ctx)
@@ -132,6 +160,15 @@ func (repo *VacancyRepo) Create(
 		}
 	}
 
+	events, err := newEvents(vacancy.ID, entities.VacancyCreated{Vacancy: *vacancy})
+	if err == nil {
+		err = insertEvents(ctx, tx, events...)
+	}
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
@@ -140,8 +177,22 @@ func (repo *VacancyRepo) Update(
 	vacancy *entities.Vacancy,
 ) error {
 
-	tx, err := repo.db.Begin(ctx)
+	tx, err := connFrom(ctx, repo.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	var status entities.VacancyStatus
+	err = tx.QueryRow(
+		ctx,
+		`SELECT status FROM vacancy.vacancy WHERE id = $1 FOR UPDATE`,
+		vacancy.ID,
+	).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = repos.ErrVacancyNotFound
+	}
 	if err != nil {
+		tx.Rollback(ctx)
 		return err
 	}
 
@@ -150,7 +201,7 @@ func (repo *VacancyRepo) Update(
 	_, err = tx.Exec(
 		ctx,
 		`
-			UPDATES vacancy.vacancy SET
+			UPDATE vacancy.vacancy SET
 				template_id = $2,
 				title = $3,
 				status = $4,
@@ -159,7 +210,17 @@ func (repo *VacancyRepo) Update(
 				duties = $7,
 				requirements = $8,
 				experience = $9,
-				updated = $10
+				budget = $10,
+				updated = $11,
+				hiring_manager = $12,
+				recruiter = $13,
+				requisition_id = $14,
+				salary_min = $15,
+				salary_max = $16,
+				salary_currency = $17,
+				salary_gross = $18,
+				salary_period = $19,
+				salary_public = $20
 			WHERE id = $1
 		`,
 		vacancy.ID,
@@ -171,14 +232,24 @@ func (repo *VacancyRepo) Update(
 		vacancy.Duties,
 		vacancy.Requirements,
 		vacancy.Experience,
+		vacancy.Budget,
 		vacancy.Updated,
+		vacancy.HiringManager,
+		vacancy.Recruiter,
+		vacancy.RequisitionID,
+		vacancy.Salary.Min,
+		vacancy.Salary.Max,
+		vacancy.Salary.Currency,
+		vacancy.Salary.Gross,
+		vacancy.Salary.Period.String(),
+		vacancy.Salary.Public,
 	)
 	if err != nil {
 		tx.Rollback(ctx)
 		return err
 	}
 
-	tx.Exec(ctx, `DELETE FROM vacancy.skill WHERE vacancy_id = $1`, vacancy.ID)
+	_, err = tx.Exec(ctx, `DELETE FROM vacancy.skill WHERE vacancy_id = $1`, vacancy.ID)
 	if err != nil {
 		tx.Rollback(ctx)
 		return err
@@ -198,13 +269,30 @@ func (repo *VacancyRepo) Update(
 		}
 	}
 
+	payloads := []entities.EventPayload{entities.VacancyUpdated{Vacancy: *vacancy}}
+	if status != vacancy.Status {
+		payloads = append(payloads, entities.VacancyStatusChanged{
+			VacancyID: vacancy.ID,
+			From:      status,
+			To:        vacancy.Status,
+		})
+	}
+	events, err := newEvents(vacancy.ID, payloads...)
+	if err == nil {
+		err = insertEvents(ctx, tx, events...)
+	}
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
 func (repo *VacancyRepo) List(ctx context.Context) ([]entities.Vacancy, error) {
 	vacancyRows, err := repo.db.Query(
 		ctx,
-		`SELECT * FROM vacancy.vacancy ORDER BY updated desc`,
+		`SELECT `+vacancyColumns+` FROM vacancy.vacancy ORDER BY updated desc`,
 	)
 	if err != nil {
 		return nil, err
@@ -215,19 +303,7 @@ func (repo *VacancyRepo) List(ctx context.Context) ([]entities.Vacancy, error) {
 	index := make(map[uuid.UUID]int)
 	for vacancyRows.Next() {
 		vacancy := entities.Vacancy{}
-		err = vacancyRows.Scan(
-			&vacancy.ID,
-			&vacancy.TemplateID,
-			&vacancy.Title,
-			&vacancy.Status,
-			&vacancy.Area,
-			&vacancy.Department,
-			&vacancy.Duties,
-			&vacancy.Requirements,
-			&vacancy.Experience,
-			&vacancy.Created,
-			&vacancy.Updated,
-		)
+		err = scanVacancy(vacancyRows, &vacancy)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,357 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+type WebhookRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookRepo(pool *pgxpool.Pool) *WebhookRepo {
+	return &WebhookRepo{db: pool}
+}
+
+const webhookColumns = `id, url, events, secret, active, created, updated`
+
+func scanWebhook(row pgx.Row, webhook *entities.Webhook) error {
+	var events []string
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.URL,
+		&events,
+		&webhook.Secret,
+		&webhook.Active,
+		&webhook.Created,
+		&webhook.Updated,
+	)
+	if err != nil {
+		return err
+	}
+
+	webhook.Events = make([]entities.EventType, len(events))
+	for i, v := range events {
+		err = webhook.Events[i].UnmarshalText([]byte(v))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func eventTypeStrings(types []entities.EventType) []string {
+	v := make([]string, len(types))
+	for i, typ := range types {
+		v[i] = typ.String()
+	}
+	return v
+}
+
+func (repo *WebhookRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Webhook, error) {
+	var webhook entities.Webhook
+	err := scanWebhook(
+		repo.db.QueryRow(
+			ctx,
+			`SELECT `+webhookColumns+` FROM webhook.webhook WHERE id = $1`,
+			id.String(),
+		),
+		&webhook,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (repo *WebhookRepo) List(ctx context.Context) ([]entities.Webhook, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`SELECT `+webhookColumns+` FROM webhook.webhook ORDER BY created`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]entities.Webhook, 0, 100)
+	for rows.Next() {
+		webhook := entities.Webhook{}
+		err = scanWebhook(rows, &webhook)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+func (repo *WebhookRepo) Create(
+	ctx context.Context,
+	webhook *entities.Webhook,
+) error {
+	webhook.ID = uuid.New()
+	webhook.Created = time.Now()
+	webhook.Updated = time.Now()
+
+	_, err := repo.db.Exec(
+		ctx,
+		`INSERT INTO webhook.webhook (`+webhookColumns+`) VALUES($1,$2,$3,$4,$5,$6,$7)`,
+		webhook.ID,
+		webhook.URL,
+		eventTypeStrings(webhook.Events),
+		webhook.Secret,
+		webhook.Active,
+		webhook.Created,
+		webhook.Updated,
+	)
+	return err
+}
+
+func (repo *WebhookRepo) Update(
+	ctx context.Context,
+	webhook *entities.Webhook,
+) error {
+	webhook.Updated = time.Now()
+
+	tag, err := repo.db.Exec(
+		ctx,
+		`
+			UPDATE webhook.webhook SET
+				url = $2,
+				events = $3,
+				secret = $4,
+				active = $5,
+				updated = $6
+			WHERE id = $1
+		`,
+		webhook.ID,
+		webhook.URL,
+		eventTypeStrings(webhook.Events),
+		webhook.Secret,
+		webhook.Active,
+		webhook.Updated,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (repo *WebhookRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := repo.db.Exec(
+		ctx,
+		`DELETE FROM webhook.webhook WHERE id = $1`,
+		id.String(),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrWebhookNotFound
+	}
+	return nil
+}
+
+type DeliveryRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewDeliveryRepo(pool *pgxpool.Pool) *DeliveryRepo {
+	return &DeliveryRepo{db: pool}
+}
+
+const deliveryColumns = `
+	id, webhook_id, event_id, event_type, payload, status, attempts,
+	next_attempt, response_code, last_error, created, updated
+`
+
+func scanDelivery(row pgx.Row, delivery *entities.Delivery) error {
+	var payload []byte
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttempt,
+		&delivery.ResponseCode,
+		&delivery.LastError,
+		&delivery.Created,
+		&delivery.Updated,
+	)
+	delivery.Payload = payload
+	return err
+}
+
+func scanDeliveries(rows pgx.Rows) ([]entities.Delivery, error) {
+	defer rows.Close()
+
+	deliveries := make([]entities.Delivery, 0, 100)
+	for rows.Next() {
+		delivery := entities.Delivery{}
+		err := scanDelivery(rows, &delivery)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (repo *DeliveryRepo) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.Delivery, error) {
+	var delivery entities.Delivery
+	err := scanDelivery(
+		repo.db.QueryRow(
+			ctx,
+			`SELECT `+deliveryColumns+` FROM webhook.delivery WHERE id = $1`,
+			id.String(),
+		),
+		&delivery,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repos.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (repo *DeliveryRepo) List(
+	ctx context.Context,
+	webhookID uuid.UUID,
+) ([]entities.Delivery, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`
+			SELECT `+deliveryColumns+` FROM webhook.delivery
+			WHERE webhook_id = $1
+			ORDER BY created DESC
+			LIMIT 1000
+		`,
+		webhookID.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanDeliveries(rows)
+}
+
+func (repo *DeliveryRepo) Create(
+	ctx context.Context,
+	delivery *entities.Delivery,
+) error {
+	delivery.ID = uuid.New()
+	delivery.Created = time.Now()
+	delivery.Updated = time.Now()
+
+	_, err := repo.db.Exec(
+		ctx,
+		`
+			INSERT INTO webhook.delivery (`+deliveryColumns+`)
+			VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+			ON CONFLICT (webhook_id, event_id) DO NOTHING
+		`,
+		delivery.ID,
+		delivery.WebhookID,
+		delivery.EventID,
+		delivery.EventType.String(),
+		[]byte(delivery.Payload),
+		delivery.Status.String(),
+		delivery.Attempts,
+		delivery.NextAttempt,
+		delivery.ResponseCode,
+		delivery.LastError,
+		delivery.Created,
+		delivery.Updated,
+	)
+	return err
+}
+
+func (repo *DeliveryRepo) Update(
+	ctx context.Context,
+	delivery *entities.Delivery,
+) error {
+	delivery.Updated = time.Now()
+
+	tag, err := repo.db.Exec(
+		ctx,
+		`
+			UPDATE webhook.delivery SET
+				status = $2,
+				attempts = $3,
+				next_attempt = $4,
+				response_code = $5,
+				last_error = $6,
+				updated = $7
+			WHERE id = $1
+		`,
+		delivery.ID,
+		delivery.Status.String(),
+		delivery.Attempts,
+		delivery.NextAttempt,
+		delivery.ResponseCode,
+		delivery.LastError,
+		delivery.Updated,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repos.ErrDeliveryNotFound
+	}
+	return nil
+}
+
+func (repo *DeliveryRepo) Claim(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit int,
+) ([]entities.Delivery, error) {
+	rows, err := repo.db.Query(
+		ctx,
+		`
+			UPDATE webhook.delivery SET next_attempt = $2
+			WHERE id IN (
+				SELECT id FROM webhook.delivery
+				WHERE status = 'pending' AND next_attempt <= $1
+				ORDER BY next_attempt
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING `+deliveryColumns,
+		now,
+		now.Add(lease),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanDeliveries(rows)
+}
@@ -0,0 +1,22 @@
+package repos
+
+// Repos is a set of repositories services are built on.
+type Repos struct {
+	Candidate   CandidateRepo
+	Vacancy     VacancyRepo
+	Card        CardRepo
+	Outbox      OutboxRepo
+	Webhook     WebhookRepo
+	Delivery    DeliveryRepo
+	Interview   InterviewRepo
+	Scorecard   ScorecardRepo
+	Offer       OfferRepo
+	Resume      ResumeRepo
+	Attachment  AttachmentRepo
+	Consent     ConsentRepo
+	Message     MessageRepo
+	Skill       SkillRepo
+	Dictionary  DictionaryRepo
+	Org         OrgRepo
+	Requisition RequisitionRepo
+}
@@ -0,0 +1,33 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+type RequisitionFilter struct {
+	// Status limits requisitions to the status, all of them if none.
+	Status entities.RequisitionStatus
+	// Requester limits requisitions to ones of the user.
+	Requester string
+}
+
+type RequisitionRepo interface {
+	GetByID(context.Context, uuid.UUID) (*entities.Requisition, error)
+	// List returns requisitions matching the filter, the newest first.
+	List(context.Context, RequisitionFilter) ([]entities.Requisition, error)
+	Create(context.Context, *entities.Requisition) error
+	Update(context.Context, *entities.Requisition) error
+	// Filled counts hired cards of vacancies by the requisitions the
+	// vacancies are opened by.
+	Filled(context.Context) (map[uuid.UUID]int, error)
+	// Hired counts hired cards of vacancies opened by the requisition.
+	Hired(context.Context, uuid.UUID) (int, error)
+	// Hold runs fn while holding the requisition, so hires and openings
+	// checked and saved by fn are not raced by other holds of it. Saving
+	// with the context given to fn makes it a part of the hold.
+	Hold(ctx context.Context, id uuid.UUID, fn func(context.Context) error) error
+}
@@ -0,0 +1,20 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+type ResumeRepo interface {
+	GetByID(context.Context, uuid.UUID) (*entities.Resume, error)
+	// List returns resumes confirmed as the candidate ordered by upload time.
+	List(context.Context, uuid.UUID) ([]entities.Resume, error)
+	// Data returns the original uploaded file.
+	Data(context.Context, uuid.UUID) ([]byte, error)
+	Create(ctx context.Context, resume *entities.Resume, data []byte) error
+	Update(context.Context, *entities.Resume) error
+	Delete(context.Context, uuid.UUID) error
+}
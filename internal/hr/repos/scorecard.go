@@ -0,0 +1,24 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// ScorecardFilter selects scorecards of an interview or of all interviews
+// of a card.
+type ScorecardFilter struct {
+	InterviewID uuid.UUID
+	CardID      uuid.UUID
+}
+
+type ScorecardRepo interface {
+	// List returns scorecards matching the filter ordered by creation time.
+	List(context.Context, ScorecardFilter) ([]entities.Scorecard, error)
+	// Save stores the scorecard replacing one the interviewer submitted for
+	// the same interview before.
+	Save(context.Context, *entities.Scorecard) error
+}
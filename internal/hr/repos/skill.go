@@ -0,0 +1,31 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+type SkillFilter struct {
+	// Prefix matches the beginning of the name or of a synonym compared by
+	// entities.SkillKey.
+	Prefix   string
+	Category string
+}
+
+type SkillRepo interface {
+	GetByID(context.Context, uuid.UUID) (*entities.CatalogSkill, error)
+	// List returns skills matching the filter ordered by name.
+	List(context.Context, SkillFilter) ([]entities.CatalogSkill, error)
+	// Create and Update fail with ErrSkillExists if the name or a synonym
+	// is a name or a synonym of another skill.
+	Create(context.Context, *entities.CatalogSkill) error
+	Update(context.Context, *entities.CatalogSkill) error
+	Delete(context.Context, uuid.UUID) error
+	// Merge saves the skill having absorbed the duplicate and deletes the
+	// duplicate. Skills of vacancies and candidates written as any name or
+	// synonym of the skill are renamed to its name.
+	Merge(ctx context.Context, skill *entities.CatalogSkill, duplicateID uuid.UUID) error
+}
@@ -0,0 +1,31 @@
+package repos
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+type WebhookRepo interface {
+	GetByID(context.Context, uuid.UUID) (*entities.Webhook, error)
+	List(context.Context) ([]entities.Webhook, error)
+	Create(context.Context, *entities.Webhook) error
+	Update(context.Context, *entities.Webhook) error
+	Delete(context.Context, uuid.UUID) error
+}
+
+type DeliveryRepo interface {
+	GetByID(context.Context, uuid.UUID) (*entities.Delivery, error)
+	// List returns deliveries of the webhook, newest first.
+	List(context.Context, uuid.UUID) ([]entities.Delivery, error)
+	// Create stores the delivery unless the webhook already has one for
+	// the same event.
+	Create(context.Context, *entities.Delivery) error
+	Update(context.Context, *entities.Delivery) error
+	// Claim returns pending deliveries due at the given time and postpones
+	// their next attempt by lease, so concurrent senders skip them.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entities.Delivery, error)
+}
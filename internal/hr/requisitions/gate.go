@@ -0,0 +1,175 @@
+// Package requisitions keeps vacancies within approved headcount: a vacancy
+// opened by a requisition becomes active only once the requisition is
+// approved, and hires on its vacancies are counted against the positions
+// it requested.
+package requisitions
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+// Gate checks vacancies and hires against requisitions.
+type Gate struct {
+	requisition repos.RequisitionRepo
+	vacancy     repos.VacancyRepo
+	card        repos.CardRepo
+
+	// Required keeps vacancies without a requisition from becoming active.
+	Required bool
+	// Rates convert salaries of vacancies to compare them with salary bands.
+	Rates *entities.ExchangeRates
+}
+
+func New(r repos.Repos) *Gate {
+	return &Gate{
+		requisition: r.Requisition,
+		vacancy:     r.Vacancy,
+		card:        r.Card,
+		Rates:       entities.DefaultExchangeRates(),
+	}
+}
+
+// Filled counts hired cards of vacancies by the requisitions the vacancies
+// are opened by.
+func (g *Gate) Filled(ctx context.Context) (map[uuid.UUID]int, error) {
+	return g.requisition.Filled(ctx)
+}
+
+// CheckVacancy links the vacancy to the requisition it is opened by and
+// checks it may be saved, old is the stored vacancy or nil for new ones.
+// Updates leaving the requisition out keep the stored one. A vacancy
+// becoming active needs an approved requisition with positions left, one
+// without a requisition becomes active only if requisitions are not
+// required.
+func (g *Gate) CheckVacancy(ctx context.Context, vacancy, old *entities.Vacancy) error {
+	if old != nil && vacancy.RequisitionID == uuid.Nil {
+		vacancy.RequisitionID = old.RequisitionID
+	}
+
+	var requisition *entities.Requisition
+	if vacancy.RequisitionID != uuid.Nil {
+		var err error
+		requisition, err = g.requisition.GetByID(ctx, vacancy.RequisitionID)
+		if err != nil {
+			return err
+		}
+		err = requisition.FitVacancy(vacancy, g.Rates)
+		if err != nil {
+			return err
+		}
+	}
+
+	opening := vacancy.Status == entities.VacancyStatusActive &&
+		(old == nil || old.Status != entities.VacancyStatusActive || old.RequisitionID != vacancy.RequisitionID)
+	if !opening {
+		return nil
+	}
+	if requisition == nil {
+		if g.Required {
+			return entities.ErrRequisitionRequired
+		}
+		return nil
+	}
+	hired, err := g.requisition.Hired(ctx, requisition.ID)
+	if err != nil {
+		return err
+	}
+	return requisition.CheckOpening(hired)
+}
+
+// SaveVacancy checks the vacancy like CheckVacancy and saves it with save
+// while holding its requisition, so concurrent openings and hires do not
+// exceed the headcount.
+func (g *Gate) SaveVacancy(
+	ctx context.Context,
+	vacancy, old *entities.Vacancy,
+	save func(context.Context) error,
+) error {
+	if old != nil && vacancy.RequisitionID == uuid.Nil {
+		vacancy.RequisitionID = old.RequisitionID
+	}
+	check := func(ctx context.Context) error {
+		err := g.CheckVacancy(ctx, vacancy, old)
+		if err != nil {
+			return err
+		}
+		return save(ctx)
+	}
+	if vacancy.RequisitionID == uuid.Nil {
+		return check(ctx)
+	}
+	return g.requisition.Hold(ctx, vacancy.RequisitionID, check)
+}
+
+// CheckHire fails if the card moving to the stage would be a hire beyond
+// the headcount of the requisition its vacancy is opened by.
+func (g *Gate) CheckHire(ctx context.Context, cardID uuid.UUID, stage entities.CardStage) error {
+	requisitionID, err := g.hireRequisition(ctx, cardID, stage)
+	if err != nil || requisitionID == uuid.Nil {
+		return err
+	}
+	requisition, err := g.requisition.GetByID(ctx, requisitionID)
+	if err != nil {
+		return err
+	}
+	hired, err := g.requisition.Hired(ctx, requisition.ID)
+	if err != nil {
+		return err
+	}
+	if hired >= int(requisition.Positions) {
+		return entities.ErrHeadcountFilled
+	}
+	return nil
+}
+
+// MoveCard checks the card moving to the stage like CheckHire and moves it
+// with move while holding the requisition of a hire, so concurrent hires
+// and openings do not exceed the headcount.
+func (g *Gate) MoveCard(
+	ctx context.Context,
+	cardID uuid.UUID,
+	stage entities.CardStage,
+	move func(context.Context) error,
+) error {
+	requisitionID, err := g.hireRequisition(ctx, cardID, stage)
+	if err != nil {
+		return err
+	}
+	if requisitionID == uuid.Nil {
+		return move(ctx)
+	}
+	return g.requisition.Hold(ctx, requisitionID, func(ctx context.Context) error {
+		err := g.CheckHire(ctx, cardID, stage)
+		if err != nil {
+			return err
+		}
+		return move(ctx)
+	})
+}
+
+// hireRequisition returns the requisition the card moving to the stage is
+// hired against, uuid.Nil if the move is not a new hire on a vacancy opened
+// by a requisition.
+func (g *Gate) hireRequisition(
+	ctx context.Context,
+	cardID uuid.UUID,
+	stage entities.CardStage,
+) (uuid.UUID, error) {
+	if stage != entities.CardStageHired {
+		return uuid.Nil, nil
+	}
+	card, err := g.card.GetByID(ctx, cardID)
+	if err != nil || card.Stage == entities.CardStageHired {
+		return uuid.Nil, err
+	}
+	vacancy, err := g.vacancy.GetByID(ctx, card.VacancyID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return vacancy.RequisitionID, nil
+}
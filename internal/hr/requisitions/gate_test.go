@@ -0,0 +1,126 @@
+package requisitions
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos/memory"
+)
+
+func TestGate(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.New()
+	gate := New(mem.Repos())
+
+	requisition := entities.Requisition{
+		Title:         "Go developer",
+		Department:    "IT",
+		Positions:     1,
+		SalaryMax:     250000,
+		Justification: "New payments team",
+		Approvals:     []entities.Approval{{Role: "finance", Approver: "cfo@example.com"}},
+	}
+	requisition.Submit()
+	require.NoError(t, mem.Requisition.Create(ctx, &requisition))
+
+	vacancy := entities.Vacancy{Title: "Go developer", Status: entities.VacancyStatusActive}
+	require.NoError(t, gate.CheckVacancy(ctx, &vacancy, nil), "requisitions are not required")
+	gate.Required = true
+	require.ErrorIs(t, gate.CheckVacancy(ctx, &vacancy, nil), entities.ErrRequisitionRequired)
+
+	vacancy.RequisitionID = requisition.ID
+	require.ErrorIs(t, gate.CheckVacancy(ctx, &vacancy, nil), entities.ErrRequisitionNotApproved)
+	vacancy.Status = entities.VacancyStatusDraft
+	require.NoError(t, gate.CheckVacancy(ctx, &vacancy, nil), "drafts wait for approval")
+	require.Equal(t, "IT", vacancy.Department)
+	require.Equal(t, uint32(250000), vacancy.Budget)
+	require.NoError(t, mem.Vacancy.Create(ctx, &vacancy))
+
+	require.NoError(t, requisition.Decide("cfo@example.com", entities.DecisionApproved, "", requisition.Created))
+	require.NoError(t, mem.Requisition.Update(ctx, &requisition))
+	active := vacancy
+	active.Status = entities.VacancyStatusActive
+	active.RequisitionID = uuid.Nil
+	require.NoError(t, gate.CheckVacancy(ctx, &active, &vacancy), "the stored requisition is kept")
+	require.Equal(t, requisition.ID, active.RequisitionID)
+	require.NoError(t, mem.Vacancy.Update(ctx, &active))
+
+	candidate := entities.Candidate{Name: "John Doe"}
+	require.NoError(t, mem.Candidate.Create(ctx, &candidate))
+	first := entities.Card{VacancyID: vacancy.ID, CandidateID: candidate.ID, Stage: entities.CardStageOffer}
+	require.NoError(t, mem.Card.Create(ctx, &first))
+	second := entities.Card{VacancyID: vacancy.ID, CandidateID: uuid.New(), Stage: entities.CardStageOffer}
+	require.NoError(t, mem.Card.Create(ctx, &second))
+
+	require.NoError(t, gate.CheckHire(ctx, first.ID, entities.CardStageHired))
+	_, err := mem.Card.Move(ctx, first.ID, entities.CardStageHired)
+	require.NoError(t, err)
+	filled, err := gate.Filled(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]int{requisition.ID: 1}, filled)
+
+	require.NoError(t, gate.CheckHire(ctx, second.ID, entities.CardStageRejected))
+	require.ErrorIs(t, gate.CheckHire(ctx, second.ID, entities.CardStageHired), entities.ErrHeadcountFilled)
+
+	reopened := entities.Vacancy{Title: "Go developer", Status: entities.VacancyStatusActive, RequisitionID: requisition.ID}
+	require.ErrorIs(t, gate.CheckVacancy(ctx, &reopened, nil), entities.ErrHeadcountFilled)
+	reopened.Budget = 300000
+	reopened.Status = entities.VacancyStatusDraft
+	require.ErrorIs(t, gate.CheckVacancy(ctx, &reopened, nil), entities.ErrVacancyOverBand)
+}
+
+func TestGate_MoveCard(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.New()
+	gate := New(mem.Repos())
+
+	requisition := entities.Requisition{
+		Title:         "Go developer",
+		Positions:     1,
+		Justification: "New payments team",
+		Status:        entities.RequisitionStatusApproved,
+	}
+	require.NoError(t, mem.Requisition.Create(ctx, &requisition))
+	vacancy := entities.Vacancy{Title: "Go developer", Status: entities.VacancyStatusActive, RequisitionID: requisition.ID}
+	require.NoError(t, gate.SaveVacancy(ctx, &vacancy, nil, func(ctx context.Context) error {
+		return mem.Vacancy.Create(ctx, &vacancy)
+	}))
+
+	cards := make([]entities.Card, 5)
+	for i := range cards {
+		cards[i] = entities.Card{VacancyID: vacancy.ID, CandidateID: uuid.New(), Stage: entities.CardStageOffer}
+		require.NoError(t, mem.Card.Create(ctx, &cards[i]))
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(cards))
+	for i := range cards {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = gate.MoveCard(ctx, cards[i].ID, entities.CardStageHired, func(ctx context.Context) error {
+				_, err := mem.Card.Move(ctx, cards[i].ID, entities.CardStageHired)
+				return err
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	hired := 0
+	for _, err := range errs {
+		if err == nil {
+			hired++
+			continue
+		}
+		require.ErrorIs(t, err, entities.ErrHeadcountFilled)
+	}
+	require.Equal(t, 1, hired, "concurrent hires stay within the headcount")
+	filled, err := mem.Requisition.Hired(ctx, requisition.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, filled)
+}
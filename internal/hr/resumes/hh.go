@@ -0,0 +1,136 @@
+package resumes
+
+import (
+	"strings"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// hhMeta are service fields of hh.ru resumes which carry no candidate data.
+var hhMeta = []string{"id", "url", "alternate_url", "created_at", "updated_at", "download", "actions"}
+
+var hhEducationLevels = map[string]entities.EducationLevel{
+	"secondary":         entities.EducationLevelSecondary,
+	"special_secondary": entities.EducationLevelSpecialSecondary,
+	"unfinished_higher": entities.EducationLevelUnfinishedHigher,
+	"higher":            entities.EducationLevelHigher,
+	"bachelor":          entities.EducationLevelBachelor,
+	"master":            entities.EducationLevelMaster,
+	"candidate":         entities.EducationLevelCandidate,
+	"doctor":            entities.EducationLevelDoctor,
+}
+
+var hhGenders = map[string]entities.Gender{
+	"male":   entities.GenderMale,
+	"female": entities.GenderFemale,
+}
+
+// fromHH maps hh.ru resume (https://api.hh.ru/openapi/redoc#tag/Prosmotr-rezyume).
+func fromHH(doc node) entities.Candidate {
+	for _, name := range hhMeta {
+		doc.get(name).use()
+	}
+
+	candidate := entities.Candidate{
+		Name: join(
+			" ",
+			doc.get("last_name").str(),
+			doc.get("first_name").str(),
+			doc.get("middle_name").str(),
+		),
+		Specialization: doc.get("title").str(),
+		BirthDate:      doc.get("birth_date").date(),
+		Area:           doc.get("area.name").str(),
+	}
+	if candidate.Specialization == "" {
+		for _, role := range doc.get("professional_roles").items() {
+			candidate.Specialization = role.get("name").str()
+			break
+		}
+	}
+	doc.get("area").use()
+
+	if gender, ok := hhGenders[doc.get("gender.id").str()]; ok {
+		candidate.Gender = gender
+		doc.get("gender").use()
+	}
+
+	// Salaries of hh.ru resumes are monthly.
+	salary := doc.get("salary")
+	if currency, err := entities.NormalizeCurrency(salary.get("currency").str()); err == nil && currency != "" {
+		candidate.Salary = uint32(salary.get("amount").num())
+		candidate.SalaryCurrency = currency
+		candidate.SalaryPeriod = entities.SalaryPeriodMonth
+	} else {
+		delete(salary.used, salary.get("currency").path)
+	}
+
+	for _, contact := range doc.get("contact").items() {
+		kind := contact.get("type.id")
+		value := contact.get("value")
+		switch kind.str() {
+		case "cell", "home", "work":
+			if candidate.Phone != "" {
+				continue
+			}
+			candidate.Phone = value.get("formatted").str()
+			if candidate.Phone == "" {
+				candidate.Phone = "+" + join(
+					"",
+					value.get("country").str(),
+					value.get("city").str(),
+					value.get("number").str(),
+				)
+			}
+		case "email":
+			if candidate.Email != "" {
+				continue
+			}
+			candidate.Email = value.str()
+		default:
+			delete(kind.used, kind.path)
+			continue
+		}
+		value.use()
+		contact.get("type").use()
+	}
+
+	education := doc.get("education")
+	if level, ok := hhEducationLevels[education.get("level.id").str()]; ok {
+		candidate.EducationLevel = level
+		education.get("level").use()
+	}
+	for _, kind := range []string{"primary", "additional", "attestation"} {
+		for _, edu := range education.get(kind).items() {
+			candidate.Education = append(candidate.Education, entities.Education{
+				Title: join(
+					", ",
+					edu.get("name").str(),
+					edu.get("organization").str(),
+					edu.get("result").str(),
+				),
+				Year: uint32(edu.get("year").num()),
+			})
+			edu.get("id").use()
+		}
+	}
+
+	for _, work := range doc.get("experience").items() {
+		candidate.Experience = append(candidate.Experience, entities.Experience{
+			Title:       join(", ", work.get("position").str(), work.get("company").str()),
+			Description: work.get("description").str(),
+			Start:       work.get("start").date(),
+			End:         work.get("end").date(),
+		})
+	}
+
+	for _, language := range doc.get("language").items() {
+		candidate.Languages = appendUnique(candidate.Languages, language.get("name").str())
+		language.get("id").use()
+	}
+	for _, skill := range doc.get("skill_set").items() {
+		candidate.Skills = appendUnique(candidate.Skills, strings.TrimSpace(skill.str()))
+	}
+
+	return candidate
+}
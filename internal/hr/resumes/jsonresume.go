@@ -0,0 +1,104 @@
+package resumes
+
+import (
+	"strings"
+	"unicode"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// fromJSONResume maps JSON Resume (https://jsonresume.org/schema).
+func fromJSONResume(doc node) entities.Candidate {
+	doc.get("$schema").use()
+	doc.get("meta").use()
+
+	basics := doc.get("basics")
+	candidate := entities.Candidate{
+		Name:           basics.get("name").str(),
+		Email:          basics.get("email").str(),
+		Phone:          basics.get("phone").str(),
+		Specialization: basics.get("label").str(),
+		Area:           basics.get("location.city").str(),
+	}
+	if candidate.Area == "" {
+		candidate.Area = basics.get("location.region").str()
+	}
+
+	for _, work := range doc.get("work").items() {
+		description := []string{work.get("summary").str()}
+		for _, highlight := range work.get("highlights").items() {
+			description = append(description, highlight.str())
+		}
+		candidate.Experience = append(candidate.Experience, entities.Experience{
+			Title:       join(", ", work.get("position").str(), work.get("name").str()),
+			Description: join("\n", description...),
+			Start:       work.get("startDate").date(),
+			End:         work.get("endDate").date(),
+		})
+	}
+
+	for _, edu := range doc.get("education").items() {
+		education := entities.Education{
+			Title: join(", ", edu.get("institution").str(), edu.get("area").str()),
+		}
+		if end := edu.get("endDate").date(); end != nil {
+			education.Year = uint32(end.Year())
+		}
+		candidate.Education = append(candidate.Education, education)
+
+		studyType := edu.get("studyType")
+		level := studyLevel(studyType.str())
+		if level == entities.EducationLevelNone {
+			delete(studyType.used, studyType.path)
+		}
+		if level > candidate.EducationLevel {
+			candidate.EducationLevel = level
+		}
+	}
+
+	for _, skill := range doc.get("skills").items() {
+		candidate.Skills = appendUnique(candidate.Skills, skill.get("name").str())
+		for _, keyword := range skill.get("keywords").items() {
+			candidate.Skills = appendUnique(candidate.Skills, keyword.str())
+		}
+	}
+	for _, language := range doc.get("languages").items() {
+		candidate.Languages = appendUnique(candidate.Languages, language.get("language").str())
+	}
+
+	return candidate
+}
+
+// studyLevels maps words of free form degree names to education levels,
+// from the highest.
+var studyLevels = []struct {
+	words []string
+	level entities.EducationLevel
+}{
+	{[]string{"dsc", "doctor of science", "доктор"}, entities.EducationLevelDoctor},
+	{[]string{"phd", "ph.d", "кандидат"}, entities.EducationLevelCandidate},
+	{[]string{"master", "msc", "m.sc", "ma", "mba", "магистр"}, entities.EducationLevelMaster},
+	{[]string{"bachelor", "bsc", "b.sc", "ba", "bs", "бакалавр"}, entities.EducationLevelBachelor},
+	{[]string{"specialist", "специалист", "higher", "высшее"}, entities.EducationLevelHigher},
+	{[]string{"associate", "college", "колледж", "техникум"}, entities.EducationLevelSpecialSecondary},
+	{[]string{"high school", "secondary", "школа", "среднее"}, entities.EducationLevelSecondary},
+}
+
+func studyLevel(studyType string) entities.EducationLevel {
+	words := strings.FieldsFunc(strings.ToLower(studyType), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.'
+	})
+	for i, word := range words {
+		words[i] = strings.Trim(word, ".")
+	}
+	text := " " + strings.Join(words, " ") + " "
+	for _, v := range studyLevels {
+		for _, word := range v.words {
+			if strings.Contains(text, " "+word+" ") ||
+				len(word) > 4 && strings.Contains(text, word) {
+				return v.level
+			}
+		}
+	}
+	return entities.EducationLevelNone
+}
@@ -0,0 +1,307 @@
+// Package resumes maps resumes exported by other systems to candidates.
+//
+// Supported formats are JSON Resume (https://jsonresume.org/schema) and the
+// hh.ru resume JSON as returned by its API. Fields of the source document
+// which have no place in a candidate are reported, so recruiters can review
+// what would be lost before saving.
+package resumes
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// Format of a resume document.
+type Format string
+
+const (
+	FormatNone       Format = ""
+	FormatJSONResume Format = "jsonresume"
+	FormatHH         Format = "hh"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown resume format")
+	ErrNotObject     = errors.New("resume must be a JSON object")
+)
+
+// Import is a candidate mapped from a resume.
+type Import struct {
+	Format    Format             `json:"format"`
+	Candidate entities.Candidate `json:"candidate"`
+	// Unmapped lists paths of the source fields the candidate lacks, array
+	// elements are denoted by "[]", e.g. "work[].highlights".
+	Unmapped []string `json:"unmapped"`
+}
+
+// ParseFormat parses format name, empty name meaning detection.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(name); f {
+	case FormatNone, FormatJSONResume, FormatHH:
+		return f, nil
+	}
+	return FormatNone, fmt.Errorf("%w: %q", ErrUnknownFormat, name)
+}
+
+// Detect guesses format of the decoded resume document.
+func Detect(doc map[string]interface{}) Format {
+	if _, ok := doc["basics"]; ok {
+		return FormatJSONResume
+	}
+	for _, key := range []string{"first_name", "last_name", "skill_set", "contact"} {
+		if _, ok := doc[key]; ok {
+			return FormatHH
+		}
+	}
+	return FormatNone
+}
+
+// Parse maps the resume to a candidate. The format is detected if none is
+// given.
+func Parse(data []byte, format Format) (*Import, error) {
+	var doc map[string]interface{}
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, ErrNotObject
+	}
+
+	if format == FormatNone {
+		format = Detect(doc)
+	}
+	root := newNode(doc)
+	result := &Import{Format: format}
+	switch format {
+	case FormatJSONResume:
+		result.Candidate = fromJSONResume(root)
+	case FormatHH:
+		result.Candidate = fromHH(root)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	result.Unmapped = root.unmapped()
+	return result, nil
+}
+
+// Split splits bulk input into resume documents. The input is either a JSON
+// array of resumes or a stream of resumes, one or more.
+func Split(r io.Reader) ([]json.RawMessage, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []json.RawMessage
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		err = json.Unmarshal(data, &docs)
+		return docs, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc json.RawMessage
+		err = dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+}
+
+// node is a value of the decoded document which remembers the paths read
+// from it. Paths address array elements by index, e.g. "work[1].name".
+type node struct {
+	value interface{}
+	path  string
+	used  map[string]bool
+}
+
+func newNode(doc map[string]interface{}) node {
+	return node{value: doc, used: make(map[string]bool)}
+}
+
+// get returns the child at the dotted path.
+func (n node) get(path string) node {
+	for _, name := range strings.Split(path, ".") {
+		m, _ := n.value.(map[string]interface{})
+		child := node{value: m[name], path: name, used: n.used}
+		if n.path != "" {
+			child.path = n.path + "." + name
+		}
+		n = child
+	}
+	return n
+}
+
+// use marks the value and everything below it as mapped.
+func (n node) use() {
+	n.used[n.path] = true
+}
+
+func (n node) str() string {
+	switch v := n.value.(type) {
+	case string:
+		n.use()
+		return strings.TrimSpace(v)
+	case float64:
+		n.use()
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+func (n node) num() float64 {
+	switch v := n.value.(type) {
+	case float64:
+		n.use()
+		return v
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err == nil {
+			n.use()
+		}
+		return f
+	}
+	return 0
+}
+
+// date parses dates like 2006-01-02, 2006-01 or 2006.
+func (n node) date() *time.Time {
+	v, ok := n.value.(string)
+	if !ok {
+		return nil
+	}
+	for _, layout := range []string{"2006-01-02", "2006-01", "2006"} {
+		t, err := time.Parse(layout, strings.TrimSpace(v))
+		if err == nil {
+			n.use()
+			return &t
+		}
+	}
+	return nil
+}
+
+func (n node) items() []node {
+	values, _ := n.value.([]interface{})
+	items := make([]node, len(values))
+	for i, v := range values {
+		path := n.path + "[" + strconv.Itoa(i) + "]"
+		items[i] = node{value: v, path: path, used: n.used}
+	}
+	return items
+}
+
+func (n node) isUsed(path string) bool {
+	for {
+		if n.used[path] {
+			return true
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			return false
+		}
+		path = path[:i]
+	}
+}
+
+// unmapped returns sorted paths of non-empty values which were not read.
+func (n node) unmapped() []string {
+	set := make(map[string]bool)
+	var walk func(path string, v interface{})
+	walk = func(path string, v interface{}) {
+		if path != "" && n.isUsed(path) {
+			return
+		}
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for key, child := range v {
+				if path != "" {
+					key = path + "." + key
+				}
+				walk(key, child)
+			}
+		case []interface{}:
+			for i, child := range v {
+				walk(path+"["+strconv.Itoa(i)+"]", child)
+			}
+		case string:
+			if strings.TrimSpace(v) != "" {
+				set[elementsPath(path)] = true
+			}
+		case nil:
+		default:
+			set[elementsPath(path)] = true
+		}
+	}
+	walk("", n.value)
+
+	paths := make([]string, 0, len(set))
+	for path := range set {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// elementsPath replaces indexes of array elements in the path with "[]".
+func elementsPath(path string) string {
+	var b strings.Builder
+	index := false
+	for _, r := range path {
+		switch {
+		case r == '[':
+			index = true
+		case r == ']':
+			index = false
+			b.WriteString("[]")
+		case !index:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// join joins non-empty values.
+func join(sep string, values ...string) string {
+	parts := values[:0:0]
+	for _, v := range values {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// appendUnique appends values missing in the list.
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		found := false
+		for _, item := range list {
+			if strings.EqualFold(item, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,219 @@
+package resumes
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+func date(year int, month time.Month, day int) *time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func TestParse_JSONResume(t *testing.T) {
+	data, err := os.ReadFile("testdata/jsonresume.json")
+	require.NoError(t, err)
+
+	result, err := Parse(data, FormatNone)
+	require.NoError(t, err)
+	require.Exactly(t, FormatJSONResume, result.Format)
+	require.Exactly(t, entities.Candidate{
+		Name:           "John Doe",
+		Phone:          "+7 912 345-67-89",
+		Email:          "john@example.com",
+		Specialization: "Backend developer",
+		Area:           "Moscow",
+		EducationLevel: entities.EducationLevelMaster,
+		Education: []entities.Education{
+			{Title: "Moscow State University, Applied Mathematics", Year: 2016},
+		},
+		Experience: []entities.Experience{
+			{
+				Title:       "Senior Go developer, Example Bank",
+				Description: "Payments platform.\nCut latency by half\nLed a team of 4",
+				Start:       date(2019, time.March, 1),
+			},
+			{
+				Title: "Developer, Startup",
+				Start: date(2016, time.September, 1),
+				End:   date(2019, time.February, 28),
+			},
+		},
+		Languages: []string{"Russian", "English"},
+		Skills:    []string{"Go", "gRPC", "PostgreSQL", "SQL"},
+	}, result.Candidate)
+	require.Exactly(t, []string{
+		"basics.location.countryCode",
+		"basics.profiles[].network",
+		"basics.profiles[].url",
+		"basics.profiles[].username",
+		"basics.summary",
+		"basics.url",
+		"education[].score",
+		"education[].startDate",
+		"interests[].name",
+		"languages[].fluency",
+		"skills[].level",
+	}, result.Unmapped)
+}
+
+func TestParse_HH(t *testing.T) {
+	data, err := os.ReadFile("testdata/hh.json")
+	require.NoError(t, err)
+
+	result, err := Parse(data, FormatNone)
+	require.NoError(t, err)
+	require.Exactly(t, FormatHH, result.Format)
+	require.Exactly(t, entities.Candidate{
+		Name:           "Иванова Мария Петровна",
+		Phone:          "+7 (921) 123-45-67",
+		Email:          "maria@example.com",
+		Specialization: "Аналитик данных",
+		Gender:         entities.GenderFemale,
+		BirthDate:      date(1992, time.May, 17),
+		Area:           "Санкт-Петербург",
+		Salary:         180000,
+		SalaryCurrency: "RUB",
+		SalaryPeriod:   entities.SalaryPeriodMonth,
+		EducationLevel: entities.EducationLevelMaster,
+		Education: []entities.Education{
+			{Title: "СПбГУ, Математико-механический факультет, Статистика", Year: 2015},
+			{Title: "Яндекс Практикум, Аналитик данных", Year: 2019},
+		},
+		Experience: []entities.Experience{
+			{
+				Title:       "Аналитик, Ритейл",
+				Description: "Отчётность и A/B тесты.",
+				Start:       date(2015, time.September, 1),
+			},
+		},
+		Languages: []string{"Русский", "Английский"},
+		Skills:    []string{"SQL", "Python", "Tableau"},
+	}, result.Candidate)
+	require.Exactly(t, []string{
+		"contact[].preferred",
+		"contact[].type.id",
+		"contact[].type.name",
+		"contact[].value",
+		"experience[].company_url",
+		"language[].level.id",
+		"language[].level.name",
+		"photo.small",
+		"skills",
+	}, result.Unmapped)
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := Parse([]byte(`{"name": "John"}`), FormatNone)
+	require.ErrorIs(t, err, ErrUnknownFormat)
+
+	_, err = Parse([]byte(`null`), FormatHH)
+	require.ErrorIs(t, err, ErrNotObject)
+
+	result, err := Parse([]byte(`{"first_name": "John"}`), FormatJSONResume)
+	require.NoError(t, err)
+	require.Exactly(t, []string{"first_name"}, result.Unmapped)
+}
+
+func TestStudyLevel(t *testing.T) {
+	test := func(studyType string, want entities.EducationLevel) func(*testing.T) {
+		return func(t *testing.T) {
+			require.Exactly(t, want, studyLevel(studyType))
+		}
+	}
+
+	tests := []struct {
+		name      string
+		studyType string
+		want      entities.EducationLevel
+	}{
+		{name: "empty", studyType: "", want: entities.EducationLevelNone},
+		{name: "bachelor", studyType: "Bachelor of Arts", want: entities.EducationLevelBachelor},
+		{name: "abbreviation", studyType: "B.Sc.", want: entities.EducationLevelBachelor},
+		{name: "master", studyType: "MBA", want: entities.EducationLevelMaster},
+		{name: "phd", studyType: "PhD", want: entities.EducationLevelCandidate},
+		{name: "russian", studyType: "Магистратура", want: entities.EducationLevelMaster},
+		{name: "word part", studyType: "Mathematics", want: entities.EducationLevelNone},
+		{name: "unknown", studyType: "Course", want: entities.EducationLevelNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.studyType, tt.want))
+	}
+}
+
+func TestSplit(t *testing.T) {
+	test := func(input string, want int) func(*testing.T) {
+		return func(t *testing.T) {
+			docs, err := Split(strings.NewReader(input))
+			require.NoError(t, err)
+			require.Len(t, docs, want)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{name: "single", input: `{"basics": {}}`, want: 1},
+		{name: "array", input: ` [{"basics": {}}, {"first_name": "A"}]`, want: 2},
+		{name: "lines", input: "{\"basics\": {}}\n{\"first_name\": \"A\"}\n", want: 2},
+		{name: "empty", input: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.input, tt.want))
+	}
+}
+
+func TestFromText(t *testing.T) {
+	text := `Резюме
+Петров Пётр Сергеевич
+Телефон: +7 (916) 555-12-34
+Email: petr.petrov@example.com
+Backend разработчик, готов go на переезд
+
+Опыт работы
+03.2021 — по настоящее время
+ООО «Пример», ведущий разработчик
+Платформа платежей на Go и PostgreSQL.
+Внедрил Kafka.
+Sep 2018 – Feb 2021 Developer, Startup
+REST API.
+
+Образование
+МГУ, 2018
+
+Ключевые навыки
+Docker, gRPC, postgresql`
+
+	candidate := FromText(text, []string{"Go", "PostgreSQL", "Kafka", "Docker", "gRPC", "Java", "C++"})
+	require.Exactly(t, entities.Candidate{
+		Name:  "Петров Пётр Сергеевич",
+		Phone: "+7 (916) 555-12-34",
+		Email: "petr.petrov@example.com",
+		Experience: []entities.Experience{
+			{
+				Title:       "ООО «Пример», ведущий разработчик",
+				Description: "Платформа платежей на Go и PostgreSQL.\nВнедрил Kafka.",
+				Start:       date(2021, time.March, 1),
+			},
+			{
+				Title:       "Developer, Startup",
+				Description: "REST API.",
+				Start:       date(2018, time.September, 1),
+				End:         date(2021, time.February, 1),
+			},
+		},
+		Skills: []string{"Go", "PostgreSQL", "Kafka", "Docker", "gRPC"},
+	}, candidate)
+
+	require.Exactly(t, entities.Candidate{}, FromText("", nil))
+}
@@ -0,0 +1,260 @@
+package resumes
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(
+		`(?:\+\d{1,3}|8)?[\s\-]?\(?\d{3}\)?[\s\-]?\d{3}[\s\-]?\d{2}[\s\-]?\d{2}\b`,
+	)
+
+	// Headings of resume sections, the experience one starts work history.
+	experienceHeading = regexp.MustCompile(
+		`(?i)^(опыт работы|опыт|experience|work experience|professional experience|employment history)\s*:?$`,
+	)
+	sectionHeading = regexp.MustCompile(
+		`(?i)^(образование|education|навыки|ключевые навыки|skills|key skills|языки|знание языков|languages|` +
+			`о себе|обо мне|about|about me|summary|курсы|courses|дополнительная информация|additional information)\s*:?$`,
+	)
+
+	monthExpr = `(?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec|` +
+		`янв|фев|мар|апр|май|мая|июн|июл|авг|сен|окт|ноя|дек)\p{L}*`
+	dateExpr = `(?:\d{1,2}[./]\d{4}|` + monthExpr + `\.?\s+\d{4}|\d{4})`
+	// dateRange matches periods like "03.2019 — по настоящее время" or
+	// "Mar 2019 – Present".
+	dateRange = regexp.MustCompile(`(?i)(` + dateExpr + `)\s*[-–—]\s*(` + dateExpr +
+		`|по настоящее время|настоящее время|по н\.?\s?в\.?|н\.?\s?в\.?|сейчас|present|now|current)`)
+	numericDate = regexp.MustCompile(`^(\d{1,2})[./](\d{4})$`)
+	wordDate    = regexp.MustCompile(`^(\p{L}+)\.?\s+(\d{4})$`)
+)
+
+// resumeTitles are captions which open a resume instead of the name.
+var resumeTitles = map[string]bool{"резюме": true, "resume": true, "cv": true, "curriculum vitae": true}
+
+// monthPrefixes maps beginnings of month names to months.
+var monthPrefixes = []struct {
+	prefix string
+	month  time.Month
+}{
+	{"jan", time.January}, {"feb", time.February}, {"mar", time.March},
+	{"apr", time.April}, {"may", time.May}, {"jun", time.June},
+	{"jul", time.July}, {"aug", time.August}, {"sep", time.September},
+	{"oct", time.October}, {"nov", time.November}, {"dec", time.December},
+	{"янв", time.January}, {"фев", time.February}, {"мар", time.March},
+	{"апр", time.April}, {"май", time.May}, {"мая", time.May},
+	{"июн", time.June}, {"июл", time.July}, {"авг", time.August},
+	{"сен", time.September}, {"окт", time.October}, {"ноя", time.November},
+	{"дек", time.December},
+}
+
+// FromText guesses a candidate from the plain text of a resume: the name,
+// contacts, skills of the dictionary the text mentions and work experience.
+// The result is a draft to be reviewed by a recruiter.
+func FromText(text string, skills []string) entities.Candidate {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	candidate := entities.Candidate{
+		Name:       textName(lines),
+		Email:      emailPattern.FindString(text),
+		Skills:     textSkills(text, skills),
+		Experience: textExperience(lines),
+	}
+	if phone := phonePattern.FindString(text); phone != "" {
+		candidate.Phone = strings.TrimSpace(phone)
+	}
+	return candidate
+}
+
+// textName returns the first of the leading lines which looks like a full
+// name: two to four capitalized words.
+func textName(lines []string) string {
+	for i, line := range lines {
+		if i >= 10 {
+			break
+		}
+		if resumeTitles[strings.ToLower(line)] {
+			continue
+		}
+		words := strings.Fields(line)
+		if len(words) < 2 || len(words) > 4 {
+			continue
+		}
+		name := true
+		for _, word := range words {
+			first := []rune(word)[0]
+			if !unicode.IsUpper(first) || strings.IndexFunc(word, func(r rune) bool {
+				return !unicode.IsLetter(r) && r != '-' && r != '.'
+			}) >= 0 {
+				name = false
+				break
+			}
+		}
+		if name {
+			return line
+		}
+	}
+	return ""
+}
+
+// textSkills returns skills of the dictionary mentioned in the text as whole
+// words in order of appearance. Skills up to three characters long must
+// match case too, so "Go" is not found in "go to".
+func textSkills(text string, skills []string) []string {
+	lower := strings.ToLower(text)
+	type found struct {
+		skill string
+		pos   int
+	}
+	var result []found
+	for _, skill := range skills {
+		skill = strings.TrimSpace(skill)
+		if skill == "" {
+			continue
+		}
+		haystack, needle := lower, strings.ToLower(skill)
+		if len([]rune(skill)) <= 3 {
+			haystack, needle = text, skill
+		}
+		if pos := wordIndex(haystack, needle); pos >= 0 {
+			result = append(result, found{skill: skill, pos: pos})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].pos < result[j].pos
+	})
+
+	var list []string
+	for _, f := range result {
+		list = appendUnique(list, f.skill)
+	}
+	return list
+}
+
+// wordIndex returns position of the first occurrence of the word not
+// surrounded by letters or digits, -1 if there is none.
+func wordIndex(s, word string) int {
+	isWord := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	for offset := 0; offset < len(s); {
+		i := strings.Index(s[offset:], word)
+		if i < 0 {
+			return -1
+		}
+		start, end := offset+i, offset+i+len(word)
+		before := []rune(s[:start])
+		after := []rune(s[end:])
+		if (len(before) == 0 || !isWord(before[len(before)-1])) &&
+			(len(after) == 0 || !isWord(after[0])) {
+			return start
+		}
+		offset = start + 1
+	}
+	return -1
+}
+
+// textExperience splits the experience section into jobs, each starting
+// with a line holding its period. The rest of that line or, if it holds the
+// period only, the next line is the title, the following lines describe the
+// job.
+func textExperience(lines []string) []entities.Experience {
+	var experience []entities.Experience
+	section := false
+	var job *entities.Experience
+	var description []string
+	flush := func() {
+		if job != nil {
+			job.Description = strings.Join(description, "\n")
+			experience = append(experience, *job)
+		}
+		job, description = nil, nil
+	}
+
+	for _, line := range lines {
+		switch {
+		case experienceHeading.MatchString(line):
+			flush()
+			section = true
+			continue
+		case sectionHeading.MatchString(line):
+			flush()
+			section = false
+			continue
+		case !section:
+			continue
+		}
+
+		if loc := dateRange.FindStringSubmatchIndex(line); loc != nil {
+			start := parseTextDate(line[loc[2]:loc[3]])
+			if start != nil {
+				flush()
+				job = &entities.Experience{
+					Title: strings.Trim(line[:loc[0]]+" "+line[loc[1]:], " ,;:|-–—"),
+					Start: start,
+					End:   parseTextDate(line[loc[4]:loc[5]]),
+				}
+				job.Title = strings.Join(strings.Fields(job.Title), " ")
+				continue
+			}
+		}
+		switch {
+		case job == nil:
+		case job.Title == "":
+			job.Title = line
+		default:
+			description = append(description, line)
+		}
+	}
+	flush()
+	return experience
+}
+
+// parseTextDate parses dates like "03.2019", "март 2019", "Mar 2019" or
+// "2019", nil meaning the date is unknown or not yet come.
+func parseTextDate(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	year, month := 0, time.January
+	if m := numericDate.FindStringSubmatch(s); m != nil {
+		v, _ := strconv.Atoi(m[1])
+		if v < 1 || v > 12 {
+			return nil
+		}
+		month = time.Month(v)
+		year, _ = strconv.Atoi(m[2])
+	} else if m := wordDate.FindStringSubmatch(s); m != nil {
+		word := strings.ToLower(m[1])
+		month = 0
+		for _, p := range monthPrefixes {
+			if strings.HasPrefix(word, p.prefix) {
+				month = p.month
+				break
+			}
+		}
+		if month == 0 {
+			return nil
+		}
+		year, _ = strconv.Atoi(m[2])
+	} else if v, err := strconv.Atoi(s); err == nil {
+		year = v
+	}
+	if year < 1950 || year > 2100 {
+		return nil
+	}
+	t := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return &t
+}
@@ -0,0 +1,375 @@
+package services
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// defaultAttachmentLimit limits attachment size unless configured.
+const defaultAttachmentLimit = 20 << 20
+
+var (
+	errNotUploader    = errors.New("user is not the uploader of the attachment")
+	errNoBlobStore    = errors.New("attachment storage is not configured")
+	errAttachmentSize = errors.New("attachment is too large")
+)
+
+// ListCandidateAttachments returns attachments of the candidate and its
+// cards.
+func (srv *Server) ListCandidateAttachments(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	candidateID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error listing attachments: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	_, err = srv.candidate.GetByID(req.Context(), candidateID)
+	if err != nil {
+		log.Printf("[error] [server] error listing attachments: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	srv.listAttachments(w, req, candidateID, uuid.Nil)
+}
+
+// ListCardAttachments returns attachments of the card.
+func (srv *Server) ListCardAttachments(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	cardID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error listing attachments: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	_, err = srv.card.GetByID(req.Context(), cardID)
+	if err != nil {
+		log.Printf("[error] [server] error listing attachments: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	srv.listAttachments(w, req, uuid.Nil, cardID)
+}
+
+func (srv *Server) listAttachments(
+	w http.ResponseWriter,
+	req *http.Request,
+	candidateID uuid.UUID,
+	cardID uuid.UUID,
+) {
+	if requestUser(req) == "" {
+		log.Printf("[error] [server] error listing attachments: %s", errUserRequired)
+		writeError(w, errorStatus(errUserRequired), errUserRequired)
+		return
+	}
+
+	result, err := srv.attachment.List(req.Context(), candidateID, cardID)
+	if err != nil {
+		log.Printf("[error] [server] error listing attachments: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, ListAttachmentsResponse{Items: result})
+	if err != nil {
+		log.Printf("[error] [server] error listing attachments: %s", err)
+	}
+}
+
+// AttachToCandidate stores the file in the body as an attachment of the
+// candidate.
+func (srv *Server) AttachToCandidate(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	candidateID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error attaching file: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	_, err = srv.candidate.GetByID(req.Context(), candidateID)
+	if err != nil {
+		log.Printf("[error] [server] error attaching file: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	srv.attach(w, req, entities.Attachment{CandidateID: candidateID})
+}
+
+// AttachToCard stores the file in the body as an attachment of the card.
+func (srv *Server) AttachToCard(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	cardID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error attaching file: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	card, err := srv.card.GetByID(req.Context(), cardID)
+	if err != nil {
+		log.Printf("[error] [server] error attaching file: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	srv.attach(w, req, entities.Attachment{CandidateID: card.CandidateID, CardID: card.ID})
+}
+
+// attach spools the body to a temporary file computing its hash, sniffs the
+// content type and stores the contents unless a blob with the same hash is
+// already there. Content type claimed by the client is ignored.
+func (srv *Server) attach(
+	w http.ResponseWriter,
+	req *http.Request,
+	attachment entities.Attachment,
+) {
+	attachment.Uploader = requestUser(req)
+	if attachment.Uploader == "" {
+		log.Printf("[error] [server] error attaching file: %s", errUserRequired)
+		writeError(w, errorStatus(errUserRequired), errUserRequired)
+		return
+	}
+	if srv.blobs == nil {
+		log.Printf("[error] [server] error attaching file: %s", errNoBlobStore)
+		writeError(w, errorStatus(errNoBlobStore), errNoBlobStore)
+		return
+	}
+
+	tmp, err := os.CreateTemp("", "hr-attachment-*")
+	if err != nil {
+		log.Printf("[error] [server] error attaching file: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	body := http.MaxBytesReader(w, req.Body, srv.attachmentLimit)
+	attachment.Size, err = io.Copy(io.MultiWriter(tmp, hash), body)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		err = errAttachmentSize
+	}
+	if err != nil {
+		log.Printf("[error] [server] error attaching file: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	attachment.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	attachment.Filename = path.Base("/" + strings.ReplaceAll(req.URL.Query().Get("filename"), `\`, "/"))
+	if attachment.Filename == "/" {
+		attachment.Filename = ""
+	}
+	attachment.ContentType, err = sniffContentType(tmp, attachment.Size)
+	if err == nil {
+		err = attachment.Validate()
+	}
+	if err != nil {
+		log.Printf("[error] [server] error attaching file: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	key := attachment.BlobKey()
+	exists, err := srv.blobs.Exists(req.Context(), key)
+	if err == nil && !exists {
+		_, err = tmp.Seek(0, io.SeekStart)
+		if err == nil {
+			err = srv.blobs.Put(req.Context(), key, tmp, attachment.Size)
+		}
+	}
+	if err == nil {
+		err = srv.attachment.Create(req.Context(), &attachment)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error attaching file: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, attachment)
+	if err != nil {
+		log.Printf("[error] [server] error attaching file: %s", err)
+	}
+}
+
+// sniffContentType detects content type of the file by its first bytes.
+// Office Open XML documents are zip archives told apart by their parts.
+func sniffContentType(f *os.File, size int64) (string, error) {
+	head := make([]byte, 512)
+	n, err := f.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	contentType := http.DetectContentType(head[:n])
+	if contentType != "application/zip" {
+		return contentType, nil
+	}
+
+	archive, err := zip.NewReader(f, size)
+	if err != nil {
+		return contentType, nil
+	}
+	for _, file := range archive.File {
+		switch file.Name {
+		case "word/document.xml":
+			return "application/vnd.openxmlformats-officedocument.wordprocessingml.document", nil
+		case "xl/workbook.xml":
+			return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", nil
+		case "ppt/presentation.xml":
+			return "application/vnd.openxmlformats-officedocument.presentationml.presentation", nil
+		}
+	}
+	return contentType, nil
+}
+
+// GetAttachment returns attachment metadata.
+func (srv *Server) GetAttachment(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	attachmentID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get attachment: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	attachment, err := srv.userAttachment(req, attachmentID)
+	if err != nil {
+		log.Printf("[error] [server] error get attachment: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, attachment)
+	if err != nil {
+		log.Printf("[error] [server] error get attachment: %s", err)
+	}
+}
+
+// DownloadAttachment streams attachment contents. Files are always sent as
+// downloads so uploaded HTML or SVG never runs in the API origin.
+func (srv *Server) DownloadAttachment(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	attachmentID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error downloading attachment: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	attachment, err := srv.userAttachment(req, attachmentID)
+	if err == nil && srv.blobs == nil {
+		err = errNoBlobStore
+	}
+	if err != nil {
+		log.Printf("[error] [server] error downloading attachment: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	etag := `"` + attachment.SHA256 + `"`
+	w.Header().Set("ETag", etag)
+	if req.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	rc, err := srv.blobs.Get(req.Context(), attachment.BlobKey())
+	if err != nil {
+		log.Printf("[error] [server] error downloading attachment: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set(
+		"Content-Disposition",
+		mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+	)
+	w.WriteHeader(http.StatusOK)
+	_, err = io.Copy(w, rc)
+	if err != nil {
+		log.Printf("[error] [server] error downloading attachment: %s", err)
+	}
+}
+
+// DeleteAttachment deletes the attachment on behalf of its uploader. The
+// contents are removed once no attachment refers to them.
+func (srv *Server) DeleteAttachment(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	attachmentID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error deleting attachment: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	attachment, err := srv.userAttachment(req, attachmentID)
+	if err == nil && attachment.Uploader != requestUser(req) {
+		err = errNotUploader
+	}
+	if err == nil {
+		err = srv.attachment.Delete(req.Context(), attachment.ID)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error deleting attachment: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	count, err := srv.attachment.CountBySHA256(req.Context(), attachment.SHA256)
+	if err == nil && count == 0 && srv.blobs != nil {
+		err = srv.blobs.Delete(req.Context(), attachment.BlobKey())
+	}
+	if err != nil {
+		// The attachment is gone, a leftover blob only takes space.
+		log.Printf("[error] [server] error deleting attachment blob: %s", err)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// userAttachment returns the attachment if the request comes from a known
+// user.
+func (srv *Server) userAttachment(
+	req *http.Request,
+	attachmentID uuid.UUID,
+) (*entities.Attachment, error) {
+	if requestUser(req) == "" {
+		return nil, errUserRequired
+	}
+	return srv.attachment.GetByID(req.Context(), attachmentID)
+}
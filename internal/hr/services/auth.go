@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// userHeader carries email of the user making the request. The API does not
+// authenticate users itself, the header is set by the authenticating proxy
+// in front of it and trusted as is.
+const userHeader = "X-HR-User"
+
+// userMetadata carries email of the user making a gRPC call, set by the
+// proxy like userHeader.
+const userMetadata = "x-hr-user"
+
+var (
+	errUserRequired = errors.New("user is required")
+	errNotAdmin     = errors.New("user is not an admin")
+)
+
+// requestUser returns email of the user making the request, empty for
+// anonymous requests.
+func requestUser(req *http.Request) string {
+	return strings.TrimSpace(req.Header.Get(userHeader))
+}
+
+// contextUser returns email of the user making the gRPC call, empty for
+// anonymous calls.
+func contextUser(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, userMetadata)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
+
+// adminSet indexes emails of admins.
+func adminSet(emails []string) map[string]bool {
+	admins := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		admins[strings.ToLower(strings.TrimSpace(email))] = true
+	}
+	return admins
+}
+
+// requireAdmin fails unless the user making the request is an admin.
+func (srv *Server) requireAdmin(req *http.Request) error {
+	user := requestUser(req)
+	if user == "" {
+		return errUserRequired
+	}
+	if !srv.admins[strings.ToLower(user)] {
+		return errNotAdmin
+	}
+	return nil
+}
@@ -0,0 +1,432 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/internal/hr/resumes"
+)
+
+var errMergeItself = errors.New("candidate cannot be merged into itself")
+
+// ListCandidates returns a list of candidates. Candidates may be filtered by
+// the vacancy they applied to and by the vacancy paying the salary they
+// expect.
+func (srv *Server) ListCandidates(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	vacancyID, err := queryUUID(req, "vacancy")
+	if err != nil {
+		log.Printf("[error] [server] error listing candidates: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	fitsID, err := queryUUID(req, "fitsVacancy")
+	if err != nil {
+		log.Printf("[error] [server] error listing candidates: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var fits *entities.Vacancy
+	if fitsID != uuid.Nil {
+		fits, err = srv.vacancy.GetByID(req.Context(), fitsID)
+		if err != nil {
+			log.Printf("[error] [server] error listing candidates: %s", err)
+			writeError(w, errorStatus(err), err)
+			return
+		}
+	}
+
+	result, err := srv.candidate.List(req.Context(), vacancyID)
+	if err != nil {
+		log.Printf("[error] [server] error listing candidates: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	items := make([]Candidate, 0, len(result))
+	for _, candidate := range result {
+		if fits != nil && !srv.rates.Matches(fits, &candidate) {
+			continue
+		}
+		items = append(items, Candidate{
+			ID:             candidate.ID,
+			Name:           candidate.Name,
+			Specialization: candidate.Specialization,
+			Area:           candidate.Area,
+			Created:        candidate.Created,
+			Updated:        candidate.Updated,
+		})
+	}
+
+	token := ""
+	if len(items) > 0 {
+		token = items[len(items)-1].ID.String()
+	}
+
+	response := ListCandidatesResponse{
+		Items: items,
+		Token: token,
+	}
+	err = writeJSON(w, http.StatusOK, response)
+	if err != nil {
+		log.Printf("[error] [server] error listing candidates: %s", err)
+	}
+}
+
+// GetCandidate returns detailed information about specified candidate.
+func (srv *Server) GetCandidate(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	candidateID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get candidate: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	response, err := srv.candidate.GetByID(req.Context(), candidateID)
+	if errors.Is(err, repos.ErrCandidateNotFound) {
+		mergedID, redirectErr := srv.candidate.Redirect(req.Context(), candidateID)
+		if redirectErr == nil {
+			http.Redirect(w, req, "/candidates/"+mergedID.String(), http.StatusMovedPermanently)
+			return
+		}
+	}
+	if err != nil {
+		log.Printf("[error] [server] error get candidate: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, response)
+	if err != nil {
+		log.Printf("[error] [server] error get candidate: %s", err)
+	}
+}
+
+// CreateCandidate creates candidate with the given properties. Likely
+// duplicates of the candidate are listed in the response, it is still
+// created for a recruiter to decide whether to merge them.
+func (srv *Server) CreateCandidate(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	var candidate entities.Candidate
+	err := json.NewDecoder(req.Body).Decode(&candidate)
+	if err == nil {
+		err = candidate.Normalize()
+	}
+	if err == nil {
+		err = candidate.Validate()
+	}
+	if err == nil {
+		err = srv.rates.CheckCurrency(candidate.SalaryCurrency)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error creating candidate: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	dicts, err := srv.dictionaries(req.Context())
+	if err == nil {
+		err = candidate.NormalizeDictionaries(dicts)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error creating candidate: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	var duplicates []entities.Duplicate
+	skills, err := srv.skillIndex(req.Context())
+	if err == nil {
+		candidate.NormalizeSkills(skills)
+		duplicates, err = srv.duplicates(req.Context(), &candidate)
+	}
+	if err == nil {
+		err = srv.candidate.Create(req.Context(), &candidate)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error creating candidate: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := CreateCandidateResponse{Candidate: candidate, Duplicates: duplicates}
+	err = writeJSON(w, http.StatusOK, response)
+	if err != nil {
+		log.Printf("[error] [server] error creating candidate: %s", err)
+	}
+}
+
+// ImportCandidate maps JSON Resume or hh.ru resume in the body to a
+// candidate and saves it. With dryRun the candidate is only previewed, the
+// response lists source fields which would be lost either way.
+func (srv *Server) ImportCandidate(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	format, err := resumes.ParseFormat(req.URL.Query().Get("format"))
+	if err != nil {
+		log.Printf("[error] [server] error importing candidate: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	dryRun := req.URL.Query().Get("dryRun") == "true"
+
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		log.Printf("[error] [server] error importing candidate: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := resumes.Parse(data, format)
+	if err == nil {
+		err = result.Candidate.Normalize()
+	}
+	if err == nil {
+		err = result.Candidate.Validate()
+	}
+	if err != nil {
+		log.Printf("[error] [server] error importing candidate: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	skills, err := srv.skillIndex(req.Context())
+	if err == nil {
+		result.Candidate.NormalizeSkills(skills)
+	}
+	var dicts *entities.Dictionaries
+	if err == nil {
+		dicts, err = srv.dictionaries(req.Context())
+	}
+	if err == nil {
+		result.Candidate.FitDictionaries(dicts)
+	}
+	if err == nil && !dryRun {
+		err = srv.candidate.Create(req.Context(), &result.Candidate)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error importing candidate: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, result)
+	if err != nil {
+		log.Printf("[error] [server] error importing candidate: %s", err)
+	}
+}
+
+// UpdateCandidate updates properties of the given candidate.
+func (srv *Server) UpdateCandidate(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	candidateID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error updating candidate: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var candidate entities.Candidate
+	err = json.NewDecoder(req.Body).Decode(&candidate)
+	if err != nil {
+		log.Printf("[error] [server] error updating candidate: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	candidate.ID = candidateID
+
+	err = candidate.Normalize()
+	if err == nil {
+		err = candidate.Validate()
+	}
+	if err == nil {
+		err = srv.rates.CheckCurrency(candidate.SalaryCurrency)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error updating candidate: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stored, err := srv.candidate.GetByID(req.Context(), candidateID)
+	if err == nil && stored.Anonymized != nil {
+		err = entities.ErrCandidateAnonymized
+	}
+	if err == nil {
+		candidate.Anonymized = stored.Anonymized
+	}
+	var dicts *entities.Dictionaries
+	if err == nil {
+		dicts, err = srv.dictionaries(req.Context())
+	}
+	if err == nil {
+		err = candidate.NormalizeDictionaries(dicts)
+	}
+	var skills *entities.SkillIndex
+	if err == nil {
+		skills, err = srv.skillIndex(req.Context())
+	}
+	if err == nil {
+		candidate.NormalizeSkills(skills)
+		err = srv.candidate.Update(req.Context(), &candidate)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error updating candidate: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, candidate)
+	if err != nil {
+		log.Printf("[error] [server] error updating candidate: %s", err)
+	}
+}
+
+// ListDuplicates returns candidates which are likely the same person as the
+// given one.
+func (srv *Server) ListDuplicates(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	candidateID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error listing duplicates: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	candidate, err := srv.candidate.GetByID(req.Context(), candidateID)
+	if err != nil {
+		log.Printf("[error] [server] error listing duplicates: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	duplicates, err := srv.duplicates(req.Context(), candidate)
+	if err != nil {
+		log.Printf("[error] [server] error listing duplicates: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, ListDuplicatesResponse{Items: duplicates})
+	if err != nil {
+		log.Printf("[error] [server] error listing duplicates: %s", err)
+	}
+}
+
+// MergeCandidate merges the duplicate given in the body into the candidate.
+// The duplicate is removed, its ID redirects to the candidate.
+func (srv *Server) MergeCandidate(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	candidateID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error merging candidates: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var request MergeCandidateRequest
+	err = json.NewDecoder(req.Body).Decode(&request)
+	if err == nil && request.CandidateID == candidateID {
+		err = errMergeItself
+	}
+	if err != nil {
+		log.Printf("[error] [server] error merging candidates: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	candidate, err := srv.candidate.GetByID(req.Context(), candidateID)
+	if err != nil {
+		log.Printf("[error] [server] error merging candidates: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	duplicate, err := srv.candidate.GetByID(req.Context(), request.CandidateID)
+	if err != nil {
+		log.Printf("[error] [server] error merging candidates: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	if candidate.Anonymized != nil || duplicate.Anonymized != nil {
+		err = entities.ErrCandidateAnonymized
+		log.Printf("[error] [server] error merging candidates: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	candidate.Merge(duplicate)
+	skills, err := srv.skillIndex(req.Context())
+	var dicts *entities.Dictionaries
+	if err == nil {
+		candidate.NormalizeSkills(skills)
+		dicts, err = srv.dictionaries(req.Context())
+	}
+	if err == nil {
+		candidate.FitDictionaries(dicts)
+		err = srv.candidate.Merge(req.Context(), candidate, duplicate.ID)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error merging candidates: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, candidate)
+	if err != nil {
+		log.Printf("[error] [server] error merging candidates: %s", err)
+	}
+}
+
+// duplicates finds likely duplicates of the candidate among ones sharing its
+// phone, email or name, looked up by indexes rather than read in full.
+func (srv *Server) duplicates(
+	ctx context.Context,
+	candidate *entities.Candidate,
+) ([]entities.Duplicate, error) {
+	candidates, err := srv.candidate.FindByContacts(ctx, candidate.Phone, candidate.Email)
+	if err != nil {
+		return nil, err
+	}
+	named, err := srv.candidate.FindByName(ctx, candidate.Name)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[uuid.UUID]bool, len(candidates))
+	for _, c := range candidates {
+		found[c.ID] = true
+	}
+	for _, c := range named {
+		if !found[c.ID] {
+			candidates = append(candidates, c)
+		}
+	}
+	return entities.FindDuplicates(candidate, candidates), nil
+}
+
+// queryUUID parses optional uuid query parameter. It returns uuid.Nil when
+// the parameter is missing.
+func queryUUID(req *http.Request, name string) (uuid.UUID, error) {
+	v := req.URL.Query().Get(name)
+	if v == "" {
+		return uuid.Nil, nil
+	}
+	return uuid.Parse(v)
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// ListCards return a list of canban cards with the given filter.
+func (srv *Server) ListCards(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	vacancyID, err := queryUUID(req, "vacancy")
+	if err != nil {
+		log.Printf("[error] [server] error listing cards: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := srv.card.List(req.Context(), vacancyID)
+	if err != nil {
+		log.Printf("[error] [server] error listing cards: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	items := make([]Card, len(result))
+	for i, card := range result {
+		items[i] = Card{
+			ID:          card.ID,
+			VacancyID:   card.VacancyID,
+			CandidateID: card.CandidateID,
+			Stage:       card.Stage,
+			Created:     card.Created,
+			Updated:     card.Updated,
+		}
+	}
+
+	token := ""
+	if len(items) > 0 {
+		token = items[len(items)-1].ID.String()
+	}
+
+	response := ListCardsResponse{
+		Items: items,
+		Token: token,
+	}
+	err = writeJSON(w, http.StatusOK, response)
+	if err != nil {
+		log.Printf("[error] [server] error listing cards: %s", err)
+	}
+}
+
+// CreateCard puts candidate on the canban board of the vacancy.
+func (srv *Server) CreateCard(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	var card entities.Card
+	err := json.NewDecoder(req.Body).Decode(&card)
+	if err != nil {
+		log.Printf("[error] [server] error creating card: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = card.Validate()
+	if err != nil {
+		log.Printf("[error] [server] error creating card: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	_, err = srv.vacancy.GetByID(req.Context(), card.VacancyID)
+	if err == nil {
+		_, err = srv.candidate.GetByID(req.Context(), card.CandidateID)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error creating card: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = srv.card.Create(req.Context(), &card)
+	if err != nil {
+		log.Printf("[error] [server] error creating card: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, card)
+	if err != nil {
+		log.Printf("[error] [server] error creating card: %s", err)
+	}
+}
+
+// GetCard returns detailed information about specified canban card.
+func (srv *Server) GetCard(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	cardID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get card: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	response, err := srv.card.GetByID(req.Context(), cardID)
+	if err != nil {
+		log.Printf("[error] [server] error get card: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	response.Feedback, err = srv.cardFeedback(req.Context(), requestUser(req), cardID)
+	if err != nil {
+		log.Printf("[error] [server] error get card: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, response)
+	if err != nil {
+		log.Printf("[error] [server] error get card: %s", err)
+	}
+}
+
+var errStageRequired = errors.New("stage is required")
+
+// MoveCard moves card to the specified column.
+func (srv *Server) MoveCard(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	cardID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error moving card: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var request MoveCardRequest
+	err = json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error moving card: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if request.Stage == entities.CardStageNone {
+		writeError(w, http.StatusBadRequest, errStageRequired)
+		return
+	}
+
+	var response *entities.Card
+	err = srv.requisitions.MoveCard(req.Context(), cardID, request.Stage, func(ctx context.Context) error {
+		response, err = srv.card.Move(ctx, cardID, request.Stage)
+		return err
+	})
+	if err != nil {
+		log.Printf("[error] [server] error moving card: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, response)
+	if err != nil {
+		log.Printf("[error] [server] error moving card: %s", err)
+	}
+}
+
+var errCommentTextRequired = errors.New("comment text is required")
+
+// AddComment adds comments to the specified card.
+func (srv *Server) AddComment(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	cardID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error adding comment: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var request AddCommentRequest
+	err = json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error adding comment: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if request.Text == "" {
+		writeError(w, http.StatusBadRequest, errCommentTextRequired)
+		return
+	}
+
+	comment := entities.Comment{
+		Author: request.Author,
+		Text:   request.Text,
+	}
+	err = srv.card.AddComment(req.Context(), cardID, &comment)
+	if err != nil {
+		log.Printf("[error] [server] error adding comment: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, comment)
+	if err != nil {
+		log.Printf("[error] [server] error adding comment: %s", err)
+	}
+}
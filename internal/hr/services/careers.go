@@ -0,0 +1,307 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/careers"
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/pkg/textract"
+)
+
+// defaultCareersLimit is the number of applications a client may send per
+// hour.
+const defaultCareersLimit = 10
+
+// honeypotField is hidden from people by the careers site, only bots fill
+// it in.
+const honeypotField = "website"
+
+var errTooManyApplications = errors.New("too many applications, try again later")
+
+// ApplicationReceipt confirms the application without telling anything
+// about the candidate to the public.
+type ApplicationReceipt struct {
+	Received time.Time `json:"received"`
+}
+
+// ListPublicVacancies returns active vacancies without internal fields. The
+// salary query parameter hides vacancies publishing a salary lower than the
+// one given.
+func (srv *Server) ListPublicVacancies(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	expected, bySalary, err := srv.expectedSalary(req)
+	if err != nil {
+		log.Printf("[error] [server] error listing public vacancies: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	all, err := srv.careers.Vacancies(req.Context())
+	if err != nil {
+		log.Printf("[error] [server] error listing public vacancies: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	items := make([]careers.Vacancy, 0, len(all))
+	for _, item := range all {
+		if bySalary && item.Salary != nil && !srv.rates.Fits(item.Salary, expected) {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	err = writeJSON(w, http.StatusOK, map[string]interface{}{"items": items})
+	if err != nil {
+		log.Printf("[error] [server] error listing public vacancies: %s", err)
+	}
+}
+
+// GetPublicVacancy returns the active vacancy without internal fields.
+func (srv *Server) GetPublicVacancy(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	vacancyID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get public vacancy: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	vacancy, err := srv.careers.Vacancy(req.Context(), vacancyID)
+	if err != nil {
+		log.Printf("[error] [server] error get public vacancy: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, vacancy)
+	if err != nil {
+		log.Printf("[error] [server] error get public vacancy: %s", err)
+	}
+}
+
+// Apply accepts an application form with an optional resume file. A new
+// candidate, consents and a card in the first stage are created without a
+// recruiter, known candidates with the same contacts are left for recruiters
+// to merge. Clients are rate limited by address and forms with the
+// honeypot filled in are dropped, the bots get the usual answer.
+func (srv *Server) Apply(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	ok, wait := srv.careersLimit.Allow(srv.clientAddress(req))
+	if !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		log.Printf("[error] [server] error applying: %s", errTooManyApplications)
+		writeError(w, http.StatusTooManyRequests, errTooManyApplications)
+		return
+	}
+
+	vacancyID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error applying: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, maxResumeSize+1<<20)
+	err = req.ParseMultipartForm(maxResumeSize)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		log.Printf("[error] [server] error applying: %s", err)
+		writeError(w, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+	if err != nil {
+		log.Printf("[error] [server] error applying: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	receipt := ApplicationReceipt{Received: time.Now()}
+	if req.FormValue(honeypotField) != "" {
+		log.Printf("[info] [server] application from %s dropped by honeypot", srv.clientAddress(req))
+		err = writeJSON(w, http.StatusAccepted, receipt)
+		if err != nil {
+			log.Printf("[error] [server] error applying: %s", err)
+		}
+		return
+	}
+
+	app, code, err := srv.applicationForm(req)
+	if err == nil {
+		app.VacancyID = vacancyID
+		err = app.Validate()
+		code = http.StatusBadRequest
+	}
+	if err != nil {
+		log.Printf("[error] [server] error applying: %s", err)
+		writeError(w, code, err)
+		return
+	}
+
+	result, err := srv.careers.Apply(req.Context(), app)
+	if err != nil {
+		log.Printf("[error] [server] error applying: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	log.Printf("[info] [server] application to vacancy %s saved as card %s", vacancyID, result.CardID)
+	for _, duplicate := range result.Duplicates {
+		log.Printf(
+			"[info] [server] applicant %s is a likely duplicate of candidate %s",
+			result.CandidateID,
+			duplicate.CandidateID,
+		)
+	}
+
+	err = writeJSON(w, http.StatusAccepted, receipt)
+	if err != nil {
+		log.Printf("[error] [server] error applying: %s", err)
+	}
+}
+
+// applicationForm reads the parsed form. Fields of the form take precedence
+// over the ones guessed from the resume. On error it also returns the status
+// to answer with.
+func (srv *Server) applicationForm(req *http.Request) (*careers.Application, int, error) {
+	app := &careers.Application{
+		Message:    strings.TrimSpace(req.FormValue("message")),
+		Consent:    formBool(req.FormValue("consent")),
+		TalentPool: formBool(req.FormValue("talentPool")),
+	}
+
+	file, header, err := req.FormFile("resume")
+	if errors.Is(err, http.ErrMissingFile) {
+		err = nil
+	} else if err == nil {
+		defer file.Close()
+		var data []byte
+		data, err = io.ReadAll(file)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+
+		resume := &entities.Resume{
+			Filename: path.Base("/" + strings.ReplaceAll(header.Filename, `\`, "/")),
+			Size:     int64(len(data)),
+			Status:   entities.ResumeStatusDraft,
+		}
+		if resume.Filename == "/" {
+			resume.Filename = "resume"
+		}
+		err = resume.Validate()
+		if err == nil {
+			resume.Text, resume.ContentType, err = textract.Extract(data)
+		}
+		if errors.Is(err, textract.ErrUnsupported) {
+			return nil, http.StatusUnsupportedMediaType, err
+		}
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+
+		resume.Draft, err = srv.draftCandidate(req.Context(), resume.Text)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		app.Candidate = resume.Draft
+		app.Resume, app.ResumeData = resume, data
+	}
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	for field, value := range map[string]*string{
+		"name":  &app.Candidate.Name,
+		"email": &app.Candidate.Email,
+		"phone": &app.Candidate.Phone,
+		"area":  &app.Candidate.Area,
+	} {
+		if v := strings.TrimSpace(req.FormValue(field)); v != "" {
+			*value = v
+		}
+	}
+	return app, http.StatusOK, nil
+}
+
+// formBool reads a checkbox, browsers send "on" for checked ones.
+func formBool(value string) bool {
+	return value == "on" || value == "true" || value == "1"
+}
+
+// clientAddress returns the address of the client. Behind a trusted proxy
+// it is the last address in X-Forwarded-For, the one the proxy appended;
+// earlier ones are sent by the client and can not be trusted. Clients
+// connecting directly may send anything in the header, so it is ignored.
+func (srv *Server) clientAddress(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	if !srv.trustedProxy(host) {
+		return host
+	}
+	if values := req.Header.Values("X-Forwarded-For"); len(values) > 0 {
+		addresses := strings.Split(values[len(values)-1], ",")
+		address := strings.TrimSpace(addresses[len(addresses)-1])
+		if address != "" {
+			return address
+		}
+	}
+	return host
+}
+
+// trustedProxy reports whether the address belongs to a trusted proxy.
+func (srv *Server) trustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range srv.trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseProxies parses addresses and CIDR ranges of proxies, an address is
+// a range of itself.
+func parseProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid proxy address %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipnet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, ipnet)
+	}
+	return nets, nil
+}
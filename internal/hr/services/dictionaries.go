@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+// dictionaries returns the dictionaries areas, departments and
+// specializations of vacancies and candidates are checked with.
+func dictionaries(ctx context.Context, dictionary repos.DictionaryRepo) (*entities.Dictionaries, error) {
+	items, err := dictionary.List(ctx, repos.DictionaryFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewDictionaries(items), nil
+}
+
+func (srv *Server) dictionaries(ctx context.Context) (*entities.Dictionaries, error) {
+	return dictionaries(ctx, srv.dictionary)
+}
+
+// dictionaryKind returns the dictionary of the request path, unknown ones
+// are not found.
+func dictionaryKind(req *http.Request) (entities.DictionaryKind, error) {
+	var kind entities.DictionaryKind
+	err := kind.UnmarshalText([]byte(mux.Vars(req)["kind"]))
+	if err != nil || kind == entities.DictionaryKindNone {
+		return kind, errNotFound
+	}
+	return kind, nil
+}
+
+// dictionaryItem returns the item of the request path, items of other
+// dictionaries are not found.
+func (srv *Server) dictionaryItem(req *http.Request) (*entities.DictionaryItem, error) {
+	kind, err := dictionaryKind(req)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		return nil, errNotFound
+	}
+	item, err := srv.dictionary.GetByID(req.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Kind != kind {
+		return nil, repos.ErrDictionaryNotFound
+	}
+	return item, nil
+}
+
+// ListDictionary returns items of the dictionary ordered by name. The parent
+// query parameter lists children of the item, prefix autocompletes by the
+// beginning of the name.
+func (srv *Server) ListDictionary(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	kind, err := dictionaryKind(req)
+	if err != nil {
+		log.Printf("[error] [server] error listing dictionary: %s", err)
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	parentID, err := queryUUID(req, "parent")
+	if err != nil {
+		log.Printf("[error] [server] error listing dictionary: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	items, err := srv.dictionary.List(req.Context(), repos.DictionaryFilter{
+		Kind:     kind,
+		ParentID: parentID,
+		Prefix:   req.URL.Query().Get("prefix"),
+	})
+	if err != nil {
+		log.Printf("[error] [server] error listing dictionary: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, ListDictionaryResponse{Items: items})
+	if err != nil {
+		log.Printf("[error] [server] error listing dictionary: %s", err)
+	}
+}
+
+// GetDictionaryItem returns the given item of the dictionary.
+func (srv *Server) GetDictionaryItem(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	item, err := srv.dictionaryItem(req)
+	if err != nil {
+		log.Printf("[error] [server] error get dictionary item: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, item)
+	if err != nil {
+		log.Printf("[error] [server] error get dictionary item: %s", err)
+	}
+}
+
+// CreateDictionaryItem adds the item to the dictionary, nested in the parent
+// if one is given.
+func (srv *Server) CreateDictionaryItem(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	kind, err := dictionaryKind(req)
+	if err != nil {
+		log.Printf("[error] [server] error creating dictionary item: %s", err)
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	var request DictionaryItemRequest
+	err = json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error creating dictionary item: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	item := entities.DictionaryItem{
+		Kind:     kind,
+		Name:     request.Name,
+		ParentID: request.ParentID,
+	}
+	err = srv.checkDictionaryItem(req.Context(), &item)
+	if err != nil {
+		log.Printf("[error] [server] error creating dictionary item: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = srv.dictionary.Create(req.Context(), &item)
+	if err != nil {
+		log.Printf("[error] [server] error creating dictionary item: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, item)
+	if err != nil {
+		log.Printf("[error] [server] error creating dictionary item: %s", err)
+	}
+}
+
+// UpdateDictionaryItem renames the item or moves it to another parent.
+// Vacancies and candidates having the old name get the new one.
+func (srv *Server) UpdateDictionaryItem(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	item, err := srv.dictionaryItem(req)
+	if err != nil {
+		log.Printf("[error] [server] error updating dictionary item: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	var request DictionaryItemRequest
+	err = json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error updating dictionary item: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	item.Name = request.Name
+	item.ParentID = request.ParentID
+	err = srv.checkDictionaryItem(req.Context(), item)
+	if err != nil {
+		log.Printf("[error] [server] error updating dictionary item: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = srv.dictionary.Update(req.Context(), item)
+	if err != nil {
+		log.Printf("[error] [server] error updating dictionary item: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, item)
+	if err != nil {
+		log.Printf("[error] [server] error updating dictionary item: %s", err)
+	}
+}
+
+// DeleteDictionaryItem removes the item from the dictionary. Items having
+// children or used by vacancies and candidates are kept.
+func (srv *Server) DeleteDictionaryItem(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	item, err := srv.dictionaryItem(req)
+	if err == nil {
+		err = srv.dictionary.Delete(req.Context(), item.ID)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error deleting dictionary item: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkDictionaryItem normalizes the item and checks it fits the tree of its
+// dictionary.
+func (srv *Server) checkDictionaryItem(ctx context.Context, item *entities.DictionaryItem) error {
+	item.Normalize()
+	err := item.Validate()
+	if err != nil {
+		return err
+	}
+	d, err := srv.dictionaries(ctx)
+	if err != nil {
+		return err
+	}
+	return d.CheckParent(item)
+}
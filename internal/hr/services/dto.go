@@ -9,13 +9,15 @@ import (
 )
 
 type Vacancy struct {
-	ID         uuid.UUID              `json:"id"`
-	Title      string                 `json:"title"`
-	Status     entities.VacancyStatus `json:"status"`
-	Area       string                 `json:"area"`
-	Department string                 `json:"department"`
-	Created    time.Time              `json:"created"`
-	Updated    time.Time              `json:"updated"`
+	ID            uuid.UUID              `json:"id"`
+	Title         string                 `json:"title"`
+	Status        entities.VacancyStatus `json:"status"`
+	Area          string                 `json:"area"`
+	Department    string                 `json:"department"`
+	HiringManager string                 `json:"hiringManager"`
+	Recruiter     string                 `json:"recruiter"`
+	Created       time.Time              `json:"created"`
+	Updated       time.Time              `json:"updated"`
 }
 
 type ListVacanciesResponse struct {
@@ -27,3 +29,184 @@ type ErrorResponse struct {
 	Code int    `json:"code"`
 	Text string `json:"message"`
 }
+
+type Candidate struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	Specialization string    `json:"specialization"`
+	Area           string    `json:"area"`
+	Created        time.Time `json:"created"`
+	Updated        time.Time `json:"updated"`
+}
+
+type ListCandidatesResponse struct {
+	Items []Candidate `json:"items"`
+	Token string      `json:"token,omitempty"`
+}
+
+type Card struct {
+	ID          uuid.UUID          `json:"id"`
+	VacancyID   uuid.UUID          `json:"vacancyID"`
+	CandidateID uuid.UUID          `json:"candidateID"`
+	Stage       entities.CardStage `json:"stage"`
+	Created     time.Time          `json:"created"`
+	Updated     time.Time          `json:"updated"`
+}
+
+type ListCardsResponse struct {
+	Items []Card `json:"items"`
+	Token string `json:"token,omitempty"`
+}
+
+type MoveCardRequest struct {
+	Stage entities.CardStage `json:"stage"`
+}
+
+type AddCommentRequest struct {
+	Author string `json:"author"`
+	Text   string `json:"text"`
+}
+
+// Webhook is a webhook as exposed by the API. The secret is write-only.
+type Webhook struct {
+	ID      uuid.UUID            `json:"id"`
+	URL     string               `json:"url"`
+	Events  []entities.EventType `json:"events"`
+	Active  bool                 `json:"active"`
+	Created time.Time            `json:"created"`
+	Updated time.Time            `json:"updated"`
+}
+
+type ListWebhooksResponse struct {
+	Items []Webhook `json:"items"`
+}
+
+type WebhookRequest struct {
+	URL    string               `json:"url"`
+	Events []entities.EventType `json:"events"`
+	// Secret may be omitted on update to keep the current one.
+	Secret string `json:"secret,omitempty"`
+	Active *bool  `json:"active,omitempty"`
+}
+
+type ListDeliveriesResponse struct {
+	Items []entities.Delivery `json:"items"`
+}
+
+type ListInterviewsResponse struct {
+	Items []entities.Interview `json:"items"`
+}
+
+type ScorecardRequest struct {
+	Ratings        []entities.Rating       `json:"ratings"`
+	Recommendation entities.Recommendation `json:"recommendation"`
+	Notes          string                  `json:"notes"`
+}
+
+type ListScorecardsResponse struct {
+	Items []entities.Scorecard `json:"items"`
+	// Hidden is the number of scorecards the user may not read yet.
+	Hidden int `json:"hidden"`
+}
+
+// OfferRequest creates an offer. Position defaults to the vacancy title and
+// approvals to the chain configured on the server, only admins may give
+// another one.
+type OfferRequest struct {
+	CardID    uuid.UUID           `json:"cardID"`
+	Position  string              `json:"position,omitempty"`
+	Salary    uint32              `json:"salary"`
+	StartDate time.Time           `json:"startDate"`
+	Expires   time.Time           `json:"expires"`
+	Approvals []entities.Approval `json:"approvals,omitempty"`
+}
+
+type DecisionRequest struct {
+	Comment string `json:"comment"`
+}
+
+type ListOffersResponse struct {
+	Items []entities.Offer `json:"items"`
+}
+
+type CreateCandidateResponse struct {
+	entities.Candidate
+	// Duplicates are candidates likely being the same person.
+	Duplicates []entities.Duplicate `json:"duplicates,omitempty"`
+}
+
+type ListDuplicatesResponse struct {
+	Items []entities.Duplicate `json:"items"`
+}
+
+type MergeCandidateRequest struct {
+	// CandidateID is the duplicate to merge.
+	CandidateID uuid.UUID `json:"candidateID"`
+}
+
+type ListConsentsResponse struct {
+	Items []entities.Consent `json:"items"`
+}
+
+type ListAttachmentsResponse struct {
+	Items []entities.Attachment `json:"items"`
+}
+
+type ListMessagesResponse struct {
+	Items []entities.Message `json:"items"`
+}
+
+type SkillRequest struct {
+	Name     string   `json:"name"`
+	Synonyms []string `json:"synonyms"`
+	Category string   `json:"category"`
+}
+
+type ListSkillsResponse struct {
+	Items []entities.CatalogSkill `json:"items"`
+}
+
+type MergeSkillRequest struct {
+	// SkillID is the duplicate to merge.
+	SkillID uuid.UUID `json:"skillID"`
+}
+
+type DictionaryItemRequest struct {
+	Name string `json:"name"`
+	// ParentID nests the item in another item of the dictionary.
+	ParentID uuid.UUID `json:"parentID"`
+}
+
+type ListDictionaryResponse struct {
+	Items []entities.DictionaryItem `json:"items"`
+}
+
+type ListDepartmentsResponse struct {
+	Items []entities.Department `json:"items"`
+}
+
+type SetManagersRequest struct {
+	Managers []string `json:"managers"`
+}
+
+// RequisitionRequest requests headcount, the requester is the user making
+// the request and approvals follow the chain configured on the server.
+type RequisitionRequest struct {
+	Title         string `json:"title"`
+	Department    string `json:"department,omitempty"`
+	Positions     uint32 `json:"positions"`
+	Budget        uint32 `json:"budget,omitempty"`
+	SalaryMin     uint32 `json:"salaryMin,omitempty"`
+	SalaryMax     uint32 `json:"salaryMax,omitempty"`
+	Justification string `json:"justification"`
+}
+
+type Requisition struct {
+	entities.Requisition
+	// Filled is the number of hires on vacancies opened by the requisition.
+	Filled int `json:"filled"`
+}
+
+type ListRequisitionsResponse struct {
+	Items []Requisition `json:"items"`
+}
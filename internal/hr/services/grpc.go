@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"gpb.ru/hr/internal/hr/api/hrv1"
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/internal/hr/requisitions"
+)
+
+// GRPCServer serves the HR API over gRPC using the same repositories as
+// the http Server.
+type GRPCServer struct {
+	addr   string
+	server *grpc.Server
+
+	requisitions *requisitions.Gate
+	vacancies    *VacancyService
+	candidates   *CandidateService
+}
+
+// NewGRPCServer creates new gRPC server with the given properties.
+func NewGRPCServer(addr string, repos repos.Repos) *GRPCServer {
+	server := grpc.NewServer()
+	gate := requisitions.New(repos)
+	vacancies := &VacancyService{
+		vacancy:    repos.Vacancy,
+		skill:      repos.Skill,
+		dictionary: repos.Dictionary,
+		org:        repos.Org,
+		rates:      entities.DefaultExchangeRates(),
+
+		requisitions: gate,
+	}
+	hrv1.RegisterVacancyServiceServer(server, vacancies)
+	candidates := &CandidateService{
+		candidate:  repos.Candidate,
+		skill:      repos.Skill,
+		dictionary: repos.Dictionary,
+		rates:      entities.DefaultExchangeRates(),
+	}
+	hrv1.RegisterCandidateServiceServer(server, candidates)
+	hrv1.RegisterCardServiceServer(server, &CardService{
+		candidate: repos.Candidate,
+		vacancy:   repos.Vacancy,
+		card:      repos.Card,
+
+		requisitions: gate,
+	})
+	reflection.Register(server)
+
+	return &GRPCServer{
+		addr:         addr,
+		server:       server,
+		requisitions: gate,
+		vacancies:    vacancies,
+		candidates:   candidates,
+	}
+}
+
+// SetRequisitionsRequired keeps vacancies without an approved requisition
+// from becoming active.
+func (srv *GRPCServer) SetRequisitionsRequired(required bool) {
+	srv.requisitions.Required = required
+}
+
+// SetAdmins sets emails of users allowed to reassign any vacancy.
+func (srv *GRPCServer) SetAdmins(emails []string) {
+	srv.vacancies.admins = adminSet(emails)
+}
+
+// SetExchangeRates sets the table salaries in different currencies are
+// checked with.
+func (srv *GRPCServer) SetExchangeRates(rates *entities.ExchangeRates) {
+	srv.vacancies.rates = rates
+	srv.candidates.rates = rates
+	srv.requisitions.Rates = rates
+}
+
+// Run runs the server on the given address.
+func (srv *GRPCServer) Run() error {
+	listener, err := net.Listen("tcp", srv.addr)
+	if err != nil {
+		log.Printf("[error] [grpc] %s", err)
+		return err
+	}
+	log.Printf("[info] [grpc] listen on %s", srv.addr)
+	return srv.Serve(listener)
+}
+
+// Serve accepts connections on the given listener.
+func (srv *GRPCServer) Serve(listener net.Listener) error {
+	return srv.server.Serve(listener)
+}
+
+// Close gracefully stops the server. Pending calls are cancelled when
+// the context is done.
+func (srv *GRPCServer) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		srv.server.GracefulStop()
+	}()
+
+	select {
+	case <-ctx.Done():
+		srv.server.Stop()
+		return ctx.Err()
+	case <-done:
+	}
+
+	return nil
+}
+
+// grpcError converts repository and validation errors to gRPC statuses.
+func grpcError(err error) error {
+	var invalid invalidArgument
+	switch {
+	case errorStatus(err) == http.StatusNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &invalid), errorStatus(err) == http.StatusBadRequest:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errorStatus(err) == http.StatusConflict:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errorStatus(err) == http.StatusUnauthorized:
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errorStatus(err) == http.StatusForbidden:
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// invalidArgument marks errors caused by malformed requests.
+type invalidArgument struct{ error }
+
+func (err invalidArgument) Unwrap() error { return err.error }
+
+// protoEnum casts the proto enum value to the entity enum, rejecting values
+// the entity enum lacks, which would be stored and render as none.
+func protoEnum[T interface {
+	~byte
+	fmt.Stringer
+}](v int32, invalid error) (T, error) {
+	e := T(v)
+	if v < 0 || v > math.MaxUint8 || e != 0 && e.String() == T(0).String() {
+		return 0, invalidArgument{invalid}
+	}
+	return e, nil
+}
+
+func parseID(id string) (uuid.UUID, error) {
+	v, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, invalidArgument{err}
+	}
+	return v, nil
+}
+
+func parseOptionalID(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, nil
+	}
+	return parseID(id)
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"gpb.ru/hr/internal/hr/api/hrv1"
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+// CandidateService implements hrv1.CandidateServiceServer.
+type CandidateService struct {
+	hrv1.UnimplementedCandidateServiceServer
+	candidate  repos.CandidateRepo
+	skill      repos.SkillRepo
+	dictionary repos.DictionaryRepo
+	rates      *entities.ExchangeRates
+}
+
+func (svc *CandidateService) ListCandidates(
+	ctx context.Context,
+	req *hrv1.ListCandidatesRequest,
+) (*hrv1.ListCandidatesResponse, error) {
+	vacancyID, err := parseOptionalID(req.GetVacancyId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	candidates, err := svc.candidate.List(ctx, vacancyID)
+	if err != nil {
+		log.Printf("[error] [grpc] error listing candidates: %s", err)
+		return nil, grpcError(err)
+	}
+
+	response := &hrv1.ListCandidatesResponse{
+		Candidates: make([]*hrv1.Candidate, len(candidates)),
+	}
+	for i := range candidates {
+		response.Candidates[i] = candidateToProto(&candidates[i])
+	}
+	return response, nil
+}
+
+func (svc *CandidateService) GetCandidate(
+	ctx context.Context,
+	req *hrv1.GetCandidateRequest,
+) (*hrv1.GetCandidateResponse, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	candidate, err := svc.candidate.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("[error] [grpc] error get candidate: %s", err)
+		return nil, grpcError(err)
+	}
+	return &hrv1.GetCandidateResponse{Candidate: candidateToProto(candidate)}, nil
+}
+
+func (svc *CandidateService) CreateCandidate(
+	ctx context.Context,
+	req *hrv1.CreateCandidateRequest,
+) (*hrv1.CreateCandidateResponse, error) {
+	candidate, err := candidateFromProto(req.GetCandidate())
+	if err == nil {
+		err = svc.rates.CheckCurrency(candidate.SalaryCurrency)
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	skills, err := skillIndex(ctx, svc.skill)
+	if err != nil {
+		log.Printf("[error] [grpc] error creating candidate: %s", err)
+		return nil, grpcError(err)
+	}
+	candidate.NormalizeSkills(skills)
+
+	dicts, err := dictionaries(ctx, svc.dictionary)
+	if err == nil {
+		err = candidate.NormalizeDictionaries(dicts)
+	}
+	if err != nil {
+		log.Printf("[error] [grpc] error creating candidate: %s", err)
+		return nil, grpcError(err)
+	}
+
+	err = svc.candidate.Create(ctx, candidate)
+	if err != nil {
+		log.Printf("[error] [grpc] error creating candidate: %s", err)
+		return nil, grpcError(err)
+	}
+	return &hrv1.CreateCandidateResponse{Candidate: candidateToProto(candidate)}, nil
+}
+
+func (svc *CandidateService) UpdateCandidate(
+	ctx context.Context,
+	req *hrv1.UpdateCandidateRequest,
+) (*hrv1.UpdateCandidateResponse, error) {
+	candidate, err := candidateFromProto(req.GetCandidate())
+	if err == nil {
+		err = svc.rates.CheckCurrency(candidate.SalaryCurrency)
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	candidate.ID, err = parseID(req.GetCandidate().GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	skills, err := skillIndex(ctx, svc.skill)
+	if err != nil {
+		log.Printf("[error] [grpc] error updating candidate: %s", err)
+		return nil, grpcError(err)
+	}
+	candidate.NormalizeSkills(skills)
+
+	dicts, err := dictionaries(ctx, svc.dictionary)
+	if err == nil {
+		err = candidate.NormalizeDictionaries(dicts)
+	}
+	if err != nil {
+		log.Printf("[error] [grpc] error updating candidate: %s", err)
+		return nil, grpcError(err)
+	}
+
+	err = svc.candidate.Update(ctx, candidate)
+	if err != nil {
+		log.Printf("[error] [grpc] error updating candidate: %s", err)
+		return nil, grpcError(err)
+	}
+	return &hrv1.UpdateCandidateResponse{Candidate: candidateToProto(candidate)}, nil
+}
+
+func candidateToProto(candidate *entities.Candidate) *hrv1.Candidate {
+	education := make([]*hrv1.Education, len(candidate.Education))
+	for i, e := range candidate.Education {
+		education[i] = &hrv1.Education{Title: e.Title, Year: e.Year}
+	}
+	experience := make([]*hrv1.Experience, len(candidate.Experience))
+	for i, e := range candidate.Experience {
+		experience[i] = &hrv1.Experience{
+			Title:       e.Title,
+			Description: e.Description,
+			Start:       toTimestamp(e.Start),
+			End:         toTimestamp(e.End),
+		}
+	}
+	return &hrv1.Candidate{
+		Id:             candidate.ID.String(),
+		Name:           candidate.Name,
+		Phone:          candidate.Phone,
+		Email:          candidate.Email,
+		Specialization: candidate.Specialization,
+		Gender:         hrv1.Gender(candidate.Gender),
+		BirthDate:      toTimestamp(candidate.BirthDate),
+		Area:           candidate.Area,
+		Salary:         candidate.Salary,
+		EducationLevel: hrv1.EducationLevel(candidate.EducationLevel),
+		Education:      education,
+		Experience:     experience,
+		Languages:      candidate.Languages,
+		Skills:         candidate.Skills,
+		Created:        toTimestamp(&candidate.Created),
+		Updated:        toTimestamp(&candidate.Updated),
+		SalaryCurrency: candidate.SalaryCurrency,
+		SalaryPeriod:   hrv1.SalaryPeriod(candidate.SalaryPeriod),
+	}
+}
+
+func candidateFromProto(msg *hrv1.Candidate) (*entities.Candidate, error) {
+	gender, err := protoEnum[entities.Gender](int32(msg.GetGender()), entities.ErrInvalidGender)
+	if err != nil {
+		return nil, err
+	}
+	level, err := protoEnum[entities.EducationLevel](int32(msg.GetEducationLevel()), entities.ErrInvalidEducationLevel)
+	if err != nil {
+		return nil, err
+	}
+	period, err := protoEnum[entities.SalaryPeriod](int32(msg.GetSalaryPeriod()), entities.ErrInvalidSalaryPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate := &entities.Candidate{
+		Name:           msg.GetName(),
+		Phone:          msg.GetPhone(),
+		Email:          msg.GetEmail(),
+		Specialization: msg.GetSpecialization(),
+		Gender:         gender,
+		BirthDate:      fromTimestamp(msg.GetBirthDate()),
+		Area:           msg.GetArea(),
+		Salary:         msg.GetSalary(),
+		SalaryCurrency: msg.GetSalaryCurrency(),
+		SalaryPeriod:   period,
+		EducationLevel: level,
+		Languages:      msg.GetLanguages(),
+		Skills:         msg.GetSkills(),
+	}
+	for _, e := range msg.GetEducation() {
+		candidate.Education = append(candidate.Education, entities.Education{
+			Title: e.GetTitle(),
+			Year:  e.GetYear(),
+		})
+	}
+	for _, e := range msg.GetExperience() {
+		candidate.Experience = append(candidate.Experience, entities.Experience{
+			Title:       e.GetTitle(),
+			Description: e.GetDescription(),
+			Start:       fromTimestamp(e.GetStart()),
+			End:         fromTimestamp(e.GetEnd()),
+		})
+	}
+
+	err = candidate.Normalize()
+	if err == nil {
+		err = candidate.Validate()
+	}
+	if err != nil {
+		return nil, invalidArgument{err}
+	}
+	return candidate, nil
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"gpb.ru/hr/internal/hr/api/hrv1"
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/internal/hr/requisitions"
+)
+
+// CardService implements hrv1.CardServiceServer.
+type CardService struct {
+	hrv1.UnimplementedCardServiceServer
+	candidate repos.CandidateRepo
+	vacancy   repos.VacancyRepo
+	card      repos.CardRepo
+
+	requisitions *requisitions.Gate
+}
+
+func (svc *CardService) ListCards(
+	ctx context.Context,
+	req *hrv1.ListCardsRequest,
+) (*hrv1.ListCardsResponse, error) {
+	vacancyID, err := parseOptionalID(req.GetVacancyId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	cards, err := svc.card.List(ctx, vacancyID)
+	if err != nil {
+		log.Printf("[error] [grpc] error listing cards: %s", err)
+		return nil, grpcError(err)
+	}
+
+	response := &hrv1.ListCardsResponse{Cards: make([]*hrv1.Card, len(cards))}
+	for i := range cards {
+		response.Cards[i] = cardToProto(&cards[i])
+	}
+	return response, nil
+}
+
+func (svc *CardService) GetCard(
+	ctx context.Context,
+	req *hrv1.GetCardRequest,
+) (*hrv1.GetCardResponse, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	card, err := svc.card.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("[error] [grpc] error get card: %s", err)
+		return nil, grpcError(err)
+	}
+	return &hrv1.GetCardResponse{Card: cardToProto(card)}, nil
+}
+
+func (svc *CardService) CreateCard(
+	ctx context.Context,
+	req *hrv1.CreateCardRequest,
+) (*hrv1.CreateCardResponse, error) {
+	card := &entities.Card{}
+	var err error
+	card.VacancyID, err = parseOptionalID(req.GetVacancyId())
+	if err == nil {
+		card.CandidateID, err = parseOptionalID(req.GetCandidateId())
+	}
+	if err == nil {
+		err = card.Validate()
+	}
+	if err != nil {
+		return nil, grpcError(invalidArgument{err})
+	}
+
+	_, err = svc.vacancy.GetByID(ctx, card.VacancyID)
+	if err == nil {
+		_, err = svc.candidate.GetByID(ctx, card.CandidateID)
+	}
+	if err == nil {
+		err = svc.card.Create(ctx, card)
+	}
+	if err != nil {
+		log.Printf("[error] [grpc] error creating card: %s", err)
+		return nil, grpcError(err)
+	}
+	return &hrv1.CreateCardResponse{Card: cardToProto(card)}, nil
+}
+
+func (svc *CardService) MoveCard(
+	ctx context.Context,
+	req *hrv1.MoveCardRequest,
+) (*hrv1.MoveCardResponse, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	stage, err := protoEnum[entities.CardStage](int32(req.GetStage()), entities.ErrInvalidCardStage)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if stage == entities.CardStageNone {
+		return nil, grpcError(invalidArgument{errStageRequired})
+	}
+
+	var card *entities.Card
+	err = svc.requisitions.MoveCard(ctx, id, stage, func(ctx context.Context) error {
+		card, err = svc.card.Move(ctx, id, stage)
+		return err
+	})
+	if err != nil {
+		log.Printf("[error] [grpc] error moving card: %s", err)
+		return nil, grpcError(err)
+	}
+	return &hrv1.MoveCardResponse{Card: cardToProto(card)}, nil
+}
+
+func (svc *CardService) AddComment(
+	ctx context.Context,
+	req *hrv1.AddCommentRequest,
+) (*hrv1.AddCommentResponse, error) {
+	id, err := parseID(req.GetCardId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if req.GetText() == "" {
+		return nil, grpcError(invalidArgument{errCommentTextRequired})
+	}
+
+	comment := &entities.Comment{Author: req.GetAuthor(), Text: req.GetText()}
+	err = svc.card.AddComment(ctx, id, comment)
+	if err != nil {
+		log.Printf("[error] [grpc] error adding comment: %s", err)
+		return nil, grpcError(err)
+	}
+	return &hrv1.AddCommentResponse{Comment: commentToProto(comment)}, nil
+}
+
+func cardToProto(card *entities.Card) *hrv1.Card {
+	comments := make([]*hrv1.Comment, len(card.Comments))
+	for i := range card.Comments {
+		comments[i] = commentToProto(&card.Comments[i])
+	}
+	return &hrv1.Card{
+		Id:          card.ID.String(),
+		VacancyId:   card.VacancyID.String(),
+		CandidateId: card.CandidateID.String(),
+		Stage:       hrv1.CardStage(card.Stage),
+		Comments:    comments,
+		Created:     toTimestamp(&card.Created),
+		Updated:     toTimestamp(&card.Updated),
+	}
+}
+
+func commentToProto(comment *entities.Comment) *hrv1.Comment {
+	return &hrv1.Comment{
+		Id:      comment.ID.String(),
+		Author:  comment.Author,
+		Text:    comment.Text,
+		Created: toTimestamp(&comment.Created),
+	}
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"gpb.ru/hr/internal/hr/api/hrv1"
+	"gpb.ru/hr/internal/hr/repos/memory"
+)
+
+func newGRPCClient(t *testing.T) *grpc.ClientConn {
+	mem := memory.New()
+	srv := NewGRPCServer("", mem.Repos())
+
+	listener := bufconn.Listen(1 << 20)
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Close(context.Background())
+	})
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func TestGRPCServer_Board(t *testing.T) {
+	conn := newGRPCClient(t)
+	ctx := context.Background()
+
+	vacancies := hrv1.NewVacancyServiceClient(conn)
+	candidates := hrv1.NewCandidateServiceClient(conn)
+	cards := hrv1.NewCardServiceClient(conn)
+
+	vacancy, err := vacancies.CreateVacancy(ctx, &hrv1.CreateVacancyRequest{
+		Vacancy: &hrv1.Vacancy{
+			Title:        "Go developer",
+			Status:       hrv1.VacancyStatus_VACANCY_STATUS_ACTIVE,
+			Skills:       []*hrv1.Skill{{Title: "Go", Important: true}},
+			SalaryMax:    60000,
+			SalaryPeriod: hrv1.SalaryPeriod_SALARY_PERIOD_YEAR,
+		},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, vacancy.GetVacancy().GetId())
+	require.Equal(t, hrv1.SalaryPeriod_SALARY_PERIOD_YEAR, vacancy.GetVacancy().GetSalaryPeriod())
+
+	_, err = vacancies.CreateVacancy(ctx, &hrv1.CreateVacancyRequest{
+		Vacancy: &hrv1.Vacancy{Title: "Go developer", Status: hrv1.VacancyStatus(42)},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "unknown status")
+
+	_, err = vacancies.CreateVacancy(ctx, &hrv1.CreateVacancyRequest{
+		Vacancy: &hrv1.Vacancy{Title: "Go developer", SalaryPeriod: hrv1.SalaryPeriod(99)},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "unknown salary period")
+
+	list, err := vacancies.ListVacancies(ctx, &hrv1.ListVacanciesRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.GetVacancies(), 1)
+
+	candidate, err := candidates.CreateCandidate(ctx, &hrv1.CreateCandidateRequest{
+		Candidate: &hrv1.Candidate{
+			Name:           "John Doe",
+			EducationLevel: hrv1.EducationLevel_EDUCATION_LEVEL_MASTER,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = candidates.CreateCandidate(ctx, &hrv1.CreateCandidateRequest{
+		Candidate: &hrv1.Candidate{},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = candidates.CreateCandidate(ctx, &hrv1.CreateCandidateRequest{
+		Candidate: &hrv1.Candidate{Name: "Jane Doe", SalaryPeriod: hrv1.SalaryPeriod(99)},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "unknown salary period")
+
+	card, err := cards.CreateCard(ctx, &hrv1.CreateCardRequest{
+		VacancyId:   vacancy.GetVacancy().GetId(),
+		CandidateId: candidate.GetCandidate().GetId(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, hrv1.CardStage_CARD_STAGE_NEW, card.GetCard().GetStage())
+
+	moved, err := cards.MoveCard(ctx, &hrv1.MoveCardRequest{
+		Id:    card.GetCard().GetId(),
+		Stage: hrv1.CardStage_CARD_STAGE_OFFER,
+	})
+	require.NoError(t, err)
+	require.Equal(t, hrv1.CardStage_CARD_STAGE_OFFER, moved.GetCard().GetStage())
+
+	_, err = cards.MoveCard(ctx, &hrv1.MoveCardRequest{
+		Id:    card.GetCard().GetId(),
+		Stage: hrv1.CardStage(300),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "unknown stage")
+
+	_, err = cards.AddComment(ctx, &hrv1.AddCommentRequest{
+		CardId: card.GetCard().GetId(),
+		Text:   "Ready for offer",
+	})
+	require.NoError(t, err)
+
+	got, err := cards.GetCard(ctx, &hrv1.GetCardRequest{Id: card.GetCard().GetId()})
+	require.NoError(t, err)
+	require.Len(t, got.GetCard().GetComments(), 1)
+
+	byVacancy, err := candidates.ListCandidates(ctx, &hrv1.ListCandidatesRequest{
+		VacancyId: vacancy.GetVacancy().GetId(),
+	})
+	require.NoError(t, err)
+	require.Len(t, byVacancy.GetCandidates(), 1)
+
+	_, err = cards.GetCard(ctx, &hrv1.GetCardRequest{Id: "00000000-0000-0000-0000-000000000001"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPCServer_Reassign(t *testing.T) {
+	conn := newGRPCClient(t)
+	ctx := context.Background()
+	vacancies := hrv1.NewVacancyServiceClient(conn)
+
+	created, err := vacancies.CreateVacancy(ctx, &hrv1.CreateVacancyRequest{
+		Vacancy: &hrv1.Vacancy{Title: "Go developer", HiringManager: "boss@example.com"},
+	})
+	require.NoError(t, err)
+	vacancy := created.GetVacancy()
+	vacancy.HiringManager = "other@example.com"
+
+	_, err = vacancies.UpdateVacancy(ctx, &hrv1.UpdateVacancyRequest{Vacancy: vacancy})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	as := func(user string) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, "x-hr-user", user)
+	}
+	_, err = vacancies.UpdateVacancy(as("stranger@example.com"), &hrv1.UpdateVacancyRequest{Vacancy: vacancy})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	updated, err := vacancies.UpdateVacancy(as("Boss@example.com"), &hrv1.UpdateVacancyRequest{Vacancy: vacancy})
+	require.NoError(t, err)
+	require.Equal(t, "other@example.com", updated.GetVacancy().GetHiringManager())
+
+	updated.GetVacancy().Title = "Senior Go developer"
+	_, err = vacancies.UpdateVacancy(ctx, &hrv1.UpdateVacancyRequest{Vacancy: updated.GetVacancy()})
+	require.NoError(t, err, "keeping owners needs no user")
+}
+
+func TestGRPCServer_Reflection(t *testing.T) {
+	conn := newGRPCClient(t)
+
+	stream, err := grpc_reflection_v1.NewServerReflectionClient(conn).
+		ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+
+	err = stream.Send(&grpc_reflection_v1.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1.ServerReflectionRequest_ListServices{},
+	})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+
+	services := []string{}
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		services = append(services, svc.GetName())
+	}
+	require.Contains(t, services, "hr.v1.VacancyService")
+	require.Contains(t, services, "hr.v1.CandidateService")
+	require.Contains(t, services, "hr.v1.CardService")
+}
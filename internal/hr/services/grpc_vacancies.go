@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"gpb.ru/hr/internal/hr/api/hrv1"
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/internal/hr/requisitions"
+)
+
+// VacancyService implements hrv1.VacancyServiceServer.
+type VacancyService struct {
+	hrv1.UnimplementedVacancyServiceServer
+	vacancy    repos.VacancyRepo
+	skill      repos.SkillRepo
+	dictionary repos.DictionaryRepo
+	org        repos.OrgRepo
+	rates      *entities.ExchangeRates
+	// admins may reassign any vacancy.
+	admins map[string]bool
+
+	requisitions *requisitions.Gate
+}
+
+func (svc *VacancyService) ListVacancies(
+	ctx context.Context,
+	req *hrv1.ListVacanciesRequest,
+) (*hrv1.ListVacanciesResponse, error) {
+	vacancies, err := svc.vacancy.List(ctx)
+	if err != nil {
+		log.Printf("[error] [grpc] error listing vacancies: %s", err)
+		return nil, grpcError(err)
+	}
+
+	response := &hrv1.ListVacanciesResponse{
+		Vacancies: make([]*hrv1.Vacancy, len(vacancies)),
+	}
+	for i := range vacancies {
+		response.Vacancies[i] = vacancyToProto(&vacancies[i])
+	}
+	return response, nil
+}
+
+func (svc *VacancyService) GetVacancy(
+	ctx context.Context,
+	req *hrv1.GetVacancyRequest,
+) (*hrv1.GetVacancyResponse, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	vacancy, err := svc.vacancy.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("[error] [grpc] error get vacancy: %s", err)
+		return nil, grpcError(err)
+	}
+	return &hrv1.GetVacancyResponse{Vacancy: vacancyToProto(vacancy)}, nil
+}
+
+func (svc *VacancyService) CreateVacancy(
+	ctx context.Context,
+	req *hrv1.CreateVacancyRequest,
+) (*hrv1.CreateVacancyResponse, error) {
+	vacancy, err := vacancyFromProto(req.GetVacancy())
+	if err == nil {
+		err = svc.rates.CheckCurrency(vacancy.Salary.Currency)
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	skills, err := skillIndex(ctx, svc.skill)
+	if err != nil {
+		log.Printf("[error] [grpc] error creating vacancy: %s", err)
+		return nil, grpcError(err)
+	}
+	vacancy.NormalizeSkills(skills)
+
+	dicts, err := dictionaries(ctx, svc.dictionary)
+	if err == nil {
+		err = vacancy.NormalizeDictionaries(dicts)
+	}
+	if err != nil {
+		log.Printf("[error] [grpc] error creating vacancy: %s", err)
+		return nil, grpcError(err)
+	}
+
+	org, err := orgChart(ctx, svc.dictionary, svc.org)
+	if err == nil {
+		err = assignOwners(vacancy, nil, org)
+	}
+	if err == nil {
+		err = svc.requisitions.SaveVacancy(ctx, vacancy, nil, func(ctx context.Context) error {
+			return svc.vacancy.Create(ctx, vacancy)
+		})
+	}
+	if err != nil {
+		log.Printf("[error] [grpc] error creating vacancy: %s", err)
+		return nil, grpcError(err)
+	}
+	return &hrv1.CreateVacancyResponse{Vacancy: vacancyToProto(vacancy)}, nil
+}
+
+// UpdateVacancy replaces the vacancy. Replacing its owners takes the same
+// rights as in the http API, the user is read from x-hr-user metadata.
+func (svc *VacancyService) UpdateVacancy(
+	ctx context.Context,
+	req *hrv1.UpdateVacancyRequest,
+) (*hrv1.UpdateVacancyResponse, error) {
+	vacancy, err := vacancyFromProto(req.GetVacancy())
+	if err == nil {
+		err = svc.rates.CheckCurrency(vacancy.Salary.Currency)
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	vacancy.ID, err = parseID(req.GetVacancy().GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	skills, err := skillIndex(ctx, svc.skill)
+	if err != nil {
+		log.Printf("[error] [grpc] error updating vacancy: %s", err)
+		return nil, grpcError(err)
+	}
+	vacancy.NormalizeSkills(skills)
+
+	dicts, err := dictionaries(ctx, svc.dictionary)
+	if err == nil {
+		err = vacancy.NormalizeDictionaries(dicts)
+	}
+	if err != nil {
+		log.Printf("[error] [grpc] error updating vacancy: %s", err)
+		return nil, grpcError(err)
+	}
+
+	old, err := svc.vacancy.GetByID(ctx, vacancy.ID)
+	if err != nil {
+		log.Printf("[error] [grpc] error updating vacancy: %s", err)
+		return nil, grpcError(err)
+	}
+	org, err := orgChart(ctx, svc.dictionary, svc.org)
+	if err == nil {
+		err = assignOwners(vacancy, old, org)
+	}
+	if err == nil {
+		err = checkReassign(contextUser(ctx), svc.admins, vacancy, old, org)
+	}
+	if err == nil {
+		err = svc.requisitions.SaveVacancy(ctx, vacancy, old, func(ctx context.Context) error {
+			return svc.vacancy.Update(ctx, vacancy)
+		})
+	}
+	if err != nil {
+		log.Printf("[error] [grpc] error updating vacancy: %s", err)
+		return nil, grpcError(err)
+	}
+	return &hrv1.UpdateVacancyResponse{Vacancy: vacancyToProto(vacancy)}, nil
+}
+
+// Enum values of the api mirror entities, so the conversion is a cast, checked
+// by protoEnum for incoming values.
+
+func vacancyToProto(vacancy *entities.Vacancy) *hrv1.Vacancy {
+	skills := make([]*hrv1.Skill, len(vacancy.Skills))
+	for i, skill := range vacancy.Skills {
+		skills[i] = &hrv1.Skill{Title: skill.Title, Important: skill.Important}
+	}
+	return &hrv1.Vacancy{
+		Id:             vacancy.ID.String(),
+		TemplateId:     vacancy.TemplateID.String(),
+		RequisitionId:  vacancy.RequisitionID.String(),
+		Title:          vacancy.Title,
+		Status:         hrv1.VacancyStatus(vacancy.Status),
+		Area:           vacancy.Area,
+		Department:     vacancy.Department,
+		Skills:         skills,
+		Duties:         vacancy.Duties,
+		Requirements:   vacancy.Requirements,
+		Experience:     vacancy.Experience,
+		Budget:         vacancy.Budget,
+		HiringManager:  vacancy.HiringManager,
+		Recruiter:      vacancy.Recruiter,
+		SalaryMin:      vacancy.Salary.Min,
+		SalaryMax:      vacancy.Salary.Max,
+		SalaryCurrency: vacancy.Salary.Currency,
+		SalaryGross:    vacancy.Salary.Gross,
+		SalaryPeriod:   hrv1.SalaryPeriod(vacancy.Salary.Period),
+		SalaryPublic:   vacancy.Salary.Public,
+		Created:        toTimestamp(&vacancy.Created),
+		Updated:        toTimestamp(&vacancy.Updated),
+	}
+}
+
+func vacancyFromProto(msg *hrv1.Vacancy) (*entities.Vacancy, error) {
+	templateID, err := parseOptionalID(msg.GetTemplateId())
+	if err != nil {
+		return nil, err
+	}
+	requisitionID, err := parseOptionalID(msg.GetRequisitionId())
+	if err != nil {
+		return nil, err
+	}
+	status, err := protoEnum[entities.VacancyStatus](int32(msg.GetStatus()), entities.ErrInvalidVacancyStatus)
+	if err != nil {
+		return nil, err
+	}
+	period, err := protoEnum[entities.SalaryPeriod](int32(msg.GetSalaryPeriod()), entities.ErrInvalidSalaryPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	vacancy := &entities.Vacancy{
+		TemplateID:    templateID,
+		RequisitionID: requisitionID,
+		Title:         msg.GetTitle(),
+		Status:        status,
+		Area:          msg.GetArea(),
+		Department:    msg.GetDepartment(),
+		Duties:        msg.GetDuties(),
+		Requirements:  msg.GetRequirements(),
+		Experience:    msg.GetExperience(),
+		Budget:        msg.GetBudget(),
+		HiringManager: msg.GetHiringManager(),
+		Recruiter:     msg.GetRecruiter(),
+		Salary: entities.SalaryRange{
+			Min:      msg.GetSalaryMin(),
+			Max:      msg.GetSalaryMax(),
+			Currency: msg.GetSalaryCurrency(),
+			Gross:    msg.GetSalaryGross(),
+			Period:   period,
+			Public:   msg.GetSalaryPublic(),
+		},
+	}
+	for _, skill := range msg.GetSkills() {
+		vacancy.Skills = append(vacancy.Skills, entities.Skill{
+			Title:     skill.GetTitle(),
+			Important: skill.GetImportant(),
+		})
+	}
+
+	err = vacancy.Validate()
+	if err != nil {
+		return nil, invalidArgument{err}
+	}
+	return vacancy, nil
+}
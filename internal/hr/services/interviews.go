@@ -0,0 +1,340 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/calendar"
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+var errInterviewerBusy = errors.New("interviewer is busy")
+
+// ListInterviews returns interviews ordered by start time. Interviews may be
+// filtered by card, interviewer and time range, e.g. to fill a calendar.
+func (srv *Server) ListInterviews(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	filter := repos.InterviewFilter{
+		Interviewer: entities.NormalizeEmail(req.URL.Query().Get("interviewer")),
+	}
+	var err error
+	filter.CardID, err = queryUUID(req, "card")
+	if err == nil {
+		filter.From, err = queryTime(req, "from")
+	}
+	if err == nil {
+		filter.To, err = queryTime(req, "to")
+	}
+	if err != nil {
+		log.Printf("[error] [server] error listing interviews: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := srv.interview.List(req.Context(), filter)
+	if err != nil {
+		log.Printf("[error] [server] error listing interviews: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, ListInterviewsResponse{Items: result})
+	if err != nil {
+		log.Printf("[error] [server] error listing interviews: %s", err)
+	}
+}
+
+// GetInterview returns the given interview.
+func (srv *Server) GetInterview(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	interviewID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get interview: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	interview, err := srv.interview.GetByID(req.Context(), interviewID)
+	if err != nil {
+		log.Printf("[error] [server] error get interview: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, interview)
+	if err != nil {
+		log.Printf("[error] [server] error get interview: %s", err)
+	}
+}
+
+// CreateInterview schedules interview for the candidate of a card. It fails
+// with 409 if any interviewer has another interview at that time. The check
+// and the write hold the interviewers, so concurrent bookings can not
+// overlap.
+func (srv *Server) CreateInterview(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	var interview entities.Interview
+	err := json.NewDecoder(req.Body).Decode(&interview)
+	if err != nil {
+		log.Printf("[error] [server] error creating interview: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if interview.Status == entities.InterviewStatusNone {
+		interview.Status = entities.InterviewStatusScheduled
+	}
+
+	err = interview.Validate()
+	if err != nil {
+		log.Printf("[error] [server] error creating interview: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	_, err = srv.card.GetByID(req.Context(), interview.CardID)
+	if err != nil {
+		log.Printf("[error] [server] error creating interview: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = srv.interview.Hold(req.Context(), interview.Interviewers, func(ctx context.Context) error {
+		err := srv.checkInterviewers(ctx, &interview)
+		if err != nil {
+			return err
+		}
+		return srv.interview.Create(ctx, &interview)
+	})
+	if err != nil {
+		log.Printf("[error] [server] error creating interview: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	srv.notifyInterview(req.Context(), &interview)
+
+	err = writeJSON(w, http.StatusOK, interview)
+	if err != nil {
+		log.Printf("[error] [server] error creating interview: %s", err)
+	}
+}
+
+// UpdateInterview reschedules the given interview or changes its status.
+// Invites are sent again only if its time, place or interviewers change.
+func (srv *Server) UpdateInterview(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	interviewID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error updating interview: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	prev, err := srv.interview.GetByID(req.Context(), interviewID)
+	if err != nil {
+		log.Printf("[error] [server] error updating interview: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	var interview entities.Interview
+	err = json.NewDecoder(req.Body).Decode(&interview)
+	if err != nil {
+		log.Printf("[error] [server] error updating interview: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	interview.ID = prev.ID
+	interview.CardID = prev.CardID
+	if interview.Status == entities.InterviewStatusNone {
+		interview.Status = prev.Status
+	}
+
+	err = interview.Validate()
+	if err != nil {
+		log.Printf("[error] [server] error updating interview: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = srv.interview.Hold(req.Context(), interview.Interviewers, func(ctx context.Context) error {
+		err := srv.checkInterviewers(ctx, &interview)
+		if err != nil {
+			return err
+		}
+		return srv.interview.Update(ctx, &interview)
+	})
+	if err != nil {
+		log.Printf("[error] [server] error updating interview: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	if interview.Rescheduled(prev) {
+		srv.notifyInterview(req.Context(), &interview)
+	}
+
+	err = writeJSON(w, http.StatusOK, interview)
+	if err != nil {
+		log.Printf("[error] [server] error updating interview: %s", err)
+	}
+}
+
+// GetInterviewInvite returns iCalendar invite for the interview. Invites of
+// cancelled interviews cancel the event in calendars which imported it.
+func (srv *Server) GetInterviewInvite(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	interviewID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get interview invite: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	interview, err := srv.interview.GetByID(req.Context(), interviewID)
+	if err != nil {
+		log.Printf("[error] [server] error get interview invite: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	event, err := srv.interviewEvent(req.Context(), interview)
+	if err != nil {
+		log.Printf("[error] [server] error get interview invite: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	method := calendar.MethodRequest
+	if event.Cancelled {
+		method = calendar.MethodCancel
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8; method="+method)
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf(`attachment; filename="interview-%s.ics"`, interview.ID),
+	)
+	w.WriteHeader(http.StatusOK)
+	err = calendar.Write(w, method, *event)
+	if err != nil {
+		log.Printf("[error] [server] error get interview invite: %s", err)
+	}
+}
+
+// checkInterviewers returns error if any interviewer has another interview
+// overlapping the given one.
+func (srv *Server) checkInterviewers(
+	ctx context.Context,
+	interview *entities.Interview,
+) error {
+	if interview.Status == entities.InterviewStatusCancelled {
+		return nil
+	}
+
+	for _, interviewer := range interview.Interviewers {
+		others, err := srv.interview.List(ctx, repos.InterviewFilter{
+			Interviewer: interviewer,
+			From:        interview.Start,
+			To:          interview.End,
+		})
+		if err != nil {
+			return err
+		}
+		for i := range others {
+			if interview.Conflicts(&others[i]) {
+				return fmt.Errorf(
+					"%w: %s has interview %s at %s",
+					errInterviewerBusy,
+					interviewer,
+					others[i].ID,
+					others[i].Start.Format(time.RFC3339),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// interviewEvent describes the interview as a calendar event. The candidate
+// is invited too if their email is known.
+func (srv *Server) interviewEvent(
+	ctx context.Context,
+	interview *entities.Interview,
+) (*calendar.Event, error) {
+	card, err := srv.card.GetByID(ctx, interview.CardID)
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := srv.candidate.GetByID(ctx, card.CandidateID)
+	if err != nil {
+		return nil, err
+	}
+	vacancy, err := srv.vacancy.GetByID(ctx, card.VacancyID)
+	if err != nil {
+		return nil, err
+	}
+
+	attendees := append([]string(nil), interview.Interviewers...)
+	if candidate.Email != "" {
+		attendees = append(attendees, candidate.Email)
+	}
+
+	description := []string{"Format: " + interview.Format.String()}
+	if interview.Link != "" {
+		description = append(description, "Link: "+interview.Link)
+	}
+
+	return &calendar.Event{
+		UID:         interview.ID.String() + "@hr",
+		Sequence:    interview.Sequence,
+		Stamp:       interview.Updated,
+		Start:       interview.Start,
+		End:         interview.End,
+		Summary:     fmt.Sprintf("Interview: %s, %s", candidate.Name, vacancy.Title),
+		Description: strings.Join(description, "\n"),
+		Location:    interview.Location,
+		URL:         interview.Link,
+		Attendees:   attendees,
+		Cancelled:   interview.Status == entities.InterviewStatusCancelled,
+	}, nil
+}
+
+// notifyInterview invites the candidate and the interviewers to the
+// scheduled interview by email. Failures are logged only, the interview is
+// saved anyway.
+func (srv *Server) notifyInterview(ctx context.Context, interview *entities.Interview) {
+	if srv.notifier == nil || interview.Status != entities.InterviewStatusScheduled {
+		return
+	}
+	event, err := srv.interviewEvent(ctx, interview)
+	if err == nil {
+		err = srv.notifier.InterviewScheduled(ctx, interview, *event)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error notifying of interview: %s", err)
+	}
+}
+
+// queryTime parses optional RFC 3339 time query parameter. It returns zero
+// time when the parameter is missing.
+func queryTime(req *http.Request, name string) (time.Time, error) {
+	v := req.URL.Query().Get(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// ListCardMessages returns the communication log of the candidate of the
+// card: emails received from the candidate and notifications sent or queued,
+// about this card and the other ones.
+func (srv *Server) ListCardMessages(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	cardID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error listing messages: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	card, err := srv.card.GetByID(req.Context(), cardID)
+	if err != nil {
+		log.Printf("[error] [server] error listing messages: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	result, err := srv.message.List(req.Context(), card.CandidateID)
+	if err != nil {
+		log.Printf("[error] [server] error listing messages: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, ListMessagesResponse{Items: result})
+	if err != nil {
+		log.Printf("[error] [server] error listing messages: %s", err)
+	}
+}
@@ -0,0 +1,369 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/letters"
+)
+
+var errCardNotInOfferStage = errors.New("card is not in the offer stage")
+
+// ListOffers returns offers, optionally of the given card only.
+func (srv *Server) ListOffers(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	cardID, err := queryUUID(req, "card")
+	if err != nil {
+		log.Printf("[error] [server] error listing offers: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := srv.offer.List(req.Context(), cardID)
+	if err != nil {
+		log.Printf("[error] [server] error listing offers: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, ListOffersResponse{Items: result})
+	if err != nil {
+		log.Printf("[error] [server] error listing offers: %s", err)
+	}
+}
+
+// GetOffer returns the given offer.
+func (srv *Server) GetOffer(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	offerID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get offer: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	offer, err := srv.offer.GetByID(req.Context(), offerID)
+	if err != nil {
+		log.Printf("[error] [server] error get offer: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, offer)
+	if err != nil {
+		log.Printf("[error] [server] error get offer: %s", err)
+	}
+}
+
+// CreateOffer makes an offer to the candidate of a card in the offer stage
+// and submits it for approval. The salary, net monthly in the base currency,
+// may not exceed the vacancy budget or salary range, a salary below the one
+// the candidate expects converted the same way is reported in warnings. Only admins may replace the
+// approval chain configured on the server.
+func (srv *Server) CreateOffer(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	var request OfferRequest
+	err := json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error creating offer: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	card, err := srv.card.GetByID(req.Context(), request.CardID)
+	if err != nil {
+		log.Printf("[error] [server] error creating offer: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	if card.Stage != entities.CardStageOffer {
+		log.Printf("[error] [server] error creating offer: %s", errCardNotInOfferStage)
+		writeError(w, errorStatus(errCardNotInOfferStage), errCardNotInOfferStage)
+		return
+	}
+	vacancy, err := srv.vacancy.GetByID(req.Context(), card.VacancyID)
+	if err != nil {
+		log.Printf("[error] [server] error creating offer: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	candidate, err := srv.candidate.GetByID(req.Context(), card.CandidateID)
+	if err != nil {
+		log.Printf("[error] [server] error creating offer: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	offer := entities.Offer{
+		CardID:    card.ID,
+		Position:  request.Position,
+		Salary:    request.Salary,
+		StartDate: request.StartDate,
+		Expires:   request.Expires,
+		Approvals: append([]entities.Approval{}, srv.offerChain...),
+	}
+	if offer.Position == "" {
+		offer.Position = vacancy.Title
+	}
+	if request.Approvals != nil {
+		err = srv.requireAdmin(req)
+		if err == nil {
+			err = offer.OverrideApprovals(request.Approvals)
+		}
+		if err != nil {
+			log.Printf("[error] [server] error creating offer: %s", err)
+			writeError(w, errorStatus(err), err)
+			return
+		}
+	}
+
+	// Expectations in currencies missing from exchange rates are not
+	// compared.
+	expected, _ := srv.rates.Expected(candidate)
+	err = offer.Validate()
+	if err == nil {
+		err = offer.CheckSalary(vacancy, srv.rates, uint32(math.Round(expected)))
+	}
+	if err != nil {
+		log.Printf("[error] [server] error creating offer: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	offer.Submit()
+
+	err = srv.offer.Create(req.Context(), &offer)
+	if err != nil {
+		log.Printf("[error] [server] error creating offer: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if offer.Status == entities.OfferStatusApproved {
+		srv.notifyOffer(req.Context(), &offer)
+	}
+
+	err = writeJSON(w, http.StatusOK, offer)
+	if err != nil {
+		log.Printf("[error] [server] error creating offer: %s", err)
+	}
+}
+
+// ApproveOffer records approval of the requesting user whose turn it is in
+// the approval chain.
+func (srv *Server) ApproveOffer(w http.ResponseWriter, req *http.Request) {
+	srv.decideOffer(w, req, entities.DecisionApproved)
+}
+
+// RejectOffer records rejection of the requesting user whose turn it is in
+// the approval chain. Rejected offers are not sent.
+func (srv *Server) RejectOffer(w http.ResponseWriter, req *http.Request) {
+	srv.decideOffer(w, req, entities.DecisionRejected)
+}
+
+func (srv *Server) decideOffer(
+	w http.ResponseWriter,
+	req *http.Request,
+	decision entities.Decision,
+) {
+	defer req.Body.Close()
+
+	var request DecisionRequest
+	err := json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error deciding offer: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	user := requestUser(req)
+	if user == "" {
+		log.Printf("[error] [server] error deciding offer: %s", errUserRequired)
+		writeError(w, errorStatus(errUserRequired), errUserRequired)
+		return
+	}
+
+	srv.changeOffer(w, req, "deciding", func(offer *entities.Offer) error {
+		return offer.Decide(user, decision, request.Comment, time.Now())
+	})
+}
+
+// AcceptOffer records the candidate accepted the approved offer.
+func (srv *Server) AcceptOffer(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	srv.changeOffer(w, req, "accepting", func(offer *entities.Offer) error {
+		return offer.Accept(time.Now())
+	})
+}
+
+// DeclineOffer records the candidate declined the approved offer.
+func (srv *Server) DeclineOffer(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	srv.changeOffer(w, req, "declining", (*entities.Offer).Decline)
+}
+
+// WithdrawOffer cancels the offer the candidate has not answered yet.
+func (srv *Server) WithdrawOffer(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	srv.changeOffer(w, req, "withdrawing", (*entities.Offer).Withdraw)
+}
+
+// changeOffer applies the change to the offer of the request and stores it.
+func (srv *Server) changeOffer(
+	w http.ResponseWriter,
+	req *http.Request,
+	verb string,
+	change func(*entities.Offer) error,
+) {
+	offerID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error %s offer: %s", verb, err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	offer, err := srv.offer.GetByID(req.Context(), offerID)
+	var prev entities.OfferStatus
+	if err == nil {
+		prev = offer.Status
+		err = change(offer)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error %s offer: %s", verb, err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = srv.offer.Update(req.Context(), offer)
+	if err != nil {
+		log.Printf("[error] [server] error %s offer: %s", verb, err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	if prev != entities.OfferStatusApproved && offer.Status == entities.OfferStatusApproved {
+		srv.notifyOffer(req.Context(), offer)
+	}
+
+	err = writeJSON(w, http.StatusOK, offer)
+	if err != nil {
+		log.Printf("[error] [server] error %s offer: %s", verb, err)
+	}
+}
+
+// GetOfferLetterHTML renders the offer letter as an HTML page.
+func (srv *Server) GetOfferLetterHTML(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	offerID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get offer letter: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	doc, err := srv.offerLetter(req.Context(), offerID)
+	if err != nil {
+		log.Printf("[error] [server] error get offer letter: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf(`inline; filename="offer-%s.html"`, offerID),
+	)
+	w.WriteHeader(http.StatusOK)
+	err = letters.WriteHTML(w, doc)
+	if err != nil {
+		log.Printf("[error] [server] error get offer letter: %s", err)
+	}
+}
+
+// GetOfferLetterPDF renders the offer letter as a PDF document.
+func (srv *Server) GetOfferLetterPDF(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	offerID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get offer letter: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	doc, err := srv.offerLetter(req.Context(), offerID)
+	if err != nil {
+		log.Printf("[error] [server] error get offer letter: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf(`attachment; filename="offer-%s.pdf"`, offerID),
+	)
+	w.WriteHeader(http.StatusOK)
+	err = letters.WritePDF(w, doc)
+	if err != nil {
+		log.Printf("[error] [server] error get offer letter: %s", err)
+	}
+}
+
+// offerLetter renders letter of the given offer.
+func (srv *Server) offerLetter(
+	ctx context.Context,
+	offerID uuid.UUID,
+) (letters.Document, error) {
+	offer, err := srv.offer.GetByID(ctx, offerID)
+	if err != nil {
+		return letters.Document{}, err
+	}
+	card, err := srv.card.GetByID(ctx, offer.CardID)
+	if err != nil {
+		return letters.Document{}, err
+	}
+	candidate, err := srv.candidate.GetByID(ctx, card.CandidateID)
+	if err != nil {
+		return letters.Document{}, err
+	}
+	vacancy, err := srv.vacancy.GetByID(ctx, card.VacancyID)
+	if err != nil {
+		return letters.Document{}, err
+	}
+
+	return srv.letter.Render(letters.Letter{
+		Offer:     *offer,
+		Candidate: *candidate,
+		Vacancy:   *vacancy,
+	})
+}
+
+// notifyOffer sends the approved offer to the candidate. Failures are logged
+// only, the offer stays approved anyway.
+func (srv *Server) notifyOffer(ctx context.Context, offer *entities.Offer) {
+	if srv.notifier == nil {
+		return
+	}
+	doc, err := srv.offerLetter(ctx, offer.ID)
+	if err == nil {
+		err = srv.notifier.OfferApproved(ctx, offer, doc)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error notifying of offer: %s", err)
+	}
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	_ "embed" // embeds api specification and docs page
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	//go:embed openapi.yaml
+	openapiYAML []byte
+
+	//go:embed docs.html
+	docsHTML []byte
+)
+
+var openapiJSON struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// OpenAPISpec returns the OpenAPI 3 document of the server encoded as JSON.
+// The document is maintained in openapi.yaml next to the handlers and is
+// checked against real responses by the package tests.
+func OpenAPISpec() ([]byte, error) {
+	openapiJSON.once.Do(func() {
+		var doc interface{}
+		openapiJSON.err = yaml.Unmarshal(openapiYAML, &doc)
+		if openapiJSON.err != nil {
+			return
+		}
+		openapiJSON.data, openapiJSON.err = json.Marshal(doc)
+	})
+	return openapiJSON.data, openapiJSON.err
+}
+
+// OpenAPI serves the OpenAPI 3 document of the server.
+func (srv *Server) OpenAPI(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	spec, err := OpenAPISpec()
+	if err != nil {
+		log.Printf("[error] [server] error encoding openapi spec: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(spec)
+	if err != nil {
+		log.Printf("[error] [server] error writing openapi spec: %s", err)
+	}
+}
+
+// Docs serves a self-contained page that renders the OpenAPI document.
+func (srv *Server) Docs(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write(docsHTML)
+	if err != nil {
+		log.Printf("[error] [server] error writing docs: %s", err)
+	}
+}
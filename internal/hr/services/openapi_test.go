@@ -0,0 +1,1768 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/notify"
+	"gpb.ru/hr/internal/hr/privacy"
+	"gpb.ru/hr/internal/hr/reports"
+	"gpb.ru/hr/internal/hr/repos/memory"
+	"gpb.ru/hr/internal/hr/vacancies"
+	"gpb.ru/hr/pkg/blob"
+	"gpb.ru/hr/pkg/xlsx"
+)
+
+type apiTester struct {
+	t      *testing.T
+	doc    *openapi3.T
+	srv    *Server
+	mem    *memory.Memory
+	router routers.Router
+	// user is sent as the requesting user unless empty.
+	user string
+}
+
+// as returns tester sending requests on behalf of the user.
+func (tt *apiTester) as(user string) *apiTester {
+	v := *tt
+	v.user = user
+	return &v
+}
+
+func newAPITester(t *testing.T) *apiTester {
+	spec, err := OpenAPISpec()
+	require.NoError(t, err)
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(context.Background()))
+
+	// Route matching only depends on paths, the server address is irrelevant.
+	doc.Servers = openapi3.Servers{{URL: "http://hr.test"}}
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+	openapi3filter.RegisterBodyDecoder("text/html", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("text/calendar", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("application/pdf", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("application/xml", openapi3filter.FileBodyDecoder)
+
+	mem := memory.New()
+	srv := NewServer("", mem.Repos())
+
+	return &apiTester{t: t, doc: doc, srv: srv, mem: mem, router: router}
+}
+
+// multipartForm is a request body of HTML form fields and files.
+type multipartForm struct {
+	buf         bytes.Buffer
+	contentType string
+}
+
+func newMultipartForm(t *testing.T, fields map[string]string, files map[string][]byte) *multipartForm {
+	form := &multipartForm{}
+	mw := multipart.NewWriter(&form.buf)
+	for name, value := range fields {
+		require.NoError(t, mw.WriteField(name, value))
+	}
+	for name, data := range files {
+		fw, err := mw.CreateFormFile(name, name+".txt")
+		require.NoError(t, err)
+		_, err = fw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, mw.Close())
+	form.contentType = mw.FormDataContentType()
+	return form
+}
+
+// do sends the request to the server and checks both request and response
+// against the OpenAPI document. It returns response body.
+func (tt *apiTester) do(method, path string, body interface{}, wantCode int) []byte {
+	t := tt.t
+	t.Helper()
+
+	// Raw bytes are sent as a file, anything else as JSON.
+	var payload []byte
+	contentType := "application/json"
+	switch body := body.(type) {
+	case nil:
+		contentType = ""
+	case []byte:
+		payload, contentType = body, "application/octet-stream"
+	case *multipartForm:
+		payload, contentType = body.buf.Bytes(), body.contentType
+	default:
+		var err error
+		payload, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(method, "http://hr.test"+path, bytes.NewReader(payload))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if tt.user != "" {
+		req.Header.Set(userHeader, tt.user)
+	}
+
+	route, params, err := tt.router.FindRoute(req)
+	require.NoError(t, err, "%s %s is not documented", method, path)
+
+	ctx := context.Background()
+	requestInput := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: params,
+		Route:      route,
+	}
+	if wantCode < http.StatusBadRequest {
+		require.NoError(t, openapi3filter.ValidateRequest(ctx, requestInput))
+	}
+	req.Body = io.NopCloser(bytes.NewReader(payload))
+
+	rec := httptest.NewRecorder()
+	tt.srv.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, wantCode, rec.Code, rec.Body.String())
+
+	responseInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: requestInput,
+		Status:                 rec.Code,
+		Header:                 rec.Header(),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	}
+	responseInput.SetBodyBytes(rec.Body.Bytes())
+	require.NoError(t, openapi3filter.ValidateResponse(ctx, responseInput))
+
+	return rec.Body.Bytes()
+}
+
+func (tt *apiTester) decode(data []byte, v interface{}) {
+	tt.t.Helper()
+	require.NoError(tt.t, json.Unmarshal(data, v))
+}
+
+func TestOpenAPI_AllRoutesDocumented(t *testing.T) {
+	tt := newAPITester(t)
+	params := regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+	err := tt.srv.router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tpl, err := route.GetPathTemplate()
+		require.NoError(t, err)
+		methods, err := route.GetMethods()
+		require.NoError(t, err)
+
+		path := params.ReplaceAllString(tpl, "{$1}")
+		item := tt.doc.Paths.Find(path)
+		require.NotNil(t, item, "path %s is not documented", path)
+		for _, method := range methods {
+			require.NotNil(t, item.GetOperation(method), "%s %s is not documented", method, path)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestOpenAPI_Vacancies(t *testing.T) {
+	tt := newAPITester(t)
+
+	tt.do(http.MethodGet, "/vacancies", nil, http.StatusOK)
+
+	var vacancy map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"templateID":   "00000000-0000-0000-0000-000000000000",
+		"title":        "Go developer",
+		"status":       "draft",
+		"area":         "Moscow",
+		"skills":       []map[string]interface{}{{"title": "Go", "important": true}},
+		"duties":       []string{"code"},
+		"requirements": []string{"Go"},
+		"experience":   3,
+	}, http.StatusOK), &vacancy)
+	id := vacancy["id"].(string)
+
+	tt.do(http.MethodGet, "/vacancies", nil, http.StatusOK)
+	tt.do(http.MethodGet, "/vacancies/"+id, nil, http.StatusOK)
+	tt.do(http.MethodPost, "/vacancies/"+id, map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Senior Go developer",
+		"status":     "active",
+		"experience": 5,
+	}, http.StatusOK)
+
+	tt.do(http.MethodGet, "/vacancies/00000000-0000-0000-0000-000000000001", nil, http.StatusNotFound)
+	tt.do(http.MethodGet, "/vacancies/foo", nil, http.StatusBadRequest)
+}
+
+func TestOpenAPI_CandidatesAndCards(t *testing.T) {
+	tt := newAPITester(t)
+
+	var vacancy map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Go developer",
+		"status":     "active",
+		"experience": 1,
+	}, http.StatusOK), &vacancy)
+	vacancyID := vacancy["id"].(string)
+
+	tt.do(http.MethodGet, "/candidates", nil, http.StatusOK)
+
+	var candidate map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":           "John Doe",
+		"phone":          "+79990000000",
+		"email":          "john@example.com",
+		"gender":         "male",
+		"birthDate":      "1990-01-01T00:00:00Z",
+		"salary":         100000,
+		"educationLevel": "master",
+		"education":      []map[string]interface{}{{"title": "MSU", "year": 2012}},
+		"experience": []map[string]interface{}{{
+			"title":       "Developer",
+			"description": "Backend",
+			"start":       "2012-09-01T00:00:00Z",
+			"end":         nil,
+		}},
+		"languages": []string{"en"},
+		"skills":    []string{"Go"},
+	}, http.StatusOK), &candidate)
+	candidateID := candidate["id"].(string)
+
+	tt.do(http.MethodGet, "/candidates/"+candidateID, nil, http.StatusOK)
+	tt.do(http.MethodPost, "/candidates/"+candidateID, map[string]interface{}{
+		"name": "John Smith",
+	}, http.StatusOK)
+	tt.do(http.MethodPost, "/candidates", map[string]interface{}{}, http.StatusBadRequest)
+
+	tt.do(http.MethodGet, "/cards", nil, http.StatusOK)
+
+	var card map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/cards", map[string]interface{}{
+		"vacancyID":   vacancyID,
+		"candidateID": candidateID,
+	}, http.StatusOK), &card)
+	cardID := card["id"].(string)
+	require.Equal(t, "new", card["stage"])
+
+	tt.do(http.MethodGet, "/cards?vacancy="+vacancyID, nil, http.StatusOK)
+	tt.do(http.MethodGet, "/candidates?vacancy="+vacancyID, nil, http.StatusOK)
+	tt.do(http.MethodPut, "/cards/"+cardID, map[string]interface{}{
+		"stage": "interview",
+	}, http.StatusOK)
+	tt.do(http.MethodPost, "/cards/"+cardID+"/comments", map[string]interface{}{
+		"author": "hr",
+		"text":   "Strong candidate",
+	}, http.StatusOK)
+
+	tt.decode(tt.do(http.MethodGet, "/cards/"+cardID, nil, http.StatusOK), &card)
+	require.Equal(t, "interview", card["stage"])
+	require.Len(t, card["comments"], 1)
+
+	tt.do(http.MethodGet, "/cards/00000000-0000-0000-0000-000000000001", nil, http.StatusNotFound)
+}
+
+func TestOpenAPI_Docs(t *testing.T) {
+	tt := newAPITester(t)
+
+	tt.do(http.MethodGet, "/openapi.json", nil, http.StatusOK)
+	body := tt.do(http.MethodGet, "/docs", nil, http.StatusOK)
+	require.True(t, strings.Contains(string(body), "openapi.json"))
+}
+
+func TestOpenAPI_Webhooks(t *testing.T) {
+	tt := newAPITester(t)
+	tt.srv.SetAdmins([]string{"admin@example.com"})
+	admin := tt.as("Admin@example.com")
+
+	tt.do(http.MethodGet, "/webhooks", nil, http.StatusUnauthorized)
+	tt.as("lead@example.com").do(http.MethodGet, "/webhooks", nil, http.StatusForbidden)
+	admin.do(http.MethodGet, "/webhooks", nil, http.StatusOK)
+
+	var webhook map[string]interface{}
+	tt.decode(admin.do(http.MethodPost, "/webhooks", map[string]interface{}{
+		"url":    "https://example.com/hooks",
+		"events": []string{"cardMoved", "commentAdded"},
+		"secret": "0123456789abcdef",
+	}, http.StatusOK), &webhook)
+	webhookID := webhook["id"].(string)
+	require.Equal(t, true, webhook["active"])
+	require.NotContains(t, webhook, "secret")
+	tt.as("lead@example.com").do(http.MethodDelete, "/webhooks/"+webhookID, nil, http.StatusForbidden)
+
+	admin.do(http.MethodPost, "/webhooks", map[string]interface{}{
+		"url":    "https://example.com/hooks",
+		"events": []string{"cardMoved"},
+		"secret": "short",
+	}, http.StatusBadRequest)
+
+	admin.do(http.MethodGet, "/webhooks/"+webhookID, nil, http.StatusOK)
+	tt.decode(admin.do(http.MethodPost, "/webhooks/"+webhookID, map[string]interface{}{
+		"url":    "https://example.com/hooks/v2",
+		"events": []string{"cardMoved"},
+		"active": false,
+	}, http.StatusOK), &webhook)
+	require.Equal(t, false, webhook["active"])
+
+	id, err := uuid.Parse(webhookID)
+	require.NoError(t, err)
+	delivery := entities.Delivery{
+		WebhookID: id,
+		EventID:   uuid.New(),
+		EventType: entities.EventTypeCardMoved,
+		Payload:   json.RawMessage(`{"cardID":"1"}`),
+		Status:    entities.DeliveryStatusDead,
+		Attempts:  10,
+	}
+	require.NoError(t, tt.srv.delivery.Create(context.Background(), &delivery))
+
+	var deliveries map[string]interface{}
+	tt.decode(admin.do(http.MethodGet, "/webhooks/"+webhookID+"/deliveries", nil, http.StatusOK), &deliveries)
+	require.Len(t, deliveries["items"], 1)
+
+	var redelivered map[string]interface{}
+	tt.decode(admin.do(
+		http.MethodPost,
+		"/webhooks/"+webhookID+"/deliveries/"+delivery.ID.String()+"/redeliver",
+		nil,
+		http.StatusOK,
+	), &redelivered)
+	require.Equal(t, "pending", redelivered["status"])
+	admin.do(
+		http.MethodPost,
+		"/webhooks/00000000-0000-0000-0000-000000000001/deliveries/"+delivery.ID.String()+"/redeliver",
+		nil,
+		http.StatusNotFound,
+	)
+
+	admin.do(http.MethodDelete, "/webhooks/"+webhookID, nil, http.StatusNoContent)
+	admin.do(http.MethodGet, "/webhooks/"+webhookID, nil, http.StatusNotFound)
+}
+
+func TestOpenAPI_Interviews(t *testing.T) {
+	tt := newAPITester(t)
+
+	var vacancy, candidate, card map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Go developer",
+		"status":     "active",
+		"experience": 1,
+	}, http.StatusOK), &vacancy)
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":  "John Doe",
+		"email": "john@example.com",
+	}, http.StatusOK), &candidate)
+	tt.decode(tt.do(http.MethodPost, "/cards", map[string]interface{}{
+		"vacancyID":   vacancy["id"],
+		"candidateID": candidate["id"],
+	}, http.StatusOK), &card)
+
+	var interview map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/interviews", map[string]interface{}{
+		"cardID":       card["id"],
+		"interviewers": []string{"lead@example.com", "dev@example.com"},
+		"start":        "2024-03-01T10:00:00Z",
+		"end":          "2024-03-01T11:00:00Z",
+		"format":       "video",
+		"link":         "https://meet.example.com/abc",
+	}, http.StatusOK), &interview)
+	id := interview["id"].(string)
+	require.Equal(t, "scheduled", interview["status"])
+
+	tt.do(http.MethodPost, "/interviews", map[string]interface{}{
+		"cardID":       card["id"],
+		"interviewers": []string{"dev@example.com"},
+		"start":        "2024-03-01T10:30:00Z",
+		"end":          "2024-03-01T11:30:00Z",
+		"format":       "phone",
+	}, http.StatusConflict)
+	tt.do(http.MethodPost, "/interviews", map[string]interface{}{
+		"cardID":       card["id"],
+		"interviewers": []string{"dev@example.com"},
+		"start":        "2024-03-01T11:00:00Z",
+		"end":          "2024-03-01T12:00:00Z",
+		"format":       "onsite",
+		"location":     "Room 42",
+	}, http.StatusOK)
+
+	var list map[string]interface{}
+	tt.decode(tt.do(
+		http.MethodGet,
+		"/interviews?interviewer=lead@example.com&from=2024-03-01T00:00:00Z&to=2024-03-02T00:00:00Z",
+		nil,
+		http.StatusOK,
+	), &list)
+	require.Len(t, list["items"], 1)
+	tt.decode(tt.do(http.MethodGet, "/interviews?interviewer=dev@example.com", nil, http.StatusOK), &list)
+	require.Len(t, list["items"], 2)
+	tt.do(http.MethodGet, "/interviews?from=yesterday", nil, http.StatusBadRequest)
+
+	// Unfold long lines of the invite.
+	unfold := strings.NewReplacer("\r\n ", "")
+	invite := unfold.Replace(string(tt.do(http.MethodGet, "/interviews/"+id+"/invite.ics", nil, http.StatusOK)))
+	require.Contains(t, invite, "METHOD:REQUEST\r\n")
+	require.Contains(t, invite, "SUMMARY:Interview: John Doe\\, Go developer\r\n")
+	require.Contains(t, invite, "mailto:john@example.com")
+
+	tt.decode(tt.do(http.MethodPost, "/interviews/"+id, map[string]interface{}{
+		"cardID":       card["id"],
+		"interviewers": []string{"lead@example.com", "dev@example.com"},
+		"start":        "2024-03-01T10:00:00Z",
+		"end":          "2024-03-01T11:00:00Z",
+		"format":       "video",
+		"link":         "https://meet.example.com/abc",
+		"status":       "cancelled",
+	}, http.StatusOK), &interview)
+	require.Equal(t, float64(1), interview["sequence"])
+
+	invite = unfold.Replace(string(tt.do(http.MethodGet, "/interviews/"+id+"/invite.ics", nil, http.StatusOK)))
+	require.Contains(t, invite, "METHOD:CANCEL")
+	require.Contains(t, invite, "SEQUENCE:1")
+
+	tt.do(http.MethodGet, "/interviews/00000000-0000-0000-0000-000000000001", nil, http.StatusNotFound)
+}
+
+func TestOpenAPI_InterviewsConcurrent(t *testing.T) {
+	tt := newAPITester(t)
+
+	var vacancy, candidate, card map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Go developer",
+		"status":     "active",
+		"experience": 1,
+	}, http.StatusOK), &vacancy)
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name": "John Doe",
+	}, http.StatusOK), &candidate)
+	tt.decode(tt.do(http.MethodPost, "/cards", map[string]interface{}{
+		"vacancyID":   vacancy["id"],
+		"candidateID": candidate["id"],
+	}, http.StatusOK), &card)
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"cardID":       card["id"],
+		"interviewers": []string{"lead@example.com"},
+		"start":        "2024-03-01T10:00:00Z",
+		"end":          "2024-03-01T11:00:00Z",
+		"format":       "phone",
+	})
+	require.NoError(t, err)
+
+	// Bookings of the same slot race, only one of them may win.
+	codes := make([]int, 10)
+	var wg sync.WaitGroup
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, "http://hr.test/interviews", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			tt.srv.server.Handler.ServeHTTP(rec, req)
+			codes[i] = rec.Code
+		}(i)
+	}
+	wg.Wait()
+
+	booked := 0
+	for _, code := range codes {
+		if code == http.StatusOK {
+			booked++
+			continue
+		}
+		require.Equal(t, http.StatusConflict, code)
+	}
+	require.Equal(t, 1, booked)
+}
+
+func TestOpenAPI_Scorecards(t *testing.T) {
+	tt := newAPITester(t)
+
+	var vacancy, candidate, card, interview map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Go developer",
+		"status":     "active",
+		"experience": 1,
+		"skills": []map[string]interface{}{
+			{"title": "Go", "important": true},
+			{"title": "SQL"},
+		},
+	}, http.StatusOK), &vacancy)
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name": "John Doe",
+	}, http.StatusOK), &candidate)
+	tt.decode(tt.do(http.MethodPost, "/cards", map[string]interface{}{
+		"vacancyID":   vacancy["id"],
+		"candidateID": candidate["id"],
+	}, http.StatusOK), &card)
+	tt.decode(tt.do(http.MethodPost, "/interviews", map[string]interface{}{
+		"cardID":       card["id"],
+		"interviewers": []string{"lead@example.com", "dev@example.com"},
+		"start":        "2024-03-01T10:00:00Z",
+		"end":          "2024-03-01T11:00:00Z",
+		"format":       "phone",
+	}, http.StatusOK), &interview)
+	scorecards := "/interviews/" + interview["id"].(string) + "/scorecards"
+	cardPath := "/cards/" + card["id"].(string)
+
+	lead := tt.as("lead@example.com")
+	dev := tt.as("dev@example.com")
+	recruiter := tt.as("hr@example.com")
+
+	submit := map[string]interface{}{
+		"ratings": []map[string]interface{}{
+			{"skill": "Go", "score": 5},
+			{"skill": "SQL", "score": 3},
+		},
+		"recommendation": "yes",
+		"notes":          "Strong backend",
+	}
+	tt.do(http.MethodPost, scorecards, submit, http.StatusUnauthorized)
+	recruiter.do(http.MethodPost, scorecards, submit, http.StatusForbidden)
+	lead.do(http.MethodPost, scorecards, map[string]interface{}{
+		"ratings":        []map[string]interface{}{{"skill": "Go", "score": 5}},
+		"recommendation": "yes",
+	}, http.StatusBadRequest)
+	lead.do(http.MethodPost, scorecards, submit, http.StatusOK)
+
+	var list map[string]interface{}
+	dev.decode(dev.do(http.MethodGet, scorecards, nil, http.StatusOK), &list)
+	require.Empty(t, list["items"], "scorecards must be hidden until own is submitted")
+	require.Equal(t, float64(1), list["hidden"])
+	list = nil
+	upper := tt.as("Dev@Example.com")
+	upper.decode(upper.do(http.MethodGet, scorecards, nil, http.StatusOK), &list)
+	require.Empty(t, list["items"], "emails are compared regardless of case")
+	require.Equal(t, float64(1), list["hidden"])
+
+	var got map[string]interface{}
+	dev.decode(dev.do(http.MethodGet, cardPath, nil, http.StatusOK), &got)
+	require.Equal(t, float64(1), got["feedback"].(map[string]interface{})["hidden"])
+
+	dev.do(http.MethodPost, scorecards, map[string]interface{}{
+		"ratings": []map[string]interface{}{
+			{"skill": "SQL", "score": 4},
+			{"skill": "Go", "score": 4},
+		},
+		"recommendation": "strongYes",
+	}, http.StatusOK)
+	tt.as("LEAD@example.com").do(http.MethodPost, scorecards, submit, http.StatusOK)
+
+	dev.decode(dev.do(http.MethodGet, scorecards, nil, http.StatusOK), &list)
+	require.Len(t, list["items"], 2, "the lead replaced own scorecard")
+	require.Equal(t, float64(0), list["hidden"])
+
+	recruiter.decode(recruiter.do(http.MethodGet, cardPath, nil, http.StatusOK), &got)
+	feedback := got["feedback"].(map[string]interface{})
+	require.Equal(t, float64(2), feedback["scorecards"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"skill": "Go", "average": 4.5, "count": float64(2)},
+		map[string]interface{}{"skill": "SQL", "average": 3.5, "count": float64(2)},
+	}, feedback["skills"])
+	require.Equal(t, map[string]interface{}{
+		"yes":       float64(1),
+		"strongYes": float64(1),
+	}, feedback["recommendations"])
+
+	tt.decode(tt.do(http.MethodGet, scorecards, nil, http.StatusOK), &list)
+	require.Empty(t, list["items"], "anonymous users must not see scorecards")
+}
+
+func TestOpenAPI_Offers(t *testing.T) {
+	tt := newAPITester(t)
+	tt.srv.SetOfferChain([]entities.Approval{
+		{Role: "hiring manager", Approver: "lead@example.com"},
+		{Role: "finance", Approver: "cfo@example.com"},
+	})
+
+	var vacancy, candidate, card map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Go developer",
+		"status":     "active",
+		"budget":     300000,
+	}, http.StatusOK), &vacancy)
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":   "John Doe",
+		"salary": 280000,
+	}, http.StatusOK), &candidate)
+	tt.decode(tt.do(http.MethodPost, "/cards", map[string]interface{}{
+		"vacancyID":   vacancy["id"],
+		"candidateID": candidate["id"],
+	}, http.StatusOK), &card)
+
+	request := map[string]interface{}{
+		"cardID":    card["id"],
+		"salary":    250000,
+		"startDate": "2030-02-01T00:00:00Z",
+		"expires":   "2030-01-15T00:00:00Z",
+	}
+	tt.do(http.MethodPost, "/offers", request, http.StatusConflict)
+	tt.do(http.MethodPut, "/cards/"+card["id"].(string), map[string]interface{}{
+		"stage": "offer",
+	}, http.StatusOK)
+
+	request["salary"] = 350000
+	tt.do(http.MethodPost, "/offers", request, http.StatusBadRequest)
+	request["salary"] = 250000
+
+	tt.srv.SetAdmins([]string{"admin@example.com"})
+	request["approvals"] = []interface{}{}
+	tt.as("lead@example.com").do(http.MethodPost, "/offers", request, http.StatusForbidden)
+	tt.as("admin@example.com").do(http.MethodPost, "/offers", request, http.StatusBadRequest)
+	delete(request, "approvals")
+
+	var offer map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/offers", request, http.StatusOK), &offer)
+	require.Equal(t, "pending", offer["status"])
+	require.Equal(t, "Go developer", offer["position"])
+	require.Equal(t, []interface{}{"belowExpectation"}, offer["warnings"])
+	path := "/offers/" + offer["id"].(string)
+
+	lead := tt.as("lead@example.com")
+	cfo := tt.as("cfo@example.com")
+	decision := map[string]interface{}{"comment": "ok"}
+
+	tt.do(http.MethodPost, path+"/approve", decision, http.StatusUnauthorized)
+	cfo.do(http.MethodPost, path+"/approve", decision, http.StatusForbidden)
+	tt.do(http.MethodPost, path+"/accept", nil, http.StatusConflict)
+	lead.do(http.MethodPost, path+"/approve", decision, http.StatusOK)
+	cfo.decode(cfo.do(http.MethodPost, path+"/approve", decision, http.StatusOK), &offer)
+	require.Equal(t, "approved", offer["status"])
+
+	html := string(tt.do(http.MethodGet, path+"/letter.html", nil, http.StatusOK))
+	require.Contains(t, html, "John Doe")
+	require.Contains(t, html, "01.02.2030")
+	pdf := tt.do(http.MethodGet, path+"/letter.pdf", nil, http.StatusOK)
+	require.True(t, bytes.HasPrefix(pdf, []byte("%PDF-")))
+
+	tt.decode(tt.do(http.MethodPost, path+"/accept", nil, http.StatusOK), &offer)
+	require.Equal(t, "accepted", offer["status"])
+	tt.do(http.MethodPost, path+"/withdraw", nil, http.StatusConflict)
+
+	var list map[string]interface{}
+	tt.decode(tt.do(http.MethodGet, "/offers?card="+card["id"].(string), nil, http.StatusOK), &list)
+	require.Len(t, list["items"], 1)
+}
+
+func TestOpenAPI_Messages(t *testing.T) {
+	tt := newAPITester(t)
+	tt.srv.SetNotifier(notify.New(tt.mem.Repos(), notify.DefaultTemplates(time.UTC), "HR <hr@example.com>"))
+
+	var vacancy, candidate, card map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Go developer",
+		"status":     "active",
+	}, http.StatusOK), &vacancy)
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":  "John Doe",
+		"email": "john@example.com",
+	}, http.StatusOK), &candidate)
+	tt.decode(tt.do(http.MethodPost, "/cards", map[string]interface{}{
+		"vacancyID":   vacancy["id"],
+		"candidateID": candidate["id"],
+	}, http.StatusOK), &card)
+	path := "/cards/" + card["id"].(string) + "/messages"
+
+	var list ListMessagesResponse
+	tt.decode(tt.do(http.MethodGet, path, nil, http.StatusOK), &list)
+	require.Empty(t, list.Items)
+
+	interview := map[string]interface{}{
+		"cardID":       card["id"],
+		"interviewers": []string{"Lead@Example.com"},
+		"start":        "2030-03-01T10:00:00Z",
+		"end":          "2030-03-01T11:00:00Z",
+		"format":       "video",
+		"link":         "https://meet.example.com/abc",
+	}
+	var created entities.Interview
+	tt.decode(tt.do(http.MethodPost, "/interviews", interview, http.StatusOK), &created)
+	require.Equal(t, []string{"lead@example.com"}, created.Interviewers)
+
+	tt.decode(tt.do(http.MethodGet, path, nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 2)
+	recipients := []string{list.Items[0].To, list.Items[1].To}
+	require.ElementsMatch(t, []string{"john@example.com", "lead@example.com"}, recipients)
+	for _, message := range list.Items {
+		require.Equal(t, entities.MessageDirectionOutbound, message.Direction)
+		require.Equal(t, entities.MessageStatusPending, message.Status)
+		require.Equal(t, notify.TemplateInterviewScheduled, message.Template)
+		require.Equal(t, "invite.ics", message.Files[0].Filename)
+	}
+
+	interview["interviewers"] = []string{"LEAD@example.com"}
+	tt.do(http.MethodPost, "/interviews", interview, http.StatusConflict)
+	tt.do(http.MethodPost, "/interviews/"+created.ID.String(), interview, http.StatusOK)
+	tt.decode(tt.do(http.MethodGet, path, nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 2, "nothing changed")
+	interview["start"] = "2030-03-01T12:00:00Z"
+	interview["end"] = "2030-03-01T13:00:00Z"
+	tt.do(http.MethodPost, "/interviews/"+created.ID.String(), interview, http.StatusOK)
+	tt.decode(tt.do(http.MethodGet, path, nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 4, "rescheduled")
+
+	tt.do(http.MethodGet, "/cards/00000000-0000-0000-0000-000000000001/messages", nil, http.StatusNotFound)
+}
+
+func TestOpenAPI_Skills(t *testing.T) {
+	tt := newAPITester(t)
+	tt.srv.SetAdmins([]string{"Admin@example.com"})
+
+	var golang, golang2 entities.CatalogSkill
+	tt.decode(tt.do(http.MethodPost, "/skills", map[string]interface{}{
+		"name":     " Go ",
+		"synonyms": []string{"Golang", "go"},
+		"category": "Languages",
+	}, http.StatusOK), &golang)
+	require.Equal(t, "Go", golang.Name)
+	require.Equal(t, []string{"Golang"}, golang.Synonyms)
+	tt.do(http.MethodPost, "/skills", map[string]interface{}{"name": "GoLang"}, http.StatusConflict)
+	tt.do(http.MethodPost, "/skills", map[string]interface{}{"name": " "}, http.StatusBadRequest)
+	tt.decode(tt.do(http.MethodPost, "/skills", map[string]interface{}{"name": "Go lang 2"}, http.StatusOK), &golang2)
+	tt.do(http.MethodPost, "/skills", map[string]interface{}{"name": "PostgreSQL"}, http.StatusOK)
+
+	var list ListSkillsResponse
+	tt.decode(tt.do(http.MethodGet, "/skills?prefix=go", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 2)
+	require.Equal(t, "Go", list.Items[0].Name)
+	tt.decode(tt.do(http.MethodGet, "/skills?prefix=gola", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 2, "synonyms are completed too")
+	tt.decode(tt.do(http.MethodGet, "/skills?category=Languages", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 1)
+
+	var vacancy entities.Vacancy
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Go developer",
+		"status":     "active",
+		"skills": []map[string]interface{}{
+			{"title": "golang"},
+			{"title": "go lang 2", "important": true},
+			{"title": "Go", "important": true},
+			{"title": "Kafka"},
+		},
+	}, http.StatusOK), &vacancy)
+	require.Equal(t, []entities.Skill{
+		{Title: "Go", Important: true},
+		{Title: "Go lang 2", Important: true},
+		{Title: "Kafka"},
+	}, vacancy.Skills)
+
+	tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":           "John Doe",
+		"salary":         2450,
+		"salaryCurrency": "eur",
+	}, http.StatusBadRequest)
+	var candidate CreateCandidateResponse
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":   "John Doe",
+		"skills": []string{"GOLANG", "postgresql", "Go Lang 2"},
+	}, http.StatusOK), &candidate)
+	require.Equal(t, []string{"Go", "PostgreSQL", "Go lang 2"}, candidate.Candidate.Skills)
+
+	path := "/skills/" + golang.ID.String() + ":merge"
+	merge := map[string]interface{}{"skillID": golang2.ID}
+	tt.do(http.MethodPost, path, merge, http.StatusUnauthorized)
+	tt.as("recruiter@example.com").do(http.MethodPost, path, merge, http.StatusForbidden)
+	tt.as("admin@example.com").do(http.MethodPost, path, map[string]interface{}{"skillID": golang.ID}, http.StatusBadRequest)
+
+	var merged entities.CatalogSkill
+	tt.decode(tt.as("admin@example.com").do(http.MethodPost, path, merge, http.StatusOK), &merged)
+	require.Equal(t, []string{"Golang", "Go lang 2"}, merged.Synonyms)
+	tt.do(http.MethodGet, "/skills/"+golang2.ID.String(), nil, http.StatusNotFound)
+
+	tt.decode(tt.do(http.MethodGet, "/vacancies/"+vacancy.ID.String(), nil, http.StatusOK), &vacancy)
+	require.Equal(t, []entities.Skill{{Title: "Go", Important: true}, {Title: "Kafka"}}, vacancy.Skills)
+	stored, err := tt.mem.Candidate.GetByID(context.Background(), candidate.Candidate.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Go", "PostgreSQL"}, stored.Skills)
+
+	tt.do(http.MethodPost, "/skills/"+golang.ID.String(), map[string]interface{}{"name": "PostgreSQL"}, http.StatusConflict)
+	tt.do(http.MethodDelete, "/skills/"+golang.ID.String(), nil, http.StatusNoContent)
+	tt.do(http.MethodDelete, "/skills/"+golang.ID.String(), nil, http.StatusNotFound)
+}
+
+func TestOpenAPI_Dictionaries(t *testing.T) {
+	tt := newAPITester(t)
+
+	var region, city, division entities.DictionaryItem
+	tt.decode(tt.do(http.MethodPost, "/dictionaries/area", map[string]interface{}{
+		"name": " Московская  область ",
+	}, http.StatusOK), &region)
+	require.Equal(t, "Московская область", region.Name)
+	require.Equal(t, entities.DictionaryKindArea, region.Kind)
+	tt.decode(tt.do(http.MethodPost, "/dictionaries/area", map[string]interface{}{
+		"name":     "Химки",
+		"parentID": region.ID,
+	}, http.StatusOK), &city)
+	tt.do(http.MethodPost, "/dictionaries/area", map[string]interface{}{"name": "химки"}, http.StatusConflict)
+	tt.do(http.MethodPost, "/dictionaries/area", map[string]interface{}{"name": " "}, http.StatusBadRequest)
+	tt.decode(tt.do(http.MethodPost, "/dictionaries/department", map[string]interface{}{"name": "IT"}, http.StatusOK), &division)
+	tt.do(http.MethodPost, "/dictionaries/department", map[string]interface{}{
+		"name":     "Backend",
+		"parentID": city.ID,
+	}, http.StatusBadRequest)
+	tt.do(http.MethodPost, "/dictionaries/area/"+region.ID.String(), map[string]interface{}{
+		"name":     region.Name,
+		"parentID": city.ID,
+	}, http.StatusBadRequest)
+
+	var list ListDictionaryResponse
+	tt.decode(tt.do(http.MethodGet, "/dictionaries/area?parent="+region.ID.String(), nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 1)
+	require.Equal(t, city.ID, list.Items[0].ID)
+	tt.decode(tt.do(http.MethodGet, "/dictionaries/area?prefix=мос", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 1)
+	tt.do(http.MethodGet, "/dictionaries/department/"+city.ID.String(), nil, http.StatusNotFound)
+
+	vacancy := map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Go developer",
+		"area":       "Химкм",
+		"department": "it",
+	}
+	tt.do(http.MethodPost, "/vacancies", vacancy, http.StatusBadRequest)
+	vacancy["area"] = "химки"
+	var created entities.Vacancy
+	tt.decode(tt.do(http.MethodPost, "/vacancies", vacancy, http.StatusOK), &created)
+	require.Equal(t, "Химки", created.Area)
+	require.Equal(t, "IT", created.Department)
+
+	tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name": "John Doe",
+		"area": "Moscow",
+	}, http.StatusBadRequest)
+	tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":           "John Doe",
+		"area":           "Химки",
+		"specialization": "Go developer",
+	}, http.StatusOK)
+
+	tt.decode(tt.do(http.MethodPost, "/dictionaries/area/"+city.ID.String(), map[string]interface{}{
+		"name":     "г. Химки",
+		"parentID": region.ID,
+	}, http.StatusOK), &city)
+	tt.decode(tt.do(http.MethodGet, "/vacancies/"+created.ID.String(), nil, http.StatusOK), &created)
+	require.Equal(t, "г. Химки", created.Area)
+
+	tt.do(http.MethodDelete, "/dictionaries/area/"+region.ID.String(), nil, http.StatusConflict)
+	tt.do(http.MethodDelete, "/dictionaries/area/"+city.ID.String(), nil, http.StatusConflict)
+	tt.do(http.MethodDelete, "/dictionaries/department/"+division.ID.String(), nil, http.StatusConflict)
+	var spare entities.DictionaryItem
+	tt.decode(tt.do(http.MethodPost, "/dictionaries/specialization", map[string]interface{}{"name": "Analyst"}, http.StatusOK), &spare)
+	tt.do(http.MethodDelete, "/dictionaries/specialization/"+spare.ID.String(), nil, http.StatusNoContent)
+	tt.do(http.MethodGet, "/dictionaries/specialization/"+spare.ID.String(), nil, http.StatusNotFound)
+}
+
+func TestOpenAPI_Org(t *testing.T) {
+	tt := newAPITester(t)
+	tt.srv.SetAdmins([]string{"admin@example.com"})
+	admin := tt.as("admin@example.com")
+	cto := tt.as("cto@example.com")
+	lead := tt.as("lead@example.com")
+	recruiter := tt.as("hr@example.com")
+
+	var it, backend entities.DictionaryItem
+	tt.decode(tt.do(http.MethodPost, "/dictionaries/department", map[string]interface{}{"name": "IT"}, http.StatusOK), &it)
+	tt.decode(tt.do(http.MethodPost, "/dictionaries/department", map[string]interface{}{
+		"name":     "Backend",
+		"parentID": it.ID,
+	}, http.StatusOK), &backend)
+
+	managers := map[string]interface{}{"managers": []string{"CTO@example.com"}}
+	tt.do(http.MethodPost, "/departments/"+it.ID.String()+"/managers", managers, http.StatusUnauthorized)
+	cto.do(http.MethodPost, "/departments/"+it.ID.String()+"/managers", managers, http.StatusForbidden)
+	admin.do(http.MethodPost, "/departments/"+it.ID.String()+"/managers", map[string]interface{}{
+		"managers": []string{"cto"},
+	}, http.StatusBadRequest)
+	admin.do(http.MethodPost, "/departments/"+uuid.NewString()+"/managers", managers, http.StatusNotFound)
+	var department entities.Department
+	tt.decode(admin.do(http.MethodPost, "/departments/"+it.ID.String()+"/managers", managers, http.StatusOK), &department)
+	require.Equal(t, []string{"cto@example.com"}, department.Managers)
+	lead.do(http.MethodPost, "/departments/"+backend.ID.String()+"/managers", map[string]interface{}{
+		"managers": []string{"lead@example.com"},
+	}, http.StatusForbidden)
+	cto.do(http.MethodPost, "/departments/"+backend.ID.String()+"/managers", map[string]interface{}{
+		"managers": []string{"lead@example.com"},
+	}, http.StatusOK)
+
+	var departments ListDepartmentsResponse
+	tt.decode(tt.do(http.MethodGet, "/departments", nil, http.StatusOK), &departments)
+	require.Len(t, departments.Items, 2)
+	require.Equal(t, "Backend", departments.Items[0].Name)
+	require.Equal(t, it.ID, departments.Items[0].ParentID)
+	require.Equal(t, []string{"lead@example.com"}, departments.Items[0].Managers)
+
+	vacancy := map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Go developer",
+		"status":     "active",
+		"department": "Backend",
+	}
+	var golang, analyst entities.Vacancy
+	tt.decode(recruiter.do(http.MethodPost, "/vacancies", vacancy, http.StatusOK), &golang)
+	require.Equal(t, "lead@example.com", golang.HiringManager, "the nearest manager of the department")
+	require.Equal(t, "hr@example.com", golang.Recruiter, "the user creating the vacancy")
+	vacancy["title"] = "Analyst"
+	vacancy["department"] = "IT"
+	vacancy["recruiter"] = "other@example.com"
+	tt.decode(tt.do(http.MethodPost, "/vacancies", vacancy, http.StatusOK), &analyst)
+	require.Equal(t, "cto@example.com", analyst.HiringManager)
+	vacancy["hiringManager"] = "boss"
+	tt.do(http.MethodPost, "/vacancies", vacancy, http.StatusBadRequest)
+
+	var list ListVacanciesResponse
+	tt.do(http.MethodGet, "/vacancies?owner=me", nil, http.StatusUnauthorized)
+	tt.decode(recruiter.do(http.MethodGet, "/vacancies?owner=me", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 1)
+	require.Equal(t, golang.ID, list.Items[0].ID)
+	tt.decode(tt.do(http.MethodGet, "/vacancies?owner=CTO@example.com", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 1)
+	require.Equal(t, analyst.ID, list.Items[0].ID)
+
+	update := map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Senior Go developer",
+		"status":     "active",
+		"department": "Backend",
+	}
+	var updated entities.Vacancy
+	tt.decode(tt.do(http.MethodPost, "/vacancies/"+golang.ID.String(), update, http.StatusOK), &updated)
+	require.Equal(t, "lead@example.com", updated.HiringManager, "empty owners are kept")
+	require.Equal(t, "hr@example.com", updated.Recruiter)
+	update["recruiter"] = "other@example.com"
+	tt.do(http.MethodPost, "/vacancies/"+golang.ID.String(), update, http.StatusUnauthorized)
+	recruiter.do(http.MethodPost, "/vacancies/"+golang.ID.String(), update, http.StatusForbidden)
+	tt.decode(cto.do(http.MethodPost, "/vacancies/"+golang.ID.String(), update, http.StatusOK), &updated)
+	require.Equal(t, "other@example.com", updated.Recruiter, "managers of parent departments reassign")
+
+	candidate := entities.Candidate{Name: "John Doe"}
+	require.NoError(t, tt.mem.Candidate.Create(context.Background(), &candidate))
+	for _, stage := range []entities.CardStage{entities.CardStageNew, entities.CardStageInterview, entities.CardStageInterview} {
+		card := entities.Card{VacancyID: golang.ID, CandidateID: candidate.ID, Stage: stage}
+		require.NoError(t, tt.mem.Card.Create(context.Background(), &card))
+	}
+
+	tt.do(http.MethodGet, "/dashboard", nil, http.StatusUnauthorized)
+	var dashboard reports.Dashboard
+	tt.decode(lead.do(http.MethodGet, "/dashboard", nil, http.StatusOK), &dashboard)
+	require.Len(t, dashboard.Vacancies, 1)
+	require.Equal(t, reports.RoleHiringManager, dashboard.Vacancies[0].Role)
+	require.Equal(t, 3, dashboard.Vacancies[0].Cards)
+	require.Equal(t, 2, dashboard.Vacancies[0].Stages[entities.CardStageInterview])
+	require.Equal(t, 0, dashboard.Stages[entities.CardStageHired])
+
+	tt.decode(cto.do(http.MethodGet, "/dashboard", nil, http.StatusOK), &dashboard)
+	require.Len(t, dashboard.Vacancies, 2)
+	roles := map[uuid.UUID]string{}
+	for _, v := range dashboard.Vacancies {
+		roles[v.VacancyID] = v.Role
+	}
+	require.Equal(t, reports.RoleHiringManager, roles[analyst.ID])
+	require.Equal(t, reports.RoleDepartmentManager, roles[golang.ID], "nested departments are managed too")
+	require.Equal(t, 3, dashboard.Stages[entities.CardStageNew]+dashboard.Stages[entities.CardStageInterview])
+
+	var unowned entities.Vacancy
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Unowned",
+		"status":     "active",
+	}, http.StatusOK), &unowned)
+	require.Empty(t, unowned.HiringManager)
+	require.Empty(t, unowned.Recruiter)
+	claim := map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Unowned",
+		"status":     "active",
+		"recruiter":  "hr@example.com",
+	}
+	tt.do(http.MethodPost, "/vacancies/"+unowned.ID.String(), claim, http.StatusUnauthorized)
+	recruiter.do(http.MethodPost, "/vacancies/"+unowned.ID.String(), claim, http.StatusForbidden)
+	claim["hiringManager"] = "lead@example.com"
+	lead.do(http.MethodPost, "/vacancies/"+unowned.ID.String(), claim, http.StatusForbidden)
+	tt.decode(admin.do(http.MethodPost, "/vacancies/"+unowned.ID.String(), claim, http.StatusOK), &updated)
+	require.Equal(t, "hr@example.com", updated.Recruiter, "admins give unowned vacancies owners")
+	require.Equal(t, "lead@example.com", updated.HiringManager)
+
+	var sales entities.DictionaryItem
+	tt.decode(tt.do(http.MethodPost, "/dictionaries/department", map[string]interface{}{"name": "Sales"}, http.StatusOK), &sales)
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Account manager",
+		"status":     "active",
+		"department": "Sales",
+	}, http.StatusOK), &unowned)
+	require.Empty(t, unowned.HiringManager, "the department has no managers yet")
+	admin.do(http.MethodPost, "/departments/"+sales.ID.String()+"/managers", map[string]interface{}{
+		"managers": []string{"sales@example.com"},
+	}, http.StatusOK)
+	claim = map[string]interface{}{
+		"templateID":    "00000000-0000-0000-0000-000000000000",
+		"title":         "Account manager",
+		"status":        "active",
+		"department":    "Sales",
+		"hiringManager": "sales@example.com",
+	}
+	cto.do(http.MethodPost, "/vacancies/"+unowned.ID.String(), claim, http.StatusForbidden)
+	tt.decode(tt.as("sales@example.com").do(http.MethodPost, "/vacancies/"+unowned.ID.String(), claim, http.StatusOK), &updated)
+	require.Equal(t, "sales@example.com", updated.HiringManager, "department managers give them owners too")
+}
+
+func TestOpenAPI_Requisitions(t *testing.T) {
+	tt := newAPITester(t)
+	tt.srv.SetAdmins([]string{"admin@example.com"})
+	tt.srv.SetRequisitionChain([]entities.Approval{
+		{Role: "hr director", Approver: "hrd@example.com"},
+		{Role: "finance", Approver: "cfo@example.com"},
+	})
+	tt.srv.SetRequisitionsRequired(true)
+	lead := tt.as("lead@example.com")
+	hrd := tt.as("hrd@example.com")
+	cfo := tt.as("cfo@example.com")
+
+	request := map[string]interface{}{
+		"title":         "Go developer",
+		"department":    "IT",
+		"positions":     1,
+		"budget":        500000,
+		"salaryMin":     200000,
+		"salaryMax":     250000,
+		"justification": "New payments team",
+	}
+	tt.do(http.MethodPost, "/requisitions", request, http.StatusUnauthorized)
+	request["positions"] = 3
+	lead.do(http.MethodPost, "/requisitions", request, http.StatusBadRequest)
+	request["positions"] = 1
+	var requisition Requisition
+	tt.decode(lead.do(http.MethodPost, "/requisitions", request, http.StatusOK), &requisition)
+	require.Equal(t, entities.RequisitionStatusPending, requisition.Status)
+	require.Equal(t, "lead@example.com", requisition.Requester)
+	require.Len(t, requisition.Approvals, 2)
+	path := "/requisitions/" + requisition.ID.String()
+
+	vacancy := map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Go developer",
+		"status":     "active",
+	}
+	tt.do(http.MethodPost, "/vacancies", vacancy, http.StatusConflict)
+	vacancy["requisitionID"] = requisition.ID
+	tt.do(http.MethodPost, "/vacancies", vacancy, http.StatusConflict)
+	vacancy["status"] = "draft"
+	var draft entities.Vacancy
+	tt.decode(lead.do(http.MethodPost, "/vacancies", vacancy, http.StatusOK), &draft)
+	require.Equal(t, "IT", draft.Department)
+	require.Equal(t, uint32(250000), draft.Budget)
+
+	cfo.do(http.MethodPost, path+"/approve", map[string]interface{}{}, http.StatusForbidden)
+	hrd.do(http.MethodPost, path+"/approve", map[string]interface{}{"comment": "ok"}, http.StatusOK)
+	tt.decode(cfo.do(http.MethodPost, path+"/approve", map[string]interface{}{}, http.StatusOK), &requisition)
+	require.Equal(t, entities.RequisitionStatusApproved, requisition.Status)
+	cfo.do(http.MethodPost, path+"/approve", map[string]interface{}{}, http.StatusConflict)
+
+	update := map[string]interface{}{
+		"templateID": "00000000-0000-0000-0000-000000000000",
+		"title":      "Go developer",
+		"status":     "active",
+		"budget":     300000,
+	}
+	lead.do(http.MethodPost, "/vacancies/"+draft.ID.String(), update, http.StatusBadRequest)
+	delete(update, "budget")
+	var active entities.Vacancy
+	tt.decode(lead.do(http.MethodPost, "/vacancies/"+draft.ID.String(), update, http.StatusOK), &active)
+	require.Equal(t, requisition.ID, active.RequisitionID, "the requisition is kept")
+
+	candidate := entities.Candidate{Name: "John Doe"}
+	require.NoError(t, tt.mem.Candidate.Create(context.Background(), &candidate))
+	cards := make([]entities.Card, 2)
+	for i := range cards {
+		cards[i] = entities.Card{VacancyID: active.ID, CandidateID: candidate.ID, Stage: entities.CardStageOffer}
+		require.NoError(t, tt.mem.Card.Create(context.Background(), &cards[i]))
+	}
+	hired := map[string]interface{}{"stage": "hired"}
+	tt.do(http.MethodPut, "/cards/"+cards[0].ID.String(), hired, http.StatusOK)
+	tt.do(http.MethodPut, "/cards/"+cards[1].ID.String(), hired, http.StatusConflict)
+
+	tt.decode(tt.do(http.MethodGet, path, nil, http.StatusOK), &requisition)
+	require.Equal(t, 1, requisition.Filled)
+	var list ListRequisitionsResponse
+	tt.decode(tt.do(http.MethodGet, "/requisitions?status=approved", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 1)
+	tt.decode(tt.do(http.MethodGet, "/requisitions?status=pending", nil, http.StatusOK), &list)
+	require.Empty(t, list.Items)
+
+	hrd.do(http.MethodPost, path+"/withdraw", nil, http.StatusForbidden)
+	tt.decode(lead.do(http.MethodPost, path+"/withdraw", nil, http.StatusOK), &requisition)
+	require.Equal(t, entities.RequisitionStatusWithdrawn, requisition.Status)
+	tt.do(http.MethodGet, "/requisitions/"+uuid.NewString(), nil, http.StatusNotFound)
+}
+
+func TestOpenAPI_ImportCandidate(t *testing.T) {
+	tt := newAPITester(t)
+
+	resume := map[string]interface{}{
+		"basics": map[string]interface{}{
+			"name":    "John Doe",
+			"email":   "john@example.com",
+			"summary": "Builds reliable services.",
+		},
+		"skills": []map[string]interface{}{{"name": "Go", "level": "Master"}},
+	}
+
+	var preview map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/candidates:import?dryRun=true", resume, http.StatusOK), &preview)
+	require.Equal(t, "jsonresume", preview["format"])
+	require.Equal(t, []interface{}{"basics.summary", "skills[].level"}, preview["unmapped"])
+	require.Equal(t, uuid.Nil.String(), preview["candidate"].(map[string]interface{})["id"])
+
+	var list map[string]interface{}
+	tt.decode(tt.do(http.MethodGet, "/candidates", nil, http.StatusOK), &list)
+	require.Empty(t, list["items"], "dry run must not save the candidate")
+
+	var imported map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/candidates:import", resume, http.StatusOK), &imported)
+	candidate := imported["candidate"].(map[string]interface{})
+	require.Equal(t, "John Doe", candidate["name"])
+	require.Equal(t, []interface{}{"Go"}, candidate["skills"])
+	tt.do(http.MethodGet, "/candidates/"+candidate["id"].(string), nil, http.StatusOK)
+
+	tt.do(http.MethodPost, "/candidates:import", map[string]interface{}{"name": "x"}, http.StatusBadRequest)
+	tt.do(http.MethodPost, "/candidates:import?format=hh", map[string]interface{}{
+		"skill_set": []string{"Go"},
+	}, http.StatusBadRequest)
+}
+
+func TestOpenAPI_Resumes(t *testing.T) {
+	tt := newAPITester(t)
+
+	tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"title":  "Go developer",
+		"skills": []map[string]interface{}{{"title": "Go"}, {"title": "PostgreSQL"}},
+	}, http.StatusOK)
+
+	text := "Иванов Иван\nivan@example.com\n+7 999 123-45-67\nGo, PostgreSQL, Rust\n"
+	var resume map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/resumes?filename=cv.txt", []byte(text), http.StatusOK), &resume)
+	require.Equal(t, "draft", resume["status"])
+	require.Equal(t, "cv.txt", resume["filename"])
+	draft := resume["draft"].(map[string]interface{})
+	require.Equal(t, "Иванов Иван", draft["name"])
+	require.Equal(t, "ivan@example.com", draft["email"])
+	require.Equal(t, "+7 999 123-45-67", draft["phone"])
+	require.Equal(t, []interface{}{"Go", "PostgreSQL"}, draft["skills"])
+
+	id := resume["id"].(string)
+	tt.do(http.MethodGet, "/resumes/"+id, nil, http.StatusOK)
+	file := tt.do(http.MethodGet, "/resumes/"+id+"/file", nil, http.StatusOK)
+	require.Equal(t, text, string(file))
+
+	draft["name"] = "Иван Иванов"
+	for _, field := range []string{"id", "created", "updated"} {
+		delete(draft, field)
+	}
+	var confirmed map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/resumes/"+id+"/confirm", draft, http.StatusOK), &confirmed)
+	require.Equal(t, "confirmed", confirmed["status"])
+
+	var candidate map[string]interface{}
+	tt.decode(tt.do(http.MethodGet, "/candidates/"+confirmed["candidateID"].(string), nil, http.StatusOK), &candidate)
+	require.Equal(t, "Иван Иванов", candidate["name"])
+
+	tt.do(http.MethodPost, "/resumes/"+id+"/confirm", nil, http.StatusConflict)
+	tt.do(http.MethodPost, "/resumes", []byte{0x89, 'P', 'N', 'G', 0}, http.StatusUnsupportedMediaType)
+	tt.do(http.MethodPost, "/resumes", []byte{}, http.StatusBadRequest)
+	tt.do(http.MethodGet, "/resumes/"+uuid.NewString(), nil, http.StatusNotFound)
+}
+
+func TestOpenAPI_Attachments(t *testing.T) {
+	tt := newAPITester(t)
+	recruiter := tt.as("recruiter@example.com")
+
+	var vacancy, candidate, card map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{"title": "Go developer"}, http.StatusOK), &vacancy)
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{"name": "John Doe"}, http.StatusOK), &candidate)
+	tt.decode(tt.do(http.MethodPost, "/cards", map[string]interface{}{
+		"vacancyID":   vacancy["id"],
+		"candidateID": candidate["id"],
+	}, http.StatusOK), &card)
+	candidateID, cardID := candidate["id"].(string), card["id"].(string)
+
+	text := []byte("Test assignment\n")
+	recruiter.do(http.MethodPost, "/candidates/"+candidateID+"/attachments?filename=a.txt", text, http.StatusServiceUnavailable)
+
+	blobs, err := blob.NewFS(t.TempDir())
+	require.NoError(t, err)
+	tt.srv.SetBlobStore(blobs)
+
+	tt.do(http.MethodPost, "/candidates/"+candidateID+"/attachments?filename=a.txt", text, http.StatusUnauthorized)
+	recruiter.do(http.MethodPost, "/candidates/"+candidateID+"/attachments?filename=", text, http.StatusBadRequest)
+	recruiter.do(http.MethodPost, "/candidates/"+candidateID+"/attachments?filename=a.txt", []byte{}, http.StatusBadRequest)
+
+	var first, second map[string]interface{}
+	recruiter.decode(recruiter.do(http.MethodPost,
+		"/candidates/"+candidateID+"/attachments?filename=..%2F..%2Fa.txt", text, http.StatusOK), &first)
+	require.Equal(t, "a.txt", first["filename"])
+	require.Equal(t, "text/plain; charset=utf-8", first["contentType"])
+	recruiter.decode(recruiter.do(http.MethodPost,
+		"/cards/"+cardID+"/attachments?filename=task.html", text, http.StatusOK), &second)
+	require.Equal(t, candidateID, second["candidateID"])
+	require.Equal(t, first["sha256"], second["sha256"])
+
+	var list ListAttachmentsResponse
+	recruiter.decode(recruiter.do(http.MethodGet, "/candidates/"+candidateID+"/attachments", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 2)
+	recruiter.decode(recruiter.do(http.MethodGet, "/cards/"+cardID+"/attachments", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 1)
+	tt.do(http.MethodGet, "/cards/"+cardID+"/attachments", nil, http.StatusUnauthorized)
+
+	firstID, secondID := first["id"].(string), second["id"].(string)
+	recruiter.do(http.MethodGet, "/attachments/"+firstID, nil, http.StatusOK)
+	data := recruiter.do(http.MethodGet, "/attachments/"+firstID+"/content", nil, http.StatusOK)
+	require.Equal(t, text, data)
+
+	req := httptest.NewRequest(http.MethodGet, "/attachments/"+firstID+"/content", nil)
+	req.Header.Set(userHeader, "recruiter@example.com")
+	req.Header.Set("If-None-Match", `"`+first["sha256"].(string)+`"`)
+	rec := httptest.NewRecorder()
+	tt.srv.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+
+	tt.as("manager@example.com").do(http.MethodDelete, "/attachments/"+firstID, nil, http.StatusForbidden)
+	key := first["sha256"].(string)[:2] + "/" + first["sha256"].(string)
+	recruiter.do(http.MethodDelete, "/attachments/"+firstID, nil, http.StatusNoContent)
+	ok, err := blobs.Exists(context.Background(), key)
+	require.NoError(t, err)
+	require.True(t, ok, "contents are still referenced")
+	recruiter.do(http.MethodDelete, "/attachments/"+secondID, nil, http.StatusNoContent)
+	ok, err = blobs.Exists(context.Background(), key)
+	require.NoError(t, err)
+	require.False(t, ok)
+	recruiter.do(http.MethodGet, "/attachments/"+secondID, nil, http.StatusNotFound)
+
+	tt.srv.SetAttachmentLimit(4)
+	recruiter.do(http.MethodPost, "/cards/"+cardID+"/attachments?filename=a.txt", text, http.StatusRequestEntityTooLarge)
+}
+
+func TestOpenAPI_MergeCandidates(t *testing.T) {
+	tt := newAPITester(t)
+
+	var first, second, third CreateCandidateResponse
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":      "Иванов Иван",
+		"phone":     "8 (999) 123-45-67",
+		"email":     " Ivanov@Example.com",
+		"education": []map[string]interface{}{{"title": "МГУ", "year": 2012}},
+		"skills":    []string{"Go"},
+	}, http.StatusOK), &first)
+	require.Equal(t, "+79991234567", first.Phone)
+	require.Equal(t, "ivanov@example.com", first.Email)
+	require.Empty(t, first.Duplicates)
+
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":   "Ivan Ivanov",
+		"phone":  "+7 999 123 45 67",
+		"area":   "Москва",
+		"skills": []string{"go", "PostgreSQL"},
+	}, http.StatusOK), &second)
+	require.Equal(t, []entities.Duplicate{{
+		CandidateID: first.ID,
+		Name:        "Иванов Иван",
+		Reasons:     []entities.DuplicateReason{entities.DuplicateReasonPhone, entities.DuplicateReasonName},
+	}}, second.Duplicates)
+
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{"name": "Петр Петров"}, http.StatusOK), &third)
+	require.Empty(t, third.Duplicates)
+	var namesake CreateCandidateResponse
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{"name": "PETROV Petr"}, http.StatusOK), &namesake)
+	require.Equal(t, []entities.Duplicate{{
+		CandidateID: third.ID,
+		Name:        "Петр Петров",
+		Reasons:     []entities.DuplicateReason{entities.DuplicateReasonName},
+	}}, namesake.Duplicates)
+	tt.do(http.MethodPost, "/candidates", map[string]interface{}{"name": "John", "phone": "call me"}, http.StatusBadRequest)
+
+	var duplicates ListDuplicatesResponse
+	tt.decode(tt.do(http.MethodGet, "/candidates/"+first.ID.String()+"/duplicates", nil, http.StatusOK), &duplicates)
+	require.Len(t, duplicates.Items, 1)
+	require.Equal(t, second.ID, duplicates.Items[0].CandidateID)
+
+	var vacancy, other map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{"title": "Go developer"}, http.StatusOK), &vacancy)
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{"title": "Team lead"}, http.StatusOK), &other)
+	tt.do(http.MethodPost, "/cards", map[string]interface{}{
+		"vacancyID":   vacancy["id"],
+		"candidateID": first.ID,
+	}, http.StatusOK)
+	var card map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/cards", map[string]interface{}{
+		"vacancyID":   other["id"],
+		"candidateID": second.ID,
+	}, http.StatusOK), &card)
+	tt.do(http.MethodPost, "/cards", map[string]interface{}{
+		"vacancyID":   vacancy["id"],
+		"candidateID": third.ID,
+	}, http.StatusOK)
+
+	blobs, err := blob.NewFS(t.TempDir())
+	require.NoError(t, err)
+	tt.srv.SetBlobStore(blobs)
+	tt.as("recruiter@example.com").do(http.MethodPost,
+		"/candidates/"+second.ID.String()+"/attachments?filename=cv.txt", []byte("CV\n"), http.StatusOK)
+
+	merge := func(id, duplicateID uuid.UUID, wantCode int) []byte {
+		return tt.do(http.MethodPost, "/candidates/"+id.String()+":merge",
+			MergeCandidateRequest{CandidateID: duplicateID}, wantCode)
+	}
+	merge(first.ID, first.ID, http.StatusBadRequest)
+	merge(first.ID, uuid.New(), http.StatusNotFound)
+	merge(first.ID, third.ID, http.StatusConflict)
+
+	var merged entities.Candidate
+	tt.decode(merge(first.ID, second.ID, http.StatusOK), &merged)
+	require.Equal(t, "Иванов Иван", merged.Name)
+	require.Equal(t, "ivanov@example.com", merged.Email)
+	require.Equal(t, "Москва", merged.Area)
+	require.Equal(t, []string{"Go", "PostgreSQL"}, merged.Skills)
+	require.Len(t, merged.Education, 1)
+
+	var moved map[string]interface{}
+	tt.decode(tt.do(http.MethodGet, "/cards/"+card["id"].(string), nil, http.StatusOK), &moved)
+	require.Equal(t, first.ID.String(), moved["candidateID"])
+	var attachments ListAttachmentsResponse
+	tt.decode(tt.as("recruiter@example.com").do(http.MethodGet,
+		"/candidates/"+first.ID.String()+"/attachments", nil, http.StatusOK), &attachments)
+	require.Len(t, attachments.Items, 1)
+
+	req := httptest.NewRequest(http.MethodGet, "/candidates/"+second.ID.String(), nil)
+	rec := httptest.NewRecorder()
+	tt.srv.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusMovedPermanently, rec.Code)
+	require.Equal(t, "/candidates/"+first.ID.String(), rec.Header().Get("Location"))
+
+	// Merging the survivor further keeps the old ID pointing at the end of
+	// the chain.
+	var fourth CreateCandidateResponse
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{"name": "I. Ivanov"}, http.StatusOK), &fourth)
+	merge(fourth.ID, first.ID, http.StatusOK)
+	rec = httptest.NewRecorder()
+	tt.srv.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, "/candidates/"+fourth.ID.String(), rec.Header().Get("Location"))
+}
+
+func TestOpenAPI_Consents(t *testing.T) {
+	tt := newAPITester(t)
+	officer := tt.as("dpo@example.com")
+
+	tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":           "John Doe",
+		"salary":         2450,
+		"salaryCurrency": "eur",
+	}, http.StatusBadRequest)
+	var candidate CreateCandidateResponse
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":  "Иванов Иван",
+		"phone": "+79991234567",
+	}, http.StatusOK), &candidate)
+	id := candidate.ID.String()
+
+	var vacancy map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{"title": "Go developer"}, http.StatusOK), &vacancy)
+	tt.do(http.MethodPost, "/cards", map[string]interface{}{
+		"vacancyID":   vacancy["id"],
+		"candidateID": candidate.ID,
+	}, http.StatusOK)
+
+	tt.do(http.MethodPost, "/candidates/"+id+"/consents", map[string]interface{}{
+		"purpose": "recruitment",
+	}, http.StatusBadRequest)
+	var consent entities.Consent
+	tt.decode(tt.do(http.MethodPost, "/candidates/"+id+"/consents", map[string]interface{}{
+		"purpose": "recruitment",
+		"source":  "careers site",
+		"expires": time.Now().AddDate(1, 0, 0),
+	}, http.StatusOK), &consent)
+	require.Equal(t, candidate.ID, consent.CandidateID)
+	require.False(t, consent.Given.IsZero())
+
+	var consents ListConsentsResponse
+	tt.decode(tt.do(http.MethodGet, "/candidates/"+id+"/consents", nil, http.StatusOK), &consents)
+	require.Len(t, consents.Items, 1)
+
+	tt.srv.SetAdmins([]string{"dpo@example.com"})
+	tt.do(http.MethodGet, "/candidates/"+id+":export", nil, http.StatusUnauthorized)
+	tt.as("recruiter@example.com").do(http.MethodGet, "/candidates/"+id+":export", nil, http.StatusForbidden)
+	var export privacy.Export
+	tt.decode(officer.do(http.MethodGet, "/candidates/"+id+":export", nil, http.StatusOK), &export)
+	require.Equal(t, "Иванов Иван", export.Candidate.Name)
+	require.Len(t, export.Consents, 1)
+	require.Len(t, export.Cards, 1)
+
+	tt.do(http.MethodPost, "/consents/"+consent.ID.String()+"/withdraw", nil, http.StatusOK)
+	tt.do(http.MethodPost, "/consents/"+consent.ID.String()+"/withdraw", nil, http.StatusConflict)
+	tt.do(http.MethodPost, "/consents/"+uuid.NewString()+"/withdraw", nil, http.StatusNotFound)
+
+	tt.do(http.MethodPost, "/candidates/"+id+":erase", nil, http.StatusUnauthorized)
+	tt.as("recruiter@example.com").do(http.MethodPost, "/candidates/"+id+":erase", nil, http.StatusForbidden)
+	var erased entities.Candidate
+	tt.decode(officer.do(http.MethodPost, "/candidates/"+id+":erase", nil, http.StatusOK), &erased)
+	require.Equal(t, entities.AnonymousName, erased.Name)
+	require.Empty(t, erased.Phone)
+	require.NotNil(t, erased.Anonymized)
+
+	tt.do(http.MethodPost, "/candidates/"+id, map[string]interface{}{"name": "Иванов Иван"}, http.StatusConflict)
+	tt.do(http.MethodPost, "/candidates/"+id+"/consents", map[string]interface{}{
+		"purpose": "recruitment",
+		"source":  "careers site",
+		"expires": time.Now().AddDate(1, 0, 0),
+	}, http.StatusConflict)
+	officer.do(http.MethodPost, "/candidates/"+id+":erase", nil, http.StatusOK)
+}
+
+func TestOpenAPI_Reports(t *testing.T) {
+	tt := newAPITester(t)
+
+	var vacancy, candidate, card map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"title":      "Go developer",
+		"status":     "active",
+		"department": "IT",
+		"area":       "Moscow",
+	}, http.StatusOK), &vacancy)
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{"name": "Иван Иванов"}, http.StatusOK), &candidate)
+	tt.decode(tt.do(http.MethodPost, "/cards", map[string]interface{}{
+		"vacancyID":   vacancy["id"],
+		"candidateID": candidate["id"],
+		"source":      "hh",
+	}, http.StatusOK), &card)
+	require.Equal(t, "hh", card["source"])
+	for _, stage := range []string{"screening", "interview", "offer", "hired"} {
+		tt.do(http.MethodPut, "/cards/"+card["id"].(string), map[string]interface{}{"stage": stage}, http.StatusOK)
+	}
+
+	var funnel reports.Funnel
+	tt.decode(tt.do(http.MethodGet, "/reports/funnel?department=IT", nil, http.StatusOK), &funnel)
+	require.Equal(t, 1, funnel.Cards)
+	require.Equal(t, 1, funnel.Stages[len(funnel.Stages)-1].Reached)
+	require.Equal(t, []reports.SourceStats{{Source: "hh", Cards: 1, Hired: 1, Conversion: 1}}, funnel.Sources)
+	tt.decode(tt.do(http.MethodGet, "/reports/funnel?department=Sales", nil, http.StatusOK), &funnel)
+	require.Equal(t, 0, funnel.Cards)
+
+	var hires reports.TimeToHire
+	tt.decode(tt.do(http.MethodGet, "/reports/time-to-hire?area=Moscow", nil, http.StatusOK), &hires)
+	require.Equal(t, 1, hires.Hires)
+	require.Equal(t, "Go developer", hires.Items[0].Vacancy)
+
+	var stats reports.Vacancies
+	tt.decode(tt.do(http.MethodGet, "/reports/vacancies?from=2000-01-01T00:00:00Z", nil, http.StatusOK), &stats)
+	require.Len(t, stats.Items, 1)
+	require.Equal(t, 1, stats.Items[0].Hired)
+	require.NotNil(t, stats.Items[0].TimeToFillDays)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports/vacancies?format=csv", nil)
+	rec := httptest.NewRecorder()
+	tt.srv.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="vacancies.csv"`, rec.Header().Get("Content-Disposition"))
+	require.True(t, strings.HasPrefix(rec.Body.String(), "vacancy_id,title,"))
+
+	tt.do(http.MethodGet, "/reports/funnel?format=xml", nil, http.StatusBadRequest)
+	tt.do(http.MethodGet, "/reports/time-to-hire?from=yesterday", nil, http.StatusBadRequest)
+}
+
+func TestOpenAPI_VacancyTable(t *testing.T) {
+	tt := newAPITester(t)
+
+	var vacancy map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"title":  "Go developer",
+		"status": "active",
+		"skills": []map[string]interface{}{{"title": "Go", "important": true}, {"title": "Docker"}},
+	}, http.StatusOK), &vacancy)
+
+	table := tt.do(http.MethodGet, "/vacancies:export", nil, http.StatusOK)
+	require.True(t, strings.HasPrefix(string(table), "id,title,status,"))
+	require.Contains(t, string(table), ",Go*; Docker,")
+
+	req := httptest.NewRequest(http.MethodGet, "/vacancies:export?format=xlsx", nil)
+	rec := httptest.NewRecorder()
+	tt.srv.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, xlsx.ContentType, rec.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="vacancies.xlsx"`, rec.Header().Get("Content-Disposition"))
+	require.True(t, xlsx.IsXLSX(rec.Body.Bytes()))
+	tt.do(http.MethodGet, "/vacancies:export?format=ods", nil, http.StatusBadRequest)
+
+	var result vacancies.Result
+	tt.decode(tt.do(http.MethodPost, "/vacancies:import", rec.Body.Bytes(), http.StatusOK), &result)
+	require.True(t, result.Saved())
+	require.Equal(t, 1, result.Updated)
+
+	body := []byte("id,title,department\n" +
+		vacancy["id"].(string) + ",Senior Go developer,IT\n" +
+		",QA engineer,\n")
+	tt.decode(tt.do(http.MethodPost, "/vacancies:import?dryRun=true", body, http.StatusOK), &result)
+	require.True(t, result.DryRun)
+	require.Equal(t, 1, result.Created)
+	require.Equal(t, 1, result.Updated)
+
+	tt.decode(tt.do(http.MethodPost, "/vacancies:import", append(body, ",,IT\n"...), http.StatusUnprocessableEntity), &result)
+	require.Equal(t, 1, result.Failed)
+	require.Equal(t, 4, result.Rows[2].Line)
+	require.Equal(t, "title is required", result.Rows[2].Error)
+
+	tt.do(http.MethodPost, "/vacancies:import", []byte("name\nGo developer\n"), http.StatusBadRequest)
+
+	tt.decode(tt.do(http.MethodPost, "/vacancies:import", body, http.StatusOK), &result)
+	require.True(t, result.Saved())
+	var list ListVacanciesResponse
+	tt.decode(tt.do(http.MethodGet, "/vacancies", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 2)
+	tt.decode(tt.do(http.MethodGet, "/vacancies/"+vacancy["id"].(string), nil, http.StatusOK), &vacancy)
+	require.Equal(t, "Senior Go developer", vacancy["title"])
+	require.Equal(t, "active", vacancy["status"])
+	require.Len(t, vacancy["skills"], 2)
+}
+
+func TestOpenAPI_Feeds(t *testing.T) {
+	tt := newAPITester(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tt.srv.SetFeedOptions(vacancies.FeedOptions{Company: "Газпромбанк", URL: "https://careers.example.com/{id}"})
+	require.NoError(t, tt.srv.hub.Poll(ctx))
+	go tt.srv.feed.Watch(ctx, tt.srv.hub)
+
+	var vacancy map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"title":        "Go developer",
+		"status":       "active",
+		"area":         "Москва",
+		"department":   "IT",
+		"duties":       []string{"Write services"},
+		"requirements": []string{"Go"},
+	}, http.StatusOK), &vacancy)
+	tt.do(http.MethodPost, "/vacancies", map[string]interface{}{"title": "Draft", "status": "draft"}, http.StatusOK)
+	require.NoError(t, tt.srv.hub.Poll(ctx))
+
+	var postings []map[string]interface{}
+	require.Eventually(t, func() bool {
+		tt.decode(tt.do(http.MethodGet, "/feeds/vacancies.jsonld", nil, http.StatusOK), &postings)
+		return len(postings) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, "JobPosting", postings[0]["@type"])
+	require.Equal(t, "https://careers.example.com/"+vacancy["id"].(string), postings[0]["url"])
+
+	feed := string(tt.do(http.MethodGet, "/feeds/vacancies.xml", nil, http.StatusOK))
+	require.Contains(t, feed, "<referencenumber>"+vacancy["id"].(string)+"</referencenumber>")
+	require.Contains(t, feed, "<city><![CDATA[Москва]]></city>")
+	require.NotContains(t, feed, "Draft")
+
+	req := httptest.NewRequest(http.MethodGet, "/feeds/vacancies.xml", nil)
+	rec := httptest.NewRecorder()
+	tt.srv.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, vacancies.ContentTypeXML, rec.Header().Get("Content-Type"))
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	req = httptest.NewRequest(http.MethodGet, "/feeds/vacancies.xml", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	tt.srv.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.String())
+
+	tt.do(http.MethodPost, "/vacancies/"+vacancy["id"].(string), map[string]interface{}{
+		"title":  "Go developer",
+		"status": "inactive",
+	}, http.StatusOK)
+	require.NoError(t, tt.srv.hub.Poll(ctx))
+	require.Eventually(t, func() bool {
+		tt.decode(tt.do(http.MethodGet, "/feeds/vacancies.jsonld", nil, http.StatusOK), &postings)
+		return len(postings) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestOpenAPI_Careers(t *testing.T) {
+	tt := newAPITester(t)
+	tt.srv.SetCareersLimit(4)
+
+	var vacancy, draft map[string]interface{}
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"title":  "Go developer",
+		"status": "active",
+		"budget": 300000,
+		"skills": []map[string]interface{}{{"title": "Go", "important": true}},
+	}, http.StatusOK), &vacancy)
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{"title": "Draft", "status": "draft"}, http.StatusOK), &draft)
+	vacancyID := vacancy["id"].(string)
+
+	var list struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	tt.decode(tt.do(http.MethodGet, "/public/vacancies", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 1)
+	require.Equal(t, []interface{}{"Go"}, list.Items[0]["skills"])
+	require.NotContains(t, list.Items[0], "budget")
+	tt.do(http.MethodGet, "/public/vacancies/"+vacancyID, nil, http.StatusOK)
+	tt.do(http.MethodGet, "/public/vacancies/"+draft["id"].(string), nil, http.StatusNotFound)
+
+	form := newMultipartForm(t, map[string]string{
+		"name":    "Иван Иванов",
+		"email":   "Ivan@Example.com",
+		"message": "Hello",
+		"consent": "on",
+	}, map[string][]byte{"resume": []byte("Иван Иванов\nGo, Docker")})
+	var receipt ApplicationReceipt
+	tt.decode(tt.do(http.MethodPost, "/public/vacancies/"+vacancyID+"/applications", form, http.StatusAccepted), &receipt)
+	require.False(t, receipt.Received.IsZero())
+
+	var cards ListCardsResponse
+	tt.decode(tt.do(http.MethodGet, "/cards?vacancy="+vacancyID, nil, http.StatusOK), &cards)
+	require.Len(t, cards.Items, 1)
+	require.Equal(t, entities.CardStageNew, cards.Items[0].Stage)
+	var card entities.Card
+	tt.decode(tt.do(http.MethodGet, "/cards/"+cards.Items[0].ID.String(), nil, http.StatusOK), &card)
+	require.Equal(t, "careers", card.Source)
+	require.Equal(t, "Hello", card.Comments[0].Text)
+	var candidate map[string]interface{}
+	tt.decode(tt.do(http.MethodGet, "/candidates/"+cards.Items[0].CandidateID.String(), nil, http.StatusOK), &candidate)
+	require.Equal(t, "ivan@example.com", candidate["email"])
+	require.Equal(t, []interface{}{"Go"}, candidate["skills"])
+
+	// Known contacts never change the known candidate, the application
+	// becomes a duplicate of it.
+	impostor := newMultipartForm(t, map[string]string{
+		"name":    "Ivan Ivanov",
+		"email":   "ivan@example.com",
+		"phone":   "+79990000000",
+		"message": "Send the offer to me",
+		"consent": "on",
+	}, nil)
+	tt.do(http.MethodPost, "/public/vacancies/"+vacancyID+"/applications", impostor, http.StatusAccepted)
+	var known map[string]interface{}
+	tt.decode(tt.do(http.MethodGet, "/candidates/"+cards.Items[0].CandidateID.String(), nil, http.StatusOK), &known)
+	require.Equal(t, candidate, known)
+	tt.decode(tt.do(http.MethodGet, "/cards/"+cards.Items[0].ID.String(), nil, http.StatusOK), &card)
+	require.Len(t, card.Comments, 1)
+	var duplicates ListDuplicatesResponse
+	tt.decode(tt.do(http.MethodGet, "/candidates/"+cards.Items[0].CandidateID.String()+"/duplicates", nil, http.StatusOK), &duplicates)
+	require.Len(t, duplicates.Items, 1)
+	tt.decode(tt.do(http.MethodGet, "/cards?vacancy="+vacancyID, nil, http.StatusOK), &cards)
+	require.Len(t, cards.Items, 2)
+
+	bot := newMultipartForm(t, map[string]string{"name": "Bot", "email": "bot@example.com", "consent": "on", "website": "http://spam"}, nil)
+	tt.do(http.MethodPost, "/public/vacancies/"+vacancyID+"/applications", bot, http.StatusAccepted)
+	tt.decode(tt.do(http.MethodGet, "/cards?vacancy="+vacancyID, nil, http.StatusOK), &cards)
+	require.Len(t, cards.Items, 2, "honeypot applications are dropped")
+
+	noConsent := newMultipartForm(t, map[string]string{"name": "Петр", "email": "petr@example.com"}, nil)
+	tt.do(http.MethodPost, "/public/vacancies/"+vacancyID+"/applications", noConsent, http.StatusBadRequest)
+
+	req := httptest.NewRequest(http.MethodPost, "/public/vacancies/"+vacancyID+"/applications", nil)
+	rec := httptest.NewRecorder()
+	tt.srv.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	req = httptest.NewRequest(http.MethodPost, "/public/vacancies/"+vacancyID+"/applications", nil)
+	req.Header.Set("X-Forwarded-For", "203.0.113.1, 198.51.100.7")
+	rec = httptest.NewRecorder()
+	tt.srv.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code, "clients can not pick own addresses")
+
+	require.Error(t, tt.srv.SetTrustedProxies([]string{"proxy"}))
+	require.NoError(t, tt.srv.SetTrustedProxies([]string{"10.0.0.1", "192.0.2.0/24"}))
+	req = httptest.NewRequest(http.MethodPost, "/public/vacancies/"+vacancyID+"/applications", nil)
+	req.Header.Set("X-Forwarded-For", "203.0.113.1, 198.51.100.7")
+	rec = httptest.NewRecorder()
+	tt.srv.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code, "other addresses behind the proxy have own limits")
+}
+
+func TestOpenAPI_Salaries(t *testing.T) {
+	tt := newAPITester(t)
+	tt.srv.SetExchangeRates(&entities.ExchangeRates{Base: "RUB", Rates: map[string]float64{"USD": 100}, Tax: 20})
+
+	vacancy := map[string]interface{}{
+		"title":  "Go developer",
+		"status": "active",
+		"salary": map[string]interface{}{"min": 3000, "max": 2000, "currency": "usd", "period": "month"},
+	}
+	tt.do(http.MethodPost, "/vacancies", vacancy, http.StatusBadRequest)
+	vacancy["salary"] = map[string]interface{}{"min": 2000, "max": 3000, "currency": "eur", "period": "month"}
+	tt.do(http.MethodPost, "/vacancies", vacancy, http.StatusBadRequest)
+	vacancy["salary"] = map[string]interface{}{"min": 2000, "max": 3000, "currency": "usd", "gross": true, "period": "month"}
+	var remote entities.Vacancy
+	tt.decode(tt.do(http.MethodPost, "/vacancies", vacancy, http.StatusOK), &remote)
+	require.Equal(t, "USD", remote.Salary.Currency)
+	var office entities.Vacancy
+	tt.decode(tt.do(http.MethodPost, "/vacancies", map[string]interface{}{
+		"title":  "Go developer",
+		"status": "active",
+		"salary": map[string]interface{}{"max": 3000000, "period": "year", "public": true},
+	}, http.StatusOK), &office)
+
+	var vacancies ListVacanciesResponse
+	tt.decode(tt.do(http.MethodGet, "/vacancies?salary=2450&currency=USD", nil, http.StatusOK), &vacancies)
+	require.Len(t, vacancies.Items, 1, "net maximum of the remote one is 240000")
+	require.Equal(t, office.ID, vacancies.Items[0].ID)
+	tt.decode(tt.do(http.MethodGet, "/vacancies?salary=150000", nil, http.StatusOK), &vacancies)
+	require.Len(t, vacancies.Items, 2)
+	tt.do(http.MethodGet, "/vacancies?salary=1000&currency=EUR", nil, http.StatusBadRequest)
+	tt.do(http.MethodGet, "/vacancies?salary=-1", nil, http.StatusBadRequest)
+
+	tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":           "John Doe",
+		"salary":         2450,
+		"salaryCurrency": "eur",
+	}, http.StatusBadRequest)
+	var candidate CreateCandidateResponse
+	tt.decode(tt.do(http.MethodPost, "/candidates", map[string]interface{}{
+		"name":           "John Doe",
+		"salary":         2450,
+		"salaryCurrency": "usd",
+		"salaryPeriod":   "month",
+	}, http.StatusOK), &candidate)
+	require.Equal(t, "USD", candidate.SalaryCurrency)
+
+	var candidates ListCandidatesResponse
+	tt.decode(tt.do(http.MethodGet, "/candidates?fitsVacancy="+remote.ID.String(), nil, http.StatusOK), &candidates)
+	require.Empty(t, candidates.Items)
+	tt.decode(tt.do(http.MethodGet, "/candidates?fitsVacancy="+office.ID.String(), nil, http.StatusOK), &candidates)
+	require.Len(t, candidates.Items, 1)
+
+	var list struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	tt.decode(tt.do(http.MethodGet, "/public/vacancies", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 2)
+	list.Items = nil
+	tt.decode(tt.do(http.MethodGet, "/public/vacancies?salary=260000", nil, http.StatusOK), &list)
+	require.Len(t, list.Items, 1, "the hidden salary is not compared")
+	require.Equal(t, remote.ID.String(), list.Items[0]["id"])
+	require.NotContains(t, list.Items[0], "salary")
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+var (
+	errNotDepartmentManager = errors.New("user does not manage the department")
+	errNotVacancyManager    = errors.New("user may not reassign the vacancy")
+)
+
+// orgChart returns the org structure: the department dictionary with
+// managers of departments.
+func orgChart(
+	ctx context.Context,
+	dictionary repos.DictionaryRepo,
+	org repos.OrgRepo,
+) (*entities.OrgChart, error) {
+	items, err := dictionary.List(ctx, repos.DictionaryFilter{Kind: entities.DictionaryKindDepartment})
+	if err != nil {
+		return nil, err
+	}
+	managers, err := org.Managers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewOrgChart(items, managers), nil
+}
+
+func (srv *Server) orgChart(ctx context.Context) (*entities.OrgChart, error) {
+	return orgChart(ctx, srv.dictionary, srv.org)
+}
+
+// assignOwners normalizes the hiring manager and the recruiter of the
+// vacancy. A new vacancy, old is nil, without a hiring manager gets the
+// nearest manager of its department. An updated one keeps the owners the
+// request leaves empty.
+func assignOwners(vacancy, old *entities.Vacancy, org *entities.OrgChart) error {
+	err := vacancy.NormalizeOwners()
+	if err != nil {
+		return err
+	}
+	if old != nil {
+		if vacancy.HiringManager == "" {
+			vacancy.HiringManager = old.HiringManager
+		}
+		if vacancy.Recruiter == "" {
+			vacancy.Recruiter = old.Recruiter
+		}
+		return nil
+	}
+	if vacancy.HiringManager == "" {
+		vacancy.HiringManager = org.HiringManager(vacancy.Department)
+	}
+	return nil
+}
+
+// checkReassign fails unless the user making the request may change owners
+// of the vacancy: admins, its hiring manager and managers of its department
+// may. Owners of unowned vacancies are set by admins and department managers
+// only, so nobody claims one for themselves.
+func (srv *Server) checkReassign(
+	req *http.Request,
+	vacancy, old *entities.Vacancy,
+	org *entities.OrgChart,
+) error {
+	return checkReassign(requestUser(req), srv.admins, vacancy, old, org)
+}
+
+func checkReassign(
+	user string,
+	admins map[string]bool,
+	vacancy, old *entities.Vacancy,
+	org *entities.OrgChart,
+) error {
+	reassigned := vacancy.HiringManager != old.HiringManager ||
+		vacancy.Recruiter != old.Recruiter
+	if !reassigned {
+		return nil
+	}
+	switch {
+	case user == "":
+		return errUserRequired
+	case admins[entities.NormalizeEmail(user)],
+		old.HiringManager != "" && entities.NormalizeEmail(user) == old.HiringManager,
+		org.ManagesVacancy(user, old):
+		return nil
+	}
+	return errNotVacancyManager
+}
+
+// ListDepartments returns the org structure: departments ordered by name
+// with their parents and managers.
+func (srv *Server) ListDepartments(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	org, err := srv.orgChart(req.Context())
+	if err != nil {
+		log.Printf("[error] [server] error listing departments: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, ListDepartmentsResponse{Items: org.Departments()})
+	if err != nil {
+		log.Printf("[error] [server] error listing departments: %s", err)
+	}
+}
+
+// SetDepartmentManagers replaces managers of the department. Admins may set
+// managers of any department, managers of a department may set ones of the
+// departments nested in it.
+func (srv *Server) SetDepartmentManagers(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	var request SetManagersRequest
+	err := json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error setting department managers: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	managers, err := entities.NormalizeManagers(request.Managers)
+	if err != nil {
+		log.Printf("[error] [server] error setting department managers: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	org, err := srv.orgChart(req.Context())
+	if err != nil {
+		log.Printf("[error] [server] error setting department managers: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		err = errNotFound
+	}
+	department, ok := org.Department(id)
+	if err == nil && !ok {
+		err = repos.ErrDictionaryNotFound
+	}
+	if err == nil {
+		err = srv.requireDepartmentManager(req, org, department.ParentID)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error setting department managers: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = srv.org.SetManagers(req.Context(), department.ID, managers)
+	if err != nil {
+		log.Printf("[error] [server] error setting department managers: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	department.Managers = managers
+
+	err = writeJSON(w, http.StatusOK, department)
+	if err != nil {
+		log.Printf("[error] [server] error setting department managers: %s", err)
+	}
+}
+
+// requireDepartmentManager fails unless the user making the request is an
+// admin or manages the department, nil department is managed by admins
+// only.
+func (srv *Server) requireDepartmentManager(
+	req *http.Request,
+	org *entities.OrgChart,
+	departmentID uuid.UUID,
+) error {
+	err := srv.requireAdmin(req)
+	if !errors.Is(err, errNotAdmin) {
+		return err
+	}
+	if org.Manages(requestUser(req), departmentID) {
+		return nil
+	}
+	return errNotDepartmentManager
+}
+
+// GetDashboard returns open vacancies the user making the request owns or
+// manages through the org structure, with their pipelines.
+func (srv *Server) GetDashboard(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	user := requestUser(req)
+	if user == "" {
+		log.Printf("[error] [server] error get dashboard: %s", errUserRequired)
+		writeError(w, http.StatusUnauthorized, errUserRequired)
+		return
+	}
+
+	org, err := srv.orgChart(req.Context())
+	if err != nil {
+		log.Printf("[error] [server] error get dashboard: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	dashboard, err := srv.reports.Dashboard(req.Context(), user, org)
+	if err != nil {
+		log.Printf("[error] [server] error get dashboard: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, dashboard)
+	if err != nil {
+		log.Printf("[error] [server] error get dashboard: %s", err)
+	}
+}
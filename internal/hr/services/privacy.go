@@ -0,0 +1,194 @@
+package services
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// ListConsents returns consents of the candidate.
+func (srv *Server) ListConsents(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	candidateID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error listing consents: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	_, err = srv.candidate.GetByID(req.Context(), candidateID)
+	if err != nil {
+		log.Printf("[error] [server] error listing consents: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	result, err := srv.consent.List(req.Context(), candidateID)
+	if err != nil {
+		log.Printf("[error] [server] error listing consents: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, ListConsentsResponse{Items: result})
+	if err != nil {
+		log.Printf("[error] [server] error listing consents: %s", err)
+	}
+}
+
+// GiveConsent records consent of the candidate, given now unless the date
+// is in the body.
+func (srv *Server) GiveConsent(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	candidateID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error giving consent: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var consent entities.Consent
+	err = json.NewDecoder(req.Body).Decode(&consent)
+	if err != nil {
+		log.Printf("[error] [server] error giving consent: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	consent.CandidateID = candidateID
+	consent.Withdrawn = nil
+	if consent.Given.IsZero() {
+		consent.Given = time.Now()
+	}
+	err = consent.Validate()
+	if err != nil {
+		log.Printf("[error] [server] error giving consent: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	candidate, err := srv.candidate.GetByID(req.Context(), candidateID)
+	if err == nil && candidate.Anonymized != nil {
+		err = entities.ErrCandidateAnonymized
+	}
+	if err == nil {
+		err = srv.consent.Create(req.Context(), &consent)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error giving consent: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, consent)
+	if err != nil {
+		log.Printf("[error] [server] error giving consent: %s", err)
+	}
+}
+
+// WithdrawConsent ends the consent. Data of the candidate is anonymized on
+// the next check if no other consent is active.
+func (srv *Server) WithdrawConsent(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	consentID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error withdrawing consent: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	consent, err := srv.consent.GetByID(req.Context(), consentID)
+	if err == nil {
+		err = consent.Withdraw(time.Now())
+	}
+	if err == nil {
+		err = srv.consent.Update(req.Context(), consent)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error withdrawing consent: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, consent)
+	if err != nil {
+		log.Printf("[error] [server] error withdrawing consent: %s", err)
+	}
+}
+
+// ExportCandidate returns everything stored about the candidate for a data
+// subject access request. It is admin only, since the export holds
+// scorecards hidden from other interviewers.
+func (srv *Server) ExportCandidate(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	candidateID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error exporting candidate: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = srv.requireAdmin(req)
+	if err != nil {
+		log.Printf("[error] [server] error exporting candidate: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	user := requestUser(req)
+
+	export, err := srv.privacy.Export(req.Context(), candidateID)
+	if err != nil {
+		log.Printf("[error] [server] error exporting candidate: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	log.Printf("[info] [server] candidate %s exported by %s", candidateID, user)
+
+	err = writeJSON(w, http.StatusOK, export)
+	if err != nil {
+		log.Printf("[error] [server] error exporting candidate: %s", err)
+	}
+}
+
+// EraseCandidate scrubs personal data of the candidate on request of the
+// data subject. Only admins erase candidates.
+func (srv *Server) EraseCandidate(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	candidateID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error erasing candidate: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = srv.requireAdmin(req)
+	if err != nil {
+		log.Printf("[error] [server] error erasing candidate: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	user := requestUser(req)
+
+	candidate, err := srv.privacy.Erase(req.Context(), candidateID)
+	if err != nil {
+		log.Printf("[error] [server] error erasing candidate: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	log.Printf("[info] [server] candidate %s erased by %s", candidateID, user)
+
+	err = writeJSON(w, http.StatusOK, candidate)
+	if err != nil {
+		log.Printf("[error] [server] error erasing candidate: %s", err)
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"gpb.ru/hr/internal/hr/reports"
+)
+
+var errInvalidReportFormat = errors.New("report format must be json or csv")
+
+// reportRequest reads the filter and the format common to all reports.
+func reportRequest(req *http.Request) (reports.Filter, string, error) {
+	query := req.URL.Query()
+	filter := reports.Filter{
+		Department: query.Get("department"),
+		Area:       query.Get("area"),
+	}
+	format := query.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		return filter, "", errInvalidReportFormat
+	}
+
+	var err error
+	filter.From, err = queryTime(req, "from")
+	if err == nil {
+		filter.To, err = queryTime(req, "to")
+	}
+	return filter, format, err
+}
+
+// writeReport writes the report as JSON or as CSV file named after it.
+func writeReport(
+	w http.ResponseWriter,
+	name, format string,
+	report interface{ WriteCSV(io.Writer) error },
+) error {
+	if format != "csv" {
+		return writeJSON(w, http.StatusOK, report)
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, name))
+	w.WriteHeader(http.StatusOK)
+	return report.WriteCSV(w)
+}
+
+// GetFunnelReport returns conversion between stages and source effectiveness
+// of cards created in the period.
+func (srv *Server) GetFunnelReport(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	filter, format, err := reportRequest(req)
+	if err != nil {
+		log.Printf("[error] [server] error get funnel report: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	report, err := srv.reports.Funnel(req.Context(), filter)
+	if err != nil {
+		log.Printf("[error] [server] error get funnel report: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeReport(w, "funnel", format, report)
+	if err != nil {
+		log.Printf("[error] [server] error get funnel report: %s", err)
+	}
+}
+
+// GetTimeToHireReport returns hires made in the period with the time they
+// took.
+func (srv *Server) GetTimeToHireReport(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	filter, format, err := reportRequest(req)
+	if err != nil {
+		log.Printf("[error] [server] error get time to hire report: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	report, err := srv.reports.TimeToHire(req.Context(), filter)
+	if err != nil {
+		log.Printf("[error] [server] error get time to hire report: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeReport(w, "time-to-hire", format, report)
+	if err != nil {
+		log.Printf("[error] [server] error get time to hire report: %s", err)
+	}
+}
+
+// GetVacanciesReport returns statistics and time to fill of vacancies
+// created in the period.
+func (srv *Server) GetVacanciesReport(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	filter, format, err := reportRequest(req)
+	if err != nil {
+		log.Printf("[error] [server] error get vacancies report: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	report, err := srv.reports.Vacancies(req.Context(), filter)
+	if err != nil {
+		log.Printf("[error] [server] error get vacancies report: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeReport(w, "vacancies", format, report)
+	if err != nil {
+		log.Printf("[error] [server] error get vacancies report: %s", err)
+	}
+}
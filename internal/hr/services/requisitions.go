@@ -0,0 +1,252 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+var errNotRequester = errors.New("user is not the requester of the requisition")
+
+// ListRequisitions returns requisitions, the newest first, optionally of the
+// given status or requester only.
+func (srv *Server) ListRequisitions(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	filter := repos.RequisitionFilter{
+		Requester: entities.NormalizeEmail(req.URL.Query().Get("requester")),
+	}
+	err := filter.Status.UnmarshalText([]byte(req.URL.Query().Get("status")))
+	if err != nil {
+		log.Printf("[error] [server] error listing requisitions: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := srv.requisition.List(req.Context(), filter)
+	if err != nil {
+		log.Printf("[error] [server] error listing requisitions: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	filled, err := srv.requisitions.Filled(req.Context())
+	if err != nil {
+		log.Printf("[error] [server] error listing requisitions: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	items := make([]Requisition, len(result))
+	for i, r := range result {
+		items[i] = Requisition{Requisition: r, Filled: filled[r.ID]}
+	}
+	err = writeJSON(w, http.StatusOK, ListRequisitionsResponse{Items: items})
+	if err != nil {
+		log.Printf("[error] [server] error listing requisitions: %s", err)
+	}
+}
+
+// GetRequisition returns the given requisition with the number of positions
+// filled.
+func (srv *Server) GetRequisition(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	id, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get requisition: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	requisition, err := srv.requisition.GetByID(req.Context(), id)
+	if err != nil {
+		log.Printf("[error] [server] error get requisition: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	srv.writeRequisition(w, req, "get", requisition)
+}
+
+// CreateRequisition requests headcount on behalf of the user making the
+// request and submits the request to the approval chain configured on the
+// server.
+func (srv *Server) CreateRequisition(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	var request RequisitionRequest
+	err := json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error creating requisition: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	user := requestUser(req)
+	if user == "" {
+		log.Printf("[error] [server] error creating requisition: %s", errUserRequired)
+		writeError(w, errorStatus(errUserRequired), errUserRequired)
+		return
+	}
+
+	requisition := entities.Requisition{
+		Title:         request.Title,
+		Department:    request.Department,
+		Positions:     request.Positions,
+		Budget:        request.Budget,
+		SalaryMin:     request.SalaryMin,
+		SalaryMax:     request.SalaryMax,
+		Justification: request.Justification,
+		Requester:     entities.NormalizeEmail(user),
+		Approvals:     append([]entities.Approval{}, srv.requisitionChain...),
+	}
+	err = requisition.Validate()
+	if err == nil {
+		var dicts *entities.Dictionaries
+		dicts, err = srv.dictionaries(req.Context())
+		if err == nil {
+			requisition.Department, err = dicts.Name(entities.DictionaryKindDepartment, requisition.Department)
+		}
+	}
+	if err != nil {
+		log.Printf("[error] [server] error creating requisition: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	requisition.Submit()
+
+	err = srv.requisition.Create(req.Context(), &requisition)
+	if err != nil {
+		log.Printf("[error] [server] error creating requisition: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, Requisition{Requisition: requisition})
+	if err != nil {
+		log.Printf("[error] [server] error creating requisition: %s", err)
+	}
+}
+
+// ApproveRequisition records approval of the requesting user whose turn it
+// is in the approval chain.
+func (srv *Server) ApproveRequisition(w http.ResponseWriter, req *http.Request) {
+	srv.decideRequisition(w, req, entities.DecisionApproved)
+}
+
+// RejectRequisition records rejection of the requesting user whose turn it
+// is in the approval chain. Vacancies can not be opened by rejected
+// requisitions.
+func (srv *Server) RejectRequisition(w http.ResponseWriter, req *http.Request) {
+	srv.decideRequisition(w, req, entities.DecisionRejected)
+}
+
+func (srv *Server) decideRequisition(
+	w http.ResponseWriter,
+	req *http.Request,
+	decision entities.Decision,
+) {
+	defer req.Body.Close()
+
+	var request DecisionRequest
+	err := json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error deciding requisition: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	user := requestUser(req)
+	if user == "" {
+		log.Printf("[error] [server] error deciding requisition: %s", errUserRequired)
+		writeError(w, errorStatus(errUserRequired), errUserRequired)
+		return
+	}
+
+	srv.changeRequisition(w, req, "deciding", func(r *entities.Requisition) error {
+		return r.Decide(user, decision, request.Comment, time.Now())
+	})
+}
+
+// WithdrawRequisition cancels the requisition, allowed to its requester and
+// admins. Vacancies already opened by it stay active.
+func (srv *Server) WithdrawRequisition(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	srv.changeRequisition(w, req, "withdrawing", func(r *entities.Requisition) error {
+		err := srv.requireAdmin(req)
+		if errors.Is(err, errNotAdmin) && entities.NormalizeEmail(requestUser(req)) == r.Requester {
+			err = nil
+		}
+		if errors.Is(err, errNotAdmin) {
+			err = errNotRequester
+		}
+		if err != nil {
+			return err
+		}
+		return r.Withdraw()
+	})
+}
+
+// changeRequisition applies the change to the requisition of the request
+// and stores it.
+func (srv *Server) changeRequisition(
+	w http.ResponseWriter,
+	req *http.Request,
+	verb string,
+	change func(*entities.Requisition) error,
+) {
+	id, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error %s requisition: %s", verb, err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	requisition, err := srv.requisition.GetByID(req.Context(), id)
+	if err == nil {
+		err = change(requisition)
+	}
+	if err == nil {
+		err = srv.requisition.Update(req.Context(), requisition)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error %s requisition: %s", verb, err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	srv.writeRequisition(w, req, verb, requisition)
+}
+
+// writeRequisition writes the requisition with the number of positions
+// filled.
+func (srv *Server) writeRequisition(
+	w http.ResponseWriter,
+	req *http.Request,
+	verb string,
+	requisition *entities.Requisition,
+) {
+	filled, err := srv.requisition.Hired(req.Context(), requisition.ID)
+	if err != nil {
+		log.Printf("[error] [server] error %s requisition: %s", verb, err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, Requisition{
+		Requisition: *requisition,
+		Filled:      filled,
+	})
+	if err != nil {
+		log.Printf("[error] [server] error %s requisition: %s", verb, err)
+	}
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/resumes"
+	"gpb.ru/hr/pkg/textract"
+)
+
+// maxResumeSize limits uploaded resume files.
+const maxResumeSize = 10 << 20
+
+// UploadResume stores the PDF, DOCX or plain text resume in the body and
+// guesses a draft candidate from its text. Skills are recognized by the
+// skill catalog and skills of vacancies. Nothing leaves the server while
+// parsing.
+func (srv *Server) UploadResume(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxResumeSize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		log.Printf("[error] [server] error uploading resume: %s", err)
+		writeError(w, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+	if err != nil {
+		log.Printf("[error] [server] error uploading resume: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resume := entities.Resume{
+		Filename: path.Base("/" + strings.ReplaceAll(req.URL.Query().Get("filename"), `\`, "/")),
+		Size:     int64(len(data)),
+		Status:   entities.ResumeStatusDraft,
+	}
+	if resume.Filename == "/" {
+		resume.Filename = "resume"
+	}
+	err = resume.Validate()
+	if err == nil {
+		resume.Text, resume.ContentType, err = textract.Extract(data)
+	}
+	if err != nil {
+		// Documents failing to parse are malformed or protected.
+		code := http.StatusBadRequest
+		if errors.Is(err, textract.ErrUnsupported) {
+			code = http.StatusUnsupportedMediaType
+		}
+		log.Printf("[error] [server] error uploading resume: %s", err)
+		writeError(w, code, err)
+		return
+	}
+
+	resume.Draft, err = srv.draftCandidate(req.Context(), resume.Text)
+	if err != nil {
+		log.Printf("[error] [server] error uploading resume: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = srv.resume.Create(req.Context(), &resume, data)
+	if err != nil {
+		log.Printf("[error] [server] error uploading resume: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, resume)
+	if err != nil {
+		log.Printf("[error] [server] error uploading resume: %s", err)
+	}
+}
+
+// draftCandidate parses the resume text looking for skills of the catalog
+// and of vacancies, found skills get their catalog names. Areas and
+// specializations missing in dictionaries are left for the recruiter.
+func (srv *Server) draftCandidate(ctx context.Context, text string) (entities.Candidate, error) {
+	skills, err := srv.skillIndex(ctx)
+	if err != nil {
+		return entities.Candidate{}, err
+	}
+	vacancies, err := srv.vacancy.List(ctx)
+	if err != nil {
+		return entities.Candidate{}, err
+	}
+	dicts, err := srv.dictionaries(ctx)
+	if err != nil {
+		return entities.Candidate{}, err
+	}
+	draft := resumes.FromText(text, skills.Dictionary(vacancies))
+	draft.NormalizeSkills(skills)
+	draft.FitDictionaries(dicts)
+	return draft, nil
+}
+
+// GetResume returns the resume with its text and draft candidate.
+func (srv *Server) GetResume(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	resumeID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get resume: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resume, err := srv.resume.GetByID(req.Context(), resumeID)
+	if err != nil {
+		log.Printf("[error] [server] error get resume: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, resume)
+	if err != nil {
+		log.Printf("[error] [server] error get resume: %s", err)
+	}
+}
+
+// GetResumeFile returns the original uploaded file.
+func (srv *Server) GetResumeFile(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	resumeID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get resume file: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resume, err := srv.resume.GetByID(req.Context(), resumeID)
+	var data []byte
+	if err == nil {
+		data, err = srv.resume.Data(req.Context(), resumeID)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error get resume file: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", resume.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set(
+		"Content-Disposition",
+		mime.FormatMediaType("attachment", map[string]string{"filename": resume.Filename}),
+	)
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(data)
+	if err != nil {
+		log.Printf("[error] [server] error get resume file: %s", err)
+	}
+}
+
+// ConfirmResume creates a candidate from the resume draft as corrected by
+// the recruiter in the body, an empty body takes the draft as is.
+func (srv *Server) ConfirmResume(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	resumeID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error confirming resume: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		log.Printf("[error] [server] error confirming resume: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resume, err := srv.resume.GetByID(req.Context(), resumeID)
+	if err == nil && resume.Status == entities.ResumeStatusConfirmed {
+		err = entities.ErrResumeConfirmed
+	}
+	if err != nil {
+		log.Printf("[error] [server] error confirming resume: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	candidate := resume.Draft
+	if len(bytes.TrimSpace(body)) > 0 {
+		candidate = entities.Candidate{}
+		err = json.Unmarshal(body, &candidate)
+	}
+	if err == nil {
+		err = candidate.Normalize()
+	}
+	if err == nil {
+		err = candidate.Validate()
+	}
+	if err != nil {
+		log.Printf("[error] [server] error confirming resume: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	dicts, err := srv.dictionaries(req.Context())
+	if err == nil {
+		err = candidate.NormalizeDictionaries(dicts)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error confirming resume: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	skills, err := srv.skillIndex(req.Context())
+	if err == nil {
+		candidate.NormalizeSkills(skills)
+		err = srv.candidate.Create(req.Context(), &candidate)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error confirming resume: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resume.Draft = candidate
+	err = resume.Confirm(candidate.ID)
+	if err == nil {
+		err = srv.resume.Update(req.Context(), resume)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error confirming resume: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, resume)
+	if err != nil {
+		log.Printf("[error] [server] error confirming resume: %s", err)
+	}
+}
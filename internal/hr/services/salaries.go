@@ -0,0 +1,40 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+var errInvalidSalary = errors.New("salary must be a non-negative whole number")
+
+// expectedSalary parses the salary, currency and period query parameters
+// into the net monthly salary in the base currency of exchange rates, ok is
+// false without the salary parameter.
+func (srv *Server) expectedSalary(req *http.Request) (expected float64, ok bool, err error) {
+	query := req.URL.Query()
+	if query.Get("salary") == "" {
+		return 0, false, nil
+	}
+	amount, err := strconv.ParseUint(query.Get("salary"), 10, 32)
+	if err != nil {
+		return 0, false, errInvalidSalary
+	}
+	currency, err := entities.NormalizeCurrency(query.Get("currency"))
+	if err != nil {
+		return 0, false, err
+	}
+	var period entities.SalaryPeriod
+	err = period.UnmarshalText([]byte(query.Get("period")))
+	if err != nil {
+		return 0, false, err
+	}
+
+	expected, ok = srv.rates.Monthly(uint32(amount), currency, period, false)
+	if !ok {
+		return 0, false, entities.ErrUnknownCurrency
+	}
+	return expected, true, nil
+}
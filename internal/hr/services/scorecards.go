@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+var errNotInterviewer = errors.New("user is not an interviewer of the interview")
+
+// ListScorecards returns scorecards of the interview. Interviewers see the
+// scorecards of others only after submitting their own.
+func (srv *Server) ListScorecards(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	interviewID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error listing scorecards: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	interview, err := srv.interview.GetByID(req.Context(), interviewID)
+	if err != nil {
+		log.Printf("[error] [server] error listing scorecards: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	scorecards, err := srv.scorecard.List(req.Context(), repos.ScorecardFilter{
+		InterviewID: interview.ID,
+	})
+	if err != nil {
+		log.Printf("[error] [server] error listing scorecards: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	visible, hidden := visibleScorecards(
+		requestUser(req),
+		[]entities.Interview{*interview},
+		scorecards,
+	)
+	err = writeJSON(w, http.StatusOK, ListScorecardsResponse{
+		Items:  visible,
+		Hidden: hidden,
+	})
+	if err != nil {
+		log.Printf("[error] [server] error listing scorecards: %s", err)
+	}
+}
+
+// SubmitScorecard stores scorecard of the requesting interviewer, replacing
+// one they submitted before. Every skill of the vacancy must be rated.
+func (srv *Server) SubmitScorecard(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	interviewID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error submitting scorecard: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var request ScorecardRequest
+	err = json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error submitting scorecard: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	user := requestUser(req)
+	if user == "" {
+		log.Printf("[error] [server] error submitting scorecard: %s", errUserRequired)
+		writeError(w, errorStatus(errUserRequired), errUserRequired)
+		return
+	}
+
+	interview, err := srv.interview.GetByID(req.Context(), interviewID)
+	if err != nil {
+		log.Printf("[error] [server] error submitting scorecard: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	if !isInterviewer(interview, user) {
+		log.Printf("[error] [server] error submitting scorecard: %s", errNotInterviewer)
+		writeError(w, errorStatus(errNotInterviewer), errNotInterviewer)
+		return
+	}
+
+	card, err := srv.card.GetByID(req.Context(), interview.CardID)
+	if err != nil {
+		log.Printf("[error] [server] error submitting scorecard: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	vacancy, err := srv.vacancy.GetByID(req.Context(), card.VacancyID)
+	if err != nil {
+		log.Printf("[error] [server] error submitting scorecard: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	scorecard := entities.Scorecard{
+		InterviewID:    interview.ID,
+		CardID:         card.ID,
+		Interviewer:    entities.NormalizeEmail(user),
+		Ratings:        request.Ratings,
+		Recommendation: request.Recommendation,
+		Notes:          request.Notes,
+	}
+	err = scorecard.Validate(vacancy.Skills)
+	if err != nil {
+		log.Printf("[error] [server] error submitting scorecard: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = srv.scorecard.Save(req.Context(), &scorecard)
+	if err != nil {
+		log.Printf("[error] [server] error submitting scorecard: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, scorecard)
+	if err != nil {
+		log.Printf("[error] [server] error submitting scorecard: %s", err)
+	}
+}
+
+// cardFeedback aggregates scorecards of the card visible to the user.
+func (srv *Server) cardFeedback(
+	ctx context.Context,
+	user string,
+	cardID uuid.UUID,
+) (*entities.Feedback, error) {
+	interviews, err := srv.interview.List(ctx, repos.InterviewFilter{CardID: cardID})
+	if err != nil {
+		return nil, err
+	}
+	scorecards, err := srv.scorecard.List(ctx, repos.ScorecardFilter{CardID: cardID})
+	if err != nil {
+		return nil, err
+	}
+
+	visible, hidden := visibleScorecards(user, interviews, scorecards)
+	feedback := entities.Aggregate(visible)
+	feedback.Hidden = hidden
+	return &feedback, nil
+}
+
+// visibleScorecards returns scorecards the user may read and the number of
+// hidden ones. To avoid bias an interviewer sees scorecards of others on an
+// interview only after submitting their own. Users who do not interview
+// see everything, anonymous users see nothing.
+func visibleScorecards(
+	user string,
+	interviews []entities.Interview,
+	scorecards []entities.Scorecard,
+) ([]entities.Scorecard, int) {
+	visible := make([]entities.Scorecard, 0, len(scorecards))
+	if user == "" {
+		return visible, len(scorecards)
+	}
+
+	pending := make(map[uuid.UUID]bool)
+	for i := range interviews {
+		if isInterviewer(&interviews[i], user) {
+			pending[interviews[i].ID] = true
+		}
+	}
+	for _, scorecard := range scorecards {
+		if entities.NormalizeEmail(scorecard.Interviewer) == entities.NormalizeEmail(user) {
+			pending[scorecard.InterviewID] = false
+		}
+	}
+
+	hidden := 0
+	for _, scorecard := range scorecards {
+		if pending[scorecard.InterviewID] {
+			hidden++
+			continue
+		}
+		visible = append(visible, scorecard)
+	}
+	return visible, hidden
+}
+
+// isInterviewer reports whether the user interviews, emails are compared
+// normalized as the X-HR-User header may differ in case.
+func isInterviewer(interview *entities.Interview, user string) bool {
+	user = entities.NormalizeEmail(user)
+	for _, v := range interview.Interviewers {
+		if entities.NormalizeEmail(v) == user {
+			return true
+		}
+	}
+	return false
+}
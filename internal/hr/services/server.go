@@ -8,12 +8,22 @@ import (
 	"net"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
+	"gpb.ru/hr/internal/hr/careers"
 	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/events"
+	"gpb.ru/hr/internal/hr/letters"
+	"gpb.ru/hr/internal/hr/notify"
+	"gpb.ru/hr/internal/hr/privacy"
+	"gpb.ru/hr/internal/hr/reports"
 	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/internal/hr/requisitions"
+	"gpb.ru/hr/internal/hr/vacancies"
+	"gpb.ru/hr/pkg/ratelimit"
 )
 
 // Server defines how the HR API interacta and stores its state.
@@ -21,60 +31,268 @@ type Server struct {
 	mu     sync.Mutex
 	server *http.Server
 
-	candidate repos.CandidateRepo
-	vacancy   repos.VacancyRepo
+	router *mux.Router
+
+	candidate  repos.CandidateRepo
+	vacancy    repos.VacancyRepo
+	card       repos.CardRepo
+	webhook    repos.WebhookRepo
+	delivery   repos.DeliveryRepo
+	interview  repos.InterviewRepo
+	scorecard  repos.ScorecardRepo
+	offer      repos.OfferRepo
+	resume     repos.ResumeRepo
+	attachment repos.AttachmentRepo
+	consent    repos.ConsentRepo
+	message    repos.MessageRepo
+	skill      repos.SkillRepo
+	dictionary repos.DictionaryRepo
+	org        repos.OrgRepo
+	outbox     repos.OutboxRepo
+
+	requisition repos.RequisitionRepo
+
+	// admins are emails of users allowed to merge catalog skills and set
+	// managers of departments.
+	admins map[string]bool
+
+	// blobs keeps attachment contents, attachments are disabled without it.
+	blobs           repos.BlobStore
+	attachmentLimit int64
+
+	privacy *privacy.Controller
+	reports *reports.Reports
+	feed    *vacancies.Feed
+	careers *careers.Careers
+
+	// careersLimit limits applications per client address.
+	careersLimit *ratelimit.Limiter
+	// trustedProxies may tell client addresses in X-Forwarded-For.
+	trustedProxies []*net.IPNet
+
+	// offerChain is the approval chain of offers unless an admin gives one.
+	offerChain []entities.Approval
+	letter     *letters.Template
+
+	// rates compare salaries in different currencies and periods.
+	rates *entities.ExchangeRates
+
+	// requisitionChain is the approval chain of every requisition.
+	requisitionChain []entities.Approval
+	requisitions     *requisitions.Gate
+
+	// notifier emails about interviews and offers, nothing is sent if nil.
+	notifier *notify.Notifier
+
+	hub *events.Hub
+	// done is closed on shutdown to end long-living streams.
+	done     context.Context
+	shutdown context.CancelFunc
 }
 
 // NewServer creates new server with the given properties.
-func NewServer(
-	addr string,
-	candidate repos.CandidateRepo,
-	vacancy repos.VacancyRepo,
-) *Server {
-
+func NewServer(addr string, repos repos.Repos) *Server {
 	server := &Server{
-		candidate: candidate,
-		vacancy:   vacancy,
+		candidate:  repos.Candidate,
+		vacancy:    repos.Vacancy,
+		card:       repos.Card,
+		webhook:    repos.Webhook,
+		delivery:   repos.Delivery,
+		interview:  repos.Interview,
+		scorecard:  repos.Scorecard,
+		offer:      repos.Offer,
+		resume:     repos.Resume,
+		attachment: repos.Attachment,
+		consent:    repos.Consent,
+		message:    repos.Message,
+		skill:      repos.Skill,
+		dictionary: repos.Dictionary,
+		org:        repos.Org,
+		outbox:     repos.Outbox,
+
+		requisition: repos.Requisition,
+
+		attachmentLimit: defaultAttachmentLimit,
+		privacy:         privacy.New(repos, nil),
+		reports:         reports.New(repos),
+		feed:            vacancies.NewFeed(repos.Vacancy, vacancies.FeedOptions{}),
+		careers:         careers.New(repos),
+		careersLimit:    ratelimit.New(defaultCareersLimit, time.Hour),
+		requisitions:    requisitions.New(repos),
+		rates:           entities.DefaultExchangeRates(),
+
+		letter: letters.Must(letters.Parse(letters.DefaultTemplate)),
+		hub:    events.NewHub(repos.Outbox, 5*time.Second),
 	}
+	server.done, server.shutdown = context.WithCancel(context.Background())
 
 	router := mux.NewRouter()
 	router.HandleFunc("/vacancies", server.ListVacancies).Methods(http.MethodGet)
+	router.HandleFunc("/vacancies:export", server.ExportVacancies).Methods(http.MethodGet)
 	router.HandleFunc("/vacancies/{id}", server.GetVacancy).Methods(http.MethodGet)
 	router.HandleFunc("/vacancies", server.CreateVacancy).Methods(http.MethodPost)
+	router.HandleFunc("/vacancies:import", server.ImportVacancies).Methods(http.MethodPost)
 	router.HandleFunc("/vacancies/{id}", server.UpdateVacancy).Methods(http.MethodPost)
 
+	router.HandleFunc("/candidates", server.ListCandidates).Methods(http.MethodGet)
+	router.HandleFunc("/candidates/{id:[^/:]+}:export", server.ExportCandidate).Methods(http.MethodGet)
+	router.HandleFunc("/candidates/{id}", server.GetCandidate).Methods(http.MethodGet)
+	router.HandleFunc("/candidates", server.CreateCandidate).Methods(http.MethodPost)
+	router.HandleFunc("/candidates:import", server.ImportCandidate).Methods(http.MethodPost)
+	router.HandleFunc("/candidates/{id:[^/:]+}:merge", server.MergeCandidate).Methods(http.MethodPost)
+	router.HandleFunc("/candidates/{id:[^/:]+}:erase", server.EraseCandidate).Methods(http.MethodPost)
+	router.HandleFunc("/candidates/{id}", server.UpdateCandidate).Methods(http.MethodPost)
+	router.HandleFunc("/candidates/{id}/duplicates", server.ListDuplicates).Methods(http.MethodGet)
+	router.HandleFunc("/candidates/{id}/consents", server.ListConsents).Methods(http.MethodGet)
+	router.HandleFunc("/candidates/{id}/consents", server.GiveConsent).Methods(http.MethodPost)
+	router.HandleFunc("/consents/{id}/withdraw", server.WithdrawConsent).Methods(http.MethodPost)
+	router.HandleFunc("/candidates/{id}/attachments", server.ListCandidateAttachments).Methods(http.MethodGet)
+	router.HandleFunc("/candidates/{id}/attachments", server.AttachToCandidate).Methods(http.MethodPost)
+
 	router.HandleFunc("/cards", server.ListCards).Methods(http.MethodGet)
+	router.HandleFunc("/cards", server.CreateCard).Methods(http.MethodPost)
+	router.HandleFunc("/cards/stream", server.StreamCards).Methods(http.MethodGet)
 	router.HandleFunc("/cards/{id}", server.GetCard).Methods(http.MethodGet)
 	router.HandleFunc("/cards/{id}", server.MoveCard).Methods(http.MethodPut)
-
+	router.HandleFunc("/cards/{id}/comments", server.AddComment).Methods(http.MethodPost)
+	router.HandleFunc("/cards/{id}/attachments", server.ListCardAttachments).Methods(http.MethodGet)
+	router.HandleFunc("/cards/{id}/attachments", server.AttachToCard).Methods(http.MethodPost)
+	router.HandleFunc("/cards/{id}/messages", server.ListCardMessages).Methods(http.MethodGet)
+
+	router.HandleFunc("/attachments/{id}", server.GetAttachment).Methods(http.MethodGet)
+	router.HandleFunc("/attachments/{id}", server.DeleteAttachment).Methods(http.MethodDelete)
+	router.HandleFunc("/attachments/{id}/content", server.DownloadAttachment).Methods(http.MethodGet)
+
+	router.HandleFunc("/interviews", server.ListInterviews).Methods(http.MethodGet)
+	router.HandleFunc("/interviews", server.CreateInterview).Methods(http.MethodPost)
+	router.HandleFunc("/interviews/{id}", server.GetInterview).Methods(http.MethodGet)
+	router.HandleFunc("/interviews/{id}", server.UpdateInterview).Methods(http.MethodPost)
+	router.HandleFunc("/interviews/{id}/invite.ics", server.GetInterviewInvite).Methods(http.MethodGet)
+	router.HandleFunc("/interviews/{id}/scorecards", server.ListScorecards).Methods(http.MethodGet)
+	router.HandleFunc("/interviews/{id}/scorecards", server.SubmitScorecard).Methods(http.MethodPost)
+
+	router.HandleFunc("/offers", server.ListOffers).Methods(http.MethodGet)
+	router.HandleFunc("/offers", server.CreateOffer).Methods(http.MethodPost)
+	router.HandleFunc("/offers/{id}", server.GetOffer).Methods(http.MethodGet)
+	router.HandleFunc("/offers/{id}/approve", server.ApproveOffer).Methods(http.MethodPost)
+	router.HandleFunc("/offers/{id}/reject", server.RejectOffer).Methods(http.MethodPost)
+	router.HandleFunc("/offers/{id}/accept", server.AcceptOffer).Methods(http.MethodPost)
+	router.HandleFunc("/offers/{id}/decline", server.DeclineOffer).Methods(http.MethodPost)
+	router.HandleFunc("/offers/{id}/withdraw", server.WithdrawOffer).Methods(http.MethodPost)
+	router.HandleFunc("/offers/{id}/letter.html", server.GetOfferLetterHTML).Methods(http.MethodGet)
+	router.HandleFunc("/offers/{id}/letter.pdf", server.GetOfferLetterPDF).Methods(http.MethodGet)
+
+	router.HandleFunc("/resumes", server.UploadResume).Methods(http.MethodPost)
+	router.HandleFunc("/resumes/{id}", server.GetResume).Methods(http.MethodGet)
+	router.HandleFunc("/resumes/{id}/file", server.GetResumeFile).Methods(http.MethodGet)
+	router.HandleFunc("/resumes/{id}/confirm", server.ConfirmResume).Methods(http.MethodPost)
+
+	router.HandleFunc("/webhooks", server.ListWebhooks).Methods(http.MethodGet)
+	router.HandleFunc("/webhooks", server.CreateWebhook).Methods(http.MethodPost)
+	router.HandleFunc("/webhooks/{id}", server.GetWebhook).Methods(http.MethodGet)
+	router.HandleFunc("/webhooks/{id}", server.UpdateWebhook).Methods(http.MethodPost)
+	router.HandleFunc("/webhooks/{id}", server.DeleteWebhook).Methods(http.MethodDelete)
+	router.HandleFunc("/webhooks/{id}/deliveries", server.ListDeliveries).Methods(http.MethodGet)
+	router.HandleFunc("/webhooks/{id}/deliveries/{deliveryID}/redeliver", server.Redeliver).Methods(http.MethodPost)
+
+	router.HandleFunc("/skills", server.ListSkills).Methods(http.MethodGet)
+	router.HandleFunc("/skills", server.CreateSkill).Methods(http.MethodPost)
+	router.HandleFunc("/skills/{id:[^/:]+}:merge", server.MergeSkill).Methods(http.MethodPost)
+	router.HandleFunc("/skills/{id}", server.GetSkill).Methods(http.MethodGet)
+	router.HandleFunc("/skills/{id}", server.UpdateSkill).Methods(http.MethodPost)
+	router.HandleFunc("/skills/{id}", server.DeleteSkill).Methods(http.MethodDelete)
+
+	router.HandleFunc("/dictionaries/{kind}", server.ListDictionary).Methods(http.MethodGet)
+	router.HandleFunc("/dictionaries/{kind}", server.CreateDictionaryItem).Methods(http.MethodPost)
+	router.HandleFunc("/dictionaries/{kind}/{id}", server.GetDictionaryItem).Methods(http.MethodGet)
+	router.HandleFunc("/dictionaries/{kind}/{id}", server.UpdateDictionaryItem).Methods(http.MethodPost)
+	router.HandleFunc("/dictionaries/{kind}/{id}", server.DeleteDictionaryItem).Methods(http.MethodDelete)
+
+	router.HandleFunc("/departments", server.ListDepartments).Methods(http.MethodGet)
+	router.HandleFunc("/departments/{id}/managers", server.SetDepartmentManagers).Methods(http.MethodPost)
+	router.HandleFunc("/dashboard", server.GetDashboard).Methods(http.MethodGet)
+
+	router.HandleFunc("/requisitions", server.ListRequisitions).Methods(http.MethodGet)
+	router.HandleFunc("/requisitions", server.CreateRequisition).Methods(http.MethodPost)
+	router.HandleFunc("/requisitions/{id}", server.GetRequisition).Methods(http.MethodGet)
+	router.HandleFunc("/requisitions/{id}/approve", server.ApproveRequisition).Methods(http.MethodPost)
+	router.HandleFunc("/requisitions/{id}/reject", server.RejectRequisition).Methods(http.MethodPost)
+	router.HandleFunc("/requisitions/{id}/withdraw", server.WithdrawRequisition).Methods(http.MethodPost)
+
+	router.HandleFunc("/reports/funnel", server.GetFunnelReport).Methods(http.MethodGet)
+	router.HandleFunc("/reports/time-to-hire", server.GetTimeToHireReport).Methods(http.MethodGet)
+	router.HandleFunc("/reports/vacancies", server.GetVacanciesReport).Methods(http.MethodGet)
+
+	router.HandleFunc("/public/vacancies", server.ListPublicVacancies).Methods(http.MethodGet)
+	router.HandleFunc("/public/vacancies/{id}", server.GetPublicVacancy).Methods(http.MethodGet)
+	router.HandleFunc("/public/vacancies/{id}/applications", server.Apply).Methods(http.MethodPost)
+
+	router.HandleFunc("/feeds/vacancies.jsonld", server.GetJobPostings).Methods(http.MethodGet)
+	router.HandleFunc("/feeds/vacancies.xml", server.GetJobFeed).Methods(http.MethodGet)
+
+	router.HandleFunc("/openapi.json", server.OpenAPI).Methods(http.MethodGet)
+	router.HandleFunc("/docs", server.Docs).Methods(http.MethodGet)
+
+	server.router = router
 	server.server = &http.Server{
 		Addr:    addr,
 		Handler: WithCORS(router),
 	}
+	server.server.RegisterOnShutdown(server.shutdown)
 	return server
 }
 
-// ListVacancies return a list of vacancies.
+// ListVacancies return a list of vacancies. The owner query parameter
+// limits them to ones the user is the hiring manager or the recruiter of,
+// "me" stands for the user making the request. The salary query parameter
+// limits them to ones paying the net salary in the currency and the period
+// given.
 func (srv *Server) ListVacancies(w http.ResponseWriter, req *http.Request) {
 	defer req.Body.Close()
 
+	expected, bySalary, err := srv.expectedSalary(req)
+	if err != nil {
+		log.Printf("[error] [server] error listing vacancies: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	owner := req.URL.Query().Get("owner")
+	if owner == "me" {
+		owner = requestUser(req)
+		if owner == "" {
+			log.Printf("[error] [server] error listing vacancies: %s", errUserRequired)
+			writeError(w, http.StatusUnauthorized, errUserRequired)
+			return
+		}
+	}
+
 	result, err := srv.vacancy.List(req.Context())
 	if err != nil {
 		log.Printf("[error] [server] error listing vacancies: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
 		return
 	}
 
-	items := make([]Vacancy, len(result))
-	for i, vacancy := range result {
-		items[i] = Vacancy{
-			ID:         vacancy.ID,
-			Title:      vacancy.Title,
-			Status:     vacancy.Status,
-			Area:       vacancy.Area,
-			Department: vacancy.Department,
-			Created:    vacancy.Created,
-			Updated:    vacancy.Updated,
+	items := make([]Vacancy, 0, len(result))
+	for _, vacancy := range result {
+		if owner != "" && !vacancy.Owns(owner) {
+			continue
 		}
+		if bySalary && !srv.rates.Fits(&vacancy.Salary, expected) {
+			continue
+		}
+		items = append(items, Vacancy{
+			ID:            vacancy.ID,
+			Title:         vacancy.Title,
+			Status:        vacancy.Status,
+			Area:          vacancy.Area,
+			Department:    vacancy.Department,
+			HiringManager: vacancy.HiringManager,
+			Recruiter:     vacancy.Recruiter,
+			Created:       vacancy.Created,
+			Updated:       vacancy.Updated,
+		})
 	}
 
 	token := ""
@@ -98,7 +316,7 @@ func (srv *Server) GetVacancy(w http.ResponseWriter, req *http.Request) {
 
 	id, ok := mux.Vars(req)["id"]
 	if !ok {
-		err := writeJSON(w, http.StatusNotFound, errors.New("not found"))
+		err := writeError(w, http.StatusNotFound, errNotFound)
 		if err != nil {
 			log.Printf("[error] [server] error get vacancy: %s", err)
 		}
@@ -118,7 +336,7 @@ func (srv *Server) GetVacancy(w http.ResponseWriter, req *http.Request) {
 	response, err := srv.vacancy.GetByID(req.Context(), vacancyID)
 	if err != nil {
 		log.Printf("[error] [server] error get vacancy: %s", err)
-		err := writeError(w, http.StatusBadRequest, err)
+		err := writeError(w, errorStatus(err), err)
 		if err != nil {
 			log.Printf("[error] [server] error get vacancy: %s", err)
 		}
@@ -144,23 +362,60 @@ func (srv *Server) CreateVacancy(w http.ResponseWriter, req *http.Request) {
 	}
 
 	err = vacancy.Validate()
+	if err == nil {
+		err = srv.rates.CheckCurrency(vacancy.Salary.Currency)
+	}
 	if err != nil {
 		log.Printf("[error] [server] error creating vacancy: %s", err)
 		writeError(w, http.StatusBadRequest, err)
 		return
 	}
 
-	for i := 0; i < 5; i++ {
-		err = srv.vacancy.Create(req.Context(), &vacancy)
-		if err != nil {
-			continue
-		}
-		break
+	skills, err := srv.skillIndex(req.Context())
+	if err != nil {
+		log.Printf("[error] [server] error creating vacancy: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
 	}
+	vacancy.NormalizeSkills(skills)
 
+	dicts, err := srv.dictionaries(req.Context())
+	if err == nil {
+		err = vacancy.NormalizeDictionaries(dicts)
+	}
 	if err != nil {
 		log.Printf("[error] [server] error creating vacancy: %s", err)
-		writeError(w, http.StatusInternalServerError, err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	org, err := srv.orgChart(req.Context())
+	if err == nil {
+		err = assignOwners(&vacancy, nil, org)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error creating vacancy: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	if vacancy.Recruiter == "" && vacancy.HiringManager != entities.NormalizeEmail(requestUser(req)) {
+		vacancy.Recruiter = entities.NormalizeEmail(requestUser(req))
+	}
+
+	err = srv.requisitions.SaveVacancy(req.Context(), &vacancy, nil, func(ctx context.Context) error {
+		var err error
+		for i := 0; i < 5; i++ {
+			err = srv.vacancy.Create(ctx, &vacancy)
+			if err != nil {
+				continue
+			}
+			break
+		}
+		return err
+	})
+	if err != nil {
+		log.Printf("[error] [server] error creating vacancy: %s", err)
+		writeError(w, errorStatus(err), err)
 		return
 	}
 
@@ -176,7 +431,7 @@ func (srv *Server) UpdateVacancy(w http.ResponseWriter, req *http.Request) {
 
 	id, ok := mux.Vars(req)["id"]
 	if !ok {
-		err := writeJSON(w, http.StatusNotFound, errors.New("not found"))
+		err := writeError(w, http.StatusNotFound, errNotFound)
 		if err != nil {
 			log.Printf("[error] [server] %s", err)
 		}
@@ -202,19 +457,65 @@ func (srv *Server) UpdateVacancy(w http.ResponseWriter, req *http.Request) {
 	}
 	vacancy.ID = vacancyID
 
-	for i := 0; i < 5; i++ {
-		err = srv.vacancy.Update(req.Context(), &vacancy)
-		if err != nil {
-			continue
-		}
-		break
+	err = vacancy.Validate()
+	if err == nil {
+		err = srv.rates.CheckCurrency(vacancy.Salary.Currency)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error updating vacancy: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
 	}
 
+	skills, err := srv.skillIndex(req.Context())
 	if err != nil {
 		log.Printf("[error] [server] error updating vacancy: %s", err)
 		writeError(w, http.StatusInternalServerError, err)
 		return
 	}
+	vacancy.NormalizeSkills(skills)
+
+	dicts, err := srv.dictionaries(req.Context())
+	if err == nil {
+		err = vacancy.NormalizeDictionaries(dicts)
+	}
+	if err != nil {
+		log.Printf("[error] [server] error updating vacancy: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	old, err := srv.vacancy.GetByID(req.Context(), vacancyID)
+	if err != nil {
+		log.Printf("[error] [server] error updating vacancy: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	org, err := srv.orgChart(req.Context())
+	if err == nil {
+		err = assignOwners(&vacancy, old, org)
+	}
+	if err == nil {
+		err = srv.checkReassign(req, &vacancy, old, org)
+	}
+	if err == nil {
+		err = srv.requisitions.SaveVacancy(req.Context(), &vacancy, old, func(ctx context.Context) error {
+			var err error
+			for i := 0; i < 5; i++ {
+				err = srv.vacancy.Update(ctx, &vacancy)
+				if err != nil {
+					continue
+				}
+				break
+			}
+			return err
+		})
+	}
+	if err != nil {
+		log.Printf("[error] [server] error updating vacancy: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
 
 	err = writeJSON(w, http.StatusOK, vacancy)
 	if err != nil {
@@ -222,31 +523,105 @@ func (srv *Server) UpdateVacancy(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
-// ListCards return a list of canban cards with the given filter.
-func (srv *Server) ListCards(w http.ResponseWriter, req *http.Request) {
+// Run runs the server on the given address.
+func (srv *Server) Run() error {
+	listener, err := net.Listen("tcp", srv.server.Addr)
+	if err != nil {
+		log.Printf("[error] [server] %s", err)
+		return err
+	}
+	log.Printf("[info] [server] listen on %s", srv.server.Addr)
+
+	go func() {
+		err := srv.hub.Run(srv.done)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("[error] [server] event hub: %s", err)
+		}
+	}()
+	go srv.feed.Watch(srv.done, srv.hub)
+	return srv.server.Serve(listener)
 }
 
-// GetCard returns detailed information about specified canban card.
-func (srv *Server) GetCard(w http.ResponseWriter, req *http.Request) {
+// SetOfferChain sets the approval chain of offers, e.g. the hiring manager,
+// then the HR director, then finance. Only admins may give an offer another
+// chain.
+func (srv *Server) SetOfferChain(chain []entities.Approval) {
+	srv.offerChain = chain
 }
 
-// MoveCard moves card to the specified column.
-func (srv *Server) MoveCard(w http.ResponseWriter, req *http.Request) {
+// SetRequisitionChain sets the approval chain of requisitions, e.g. the
+// department head, then finance. Requisitions are approved right away
+// without one.
+func (srv *Server) SetRequisitionChain(chain []entities.Approval) {
+	srv.requisitionChain = chain
 }
 
-// AddComment adds comments to the specified card.
-func (srv *Server) AddComment(w http.ResponseWriter, req *http.Request) {
+// SetRequisitionsRequired keeps vacancies without an approved requisition
+// from becoming active.
+func (srv *Server) SetRequisitionsRequired(required bool) {
+	srv.requisitions.Required = required
 }
 
-// Run runs the server on the given address.
-func (srv *Server) Run() error {
-	listener, err := net.Listen("tcp", srv.server.Addr)
+// SetExchangeRates sets the table salaries in different currencies and
+// periods are compared with.
+func (srv *Server) SetExchangeRates(rates *entities.ExchangeRates) {
+	srv.rates = rates
+	srv.requisitions.Rates = rates
+}
+
+// SetLetterTemplate replaces the default offer letter template.
+func (srv *Server) SetLetterTemplate(tmpl *letters.Template) {
+	srv.letter = tmpl
+}
+
+// SetNotifier enables email notifications about interviews and offers.
+func (srv *Server) SetNotifier(notifier *notify.Notifier) {
+	srv.notifier = notifier
+}
+
+// SetAdmins sets emails of users allowed to merge catalog skills and set
+// managers of departments.
+func (srv *Server) SetAdmins(emails []string) {
+	srv.admins = adminSet(emails)
+}
+
+// SetBlobStore sets the store of attachment contents.
+func (srv *Server) SetBlobStore(blobs repos.BlobStore) {
+	srv.blobs = blobs
+	srv.privacy.Blobs = blobs
+}
+
+// SetFeedOptions sets the employer and the careers site of vacancy feeds.
+func (srv *Server) SetFeedOptions(options vacancies.FeedOptions) {
+	srv.feed.SetOptions(options)
+}
+
+// SetCareersLimit sets the number of applications a client may send per
+// hour.
+func (srv *Server) SetCareersLimit(n int) {
+	srv.careersLimit = ratelimit.New(n, time.Hour)
+}
+
+// SetTrustedProxies sets addresses or CIDR ranges of the proxies in front
+// of the server. X-Forwarded-For is ignored in requests coming from others.
+func (srv *Server) SetTrustedProxies(proxies []string) error {
+	nets, err := parseProxies(proxies)
 	if err != nil {
-		log.Printf("[error] [server] %s", err)
 		return err
 	}
-	log.Printf("[info] [server] listen on %s", srv.server.Addr)
-	return srv.server.Serve(listener)
+	srv.trustedProxies = nets
+	return nil
+}
+
+// SetAttachmentLimit sets the maximum size of an attachment in bytes.
+func (srv *Server) SetAttachmentLimit(limit int64) {
+	srv.attachmentLimit = limit
+}
+
+// Hub returns hub feeding live streams. Call its Notify when new events are
+// written to the outbox to deliver them without waiting for the next poll.
+func (srv *Server) Hub() *events.Hub {
+	return srv.hub
 }
 
 // Close gracefully stops the sserver.
@@ -270,3 +645,78 @@ func writeJSON(w http.ResponseWriter, code int, response interface{}) error {
 func writeError(w http.ResponseWriter, code int, err error) error {
 	return writeJSON(w, code, ErrorResponse{Code: code, Text: err.Error()})
 }
+
+var errNotFound = errors.New("not found")
+
+// errorStatus maps repository errors to the http status codes.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, repos.ErrVacancyNotFound),
+		errors.Is(err, repos.ErrCandidateNotFound),
+		errors.Is(err, repos.ErrCardNotFound),
+		errors.Is(err, repos.ErrWebhookNotFound),
+		errors.Is(err, repos.ErrDeliveryNotFound),
+		errors.Is(err, repos.ErrInterviewNotFound),
+		errors.Is(err, repos.ErrOfferNotFound),
+		errors.Is(err, repos.ErrResumeNotFound),
+		errors.Is(err, repos.ErrAttachmentNotFound),
+		errors.Is(err, repos.ErrConsentNotFound),
+		errors.Is(err, repos.ErrSkillNotFound),
+		errors.Is(err, repos.ErrDictionaryNotFound),
+		errors.Is(err, repos.ErrRequisitionNotFound),
+		errors.Is(err, errNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errInterviewerBusy),
+		errors.Is(err, errCardNotInOfferStage),
+		errors.Is(err, entities.ErrOfferInvalidTransition),
+		errors.Is(err, entities.ErrOfferExpired),
+		errors.Is(err, entities.ErrResumeConfirmed),
+		errors.Is(err, repos.ErrMergeConflict),
+		errors.Is(err, entities.ErrCandidateAnonymized),
+		errors.Is(err, entities.ErrConsentWithdrawn),
+		errors.Is(err, repos.ErrSkillExists),
+		errors.Is(err, repos.ErrDictionaryExists),
+		errors.Is(err, repos.ErrDictionaryInUse),
+		errors.Is(err, entities.ErrRequisitionInvalidTransition),
+		errors.Is(err, entities.ErrRequisitionRequired),
+		errors.Is(err, entities.ErrRequisitionNotApproved),
+		errors.Is(err, entities.ErrHeadcountFilled):
+		return http.StatusConflict
+	case errors.Is(err, entities.ErrNotInDictionary),
+		errors.Is(err, entities.ErrDictionaryNameRequired),
+		errors.Is(err, entities.ErrDictionaryKindRequired),
+		errors.Is(err, entities.ErrDictionaryParent),
+		errors.Is(err, entities.ErrDictionaryLoop),
+		errors.Is(err, entities.ErrInvalidOwner),
+		errors.Is(err, entities.ErrInvalidManager),
+		errors.Is(err, entities.ErrRequisitionTitleRequired),
+		errors.Is(err, entities.ErrRequisitionPositionsRequired),
+		errors.Is(err, entities.ErrRequisitionJustificationRequired),
+		errors.Is(err, entities.ErrRequisitionSalaryBand),
+		errors.Is(err, entities.ErrRequisitionOverBudget),
+		errors.Is(err, entities.ErrRequisitionApproverRequired),
+		errors.Is(err, entities.ErrOfferApprovalsRequired),
+		errors.Is(err, entities.ErrVacancyOverBand),
+		errors.Is(err, entities.ErrInvalidCurrency),
+		errors.Is(err, entities.ErrUnknownCurrency),
+		errors.Is(err, entities.ErrSalaryRange),
+		errors.Is(err, entities.ErrInvalidSalaryPeriod):
+		return http.StatusBadRequest
+	case errors.Is(err, errUserRequired):
+		return http.StatusUnauthorized
+	case errors.Is(err, errNotInterviewer),
+		errors.Is(err, entities.ErrOfferNotApprover),
+		errors.Is(err, errNotUploader),
+		errors.Is(err, errNotAdmin),
+		errors.Is(err, errNotDepartmentManager),
+		errors.Is(err, errNotVacancyManager),
+		errors.Is(err, entities.ErrRequisitionNotApprover),
+		errors.Is(err, errNotRequester):
+		return http.StatusForbidden
+	case errors.Is(err, errAttachmentSize):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, errNoBlobStore):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
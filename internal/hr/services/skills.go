@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+var errMergeSkillItself = errors.New("skill cannot be merged into itself")
+
+// skillIndex returns the catalog skills of vacancies and candidates are
+// normalized with.
+func skillIndex(ctx context.Context, skill repos.SkillRepo) (*entities.SkillIndex, error) {
+	skills, err := skill.List(ctx, repos.SkillFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewSkillIndex(skills), nil
+}
+
+func (srv *Server) skillIndex(ctx context.Context) (*entities.SkillIndex, error) {
+	return skillIndex(ctx, srv.skill)
+}
+
+// ListSkills returns catalog skills ordered by name. The prefix query
+// parameter autocompletes by the beginning of the name or of a synonym.
+func (srv *Server) ListSkills(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	query := req.URL.Query()
+	skills, err := srv.skill.List(req.Context(), repos.SkillFilter{
+		Prefix:   query.Get("prefix"),
+		Category: query.Get("category"),
+	})
+	if err != nil {
+		log.Printf("[error] [server] error listing skills: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, ListSkillsResponse{Items: skills})
+	if err != nil {
+		log.Printf("[error] [server] error listing skills: %s", err)
+	}
+}
+
+// GetSkill returns the given catalog skill.
+func (srv *Server) GetSkill(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	skillID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get skill: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	skill, err := srv.skill.GetByID(req.Context(), skillID)
+	if err != nil {
+		log.Printf("[error] [server] error get skill: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, skill)
+	if err != nil {
+		log.Printf("[error] [server] error get skill: %s", err)
+	}
+}
+
+// CreateSkill adds the skill to the catalog. Its name and synonyms must not
+// be names or synonyms of other skills.
+func (srv *Server) CreateSkill(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	var request SkillRequest
+	err := json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error creating skill: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	skill := entities.CatalogSkill{
+		Name:     request.Name,
+		Synonyms: request.Synonyms,
+		Category: request.Category,
+	}
+	skill.Normalize()
+	err = skill.Validate()
+	if err != nil {
+		log.Printf("[error] [server] error creating skill: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = srv.skill.Create(req.Context(), &skill)
+	if err != nil {
+		log.Printf("[error] [server] error creating skill: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, skill)
+	if err != nil {
+		log.Printf("[error] [server] error creating skill: %s", err)
+	}
+}
+
+// UpdateSkill updates the name, synonyms and category of the given skill.
+// Vacancies and candidates get the new name on their next write, MergeSkill
+// renames them at once.
+func (srv *Server) UpdateSkill(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	skillID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error updating skill: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var request SkillRequest
+	err = json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error updating skill: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	skill := entities.CatalogSkill{
+		ID:       skillID,
+		Name:     request.Name,
+		Synonyms: request.Synonyms,
+		Category: request.Category,
+	}
+	skill.Normalize()
+	err = skill.Validate()
+	if err != nil {
+		log.Printf("[error] [server] error updating skill: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = srv.skill.Update(req.Context(), &skill)
+	if err != nil {
+		log.Printf("[error] [server] error updating skill: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, skill)
+	if err != nil {
+		log.Printf("[error] [server] error updating skill: %s", err)
+	}
+}
+
+// DeleteSkill removes the skill from the catalog. Vacancies and candidates
+// keep it as free text.
+func (srv *Server) DeleteSkill(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	skillID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error deleting skill: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = srv.skill.Delete(req.Context(), skillID)
+	if err != nil {
+		log.Printf("[error] [server] error deleting skill: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MergeSkill merges the duplicate given in the body into the skill: names
+// of the duplicate become synonyms of the skill, vacancies and candidates
+// having any of them get the name of the skill. Only admins may merge.
+func (srv *Server) MergeSkill(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	err := srv.requireAdmin(req)
+	if err != nil {
+		log.Printf("[error] [server] error merging skills: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	skillID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error merging skills: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var request MergeSkillRequest
+	err = json.NewDecoder(req.Body).Decode(&request)
+	if err == nil && request.SkillID == skillID {
+		err = errMergeSkillItself
+	}
+	if err != nil {
+		log.Printf("[error] [server] error merging skills: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	skill, err := srv.skill.GetByID(req.Context(), skillID)
+	if err != nil {
+		log.Printf("[error] [server] error merging skills: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	duplicate, err := srv.skill.GetByID(req.Context(), request.SkillID)
+	if err != nil {
+		log.Printf("[error] [server] error merging skills: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	skill.Absorb(duplicate)
+	err = srv.skill.Merge(req.Context(), skill, duplicate.ID)
+	if err != nil {
+		log.Printf("[error] [server] error merging skills: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, skill)
+	if err != nil {
+		log.Printf("[error] [server] error merging skills: %s", err)
+	}
+}
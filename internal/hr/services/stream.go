@@ -0,0 +1,139 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+)
+
+// streamPing is an interval of comments keeping idle streams alive through
+// proxies.
+const streamPing = 15 * time.Second
+
+var errStreamingUnsupported = errors.New("streaming is not supported")
+
+// boardEvents are events which change the canban board.
+var boardEvents = map[entities.EventType]bool{
+	entities.EventTypeVacancyStatusChanged: true,
+	entities.EventTypeCardCreated:          true,
+	entities.EventTypeCardMoved:            true,
+	entities.EventTypeCommentAdded:         true,
+}
+
+// StreamCards pushes board changes as Server-Sent Events. Event ID is the
+// outbox sequence number, so a client reconnecting with Last-Event-ID gets
+// the events it missed before live ones.
+func (srv *Server) StreamCards(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	vacancyID, err := queryUUID(req, "vacancy")
+	if err != nil {
+		log.Printf("[error] [server] error streaming cards: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var last int64
+	resume := req.Header.Get("Last-Event-ID") != ""
+	if resume {
+		last, err = strconv.ParseInt(req.Header.Get("Last-Event-ID"), 10, 64)
+		if err != nil {
+			log.Printf("[error] [server] error streaming cards: %s", err)
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		log.Printf("[error] [server] error streaming cards: %s", errStreamingUnsupported)
+		writeError(w, http.StatusInternalServerError, errStreamingUnsupported)
+		return
+	}
+
+	filter := func(event *entities.Event) bool {
+		if !boardEvents[event.Type] {
+			return false
+		}
+		return vacancyID == uuid.Nil || event.VacancyID() == vacancyID
+	}
+
+	// Subscribe before reading the backlog, so no event falls in between.
+	// Events present in both are skipped by sequence number.
+	sub := srv.hub.Subscribe(filter)
+	defer srv.hub.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 3000\n\n")
+
+	for resume {
+		backlog, err := srv.outbox.List(req.Context(), last, 100)
+		if err != nil {
+			log.Printf("[error] [server] error streaming cards: %s", err)
+			return
+		}
+		for i := range backlog {
+			last = backlog[i].Seq
+			if !filter(&backlog[i]) {
+				continue
+			}
+			err = writeEvent(w, &backlog[i])
+			if err != nil {
+				log.Printf("[error] [server] error streaming cards: %s", err)
+				return
+			}
+		}
+		resume = len(backlog) == 100
+	}
+	flusher.Flush()
+
+	ping := time.NewTicker(streamPing)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-srv.done.Done():
+			return
+		case <-ping.C:
+			_, err = fmt.Fprint(w, ": ping\n\n")
+		case event, ok := <-sub.C:
+			if !ok {
+				// The subscriber lagged behind, the client resumes
+				// with Last-Event-ID.
+				return
+			}
+			if event.Seq <= last {
+				continue
+			}
+			last = event.Seq
+			err = writeEvent(w, &event)
+		}
+		if err != nil {
+			log.Printf("[error] [server] error streaming cards: %s", err)
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event *entities.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, data)
+	return err
+}
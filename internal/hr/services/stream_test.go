@@ -0,0 +1,134 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos/memory"
+)
+
+type sseEvent struct {
+	id   string
+	typ  string
+	data string
+}
+
+type sseClient struct {
+	t      *testing.T
+	cancel context.CancelFunc
+	r      *bufio.Reader
+}
+
+func newSSEClient(t *testing.T, url, lastEventID string) *sseClient {
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	t.Cleanup(func() {
+		cancel()
+		resp.Body.Close()
+	})
+
+	client := &sseClient{t: t, cancel: cancel, r: bufio.NewReader(resp.Body)}
+	require.Equal(t, sseEvent{}, client.next(), "stream must start with retry")
+	return client
+}
+
+// next reads the next message of the stream skipping comments.
+func (c *sseClient) next() sseEvent {
+	var event sseEvent
+	for {
+		line, err := c.r.ReadString('\n')
+		require.NoError(c.t, err)
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "":
+			return event
+		case strings.HasPrefix(line, ":"):
+		case strings.HasPrefix(line, "id: "):
+			event.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event.typ = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestServer_StreamCards(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.New()
+	srv := NewServer("", mem.Repos())
+	ts := httptest.NewServer(srv.server.Handler)
+	t.Cleanup(ts.Close)
+
+	board := entities.Vacancy{Title: "Go developer", Status: entities.VacancyStatusActive}
+	require.NoError(t, mem.Vacancy.Create(ctx, &board))
+	other := entities.Vacancy{Title: "QA engineer", Status: entities.VacancyStatusActive}
+	require.NoError(t, mem.Vacancy.Create(ctx, &other))
+	candidate := entities.Candidate{Name: "John Doe"}
+	require.NoError(t, mem.Candidate.Create(ctx, &candidate))
+	card := entities.Card{VacancyID: board.ID, CandidateID: candidate.ID}
+	require.NoError(t, mem.Card.Create(ctx, &card))
+	otherCard := entities.Card{VacancyID: other.ID, CandidateID: candidate.ID}
+	require.NoError(t, mem.Card.Create(ctx, &otherCard))
+	require.NoError(t, srv.Hub().Poll(ctx))
+
+	url := ts.URL + "/cards/stream?vacancy=" + board.ID.String()
+	client := newSSEClient(t, url, "")
+
+	_, err := mem.Card.Move(ctx, otherCard.ID, entities.CardStageScreening)
+	require.NoError(t, err)
+	_, err = mem.Card.Move(ctx, card.ID, entities.CardStageScreening)
+	require.NoError(t, err)
+	require.NoError(t, srv.Hub().Poll(ctx))
+
+	moved := client.next()
+	require.Equal(t, "cardMoved", moved.typ)
+	require.Contains(t, moved.data, card.ID.String())
+
+	require.NoError(t, mem.Card.AddComment(ctx, card.ID, &entities.Comment{Text: "Good"}))
+	board.Status = entities.VacancyStatusInactive
+	require.NoError(t, mem.Vacancy.Update(ctx, &board))
+	require.NoError(t, srv.Hub().Poll(ctx))
+
+	require.Equal(t, "commentAdded", client.next().typ)
+	closed := client.next()
+	require.Equal(t, "vacancyStatusChanged", closed.typ)
+	client.cancel()
+
+	resumed := newSSEClient(t, url, moved.id)
+	require.Equal(t, "commentAdded", resumed.next().typ)
+	require.Equal(t, closed, resumed.next())
+
+	card2 := entities.Card{VacancyID: board.ID, CandidateID: candidate.ID}
+	require.NoError(t, mem.Card.Create(ctx, &card2))
+	require.NoError(t, srv.Hub().Poll(ctx))
+	require.Equal(t, "cardCreated", resumed.next().typ, "stream must go live after backlog")
+}
+
+func TestServer_StreamCardsBadLastEventID(t *testing.T) {
+	mem := memory.New()
+	srv := NewServer("", mem.Repos())
+
+	req := httptest.NewRequest(http.MethodGet, "/cards/stream", nil)
+	req.Header.Set("Last-Event-ID", "foo")
+	rec := httptest.NewRecorder()
+	srv.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"gpb.ru/hr/internal/hr/vacancies"
+)
+
+// maxVacancyTableSize limits imported vacancy tables.
+const maxVacancyTableSize = 10 << 20
+
+// ExportVacancies returns all vacancies as a CSV or XLSX table.
+func (srv *Server) ExportVacancies(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	format := req.URL.Query().Get("format")
+	if format == "" {
+		format = vacancies.FormatCSV
+	}
+	if format != vacancies.FormatCSV && format != vacancies.FormatXLSX {
+		log.Printf("[error] [server] error exporting vacancies: %s", vacancies.ErrInvalidFormat)
+		writeError(w, http.StatusBadRequest, vacancies.ErrInvalidFormat)
+		return
+	}
+
+	items, err := srv.vacancy.List(req.Context())
+	if err != nil {
+		log.Printf("[error] [server] error exporting vacancies: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", vacancies.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="vacancies.%s"`, format))
+	w.WriteHeader(http.StatusOK)
+	err = vacancies.Write(w, format, items)
+	if err != nil {
+		log.Printf("[error] [server] error exporting vacancies: %s", err)
+	}
+}
+
+// ImportVacancies creates and updates vacancies from the CSV or XLSX table
+// in the body. Rows are validated first and nothing is saved if any of them
+// fails, the report tells which ones and why.
+func (srv *Server) ImportVacancies(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	dryRun := req.URL.Query().Get("dryRun") == "true"
+	data, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxVacancyTableSize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		log.Printf("[error] [server] error importing vacancies: %s", err)
+		writeError(w, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+	if err != nil {
+		log.Printf("[error] [server] error importing vacancies: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	format := req.URL.Query().Get("format")
+	if format == "" {
+		format = vacancies.Detect(data)
+	}
+	rows, err := vacancies.Read(data, format)
+	if err != nil {
+		log.Printf("[error] [server] error importing vacancies: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	skills, err := srv.skillIndex(req.Context())
+	if err != nil {
+		log.Printf("[error] [server] error importing vacancies: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	dicts, err := srv.dictionaries(req.Context())
+	if err != nil {
+		log.Printf("[error] [server] error importing vacancies: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	result, err := vacancies.Import(req.Context(), srv.vacancy, skills, dicts, srv.requisitions, rows, dryRun)
+	if err != nil {
+		log.Printf("[error] [server] error importing vacancies: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	code := http.StatusOK
+	if result.Failed > 0 {
+		code = http.StatusUnprocessableEntity
+	}
+	err = writeJSON(w, code, result)
+	if err != nil {
+		log.Printf("[error] [server] error importing vacancies: %s", err)
+	}
+}
+
+// writeFeed writes the feed answering conditional requests of crawlers with
+// 304 while the feed stays the same.
+func writeFeed(w http.ResponseWriter, req *http.Request, contentType string, doc *vacancies.Document) error {
+	w.Header().Set("ETag", doc.ETag)
+	w.Header().Set("Last-Modified", doc.Modified.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if req.Header.Get("If-None-Match") == doc.ETag {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write(doc.Data)
+	return err
+}
+
+// GetJobPostings returns active vacancies as Schema.org JobPosting JSON-LD
+// for the careers site.
+func (srv *Server) GetJobPostings(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	doc, err := srv.feed.JSONLD(req.Context())
+	if err != nil {
+		log.Printf("[error] [server] error getting job postings: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeFeed(w, req, vacancies.ContentTypeJSONLD, doc)
+	if err != nil {
+		log.Printf("[error] [server] error getting job postings: %s", err)
+	}
+}
+
+// GetJobFeed returns active vacancies as XML feed for job boards.
+func (srv *Server) GetJobFeed(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	doc, err := srv.feed.XML(req.Context())
+	if err != nil {
+		log.Printf("[error] [server] error getting job feed: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeFeed(w, req, vacancies.ContentTypeXML, doc)
+	if err != nil {
+		log.Printf("[error] [server] error getting job feed: %s", err)
+	}
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/internal/hr/webhooks"
+)
+
+func webhookDTO(webhook *entities.Webhook) Webhook {
+	return Webhook{
+		ID:      webhook.ID,
+		URL:     webhook.URL,
+		Events:  webhook.Events,
+		Active:  webhook.Active,
+		Created: webhook.Created,
+		Updated: webhook.Updated,
+	}
+}
+
+// ListWebhooks returns all registered webhooks.
+func (srv *Server) ListWebhooks(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	err := srv.requireAdmin(req)
+	if err != nil {
+		log.Printf("[error] [server] error listing webhooks: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	result, err := srv.webhook.List(req.Context())
+	if err != nil {
+		log.Printf("[error] [server] error listing webhooks: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	items := make([]Webhook, len(result))
+	for i := range result {
+		items[i] = webhookDTO(&result[i])
+	}
+
+	err = writeJSON(w, http.StatusOK, ListWebhooksResponse{Items: items})
+	if err != nil {
+		log.Printf("[error] [server] error listing webhooks: %s", err)
+	}
+}
+
+// GetWebhook returns the given webhook.
+func (srv *Server) GetWebhook(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	err := srv.requireAdmin(req)
+	if err != nil {
+		log.Printf("[error] [server] error get webhook: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	webhookID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error get webhook: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	webhook, err := srv.webhook.GetByID(req.Context(), webhookID)
+	if err != nil {
+		log.Printf("[error] [server] error get webhook: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, webhookDTO(webhook))
+	if err != nil {
+		log.Printf("[error] [server] error get webhook: %s", err)
+	}
+}
+
+// CreateWebhook registers webhook. Webhooks are active unless stated
+// otherwise.
+func (srv *Server) CreateWebhook(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	err := srv.requireAdmin(req)
+	if err != nil {
+		log.Printf("[error] [server] error creating webhook: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	var request WebhookRequest
+	err = json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error creating webhook: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	webhook := entities.Webhook{
+		URL:    request.URL,
+		Events: request.Events,
+		Secret: request.Secret,
+		Active: request.Active == nil || *request.Active,
+	}
+	err = webhook.Validate()
+	if err != nil {
+		log.Printf("[error] [server] error creating webhook: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = srv.webhook.Create(req.Context(), &webhook)
+	if err != nil {
+		log.Printf("[error] [server] error creating webhook: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, webhookDTO(&webhook))
+	if err != nil {
+		log.Printf("[error] [server] error creating webhook: %s", err)
+	}
+}
+
+// UpdateWebhook updates properties of the given webhook.
+func (srv *Server) UpdateWebhook(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	err := srv.requireAdmin(req)
+	if err != nil {
+		log.Printf("[error] [server] error updating webhook: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	webhookID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error updating webhook: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var request WebhookRequest
+	err = json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		log.Printf("[error] [server] error updating webhook: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	webhook, err := srv.webhook.GetByID(req.Context(), webhookID)
+	if err != nil {
+		log.Printf("[error] [server] error updating webhook: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	webhook.URL = request.URL
+	webhook.Events = request.Events
+	if request.Secret != "" {
+		webhook.Secret = request.Secret
+	}
+	if request.Active != nil {
+		webhook.Active = *request.Active
+	}
+
+	err = webhook.Validate()
+	if err != nil {
+		log.Printf("[error] [server] error updating webhook: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = srv.webhook.Update(req.Context(), webhook)
+	if err != nil {
+		log.Printf("[error] [server] error updating webhook: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, webhookDTO(webhook))
+	if err != nil {
+		log.Printf("[error] [server] error updating webhook: %s", err)
+	}
+}
+
+// DeleteWebhook removes the given webhook together with its deliveries.
+func (srv *Server) DeleteWebhook(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	err := srv.requireAdmin(req)
+	if err != nil {
+		log.Printf("[error] [server] error deleting webhook: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	webhookID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error deleting webhook: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = srv.webhook.Delete(req.Context(), webhookID)
+	if err != nil {
+		log.Printf("[error] [server] error deleting webhook: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries returns delivery log of the given webhook, newest first.
+func (srv *Server) ListDeliveries(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	err := srv.requireAdmin(req)
+	if err != nil {
+		log.Printf("[error] [server] error listing deliveries: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	webhookID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		log.Printf("[error] [server] error listing deliveries: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	_, err = srv.webhook.GetByID(req.Context(), webhookID)
+	if err != nil {
+		log.Printf("[error] [server] error listing deliveries: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	result, err := srv.delivery.List(req.Context(), webhookID)
+	if err != nil {
+		log.Printf("[error] [server] error listing deliveries: %s", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, ListDeliveriesResponse{Items: result})
+	if err != nil {
+		log.Printf("[error] [server] error listing deliveries: %s", err)
+	}
+}
+
+// Redeliver schedules the given delivery to be sent again, e.g. after the
+// receiver was fixed and the delivery went dead.
+func (srv *Server) Redeliver(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	err := srv.requireAdmin(req)
+	if err != nil {
+		log.Printf("[error] [server] error redelivering: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	vars := mux.Vars(req)
+	webhookID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("[error] [server] error redelivering: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	deliveryID, err := uuid.Parse(vars["deliveryID"])
+	if err != nil {
+		log.Printf("[error] [server] error redelivering: %s", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	delivery, err := srv.delivery.GetByID(req.Context(), deliveryID)
+	if err == nil && delivery.WebhookID != webhookID {
+		err = repos.ErrDeliveryNotFound
+	}
+	if err != nil {
+		log.Printf("[error] [server] error redelivering: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = webhooks.Redeliver(req.Context(), srv.delivery, delivery)
+	if err != nil {
+		log.Printf("[error] [server] error redelivering: %s", err)
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	err = writeJSON(w, http.StatusOK, delivery)
+	if err != nil {
+		log.Printf("[error] [server] error redelivering: %s", err)
+	}
+}
@@ -0,0 +1,456 @@
+package vacancies
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/events"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+// Content types of feeds.
+const (
+	ContentTypeJSONLD = "application/ld+json"
+	ContentTypeXML    = "application/xml; charset=utf-8"
+)
+
+// FeedOptions describe the employer and the careers site in feeds.
+type FeedOptions struct {
+	// Company is the name of the hiring organization.
+	Company string
+	// Country is ISO 3166-1 alpha-2 code of vacancy areas, RU if empty.
+	Country string
+	// URL is the vacancy page on the careers site with {id} standing for
+	// the vacancy ID, e.g. "https://careers.example.com/vacancies/{id}".
+	// Vacancies have no links if empty.
+	URL string
+	// Currency is ISO 4217 code of salaries without one, RUB if empty.
+	Currency string
+}
+
+// Document is a generated feed.
+type Document struct {
+	Data     []byte
+	ETag     string
+	Modified time.Time
+}
+
+func newDocument(data []byte, modified time.Time) *Document {
+	sum := sha256.Sum256(data)
+	return &Document{
+		Data:     data,
+		ETag:     `"` + hex.EncodeToString(sum[:16]) + `"`,
+		Modified: modified,
+	}
+}
+
+// Feed publishes active vacancies for the careers site and job boards. Feeds
+// are generated on demand and cached until a vacancy changes.
+type Feed struct {
+	repo repos.VacancyRepo
+	now  func() time.Time
+
+	mu      sync.Mutex
+	options FeedOptions
+	jsonld  *Document
+	xml     *Document
+}
+
+// NewFeed creates feed of vacancies from the repository.
+func NewFeed(repo repos.VacancyRepo, options FeedOptions) *Feed {
+	return &Feed{repo: repo, now: time.Now, options: options}
+}
+
+// SetOptions replaces the options, feeds are regenerated with them.
+func (f *Feed) SetOptions(options FeedOptions) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.options = options
+	f.jsonld, f.xml = nil, nil
+}
+
+// Invalidate drops cached feeds, the next request regenerates them.
+func (f *Feed) Invalidate() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.jsonld, f.xml = nil, nil
+}
+
+// JSONLD returns Schema.org JobPosting objects of active vacancies as a JSON
+// array.
+func (f *Feed) JSONLD(ctx context.Context) (*Document, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.jsonld == nil {
+		err := f.build(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return f.jsonld, nil
+}
+
+// XML returns active vacancies in the XML format job boards import.
+func (f *Feed) XML(ctx context.Context) (*Document, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.xml == nil {
+		err := f.build(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return f.xml, nil
+}
+
+// isVacancyEvent reports whether the event may change the feed.
+func isVacancyEvent(event *entities.Event) bool {
+	switch event.Type {
+	case entities.EventTypeVacancyCreated,
+		entities.EventTypeVacancyUpdated,
+		entities.EventTypeVacancyStatusChanged:
+		return true
+	}
+	return false
+}
+
+// Watch invalidates the feed on vacancy events of the hub until the context
+// is done.
+func (f *Feed) Watch(ctx context.Context, hub *events.Hub) {
+	for ctx.Err() == nil {
+		sub := hub.Subscribe(isVacancyEvent)
+		// Vacancies might have changed while there was no subscription.
+		f.Invalidate()
+		f.drain(ctx, sub)
+		hub.Unsubscribe(sub)
+	}
+}
+
+// drain invalidates the feed on every event until the subscription is
+// closed or the context is done.
+func (f *Feed) drain(ctx context.Context, sub *events.Subscription) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			f.Invalidate()
+		}
+	}
+}
+
+// build generates both feeds, the caller holds the lock.
+func (f *Feed) build(ctx context.Context) error {
+	all, err := f.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	var active []entities.Vacancy
+	for _, v := range all {
+		if v.Status == entities.VacancyStatusActive {
+			active = append(active, v)
+		}
+	}
+	now := f.now()
+
+	postings := make([]JobPosting, len(active))
+	for i := range active {
+		postings[i] = f.posting(&active[i])
+	}
+	data, err := json.Marshal(postings)
+	if err != nil {
+		return err
+	}
+	jsonld := newDocument(data, now)
+
+	source := xmlSource{
+		Publisher:     f.options.Company,
+		LastBuildDate: now.UTC().Format(time.RFC1123),
+		Jobs:          make([]xmlJob, len(active)),
+	}
+	for i := range active {
+		source.Jobs[i] = f.job(&active[i])
+	}
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	err = enc.Encode(source)
+	if err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+
+	f.jsonld, f.xml = jsonld, newDocument(buf.Bytes(), now)
+	return nil
+}
+
+func (f *Feed) country() string {
+	if f.options.Country == "" {
+		return "RU"
+	}
+	return f.options.Country
+}
+
+func (f *Feed) currency(s *entities.SalaryRange) string {
+	switch {
+	case s.Currency != "":
+		return s.Currency
+	case f.options.Currency != "":
+		return f.options.Currency
+	}
+	return "RUB"
+}
+
+// publicSalary returns the salary of the vacancy if it may be published.
+func publicSalary(v *entities.Vacancy) *entities.SalaryRange {
+	if !v.Salary.Public || v.Salary.Empty() {
+		return nil
+	}
+	return &v.Salary
+}
+
+func (f *Feed) url(v *entities.Vacancy) string {
+	return strings.ReplaceAll(f.options.URL, "{id}", v.ID.String())
+}
+
+// JobPosting is a vacancy described by https://schema.org/JobPosting.
+type JobPosting struct {
+	Context                string                  `json:"@context"`
+	Type                   string                  `json:"@type"`
+	Identifier             propertyValue           `json:"identifier"`
+	Title                  string                  `json:"title"`
+	Description            string                  `json:"description"`
+	DatePosted             string                  `json:"datePosted"`
+	URL                    string                  `json:"url,omitempty"`
+	HiringOrganization     organization            `json:"hiringOrganization"`
+	JobLocation            *place                  `json:"jobLocation,omitempty"`
+	Responsibilities       string                  `json:"responsibilities,omitempty"`
+	Qualifications         string                  `json:"qualifications,omitempty"`
+	Skills                 string                  `json:"skills,omitempty"`
+	ExperienceRequirements *experienceRequirements `json:"experienceRequirements,omitempty"`
+	BaseSalary             *monetaryAmount         `json:"baseSalary,omitempty"`
+}
+
+type propertyValue struct {
+	Type  string `json:"@type"`
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value"`
+}
+
+type organization struct {
+	Type       string        `json:"@type"`
+	Name       string        `json:"name,omitempty"`
+	Department *organization `json:"department,omitempty"`
+}
+
+type place struct {
+	Type    string        `json:"@type"`
+	Address postalAddress `json:"address"`
+}
+
+type postalAddress struct {
+	Type            string `json:"@type"`
+	AddressLocality string `json:"addressLocality"`
+	AddressCountry  string `json:"addressCountry"`
+}
+
+type experienceRequirements struct {
+	Type               string `json:"@type"`
+	MonthsOfExperience uint32 `json:"monthsOfExperience"`
+}
+
+type monetaryAmount struct {
+	Type     string            `json:"@type"`
+	Currency string            `json:"currency"`
+	Value    quantitativeValue `json:"value"`
+}
+
+type quantitativeValue struct {
+	Type     string `json:"@type"`
+	MinValue uint32 `json:"minValue,omitempty"`
+	MaxValue uint32 `json:"maxValue,omitempty"`
+	UnitText string `json:"unitText"`
+}
+
+// salaryUnits are Schema.org units of salary periods.
+var salaryUnits = map[entities.SalaryPeriod]string{
+	entities.SalaryPeriodNone:  "MONTH",
+	entities.SalaryPeriodMonth: "MONTH",
+	entities.SalaryPeriodYear:  "YEAR",
+}
+
+func (f *Feed) posting(v *entities.Vacancy) JobPosting {
+	p := JobPosting{
+		Context:     "https://schema.org",
+		Type:        "JobPosting",
+		Identifier:  propertyValue{Type: "PropertyValue", Name: f.options.Company, Value: v.ID.String()},
+		Title:       v.Title,
+		Description: description(v),
+		DatePosted:  v.Created.Format(time.DateOnly),
+		URL:         f.url(v),
+		HiringOrganization: organization{
+			Type: "Organization",
+			Name: f.options.Company,
+		},
+		Responsibilities: strings.Join(v.Duties, "\n"),
+		Qualifications:   strings.Join(v.Requirements, "\n"),
+		Skills:           skillTitles(v.Skills),
+	}
+	if v.Department != "" {
+		p.HiringOrganization.Department = &organization{Type: "Organization", Name: v.Department}
+	}
+	if v.Area != "" {
+		p.JobLocation = &place{
+			Type: "Place",
+			Address: postalAddress{
+				Type:            "PostalAddress",
+				AddressLocality: v.Area,
+				AddressCountry:  f.country(),
+			},
+		}
+	}
+	if v.Experience > 0 {
+		p.ExperienceRequirements = &experienceRequirements{
+			Type:               "OccupationalExperienceRequirements",
+			MonthsOfExperience: v.Experience * 12,
+		}
+	}
+	if salary := publicSalary(v); salary != nil {
+		p.BaseSalary = &monetaryAmount{
+			Type:     "MonetaryAmount",
+			Currency: f.currency(salary),
+			Value: quantitativeValue{
+				Type:     "QuantitativeValue",
+				MinValue: salary.Min,
+				MaxValue: salary.Max,
+				UnitText: salaryUnits[salary.Period],
+			},
+		}
+	}
+	return p
+}
+
+// xmlSource is the feed format originated by Indeed and accepted by most job
+// boards.
+type xmlSource struct {
+	XMLName       xml.Name `xml:"source"`
+	Publisher     string   `xml:"publisher,omitempty"`
+	LastBuildDate string   `xml:"lastBuildDate"`
+	Jobs          []xmlJob `xml:"job"`
+}
+
+type cdata struct {
+	Text string `xml:",cdata"`
+}
+
+type xmlJob struct {
+	Title           cdata  `xml:"title"`
+	Date            string `xml:"date"`
+	ReferenceNumber string `xml:"referencenumber"`
+	URL             string `xml:"url,omitempty"`
+	Company         cdata  `xml:"company"`
+	City            cdata  `xml:"city"`
+	Country         string `xml:"country"`
+	Category        cdata  `xml:"category"`
+	Description     cdata  `xml:"description"`
+	// Experience is the required experience in years.
+	Experience string `xml:"experience,omitempty"`
+	// Salary is the range with its currency and period, e.g.
+	// "150000-200000 RUB per month".
+	Salary string `xml:"salary,omitempty"`
+}
+
+func (f *Feed) job(v *entities.Vacancy) xmlJob {
+	j := xmlJob{
+		Title:           cdata{v.Title},
+		Date:            v.Created.UTC().Format(time.RFC1123),
+		ReferenceNumber: v.ID.String(),
+		URL:             f.url(v),
+		Company:         cdata{f.options.Company},
+		City:            cdata{v.Area},
+		Country:         f.country(),
+		Category:        cdata{v.Department},
+		Description:     cdata{description(v)},
+	}
+	if v.Experience > 0 {
+		j.Experience = strconv.FormatUint(uint64(v.Experience), 10)
+	}
+	if salary := publicSalary(v); salary != nil {
+		j.Salary = f.salaryText(salary)
+	}
+	return j
+}
+
+// salaryText renders the salary the way job boards show it.
+func (f *Feed) salaryText(s *entities.SalaryRange) string {
+	amount := func(v uint32) string {
+		return strconv.FormatUint(uint64(v), 10)
+	}
+	var text string
+	switch {
+	case s.Min == s.Max:
+		text = amount(s.Max)
+	case s.Min > 0 && s.Max > 0:
+		text = amount(s.Min) + "-" + amount(s.Max)
+	case s.Max > 0:
+		text = "up to " + amount(s.Max)
+	default:
+		text = "from " + amount(s.Min)
+	}
+	period := "month"
+	if s.Period == entities.SalaryPeriodYear {
+		period = "year"
+	}
+	return text + " " + f.currency(s) + " per " + period
+}
+
+// description renders duties, requirements and skills of the vacancy as
+// HTML, the form both Schema.org and job boards expect.
+func description(v *entities.Vacancy) string {
+	var b strings.Builder
+	list := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		b.WriteString("<p><strong>" + title + "</strong></p><ul>")
+		for _, item := range items {
+			b.WriteString("<li>" + html.EscapeString(item) + "</li>")
+		}
+		b.WriteString("</ul>")
+	}
+	list("Обязанности", v.Duties)
+	list("Требования", v.Requirements)
+	if len(v.Skills) > 0 {
+		b.WriteString("<p><strong>Навыки:</strong> " + html.EscapeString(skillTitles(v.Skills)) + "</p>")
+	}
+	if b.Len() == 0 {
+		return html.EscapeString(v.Title)
+	}
+	return b.String()
+}
+
+func skillTitles(skills []entities.Skill) string {
+	titles := make([]string, len(skills))
+	for i, skill := range skills {
+		titles[i] = skill.Title
+	}
+	return strings.Join(titles, ", ")
+}
@@ -0,0 +1,158 @@
+package vacancies
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/events"
+	"gpb.ru/hr/internal/hr/repos/memory"
+)
+
+func TestFeed(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.New()
+	active := entities.Vacancy{
+		Title:        "Go developer",
+		Status:       entities.VacancyStatusActive,
+		Department:   "IT",
+		Area:         "Москва",
+		Experience:   3,
+		Budget:       300000,
+		Skills:       []entities.Skill{{Title: "Go", Important: true}, {Title: "Docker"}},
+		Duties:       []string{"Write services"},
+		Requirements: []string{"Go <3 years"},
+		Salary:       entities.SalaryRange{Min: 200000, Max: 250000, Gross: true, Public: true},
+	}
+	require.NoError(t, mem.Vacancy.Create(ctx, &active))
+	hidden := entities.Vacancy{
+		Title:  "Analyst",
+		Status: entities.VacancyStatusActive,
+		Salary: entities.SalaryRange{Min: 3000, Currency: "USD", Period: entities.SalaryPeriodYear},
+	}
+	require.NoError(t, mem.Vacancy.Create(ctx, &hidden))
+	require.NoError(t, mem.Vacancy.Create(ctx, &entities.Vacancy{Title: "Draft", Status: entities.VacancyStatusDraft}))
+
+	feed := NewFeed(mem.Vacancy, FeedOptions{
+		Company: "Газпромбанк",
+		URL:     "https://careers.example.com/vacancies/{id}",
+	})
+	feed.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	doc, err := feed.JSONLD(ctx)
+	require.NoError(t, err)
+	var postings []map[string]interface{}
+	require.NoError(t, json.Unmarshal(doc.Data, &postings))
+	require.Len(t, postings, 2)
+	posting := postings[0]
+	if posting["title"] != active.Title {
+		posting = postings[1]
+	}
+	require.Equal(t, "https://schema.org", posting["@context"])
+	require.Equal(t, "JobPosting", posting["@type"])
+	require.Equal(t, "Go developer", posting["title"])
+	require.Equal(t, "https://careers.example.com/vacancies/"+active.ID.String(), posting["url"])
+	require.Equal(t, active.Created.Format(time.DateOnly), posting["datePosted"])
+	require.Equal(t, map[string]interface{}{
+		"@type":      "Organization",
+		"name":       "Газпромбанк",
+		"department": map[string]interface{}{"@type": "Organization", "name": "IT"},
+	}, posting["hiringOrganization"])
+	require.Equal(t, map[string]interface{}{
+		"@type":           "PostalAddress",
+		"addressLocality": "Москва",
+		"addressCountry":  "RU",
+	}, posting["jobLocation"].(map[string]interface{})["address"])
+	require.Equal(t, "Write services", posting["responsibilities"])
+	require.Equal(t, "Go <3 years", posting["qualifications"])
+	require.Equal(t, "Go, Docker", posting["skills"])
+	require.Equal(t, 36.0, posting["experienceRequirements"].(map[string]interface{})["monthsOfExperience"])
+	require.Equal(t, "<p><strong>Обязанности</strong></p><ul><li>Write services</li></ul>"+
+		"<p><strong>Требования</strong></p><ul><li>Go &lt;3 years</li></ul>"+
+		"<p><strong>Навыки:</strong> Go, Docker</p>", posting["description"])
+	require.Equal(t, map[string]interface{}{
+		"@type":    "MonetaryAmount",
+		"currency": "RUB",
+		"value": map[string]interface{}{
+			"@type":    "QuantitativeValue",
+			"minValue": 200000.0,
+			"maxValue": 250000.0,
+			"unitText": "MONTH",
+		},
+	}, posting["baseSalary"])
+	require.NotContains(t, string(doc.Data), "300000", "budget is not published")
+	require.NotContains(t, string(doc.Data), "USD", "hidden salary is not published")
+
+	doc, err = feed.XML(ctx)
+	require.NoError(t, err)
+	var source struct {
+		Publisher     string `xml:"publisher"`
+		LastBuildDate string `xml:"lastBuildDate"`
+		Jobs          []struct {
+			Title           string `xml:"title"`
+			ReferenceNumber string `xml:"referencenumber"`
+			City            string `xml:"city"`
+			Country         string `xml:"country"`
+			Category        string `xml:"category"`
+			Description     string `xml:"description"`
+			Experience      string `xml:"experience"`
+			Salary          string `xml:"salary"`
+		} `xml:"job"`
+	}
+	require.NoError(t, xml.Unmarshal(doc.Data, &source))
+	require.Equal(t, "Газпромбанк", source.Publisher)
+	require.Equal(t, "Tue, 02 Jan 2024 03:04:05 UTC", source.LastBuildDate)
+	require.Len(t, source.Jobs, 2)
+	if source.Jobs[0].Title != active.Title {
+		source.Jobs[0], source.Jobs[1] = source.Jobs[1], source.Jobs[0]
+	}
+	require.Equal(t, "Go developer", source.Jobs[0].Title)
+	require.Equal(t, active.ID.String(), source.Jobs[0].ReferenceNumber)
+	require.Equal(t, "Москва", source.Jobs[0].City)
+	require.Equal(t, "RU", source.Jobs[0].Country)
+	require.Equal(t, "IT", source.Jobs[0].Category)
+	require.Equal(t, "3", source.Jobs[0].Experience)
+	require.Contains(t, source.Jobs[0].Description, "<li>Go &lt;3 years</li>")
+	require.Equal(t, "200000-250000 RUB per month", source.Jobs[0].Salary)
+	require.Empty(t, source.Jobs[1].Salary)
+
+	cached, err := feed.XML(ctx)
+	require.NoError(t, err)
+	require.Same(t, doc, cached)
+}
+
+func TestFeed_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mem := memory.New()
+	vacancy := entities.Vacancy{Title: "Go developer", Status: entities.VacancyStatusActive}
+	require.NoError(t, mem.Vacancy.Create(ctx, &vacancy))
+
+	hub := events.NewHub(mem.Outbox, 0)
+	require.NoError(t, hub.Poll(ctx))
+	feed := NewFeed(mem.Vacancy, FeedOptions{})
+	sub := hub.Subscribe(isVacancyEvent)
+	go feed.drain(ctx, sub)
+
+	before, err := feed.JSONLD(ctx)
+	require.NoError(t, err)
+	require.Contains(t, string(before.Data), "Go developer")
+
+	vacancy.Title = "Senior Go developer"
+	require.NoError(t, mem.Vacancy.Update(ctx, &vacancy))
+	require.NoError(t, hub.Poll(ctx))
+
+	require.Eventually(t, func() bool {
+		doc, err := feed.JSONLD(ctx)
+		require.NoError(t, err)
+		return doc.ETag != before.ETag
+	}, time.Second, 10*time.Millisecond)
+	doc, err := feed.JSONLD(ctx)
+	require.NoError(t, err)
+	require.Contains(t, string(doc.Data), "Senior Go developer")
+}
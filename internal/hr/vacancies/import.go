@@ -0,0 +1,147 @@
+package vacancies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+	"gpb.ru/hr/internal/hr/requisitions"
+)
+
+// Actions taken on rows.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+)
+
+var ErrDuplicateRow = errors.New("vacancy is already updated by row")
+
+// RowResult tells what happened to a row.
+type RowResult struct {
+	Line int `json:"line"`
+	// VacancyID is nil for vacancies which would be created.
+	VacancyID uuid.UUID `json:"vacancyID"`
+	Action    string    `json:"action"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Result of an import, nothing is saved in dry run or if any row failed.
+type Result struct {
+	DryRun  bool        `json:"dryRun"`
+	Created int         `json:"created"`
+	Updated int         `json:"updated"`
+	Failed  int         `json:"failed"`
+	Rows    []RowResult `json:"rows"`
+}
+
+// Saved reports whether the vacancies were saved.
+func (r *Result) Saved() bool {
+	return !r.DryRun && r.Failed == 0
+}
+
+// Import validates all rows first and saves them only if every row is valid,
+// so a fixed table can be imported again without duplicating vacancies.
+// Skills get their catalog names from the index, areas and departments
+// must be in the dictionaries. Vacancies are checked against requisitions
+// by the gate unless it is nil, and checked again while saved.
+func Import(
+	ctx context.Context,
+	repo repos.VacancyRepo,
+	skills *entities.SkillIndex,
+	dicts *entities.Dictionaries,
+	gate *requisitions.Gate,
+	rows []Row,
+	dryRun bool,
+) (*Result, error) {
+	result := &Result{DryRun: dryRun, Rows: make([]RowResult, len(rows))}
+	vacancies := make([]entities.Vacancy, len(rows))
+	olds := make([]*entities.Vacancy, len(rows))
+	seen := make(map[uuid.UUID]int)
+
+	for i := range rows {
+		row := &rows[i]
+		res := &result.Rows[i]
+		res.Line = row.Line
+		res.VacancyID = row.ID
+		res.Action = ActionCreate
+		if row.ID != uuid.Nil {
+			res.Action = ActionUpdate
+		}
+
+		err := row.Err
+		if err == nil && row.ID != uuid.Nil {
+			if line, ok := seen[row.ID]; ok {
+				err = fmt.Errorf("%w %d", ErrDuplicateRow, line)
+			}
+			seen[row.ID] = row.Line
+		}
+		if err == nil && row.ID != uuid.Nil {
+			var existing *entities.Vacancy
+			existing, err = repo.GetByID(ctx, row.ID)
+			if err != nil && !errors.Is(err, repos.ErrVacancyNotFound) {
+				return nil, err
+			}
+			if err == nil {
+				vacancies[i] = *existing
+			}
+		}
+		var old *entities.Vacancy
+		if err == nil && vacancies[i].ID != uuid.Nil {
+			stored := vacancies[i]
+			old = &stored
+		}
+		olds[i] = old
+		if err == nil {
+			row.Apply(&vacancies[i])
+			vacancies[i].NormalizeSkills(skills)
+			if vacancies[i].Status == entities.VacancyStatusNone {
+				vacancies[i].Status = entities.VacancyStatusDraft
+			}
+			err = vacancies[i].Validate()
+		}
+		if err == nil {
+			err = vacancies[i].NormalizeDictionaries(dicts)
+		}
+		if err == nil && gate != nil {
+			err = gate.CheckVacancy(ctx, &vacancies[i], old)
+		}
+
+		if err != nil {
+			res.Error = err.Error()
+			result.Failed++
+			continue
+		}
+		if row.ID == uuid.Nil {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+	if !result.Saved() {
+		return result, nil
+	}
+
+	for i := range vacancies {
+		save := func(ctx context.Context) error {
+			if rows[i].ID == uuid.Nil {
+				return repo.Create(ctx, &vacancies[i])
+			}
+			return repo.Update(ctx, &vacancies[i])
+		}
+		var err error
+		if gate != nil {
+			err = gate.SaveVacancy(ctx, &vacancies[i], olds[i], save)
+		} else {
+			err = save(ctx)
+		}
+		if err != nil {
+			return result, fmt.Errorf("row %d: %w", rows[i].Line, err)
+		}
+		result.Rows[i].VacancyID = vacancies[i].ID
+	}
+	return result, nil
+}
@@ -0,0 +1,330 @@
+// Package vacancies exchanges vacancies with spreadsheets and publishes
+// active ones in feeds for the careers site and job boards.
+//
+// A table has a header row naming columns followed by one vacancy per row.
+// Columns may go in any order, unknown ones are rejected, missing ones keep
+// values of updated vacancies and defaults of created ones:
+//
+//	id            ID of the vacancy to update, empty to create a new one
+//	title         required
+//	status        draft, active or inactive, draft if empty
+//	department
+//	area
+//	experience    required experience in whole years
+//	budget        highest monthly salary, 0 or empty if not limited
+//	skills        separated by semicolons, important ones end with an
+//	              asterisk: "Go*; PostgreSQL*; Docker"
+//	duties        one per line
+//	requirements  one per line
+//	template_id   ID of the template the vacancy was made from
+//	created       exported only, ignored on import
+//	updated       exported only, ignored on import
+//
+// Line breaks are the ones made by Alt+Enter in Excel, in CSV the cell is
+// quoted.
+package vacancies
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/pkg/xlsx"
+)
+
+// Formats of tables.
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var (
+	ErrInvalidFormat   = errors.New("format must be csv or xlsx")
+	ErrNoHeader        = errors.New("table has no header row")
+	ErrUnknownColumn   = errors.New("unknown column")
+	ErrDuplicateColumn = errors.New("duplicate column")
+	ErrNoTitleColumn   = errors.New("table has no title column")
+	ErrTitleRequired   = errors.New("title is required")
+	ErrInvalidNumber   = errors.New("must be a non-negative whole number")
+)
+
+// Columns of exported tables in order.
+var Columns = []string{
+	"id", "title", "status", "department", "area", "experience", "budget",
+	"skills", "duties", "requirements", "template_id", "created", "updated",
+}
+
+// ContentType returns content type of tables in the format.
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return xlsx.ContentType
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Detect returns format of the table judging by its content.
+func Detect(data []byte) string {
+	if xlsx.IsXLSX(data) {
+		return FormatXLSX
+	}
+	return FormatCSV
+}
+
+// Write writes the vacancies as a table in the format.
+func Write(w io.Writer, format string, vacancies []entities.Vacancy) error {
+	rows := make([][]string, 0, len(vacancies)+1)
+	rows = append(rows, Columns)
+	for i := range vacancies {
+		rows = append(rows, encode(&vacancies[i]))
+	}
+
+	switch format {
+	case FormatCSV:
+		return csv.NewWriter(w).WriteAll(rows)
+	case FormatXLSX:
+		return xlsx.Write(w, "Vacancies", rows)
+	}
+	return ErrInvalidFormat
+}
+
+func encode(v *entities.Vacancy) []string {
+	skills := make([]string, len(v.Skills))
+	for i, skill := range v.Skills {
+		skills[i] = skill.Title
+		if skill.Important {
+			skills[i] += "*"
+		}
+	}
+	templateID := ""
+	if v.TemplateID != uuid.Nil {
+		templateID = v.TemplateID.String()
+	}
+	return []string{
+		v.ID.String(),
+		v.Title,
+		v.Status.String(),
+		v.Department,
+		v.Area,
+		strconv.FormatUint(uint64(v.Experience), 10),
+		strconv.FormatUint(uint64(v.Budget), 10),
+		strings.Join(skills, "; "),
+		strings.Join(v.Duties, "\n"),
+		strings.Join(v.Requirements, "\n"),
+		templateID,
+		v.Created.Format(time.RFC3339),
+		v.Updated.Format(time.RFC3339),
+	}
+}
+
+// Row is a decoded table row.
+type Row struct {
+	// Line is the number of the row in the table counting the header as 1,
+	// as spreadsheets show it.
+	Line int
+	// ID is the vacancy to update, uuid.Nil to create a new one.
+	ID uuid.UUID
+	// Err tells why the row can not be imported.
+	Err error
+
+	values  entities.Vacancy
+	columns map[string]bool
+}
+
+// Apply copies values of columns present in the table to the vacancy.
+func (r *Row) Apply(v *entities.Vacancy) {
+	set := func(column string) bool { return r.columns[column] }
+	if set("title") {
+		v.Title = r.values.Title
+	}
+	if set("status") {
+		v.Status = r.values.Status
+	}
+	if set("department") {
+		v.Department = r.values.Department
+	}
+	if set("area") {
+		v.Area = r.values.Area
+	}
+	if set("experience") {
+		v.Experience = r.values.Experience
+	}
+	if set("budget") {
+		v.Budget = r.values.Budget
+	}
+	if set("skills") {
+		v.Skills = r.values.Skills
+	}
+	if set("duties") {
+		v.Duties = r.values.Duties
+	}
+	if set("requirements") {
+		v.Requirements = r.values.Requirements
+	}
+	if set("template_id") {
+		v.TemplateID = r.values.TemplateID
+	}
+}
+
+// Read decodes the table. Errors in the header fail the whole table, errors
+// in rows are reported in them. Empty rows are skipped.
+func Read(data []byte, format string) ([]Row, error) {
+	var records [][]string
+	var err error
+	switch format {
+	case FormatCSV:
+		r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+		r.FieldsPerRecord = -1
+		records, err = r.ReadAll()
+	case FormatXLSX:
+		records, err = xlsx.Read(data)
+	default:
+		err = ErrInvalidFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, ErrNoHeader
+	}
+	header := make([]string, len(records[0]))
+	columns := make(map[string]bool, len(header))
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "":
+		case !known(name):
+			return nil, fmt.Errorf("%w %q", ErrUnknownColumn, name)
+		case columns[name]:
+			return nil, fmt.Errorf("%w %q", ErrDuplicateColumn, name)
+		}
+		header[i] = name
+		columns[name] = true
+	}
+	if !columns["title"] {
+		return nil, ErrNoTitleColumn
+	}
+
+	var rows []Row
+	for i, record := range records[1:] {
+		if blank(record) {
+			continue
+		}
+		row := Row{Line: i + 2, columns: columns}
+		row.Err = row.decode(header, record)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func known(column string) bool {
+	for _, c := range Columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+func blank(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Row) decode(header, record []string) error {
+	v := &r.values
+	for i, column := range header {
+		cell := ""
+		if i < len(record) {
+			cell = strings.TrimSpace(record[i])
+		}
+
+		var err error
+		switch column {
+		case "id":
+			if cell != "" {
+				r.ID, err = uuid.Parse(cell)
+			}
+		case "title":
+			v.Title = cell
+		case "status":
+			err = v.Status.UnmarshalText([]byte(strings.ToLower(cell)))
+		case "department":
+			v.Department = cell
+		case "area":
+			v.Area = cell
+		case "experience":
+			v.Experience, err = number(cell)
+		case "budget":
+			v.Budget, err = number(cell)
+		case "skills":
+			v.Skills = skills(cell)
+		case "duties":
+			v.Duties = lines(cell)
+		case "requirements":
+			v.Requirements = lines(cell)
+		case "template_id":
+			if cell != "" {
+				v.TemplateID, err = uuid.Parse(cell)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", column, err)
+		}
+	}
+	if v.Title == "" {
+		return ErrTitleRequired
+	}
+	return nil
+}
+
+func number(cell string) (uint32, error) {
+	if cell == "" {
+		return 0, nil
+	}
+	// Spreadsheets may store whole numbers as "3.0".
+	if whole, fraction, ok := strings.Cut(cell, "."); ok && strings.Trim(fraction, "0") == "" {
+		cell = whole
+	}
+	n, err := strconv.ParseUint(cell, 10, 32)
+	if err != nil {
+		return 0, ErrInvalidNumber
+	}
+	return uint32(n), nil
+}
+
+func skills(cell string) []entities.Skill {
+	var result []entities.Skill
+	for _, title := range strings.Split(cell, ";") {
+		title = strings.TrimSpace(title)
+		important := strings.HasSuffix(title, "*")
+		title = strings.TrimSpace(strings.TrimSuffix(title, "*"))
+		if title != "" {
+			result = append(result, entities.Skill{Title: title, Important: important})
+		}
+	}
+	return result
+}
+
+func lines(cell string) []string {
+	var result []string
+	for _, line := range strings.Split(strings.ReplaceAll(cell, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
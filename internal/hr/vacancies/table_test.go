@@ -0,0 +1,150 @@
+package vacancies
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos/memory"
+)
+
+func TestTable_RoundTrip(t *testing.T) {
+	vacancy := entities.Vacancy{
+		ID:         uuid.New(),
+		TemplateID: uuid.New(),
+		Title:      "Go developer",
+		Status:     entities.VacancyStatusActive,
+		Department: "IT",
+		Area:       "Москва",
+		Experience: 3,
+		Budget:     300000,
+		Skills: []entities.Skill{
+			{Title: "Go", Important: true},
+			{Title: "Docker"},
+		},
+		Duties:       []string{"Write services", "Review code"},
+		Requirements: []string{"Go for 3 years"},
+	}
+
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Write(&buf, format, []entities.Vacancy{vacancy}))
+			require.Exactly(t, format, Detect(buf.Bytes()))
+
+			rows, err := Read(buf.Bytes(), format)
+			require.NoError(t, err)
+			require.Len(t, rows, 1)
+			require.NoError(t, rows[0].Err)
+			require.Exactly(t, 2, rows[0].Line)
+			require.Exactly(t, vacancy.ID, rows[0].ID)
+
+			got := entities.Vacancy{ID: vacancy.ID}
+			rows[0].Apply(&got)
+			require.Exactly(t, vacancy, got)
+		})
+	}
+}
+
+func TestTable_Read(t *testing.T) {
+	_, err := Read([]byte("title,salary\nGo developer,1\n"), FormatCSV)
+	require.ErrorIs(t, err, ErrUnknownColumn)
+	_, err = Read([]byte("Title,title\n"), FormatCSV)
+	require.ErrorIs(t, err, ErrDuplicateColumn)
+	_, err = Read([]byte("area\nМосква\n"), FormatCSV)
+	require.ErrorIs(t, err, ErrNoTitleColumn)
+	_, err = Read(nil, FormatCSV)
+	require.ErrorIs(t, err, ErrNoHeader)
+	_, err = Read(nil, "ods")
+	require.ErrorIs(t, err, ErrInvalidFormat)
+
+	rows, err := Read([]byte("\ufeffTitle,Experience,Skills\n"+
+		"Go developer,3.0,Go*; ;Docker\n"+
+		",,\n"+
+		",1,\n"+
+		"QA,-1,\n"), FormatCSV)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	require.NoError(t, rows[0].Err)
+	var v entities.Vacancy
+	rows[0].Apply(&v)
+	require.Exactly(t, uint32(3), v.Experience)
+	require.Exactly(t, []entities.Skill{{Title: "Go", Important: true}, {Title: "Docker"}}, v.Skills)
+
+	require.Exactly(t, 4, rows[1].Line)
+	require.ErrorIs(t, rows[1].Err, ErrTitleRequired)
+	require.Exactly(t, 5, rows[2].Line)
+	require.ErrorIs(t, rows[2].Err, ErrInvalidNumber)
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New().Vacancy
+	existing := entities.Vacancy{Title: "Go developer", Status: entities.VacancyStatusActive, Area: "Москва"}
+	require.NoError(t, repo.Create(ctx, &existing))
+	dicts := entities.NewDictionaries([]entities.DictionaryItem{
+		{ID: uuid.New(), Kind: entities.DictionaryKindArea, Name: "Москва"},
+		{ID: uuid.New(), Kind: entities.DictionaryKindDepartment, Name: "IT"},
+	})
+
+	table := "id,title,department\n" +
+		existing.ID.String() + ",Senior Go developer,IT\n" +
+		",QA engineer,\n" +
+		uuid.New().String() + ",Analyst,\n" +
+		existing.ID.String() + ",Go developer,\n"
+	rows, err := Read([]byte(table), FormatCSV)
+	require.NoError(t, err)
+
+	result, err := Import(ctx, repo, entities.NewSkillIndex(nil), dicts, nil, rows, false)
+	require.NoError(t, err)
+	require.False(t, result.Saved())
+	require.Exactly(t, 1, result.Created)
+	require.Exactly(t, 1, result.Updated)
+	require.Exactly(t, 2, result.Failed)
+	require.Exactly(t, "vacancy not found", result.Rows[2].Error)
+	require.Exactly(t, "vacancy is already updated by row 2", result.Rows[3].Error)
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	rows, err = Read([]byte("id,title,department\n"+
+		existing.ID.String()+",Senior Go developer,it\n"+
+		",QA engineer,Sales\n"), FormatCSV)
+	require.NoError(t, err)
+	result, err = Import(ctx, repo, entities.NewSkillIndex(nil), dicts, nil, rows, true)
+	require.NoError(t, err)
+	require.Exactly(t, `department "Sales": not in dictionary`, result.Rows[1].Error)
+
+	rows, err = Read([]byte("id,title,department\n"+
+		existing.ID.String()+",Senior Go developer,it\n"+
+		",QA engineer,\n"), FormatCSV)
+	require.NoError(t, err)
+
+	result, err = Import(ctx, repo, entities.NewSkillIndex(nil), dicts, nil, rows, true)
+	require.NoError(t, err)
+	require.False(t, result.Saved())
+	require.Exactly(t, uuid.Nil, result.Rows[1].VacancyID)
+
+	result, err = Import(ctx, repo, entities.NewSkillIndex(nil), dicts, nil, rows, false)
+	require.NoError(t, err)
+	require.True(t, result.Saved())
+	require.Exactly(t, ActionUpdate, result.Rows[0].Action)
+	require.Exactly(t, ActionCreate, result.Rows[1].Action)
+
+	updated, err := repo.GetByID(ctx, existing.ID)
+	require.NoError(t, err)
+	require.Exactly(t, "Senior Go developer", updated.Title)
+	require.Exactly(t, "IT", updated.Department)
+	require.Exactly(t, "Москва", updated.Area)
+	require.Exactly(t, entities.VacancyStatusActive, updated.Status)
+
+	created, err := repo.GetByID(ctx, result.Rows[1].VacancyID)
+	require.NoError(t, err)
+	require.Exactly(t, "QA engineer", created.Title)
+	require.Exactly(t, entities.VacancyStatusDraft, created.Status)
+}
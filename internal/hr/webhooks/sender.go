@@ -0,0 +1,183 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+// Body is the JSON document posted to webhooks.
+type Body struct {
+	ID      string             `json:"id"`
+	Event   entities.EventType `json:"event"`
+	Created time.Time          `json:"created"`
+	Payload json.RawMessage    `json:"payload"`
+}
+
+var errUnexpectedStatus = errors.New("unexpected response status")
+
+// Sender posts pending deliveries to webhooks.
+type Sender struct {
+	webhook  repos.WebhookRepo
+	delivery repos.DeliveryRepo
+	client   *http.Client
+
+	// Interval is a pause between polls of due deliveries.
+	Interval time.Duration
+	// Backoff is a delay before the first retry, doubled on every next one
+	// up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// MaxAttempts is a number of attempts after which the delivery is
+	// considered dead.
+	MaxAttempts int
+	// Lease is how long a claimed delivery stays hidden from other senders.
+	Lease time.Duration
+	Batch int
+
+	now func() time.Time
+}
+
+// NewSender creates sender with default retry policy.
+func NewSender(webhook repos.WebhookRepo, delivery repos.DeliveryRepo, client *http.Client) *Sender {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Sender{
+		webhook:     webhook,
+		delivery:    delivery,
+		client:      client,
+		Interval:    time.Second,
+		Backoff:     10 * time.Second,
+		MaxBackoff:  time.Hour,
+		MaxAttempts: 10,
+		Lease:       time.Minute,
+		Batch:       100,
+		now:         time.Now,
+	}
+}
+
+// Run sends deliveries until the context is done.
+func (s *Sender) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.Send(ctx)
+		if err != nil {
+			log.Printf("[error] [webhooks] %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Send makes one attempt for every due delivery.
+func (s *Sender) Send(ctx context.Context) error {
+	deliveries, err := s.delivery.Claim(ctx, s.now(), s.Lease, s.Batch)
+	if err != nil {
+		return err
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		s.attempt(ctx, delivery)
+
+		err = s.delivery.Update(ctx, delivery)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Sender) attempt(ctx context.Context, delivery *entities.Delivery) {
+	delivery.Attempts++
+
+	code, err := s.post(ctx, delivery)
+	delivery.ResponseCode = code
+	if err == nil {
+		delivery.Status = entities.DeliveryStatusDelivered
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= s.MaxAttempts {
+		delivery.Status = entities.DeliveryStatusDead
+		log.Printf(
+			"[error] [webhooks] delivery %s is dead after %d attempts: %s",
+			delivery.ID,
+			delivery.Attempts,
+			err,
+		)
+		return
+	}
+	delivery.NextAttempt = s.now().Add(s.backoff(delivery.Attempts))
+}
+
+// backoff returns delay after the given number of failed attempts.
+func (s *Sender) backoff(attempts int) time.Duration {
+	delay := s.Backoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= s.MaxBackoff {
+			return s.MaxBackoff
+		}
+	}
+	return delay
+}
+
+func (s *Sender) post(ctx context.Context, delivery *entities.Delivery) (int, error) {
+	webhook, err := s.webhook.GetByID(ctx, delivery.WebhookID)
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := json.Marshal(Body{
+		ID:      delivery.EventID.String(),
+		Event:   delivery.EventType,
+		Created: delivery.Created,
+		Payload: delivery.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType.String())
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("%w %s", errUnexpectedStatus, resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,98 @@
+// Package webhooks delivers domain events to external systems over HTTP.
+//
+// The Dispatcher is an events.Handler which turns every event into pending
+// deliveries for subscribed webhooks. The Sender picks due deliveries up,
+// posts them signed with the webhook secret and retries failures with
+// exponential backoff until the delivery succeeds or goes dead.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos"
+)
+
+// Request headers set on every delivery.
+const (
+	HeaderEvent     = "X-HR-Event"
+	HeaderDelivery  = "X-HR-Delivery"
+	HeaderTimestamp = "X-HR-Timestamp"
+	HeaderSignature = "X-HR-Signature"
+)
+
+const signaturePrefix = "sha256="
+
+// Sign returns signature of the body sent at the given unix timestamp. The
+// signature is HMAC-SHA256 of "timestamp.body" keyed with the secret.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether the signature matches the body and timestamp.
+// Receivers should also reject timestamps too far from their own clock to
+// protect against replays.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	want := Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(want), []byte(signature))
+}
+
+// Dispatcher creates deliveries for events subscribed webhooks want.
+type Dispatcher struct {
+	webhook  repos.WebhookRepo
+	delivery repos.DeliveryRepo
+}
+
+// NewDispatcher creates dispatcher on top of the given repositories.
+func NewDispatcher(webhook repos.WebhookRepo, delivery repos.DeliveryRepo) *Dispatcher {
+	return &Dispatcher{webhook: webhook, delivery: delivery}
+}
+
+// Handle implements events.Handler. Deliveries are unique per webhook and
+// event, so handling the same event twice is harmless.
+func (d *Dispatcher) Handle(ctx context.Context, event entities.Event) error {
+	webhooks, err := d.webhook.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Subscribed(event.Type) {
+			continue
+		}
+		err = d.delivery.Create(ctx, &entities.Delivery{
+			WebhookID:   webhook.ID,
+			EventID:     event.ID,
+			EventType:   event.Type,
+			Payload:     event.Payload,
+			Status:      entities.DeliveryStatusPending,
+			NextAttempt: event.Created,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Redeliver schedules the delivery to be sent again as soon as possible,
+// regardless of its current status.
+func Redeliver(ctx context.Context, repo repos.DeliveryRepo, delivery *entities.Delivery) error {
+	delivery.Status = entities.DeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttempt = time.Now()
+	delivery.LastError = ""
+	return repo.Update(ctx, delivery)
+}
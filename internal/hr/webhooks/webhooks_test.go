@@ -0,0 +1,167 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"gpb.ru/hr/internal/hr/entities"
+	"gpb.ru/hr/internal/hr/repos/memory"
+)
+
+const secret = "0123456789abcdef"
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	signature := Sign(secret, "1700000000", body)
+
+	require.True(t, Verify(secret, "1700000000", body, signature))
+	require.False(t, Verify(secret, "1700000001", body, signature))
+	require.False(t, Verify(secret, "1700000000", []byte(`{"id":"2"}`), signature))
+	require.False(t, Verify("another secret!!", "1700000000", body, signature))
+	require.False(t, Verify(secret, "1700000000", body, signature[len("sha256="):]))
+}
+
+type receiver struct {
+	t      *testing.T
+	fail   int
+	bodies [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	require.NoError(r.t, err)
+
+	ok := Verify(secret, req.Header.Get(HeaderTimestamp), body, req.Header.Get(HeaderSignature))
+	require.True(r.t, ok, "signature must be valid")
+	require.Equal(r.t, entities.EventTypeCandidateCreated.String(), req.Header.Get(HeaderEvent))
+	require.NotEmpty(r.t, req.Header.Get(HeaderDelivery))
+
+	if r.fail > 0 {
+		r.fail--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	r.bodies = append(r.bodies, body)
+}
+
+type fixture struct {
+	mem    *memory.Memory
+	sender *Sender
+	now    time.Time
+}
+
+func newFixture(t *testing.T, recv *receiver) *fixture {
+	ctx := context.Background()
+	srv := httptest.NewServer(recv)
+	t.Cleanup(srv.Close)
+
+	mem := memory.New()
+	require.NoError(t, mem.Webhook.Create(ctx, &entities.Webhook{
+		URL:    srv.URL,
+		Events: []entities.EventType{entities.EventTypeCandidateCreated},
+		Secret: secret,
+		Active: true,
+	}))
+	require.NoError(t, mem.Webhook.Create(ctx, &entities.Webhook{
+		URL:    srv.URL,
+		Events: []entities.EventType{entities.EventTypeCandidateCreated},
+		Secret: secret,
+	}))
+
+	require.NoError(t, mem.Vacancy.Create(ctx, &entities.Vacancy{Title: "Go developer"}))
+	require.NoError(t, mem.Candidate.Create(ctx, &entities.Candidate{Name: "John Doe"}))
+
+	dispatcher := NewDispatcher(mem.Webhook, mem.Delivery)
+	for _, event := range mem.Outbox.Events() {
+		require.NoError(t, dispatcher.Handle(ctx, event))
+		require.NoError(t, dispatcher.Handle(ctx, event), "dispatch must be idempotent")
+	}
+
+	f := &fixture{mem: mem, now: time.Now()}
+	f.sender = NewSender(mem.Webhook, mem.Delivery, srv.Client())
+	f.sender.MaxAttempts = 3
+	f.sender.now = func() time.Time { return f.now }
+	return f
+}
+
+func (f *fixture) deliveries(t *testing.T) []entities.Delivery {
+	webhooks, err := f.mem.Webhook.List(context.Background())
+	require.NoError(t, err)
+
+	var result []entities.Delivery
+	for _, webhook := range webhooks {
+		deliveries, err := f.mem.Delivery.List(context.Background(), webhook.ID)
+		require.NoError(t, err)
+		result = append(result, deliveries...)
+	}
+	return result
+}
+
+func TestSender_Retry(t *testing.T) {
+	ctx := context.Background()
+	recv := &receiver{t: t, fail: 1}
+	f := newFixture(t, recv)
+
+	deliveries := f.deliveries(t)
+	require.Len(t, deliveries, 1, "only active subscribed webhooks get deliveries")
+
+	require.NoError(t, f.sender.Send(ctx))
+	delivery := f.deliveries(t)[0]
+	require.Equal(t, entities.DeliveryStatusPending, delivery.Status)
+	require.Equal(t, 1, delivery.Attempts)
+	require.Equal(t, http.StatusServiceUnavailable, delivery.ResponseCode)
+	require.Equal(t, f.now.Add(f.sender.Backoff), delivery.NextAttempt)
+
+	require.NoError(t, f.sender.Send(ctx))
+	require.Empty(t, recv.bodies, "delivery must wait for backoff")
+
+	f.now = f.now.Add(f.sender.Backoff)
+	require.NoError(t, f.sender.Send(ctx))
+	delivery = f.deliveries(t)[0]
+	require.Equal(t, entities.DeliveryStatusDelivered, delivery.Status)
+	require.Equal(t, 2, delivery.Attempts)
+	require.Equal(t, http.StatusOK, delivery.ResponseCode)
+	require.Len(t, recv.bodies, 1)
+	require.Contains(t, string(recv.bodies[0]), `"event":"candidateCreated"`)
+}
+
+func TestSender_Dead(t *testing.T) {
+	ctx := context.Background()
+	recv := &receiver{t: t, fail: 100}
+	f := newFixture(t, recv)
+
+	for i := 0; i < f.sender.MaxAttempts; i++ {
+		require.NoError(t, f.sender.Send(ctx))
+		f.now = f.now.Add(f.sender.MaxBackoff)
+	}
+	delivery := f.deliveries(t)[0]
+	require.Equal(t, entities.DeliveryStatusDead, delivery.Status)
+	require.Equal(t, f.sender.MaxAttempts, delivery.Attempts)
+	require.NotEmpty(t, delivery.LastError)
+
+	recv.fail = 0
+	require.NoError(t, Redeliver(ctx, f.mem.Delivery, &delivery))
+	f.now = time.Now()
+	require.NoError(t, f.sender.Send(ctx))
+	delivery = f.deliveries(t)[0]
+	require.Equal(t, entities.DeliveryStatusDelivered, delivery.Status)
+	require.Equal(t, 1, delivery.Attempts)
+}
+
+func TestSender_Backoff(t *testing.T) {
+	s := NewSender(nil, nil, nil)
+	s.Backoff = time.Second
+	s.MaxBackoff = 5 * time.Second
+
+	require.Exactly(t, time.Second, s.backoff(1))
+	require.Exactly(t, 2*time.Second, s.backoff(2))
+	require.Exactly(t, 4*time.Second, s.backoff(3))
+	require.Exactly(t, 5*time.Second, s.backoff(4))
+	require.Exactly(t, 5*time.Second, s.backoff(40))
+}
@@ -0,0 +1,40 @@
+// Package blob stores file contents addressed by keys in a local directory
+// or an S3 compatible object storage.
+//
+// Keys are slash separated relative paths of letters, digits, '.', '_' and
+// '-', e.g. "ab/ab12cd". Both stores keep contents as is, metadata like
+// names and content types belongs to the caller.
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNotFound     = errors.New("blob not found")
+	ErrInvalidKey   = errors.New("invalid blob key")
+	ErrSizeMismatch = errors.New("blob size mismatch")
+)
+
+// checkKey rejects keys which could escape the store or need escaping.
+func checkKey(key string) error {
+	if key == "" {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+		for _, r := range segment {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+				r == '.', r == '_', r == '-':
+			default:
+				return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+			}
+		}
+	}
+	return nil
+}
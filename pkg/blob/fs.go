@@ -0,0 +1,103 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FS keeps blobs as files under a directory.
+type FS struct {
+	dir string
+}
+
+// NewFS creates the store, making the directory if it does not exist.
+func NewFS(dir string) (*FS, error) {
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return nil, err
+	}
+	return &FS{dir: dir}, nil
+}
+
+func (store *FS) path(key string) (string, error) {
+	err := checkKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(store.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes the blob of the given size, -1 if unknown. Readers never see
+// partially written blobs.
+func (store *FS) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".put-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	n, err := io.Copy(f, r)
+	if err != nil {
+		return err
+	}
+	if size >= 0 && n != size {
+		return fmt.Errorf("%w: %d bytes instead of %d", ErrSizeMismatch, n, size)
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (store *FS) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (store *FS) Exists(ctx context.Context, key string) (bool, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Delete removes the blob, missing blobs are not an error.
+func (store *FS) Delete(ctx context.Context, key string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
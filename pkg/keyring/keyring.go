@@ -0,0 +1,282 @@
+// Package keyring encrypts personal data like phones, emails and resumes with
+// AES-GCM under named keys and computes blind indexes for equality lookups.
+//
+// A keyring file is YAML:
+//
+//	primary: "2024-01"
+//	index: <base64 of 32 bytes>
+//	keys:
+//	  "2023-06": <base64 of 32 bytes>
+//	  "2024-01": <base64 of 32 bytes>
+//
+// New values are sealed with the primary key, older keys are only kept to
+// open values sealed before rotation. The index key never rotates, otherwise
+// every index would have to be rebuilt at once.
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// prefix marks sealed values: "enc:<key id>:<base64 of nonce and ciphertext>".
+const prefix = "enc:"
+
+// keySize selects AES-256.
+const keySize = 32
+
+var (
+	ErrUnknownKey = errors.New("unknown encryption key")
+	ErrInvalidKey = errors.New("invalid encryption key")
+	ErrCorrupted  = errors.New("encrypted value is corrupted")
+)
+
+// Keyring holds data keys by ID and the blind index key.
+//
+// A nil keyring keeps values as is and uses them as their own indexes, so
+// development setups run without a keyring file.
+type Keyring struct {
+	primary string
+	index   []byte
+	keys    map[string]cipher.AEAD
+}
+
+type file struct {
+	Primary string            `yaml:"primary"`
+	Index   string            `yaml:"index"`
+	Keys    map[string]string `yaml:"keys"`
+}
+
+// Load reads the keyring file.
+func Load(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses contents of a keyring file.
+func Parse(data []byte) (*Keyring, error) {
+	var f file
+	err := yaml.Unmarshal(data, &f)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := decodeKey("index", f.Index)
+	if err != nil {
+		return nil, err
+	}
+	keyring := &Keyring{
+		primary: f.Primary,
+		index:   index,
+		keys:    make(map[string]cipher.AEAD, len(f.Keys)),
+	}
+	for id, encoded := range f.Keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("%w: id %q must be non-empty and without colons", ErrInvalidKey, id)
+		}
+		key, err := decodeKey(id, encoded)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		keyring.keys[id] = aead
+	}
+	if _, ok := keyring.keys[f.Primary]; !ok {
+		return nil, fmt.Errorf("%w: primary %q", ErrUnknownKey, f.Primary)
+	}
+	return keyring, nil
+}
+
+func decodeKey(id, encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %s", ErrInvalidKey, id, err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("%w %q: %d bytes instead of %d", ErrInvalidKey, id, len(key), keySize)
+	}
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// AddKey appends a new random key to the keyring file and makes it primary.
+// The file is created with a new index key if it does not exist.
+func AddKey(path, id string) error {
+	var f file
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		f.Index, err = randomKey()
+		if err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		err = yaml.Unmarshal(data, &f)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, ok := f.Keys[id]; ok {
+		return fmt.Errorf("%w: %q already exists", ErrInvalidKey, id)
+	}
+	if f.Keys == nil {
+		f.Keys = make(map[string]string)
+	}
+	f.Keys[id], err = randomKey()
+	if err != nil {
+		return err
+	}
+	f.Primary = id
+
+	data, err = yaml.Marshal(f)
+	if err != nil {
+		return err
+	}
+	// Make sure the result is usable before replacing a working file.
+	_, err = Parse(data)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, data, 0o600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func randomKey() (string, error) {
+	key := make([]byte, keySize)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// Primary returns ID of the key new values are sealed with.
+func (k *Keyring) Primary() string {
+	if k == nil {
+		return ""
+	}
+	return k.primary
+}
+
+// IDs returns IDs of all keys in the keyring.
+func (k *Keyring) IDs() []string {
+	if k == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Seal encrypts the value with the primary key. The field name is
+// authenticated along, so a value copied to another column fails to open.
+// Empty values stay empty.
+func (k *Keyring) Seal(field, value string) (string, error) {
+	if k == nil || value == "" {
+		return value, nil
+	}
+
+	aead := k.keys[k.primary]
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(field))
+	return prefix + k.primary + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts the value sealed with any key of the keyring. Values which
+// are not sealed are returned as is, they are left from before encryption
+// was enabled until the next rotation.
+func (k *Keyring) Open(field, value string) (string, error) {
+	id, sealed, ok := split(value)
+	if !ok {
+		return value, nil
+	}
+	if k == nil {
+		return "", fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+	aead, ok := k.keys[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < aead.NonceSize() {
+		return "", fmt.Errorf("%w: %s field", ErrCorrupted, field)
+	}
+	nonce, data := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, data, []byte(field))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s field", ErrCorrupted, field)
+	}
+	return string(plain), nil
+}
+
+// Stale reports whether the stored value should be sealed again with the
+// primary key.
+func (k *Keyring) Stale(value string) bool {
+	if k == nil || value == "" {
+		return false
+	}
+	id, _, ok := split(value)
+	return !ok || id != k.primary
+}
+
+func split(value string) (id, sealed string, ok bool) {
+	rest, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return "", "", false
+	}
+	return strings.Cut(rest, ":")
+}
+
+// Index returns the blind index of the value: equal values of the same field
+// get equal indexes which reveal nothing else without the index key. Empty
+// values get empty indexes.
+func (k *Keyring) Index(field, value string) string {
+	if k == nil || value == "" {
+		return value
+	}
+	mac := hmac.New(sha256.New, k.index)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,97 @@
+package keyring
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.yaml")
+	require.NoError(t, AddKey(path, "2023-06"))
+	old, err := Load(path)
+	require.NoError(t, err)
+	require.Exactly(t, "2023-06", old.Primary())
+
+	sealed, err := old.Seal("phone", "+79991234567")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(sealed, "enc:2023-06:"))
+	require.NotContains(t, sealed, "9991234567")
+	again, err := old.Seal("phone", "+79991234567")
+	require.NoError(t, err)
+	require.NotEqual(t, sealed, again)
+
+	opened, err := old.Open("phone", sealed)
+	require.NoError(t, err)
+	require.Exactly(t, "+79991234567", opened)
+	_, err = old.Open("email", sealed)
+	require.ErrorIs(t, err, ErrCorrupted)
+	_, err = old.Open("phone", sealed[:len(sealed)-2])
+	require.ErrorIs(t, err, ErrCorrupted)
+
+	require.NoError(t, AddKey(path, "2024-01"))
+	require.ErrorIs(t, AddKey(path, "2024-01"), ErrInvalidKey)
+	cur, err := Load(path)
+	require.NoError(t, err)
+	require.Exactly(t, []string{"2023-06", "2024-01"}, cur.IDs())
+	require.True(t, cur.Stale(sealed))
+	require.False(t, old.Stale(sealed))
+	opened, err = cur.Open("phone", sealed)
+	require.NoError(t, err)
+	require.Exactly(t, "+79991234567", opened)
+	resealed, err := cur.Seal("phone", opened)
+	require.NoError(t, err)
+	require.False(t, cur.Stale(resealed))
+	_, err = old.Open("phone", resealed)
+	require.ErrorIs(t, err, ErrUnknownKey)
+
+	// Indexes survive rotation and differ per field.
+	require.Exactly(t, old.Index("phone", "+79991234567"), cur.Index("phone", "+79991234567"))
+	require.NotEqual(t, cur.Index("phone", "a"), cur.Index("email", "a"))
+	require.Len(t, cur.Index("phone", "+79991234567"), 64)
+
+	// Values from before encryption and empty values pass through.
+	opened, err = cur.Open("phone", "+79991234567")
+	require.NoError(t, err)
+	require.Exactly(t, "+79991234567", opened)
+	require.True(t, cur.Stale("+79991234567"))
+	empty, err := cur.Seal("phone", "")
+	require.NoError(t, err)
+	require.Exactly(t, "", empty)
+	require.Exactly(t, "", cur.Index("phone", ""))
+	require.False(t, cur.Stale(""))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Exactly(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestKeyring_Nil(t *testing.T) {
+	var k *Keyring
+	sealed, err := k.Seal("email", "a@example.com")
+	require.NoError(t, err)
+	require.Exactly(t, "a@example.com", sealed)
+	require.Exactly(t, "a@example.com", k.Index("email", "a@example.com"))
+	require.False(t, k.Stale("a@example.com"))
+	_, err = k.Open("email", "enc:k1:AAAA")
+	require.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestParse(t *testing.T) {
+	test := func(data string, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			require.ErrorIs(t, err, wantErr)
+		}
+	}
+
+	key := strings.Repeat("A", 43) + "="
+	t.Run("ok", test("primary: k1\nindex: "+key+"\nkeys:\n  k1: "+key+"\n", nil))
+	t.Run("unknown primary", test("primary: k2\nindex: "+key+"\nkeys:\n  k1: "+key+"\n", ErrUnknownKey))
+	t.Run("short key", test("primary: k1\nindex: "+key+"\nkeys:\n  k1: AAAA\n", ErrInvalidKey))
+	t.Run("no index", test("primary: k1\nkeys:\n  k1: "+key+"\n", ErrInvalidKey))
+	t.Run("colon in id", test("primary: k1\nindex: "+key+"\nkeys:\n  k1: "+key+"\n  \"a:b\": "+key+"\n", ErrInvalidKey))
+}
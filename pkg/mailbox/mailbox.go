@@ -0,0 +1,239 @@
+// Package mailbox reads RFC 822 messages from .eml files, mbox files and
+// Maildir folders, the way mail is kept locally or exported from a mail
+// client. Headers, text bodies and attachments are decoded to UTF-8.
+package mailbox
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// maxDepth limits nesting of multipart bodies.
+const maxDepth = 10
+
+var (
+	ErrNoSender = errors.New("message has no sender")
+	ErrTooDeep  = errors.New("message parts are nested too deep")
+	ErrNotMbox  = errors.New("mbox must start with a From line")
+)
+
+// Message is a parsed email.
+type Message struct {
+	// ID is the Message-ID header. Messages without one get an ID made of
+	// the hash of their contents, so the same file gives the same ID.
+	ID      string
+	From    mail.Address
+	To      []mail.Address
+	Subject string
+	// Date is zero if the header is missing or malformed.
+	Date time.Time
+	// Text is the plain text body, the HTML one stripped of tags if there
+	// is no plain text.
+	Text        string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to the message.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+var decoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+// charsetReader converts text in the charset to UTF-8. Russian mail often
+// comes in koi8-r or windows-1251.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "", "utf-8", "us-ascii":
+		return input, nil
+	}
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, charset)
+	}
+	return enc.NewDecoder().Reader(input), nil
+}
+
+// Parse parses the message.
+func Parse(data []byte) (*Message, error) {
+	raw, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w reading message", err)
+	}
+
+	parser := mail.AddressParser{WordDecoder: decoder}
+	from, err := parser.ParseList(raw.Header.Get("From"))
+	if err != nil || len(from) == 0 {
+		return nil, ErrNoSender
+	}
+	msg := &Message{
+		ID:      strings.TrimSpace(raw.Header.Get("Message-Id")),
+		From:    *from[0],
+		Subject: decodeHeader(raw.Header.Get("Subject")),
+	}
+	if msg.ID == "" {
+		sum := sha256.Sum256(data)
+		msg.ID = "<" + hex.EncodeToString(sum[:]) + "@mailbox>"
+	}
+	if to, err := parser.ParseList(raw.Header.Get("To")); err == nil {
+		for _, address := range to {
+			msg.To = append(msg.To, *address)
+		}
+	}
+	if date, err := raw.Header.Date(); err == nil {
+		msg.Date = date
+	}
+
+	var html string
+	err = msg.walk(raw.Header, raw.Body, &html, 0)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Text == "" {
+		msg.Text = stripTags(html)
+	}
+	msg.Text = strings.TrimSpace(strings.ReplaceAll(msg.Text, "\r\n", "\n"))
+	return msg, nil
+}
+
+// header is either the message header or the header of its part.
+type header interface {
+	Get(string) string
+}
+
+// walk collects texts and attachments of the part.
+func (msg *Message) walk(h header, body io.Reader, html *string, depth int) error {
+	if depth > maxDepth {
+		return ErrTooDeep
+	}
+	mediaType, params, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		parts := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := parts.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("%w reading message part", err)
+			}
+			err = msg.walk(part.Header, part, html, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(transferDecoder(h.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return fmt.Errorf("%w decoding message part", err)
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(h.Get("Content-Disposition"))
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	filename = decodeHeader(filename)
+	if disposition == "attachment" || filename != "" || !strings.HasPrefix(mediaType, "text/") {
+		if len(data) == 0 {
+			return nil
+		}
+		if filename == "" {
+			filename = "attachment"
+		}
+		msg.Attachments = append(msg.Attachments, Attachment{
+			Filename:    filename,
+			ContentType: mediaType,
+			Data:        data,
+		})
+		return nil
+	}
+
+	text := decodeText(data, params["charset"])
+	switch {
+	case mediaType == "text/html" && *html == "":
+		*html = text
+	case mediaType != "text/html" && msg.Text == "":
+		msg.Text = text
+	}
+	return nil
+}
+
+func transferDecoder(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	}
+	return body
+}
+
+// decodeText converts the text to UTF-8, text in unknown charsets is kept
+// as is.
+func decodeText(data []byte, charset string) string {
+	r, err := charsetReader(charset, bytes.NewReader(data))
+	if err != nil {
+		return string(data)
+	}
+	text, err := io.ReadAll(r)
+	if err != nil {
+		return string(data)
+	}
+	return string(text)
+}
+
+// decodeHeader decodes RFC 2047 encoded words, malformed ones are kept as
+// is.
+func decodeHeader(value string) string {
+	decoded, err := decoder.DecodeHeader(value)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(decoded)
+}
+
+var (
+	htmlSkipped = regexp.MustCompile(`(?is)<(script|style)[^>]*>.*?</(script|style)>`)
+	htmlBreaks  = regexp.MustCompile(`(?i)<(br|/p|/div|/li|/tr|/h[1-6])[^>]*>`)
+	htmlTags    = regexp.MustCompile(`<[^>]*>`)
+	blankLines  = regexp.MustCompile(`\n\s*\n\s*\n+`)
+	htmlEntity  = strings.NewReplacer(
+		"&nbsp;", " ",
+		"&lt;", "<",
+		"&gt;", ">",
+		"&quot;", `"`,
+		"&#39;", "'",
+		"&amp;", "&",
+	)
+)
+
+// stripTags turns HTML body into text keeping paragraphs apart.
+func stripTags(html string) string {
+	text := htmlSkipped.ReplaceAllString(html, "")
+	text = htmlBreaks.ReplaceAllString(text, "\n")
+	text = htmlTags.ReplaceAllString(text, "")
+	text = htmlEntity.Replace(text)
+	return blankLines.ReplaceAllString(text, "\n\n")
+}
@@ -0,0 +1,167 @@
+package mailbox
+
+import (
+	"bytes"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const application = "From: =?utf-8?b?0JjQstCw0L0g0JjQstCw0L3QvtCy?= <Ivan@Example.com>\r\n" +
+	"To: hr@example.com\r\n" +
+	"Subject: =?koi8-r?b?R28g0sHa0sHCz9Teycs=?=\r\n" +
+	"Date: Mon, 01 Jan 2024 10:00:00 +0300\r\n" +
+	"Message-ID: <1@example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"outer\"\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"inner\"\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=windows-1251\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"=C7=E4=F0=E0=E2=F1=F2=E2=F3=E9=F2=E5, =EE=F2=EA=EB=E8=EA =ED=E0 =E2=E0=EA=\r\n" +
+	"=E0=ED=F1=E8=FE.\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>HTML</p>\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: text/plain; name=\"=?utf-8?b?0YDQtdC30Y7QvNC1LnR4dA==?=\"\r\n" +
+	"Content-Disposition: attachment\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"0JjQstCw0L0g0JjQstCw0L3QvtCyCkdvLCBQb3N0Z3JlU1FMCis3IDk5OSAx\r\n" +
+	"MjMtNDUtNjc=\r\n" +
+	"--outer--\r\n"
+
+func TestParse(t *testing.T) {
+	msg, err := Parse([]byte(application))
+	require.NoError(t, err)
+	require.Equal(t, "<1@example.com>", msg.ID)
+	require.Equal(t, "Иван Иванов", msg.From.Name)
+	require.Equal(t, "Ivan@Example.com", msg.From.Address)
+	require.Equal(t, "hr@example.com", msg.To[0].Address)
+	require.Equal(t, "Go разработчик", msg.Subject)
+	require.True(t, msg.Date.Equal(time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)))
+	require.Equal(t, "Здравствуйте, отклик на вакансию.", msg.Text)
+	require.Equal(t, []Attachment{{
+		Filename:    "резюме.txt",
+		ContentType: "text/plain",
+		Data:        []byte("Иван Иванов\nGo, PostgreSQL\n+7 999 123-45-67"),
+	}}, msg.Attachments)
+}
+
+func TestParse_HTML(t *testing.T) {
+	raw := "From: ivan@example.com\n" +
+		"Subject: Hello\n" +
+		"Content-Type: text/html\n" +
+		"\n" +
+		"<html><style>p {}</style><body><p>Hello&nbsp;there</p><p>Ivan</p></body></html>\n"
+	msg, err := Parse([]byte(raw))
+	require.NoError(t, err)
+	require.Equal(t, "Hello there\nIvan", msg.Text)
+	require.True(t, strings.HasSuffix(msg.ID, "@mailbox>"))
+
+	again, err := Parse([]byte(raw))
+	require.NoError(t, err)
+	require.Equal(t, msg.ID, again.ID, "messages without Message-ID get stable ids")
+
+	_, err = Parse([]byte("Subject: Hello\n\nNo sender\n"))
+	require.ErrorIs(t, err, ErrNoSender)
+}
+
+func TestWrite(t *testing.T) {
+	msg := &Message{
+		ID:      "<1@example.com>",
+		From:    mail.Address{Name: "Отдел кадров", Address: "hr@example.com"},
+		To:      []mail.Address{{Name: "Иван Иванов", Address: "ivan@example.com"}},
+		Subject: "Собеседование: Go разработчик",
+		Date:    time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		Text:    "Здравствуйте!\n\n" + strings.TrimSpace(strings.Repeat("Очень длинная строка. ", 10)),
+	}
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, msg))
+	require.NotContains(t, buf.String(), "Собеседование", "headers must be encoded")
+
+	parsed, err := Parse(buf.Bytes())
+	require.NoError(t, err)
+	require.True(t, msg.Date.Equal(parsed.Date))
+	parsed.Date = msg.Date
+	require.Equal(t, msg, parsed)
+
+	msg.Attachments = []Attachment{
+		{Filename: "приглашение.ics", ContentType: "text/calendar; method=REQUEST", Data: []byte("BEGIN:VCALENDAR\r\n")},
+		{Filename: "offer.pdf", ContentType: "application/pdf", Data: bytes.Repeat([]byte{0, 1, 2, 0xff}, 100)},
+	}
+	buf.Reset()
+	require.NoError(t, Write(&buf, msg))
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		require.LessOrEqual(t, len(line), 998)
+	}
+
+	parsed, err = Parse(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, msg.Text, parsed.Text)
+	require.Len(t, parsed.Attachments, 2)
+	require.Equal(t, "приглашение.ics", parsed.Attachments[0].Filename)
+	require.Equal(t, "text/calendar", parsed.Attachments[0].ContentType)
+	require.Equal(t, msg.Attachments[0].Data, parsed.Attachments[0].Data)
+	require.Equal(t, msg.Attachments[1].Data, parsed.Attachments[1].Data)
+}
+
+func TestMbox(t *testing.T) {
+	mbox := "From ivan@example.com Mon Jan  1 10:00:00 2024\n" +
+		"From: ivan@example.com\n" +
+		"Message-ID: <1@example.com>\n" +
+		"\n" +
+		">From the start\n" +
+		"\n" +
+		"From petr@example.com Mon Jan  1 11:00:00 2024\n" +
+		"From: petr@example.com\n" +
+		"Message-ID: <2@example.com>\n" +
+		"\n" +
+		"Second\n"
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "inbox.mbox")
+	require.NoError(t, os.WriteFile(path, []byte(mbox), 0o600))
+
+	messages, err := ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+
+	first, err := Parse(messages[0])
+	require.NoError(t, err)
+	require.Equal(t, "<1@example.com>", first.ID)
+	require.Equal(t, "From the start", first.Text)
+	second, err := Parse(messages[1])
+	require.NoError(t, err)
+	require.Equal(t, "petr@example.com", second.From.Address)
+	require.Equal(t, "Second", second.Text)
+}
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.eml", "a.EML", "notes.txt", "Maildir/new/1700000000.1.host", "Maildir/tmp/1"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte("From: a@b.c\n\n"), 0o600))
+	}
+
+	files, err := Files(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "Maildir/new/1700000000.1.host"),
+		filepath.Join(dir, "a.EML"),
+		filepath.Join(dir, "b.eml"),
+	}, files)
+}
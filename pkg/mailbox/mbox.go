@@ -0,0 +1,127 @@
+package mailbox
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var mboxFrom = []byte("From ")
+
+// IsMbox tells whether the file starts like an mbox, with the "From " line
+// of its first message.
+func IsMbox(head []byte) bool {
+	return bytes.HasPrefix(head, mboxFrom)
+}
+
+// Mbox reads messages of an mbox file one after another.
+type Mbox struct {
+	r       *bufio.Reader
+	started bool
+	done    bool
+}
+
+// NewMbox creates the reader of the mbox.
+func NewMbox(r io.Reader) *Mbox {
+	return &Mbox{r: bufio.NewReader(r)}
+}
+
+// Next returns the next message, io.EOF after the last one. Lines quoted as
+// ">From " in the mbox are given back unquoted.
+func (m *Mbox) Next() ([]byte, error) {
+	var msg bytes.Buffer
+	blank := false
+	for !m.done {
+		line, err := m.r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			m.done = true
+		} else if err != nil {
+			return nil, fmt.Errorf("%w reading mbox", err)
+		}
+
+		if bytes.HasPrefix(line, mboxFrom) && (!m.started || blank) {
+			if m.started {
+				return trimSeparator(msg.Bytes()), nil
+			}
+			m.started = true
+			continue
+		}
+		if !m.started {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			return nil, ErrNotMbox
+		}
+
+		blank = len(bytes.TrimRight(line, "\r\n")) == 0
+		unquoted := bytes.TrimLeft(line, ">")
+		if len(unquoted) < len(line) && bytes.HasPrefix(unquoted, mboxFrom) {
+			line = line[1:]
+		}
+		msg.Write(line)
+	}
+	if msg.Len() == 0 {
+		return nil, io.EOF
+	}
+	return trimSeparator(msg.Bytes()), nil
+}
+
+// trimSeparator drops the blank line mbox puts between messages.
+func trimSeparator(msg []byte) []byte {
+	msg = bytes.TrimSuffix(msg, []byte("\n"))
+	msg = bytes.TrimSuffix(msg, []byte("\r"))
+	return append(msg, '\n')
+}
+
+// Files returns message files of the directory in name order: .eml files
+// and files of Maildir cur and new folders, which have no extension.
+func Files(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		folder := filepath.Base(filepath.Dir(path))
+		if strings.EqualFold(filepath.Ext(path), ".eml") || folder == "cur" || folder == "new" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReadFile returns messages of the file, one for an .eml file and all of
+// them for an mbox.
+func ReadFile(path string) ([][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !IsMbox(data) {
+		return [][]byte{data}, nil
+	}
+
+	var messages [][]byte
+	mbox := NewMbox(bytes.NewReader(data))
+	for {
+		msg, err := mbox.Next()
+		if errors.Is(err, io.EOF) {
+			return messages, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+}
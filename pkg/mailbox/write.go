@@ -0,0 +1,118 @@
+package mailbox
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// base64Line is the length of base64 lines, RFC 2045 limits them to 76.
+const base64Line = 76
+
+// Write writes the message in RFC 822 form. The text goes as UTF-8 encoded
+// quoted-printable, attachments in base64. The date is the current time if
+// zero.
+func Write(w io.Writer, msg *Message) error {
+	bw := bufio.NewWriter(w)
+	header := func(name, value string) {
+		bw.WriteString(name + ": " + value + "\r\n")
+	}
+
+	date := msg.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	to := make([]string, len(msg.To))
+	for i := range msg.To {
+		to[i] = msg.To[i].String()
+	}
+
+	header("From", msg.From.String())
+	header("To", strings.Join(to, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", date.Format(time.RFC1123Z))
+	if msg.ID != "" {
+		header("Message-ID", msg.ID)
+	}
+	header("MIME-Version", "1.0")
+
+	if len(msg.Attachments) == 0 {
+		header("Content-Type", "text/plain; charset=utf-8")
+		header("Content-Transfer-Encoding", "quoted-printable")
+		bw.WriteString("\r\n")
+		err := writeText(bw, msg.Text)
+		if err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+
+	parts := multipart.NewWriter(bw)
+	header("Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": parts.Boundary()}))
+	bw.WriteString("\r\n")
+
+	part, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err == nil {
+		err = writeText(part, msg.Text)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range msg.Attachments {
+		mediaType, params, err := mime.ParseMediaType(attachment.ContentType)
+		if err != nil {
+			mediaType, params = "application/octet-stream", map[string]string{}
+		}
+		params["name"] = attachment.Filename
+		part, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(mediaType, params)},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return err
+		}
+		err = writeBase64(part, attachment.Data)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = parts.Close()
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func writeText(w io.Writer, text string) error {
+	qp := quotedprintable.NewWriter(w)
+	_, err := qp.Write([]byte(strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n")))
+	if err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		n := min(base64Line, len(encoded))
+		_, err := io.WriteString(w, encoded[:n]+"\r\n")
+		if err != nil {
+			return err
+		}
+		encoded = encoded[n:]
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Package ratelimit limits the rate of events per key, e.g. requests per
+// client address, with token buckets kept in memory.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter allows up to burst events at once per key and refills at the
+// given rate. Keys idle long enough to refill are forgotten.
+type Limiter struct {
+	every time.Duration
+	burst int
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	pruned  time.Time
+
+	now func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// New creates limiter allowing n events per period with bursts of n.
+func New(n int, period time.Duration) *Limiter {
+	if n < 1 {
+		n = 1
+	}
+	return &Limiter{
+		every:   period / time.Duration(n),
+		burst:   n,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token of the key. It returns false and the time to wait
+// for the next token if there is none.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+	b.refill(now, l.every, l.burst)
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) * float64(l.every))
+	}
+	b.tokens--
+	return true, 0
+}
+
+func (b *bucket) refill(now time.Time, every time.Duration, burst int) {
+	if every > 0 {
+		b.tokens += float64(now.Sub(b.last)) / float64(every)
+	} else {
+		b.tokens = float64(burst)
+	}
+	if b.tokens > float64(burst) {
+		b.tokens = float64(burst)
+	}
+	b.last = now
+}
+
+// prune forgets full buckets once per refill period of the whole bucket.
+func (l *Limiter) prune(now time.Time) {
+	full := l.every * time.Duration(l.burst)
+	if now.Sub(l.pruned) < full {
+		return
+	}
+	l.pruned = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= full {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := New(3, time.Hour)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow("a")
+		require.True(t, ok)
+	}
+	ok, wait := l.Allow("a")
+	require.False(t, ok)
+	require.Equal(t, 20*time.Minute, wait)
+
+	ok, _ = l.Allow("b")
+	require.True(t, ok, "keys have own buckets")
+
+	now = now.Add(10 * time.Minute)
+	ok, wait = l.Allow("a")
+	require.False(t, ok)
+	require.Equal(t, 10*time.Minute, wait)
+
+	now = now.Add(10 * time.Minute)
+	ok, _ = l.Allow("a")
+	require.True(t, ok)
+	ok, _ = l.Allow("a")
+	require.False(t, ok)
+
+	now = now.Add(2 * time.Hour)
+	ok, _ = l.Allow("c")
+	require.True(t, ok)
+	require.Len(t, l.buckets, 1, "idle keys are forgotten")
+}
@@ -0,0 +1,165 @@
+// Package smtptest runs a local SMTP server for tests, the way httptest does
+// for HTTP. The server accepts any sender and recipients without
+// authentication and keeps the messages in memory.
+package smtptest
+
+import (
+	"bytes"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// Message is a message the server received.
+type Message struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// Server is a fake SMTP server listening on a loopback address.
+type Server struct {
+	// Addr is host:port the server listens on.
+	Addr string
+
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	messages []Message
+	fail     int
+}
+
+// NewServer starts the server, Close it at the end of the test.
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("smtptest: failed to listen: " + err.Error())
+	}
+	s := &Server{Addr: listener.Addr().String(), listener: listener}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// Fail makes the server reject the next n messages with a temporary error.
+func (s *Server) Fail(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fail = n
+}
+
+// Messages returns the messages received so far.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// Close stops the server and waits for open sessions to end.
+func (s *Server) Close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.session(textproto.NewConn(conn))
+		}()
+	}
+}
+
+func (s *Server) session(conn *textproto.Conn) {
+	reply := func(code int, text string) bool {
+		return conn.PrintfLine("%d %s", code, text) == nil
+	}
+	if !reply(220, "smtptest ready") {
+		return
+	}
+
+	var msg Message
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		ok := true
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			ok = conn.PrintfLine("250-smtptest") == nil && reply(250, "8BITMIME")
+		case "HELO", "NOOP":
+			ok = reply(250, "OK")
+		case "RSET":
+			msg = Message{}
+			ok = reply(250, "OK")
+		case "MAIL":
+			msg = Message{From: address(arg)}
+			ok = reply(250, "OK")
+		case "RCPT":
+			if msg.From == "" {
+				ok = reply(503, "need MAIL first")
+				break
+			}
+			msg.To = append(msg.To, address(arg))
+			ok = reply(250, "OK")
+		case "DATA":
+			if len(msg.To) == 0 {
+				ok = reply(503, "need RCPT first")
+				break
+			}
+			if !reply(354, "end data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			msg.Data, err = conn.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			if s.received(msg) {
+				ok = reply(250, "OK")
+			} else {
+				ok = reply(451, "try again later")
+			}
+			msg = Message{}
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			ok = reply(502, "command not implemented")
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// received keeps the message unless the server has to fail it.
+func (s *Server) received(msg Message) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail > 0 {
+		s.fail--
+		return false
+	}
+	// ReadDotBytes turns line endings into LF, the wire form has CRLF.
+	msg.Data = bytes.ReplaceAll(msg.Data, []byte("\n"), []byte("\r\n"))
+	s.messages = append(s.messages, msg)
+	return true
+}
+
+// address takes the address out of "FROM:<a@b>" and "TO:<a@b>" arguments.
+func address(arg string) string {
+	_, path, _ := strings.Cut(arg, ":")
+	path, _, _ = strings.Cut(strings.TrimSpace(path), " ")
+	return strings.TrimSuffix(strings.TrimPrefix(path, "<"), ">")
+}
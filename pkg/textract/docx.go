@@ -0,0 +1,92 @@
+package textract
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+const docxBody = "word/document.xml"
+
+var ErrNoDocumentBody = errors.New("docx has no document body")
+
+func isDOCX(data []byte) bool {
+	if !bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return false
+	}
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return false
+	}
+	for _, f := range r.File {
+		if f.Name == docxBody {
+			return true
+		}
+	}
+	return false
+}
+
+// DOCX returns text of the Office Open XML document body, one paragraph per
+// line. Paragraphs of table cells follow each other row by row.
+func DOCX(data []byte) (string, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+
+	var body *zip.File
+	for _, f := range r.File {
+		if f.Name == docxBody {
+			body = f
+		}
+	}
+	if body == nil {
+		return "", ErrNoDocumentBody
+	}
+
+	rc, err := body.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	var b strings.Builder
+	dec := xml.NewDecoder(io.LimitReader(rc, maxStream))
+	inText := false
+	for {
+		token, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				b.WriteByte('\t')
+			case "br", "cr":
+				b.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				b.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				b.Write(t)
+			}
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,567 @@
+package textract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Limits protecting from hostile documents.
+const (
+	maxStream = 32 << 20
+	maxDepth  = 32
+)
+
+var (
+	ErrEncrypted = errors.New("pdf is encrypted")
+	ErrNoPages   = errors.New("pdf has no pages")
+	errFilter    = errors.New("unsupported pdf stream filter")
+)
+
+// PDF values: nil, bool, float64, name, []byte strings, array, dict, ref,
+// *stream, and operator in content streams.
+type (
+	name     string
+	operator string
+	array    []interface{}
+	dict     map[name]interface{}
+	ref      struct{ num, gen int }
+	stream   struct {
+		dict dict
+		raw  []byte
+	}
+)
+
+// document is a parsed PDF. Objects are found by scanning the file rather
+// than by the cross-reference table, which is often broken.
+type document struct {
+	objects map[int]interface{}
+	// order keeps object numbers in file order.
+	order []int
+}
+
+var objectHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+func parseDocument(data []byte) (*document, error) {
+	doc := &document{objects: make(map[int]interface{})}
+	var streams []*stream
+
+	for pos := 0; pos < len(data); {
+		loc := objectHeader.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+		num, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+		lex := &lexer{data: data, pos: pos + loc[1]}
+		v, err := lex.object()
+		if err != nil {
+			pos += loc[1]
+			continue
+		}
+		if d, ok := v.(dict); ok && lex.keyword("stream") {
+			s := &stream{dict: d, raw: lex.streamData(d)}
+			if d["Type"] == name("ObjStm") {
+				streams = append(streams, s)
+			}
+			v = s
+		}
+		if _, ok := doc.objects[num]; !ok {
+			doc.order = append(doc.order, num)
+		}
+		doc.objects[num] = v
+		pos = lex.pos
+	}
+
+	for _, s := range streams {
+		doc.unpackObjects(s)
+	}
+	// The key is in the trailer, which is not an object.
+	if bytes.Contains(data, []byte("/Encrypt")) {
+		return nil, ErrEncrypted
+	}
+	return doc, nil
+}
+
+// unpackObjects adds objects compressed in the object stream unless they
+// are defined directly.
+func (doc *document) unpackObjects(s *stream) {
+	data, err := doc.decode(s)
+	if err != nil {
+		return
+	}
+	n, _ := doc.resolve(s.dict["N"]).(float64)
+	first, _ := doc.resolve(s.dict["First"]).(float64)
+	if first < 0 || int(first) > len(data) {
+		return
+	}
+
+	header := &lexer{data: data[:int(first)]}
+	for i := 0; i < int(n); i++ {
+		num, err1 := header.object()
+		offset, err2 := header.object()
+		if err1 != nil || err2 != nil {
+			return
+		}
+		numV, ok1 := num.(float64)
+		offsetV, ok2 := offset.(float64)
+		if !ok1 || !ok2 || int(first)+int(offsetV) >= len(data) || offsetV < 0 {
+			return
+		}
+		if _, ok := doc.objects[int(numV)]; ok {
+			continue
+		}
+		lex := &lexer{data: data, pos: int(first) + int(offsetV)}
+		v, err := lex.object()
+		if err != nil {
+			continue
+		}
+		doc.objects[int(numV)] = v
+		doc.order = append(doc.order, int(numV))
+	}
+}
+
+// resolve follows references.
+func (doc *document) resolve(v interface{}) interface{} {
+	for i := 0; i < maxDepth; i++ {
+		r, ok := v.(ref)
+		if !ok {
+			return v
+		}
+		v = doc.objects[r.num]
+	}
+	return nil
+}
+
+func (doc *document) dict(v interface{}) dict {
+	switch v := doc.resolve(v).(type) {
+	case dict:
+		return v
+	case *stream:
+		return v.dict
+	}
+	return nil
+}
+
+func (doc *document) array(v interface{}) array {
+	a, _ := doc.resolve(v).(array)
+	return a
+}
+
+func (doc *document) number(v interface{}, def float64) float64 {
+	if f, ok := doc.resolve(v).(float64); ok {
+		return f
+	}
+	return def
+}
+
+// decode returns decoded data of the stream.
+func (doc *document) decode(s *stream) ([]byte, error) {
+	var filters []interface{}
+	switch f := doc.resolve(s.dict["Filter"]).(type) {
+	case name:
+		filters = []interface{}{f}
+	case array:
+		filters = f
+	}
+
+	data := s.raw
+	for _, f := range filters {
+		switch doc.resolve(f) {
+		case name("FlateDecode"), name("Fl"):
+			r, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, err
+			}
+			// Truncated streams are common, keep what was decoded.
+			data, err = io.ReadAll(io.LimitReader(r, maxStream))
+			if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%w: %v", errFilter, f)
+		}
+	}
+	return data, nil
+}
+
+// pages returns page dictionaries in order, each with its inherited
+// resources.
+func (doc *document) pages() []dict {
+	var pages []dict
+	visited := make(map[interface{}]bool)
+	var walk func(node interface{}, resources interface{}, depth int)
+	walk = func(node interface{}, resources interface{}, depth int) {
+		if r, ok := node.(ref); ok {
+			if visited[r] {
+				return
+			}
+			visited[r] = true
+		}
+		d := doc.dict(node)
+		if d == nil || depth > maxDepth {
+			return
+		}
+		if d["Resources"] != nil {
+			resources = d["Resources"]
+		}
+		if d["Type"] == name("Page") || d["Kids"] == nil && d["Contents"] != nil {
+			page := make(dict, len(d)+1)
+			for k, v := range d {
+				page[k] = v
+			}
+			page["Resources"] = resources
+			pages = append(pages, page)
+			return
+		}
+		for _, kid := range doc.array(d["Kids"]) {
+			walk(kid, resources, depth+1)
+		}
+	}
+
+	for _, num := range doc.order {
+		d := doc.dict(doc.objects[num])
+		if d["Type"] == name("Catalog") {
+			walk(d["Pages"], nil, 0)
+			if len(pages) > 0 {
+				return pages
+			}
+		}
+	}
+
+	// No usable catalog, take pages in order of their object numbers.
+	nums := append([]int(nil), doc.order...)
+	sort.Ints(nums)
+	for _, num := range nums {
+		if doc.dict(doc.objects[num])["Type"] == name("Page") {
+			walk(ref{num: num}, nil, 0)
+		}
+	}
+	return pages
+}
+
+// lexer reads PDF tokens and objects.
+type lexer struct {
+	data []byte
+	pos  int
+}
+
+var errSyntax = errors.New("pdf syntax error")
+
+func isSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func (lex *lexer) skipSpace() {
+	for lex.pos < len(lex.data) {
+		c := lex.data[lex.pos]
+		switch {
+		case isSpace(c):
+			lex.pos++
+		case c == '%':
+			for lex.pos < len(lex.data) && lex.data[lex.pos] != '\n' && lex.data[lex.pos] != '\r' {
+				lex.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// keyword consumes the keyword if it comes next.
+func (lex *lexer) keyword(kw string) bool {
+	lex.skipSpace()
+	end := lex.pos + len(kw)
+	if end > len(lex.data) || string(lex.data[lex.pos:end]) != kw {
+		return false
+	}
+	if end < len(lex.data) && !isSpace(lex.data[end]) && !isDelimiter(lex.data[end]) {
+		return false
+	}
+	lex.pos = end
+	return true
+}
+
+// streamData reads stream data following the stream keyword.
+func (lex *lexer) streamData(d dict) []byte {
+	if lex.pos < len(lex.data) && lex.data[lex.pos] == '\r' {
+		lex.pos++
+	}
+	if lex.pos < len(lex.data) && lex.data[lex.pos] == '\n' {
+		lex.pos++
+	}
+	start := lex.pos
+
+	if n, ok := d["Length"].(float64); ok && n >= 0 && start+int(n) <= len(lex.data) {
+		end := start + int(n)
+		check := &lexer{data: lex.data, pos: end}
+		if check.keyword("endstream") {
+			lex.pos = check.pos
+			return lex.data[start:end]
+		}
+	}
+
+	i := bytes.Index(lex.data[start:], []byte("endstream"))
+	if i < 0 {
+		lex.pos = len(lex.data)
+		return lex.data[start:]
+	}
+	lex.pos = start + i + len("endstream")
+	return bytes.TrimRight(lex.data[start:start+i], "\r\n")
+}
+
+// token returns the next token: a value, a delimiter like "[" or "<<" as
+// operator, or an operator.
+func (lex *lexer) token() (interface{}, error) {
+	lex.skipSpace()
+	if lex.pos >= len(lex.data) {
+		return nil, io.EOF
+	}
+
+	c := lex.data[lex.pos]
+	switch {
+	case c == '/':
+		return lex.name(), nil
+	case c == '(':
+		return lex.literal(), nil
+	case c == '<':
+		if lex.pos+1 < len(lex.data) && lex.data[lex.pos+1] == '<' {
+			lex.pos += 2
+			return operator("<<"), nil
+		}
+		return lex.hex(), nil
+	case c == '>':
+		if lex.pos+1 < len(lex.data) && lex.data[lex.pos+1] == '>' {
+			lex.pos += 2
+			return operator(">>"), nil
+		}
+		lex.pos++
+		return nil, errSyntax
+	case c == '[' || c == ']' || c == '{' || c == '}':
+		lex.pos++
+		return operator(c), nil
+	case c == '+' || c == '-' || c == '.' || c >= '0' && c <= '9':
+		start := lex.pos
+		lex.pos++
+		for lex.pos < len(lex.data) {
+			c := lex.data[lex.pos]
+			if c != '.' && (c < '0' || c > '9') {
+				break
+			}
+			lex.pos++
+		}
+		f, err := strconv.ParseFloat(string(lex.data[start:lex.pos]), 64)
+		if err != nil {
+			return float64(0), nil
+		}
+		return f, nil
+	}
+
+	start := lex.pos
+	for lex.pos < len(lex.data) && !isSpace(lex.data[lex.pos]) && !isDelimiter(lex.data[lex.pos]) {
+		lex.pos++
+	}
+	if start == lex.pos {
+		lex.pos++
+		return nil, errSyntax
+	}
+	switch v := string(lex.data[start:lex.pos]); v {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	default:
+		return operator(v), nil
+	}
+}
+
+// object reads the next object, resolving compound values and references.
+func (lex *lexer) object() (interface{}, error) {
+	return lex.objectDepth(0)
+}
+
+func (lex *lexer) objectDepth(depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, errSyntax
+	}
+	token, err := lex.token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case operator("["):
+		var a array
+		for {
+			lex.skipSpace()
+			if lex.pos < len(lex.data) && lex.data[lex.pos] == ']' {
+				lex.pos++
+				return a, nil
+			}
+			v, err := lex.objectDepth(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+	case operator("<<"):
+		d := make(dict)
+		for {
+			lex.skipSpace()
+			if lex.pos+1 < len(lex.data) && lex.data[lex.pos] == '>' && lex.data[lex.pos+1] == '>' {
+				lex.pos += 2
+				return d, nil
+			}
+			key, err := lex.token()
+			if err != nil {
+				return nil, err
+			}
+			k, ok := key.(name)
+			if !ok {
+				return nil, errSyntax
+			}
+			v, err := lex.objectDepth(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			d[k] = v
+		}
+	}
+
+	// A number may start a reference "num gen R".
+	if num, ok := token.(float64); ok {
+		save := lex.pos
+		gen, err := lex.token()
+		if g, ok := gen.(float64); ok && err == nil && lex.keyword("R") {
+			return ref{num: int(num), gen: int(g)}, nil
+		}
+		lex.pos = save
+	}
+	return token, nil
+}
+
+func (lex *lexer) name() name {
+	lex.pos++
+	var b []byte
+	for lex.pos < len(lex.data) {
+		c := lex.data[lex.pos]
+		if isSpace(c) || isDelimiter(c) {
+			break
+		}
+		if c == '#' && lex.pos+2 < len(lex.data) {
+			if v, err := strconv.ParseUint(string(lex.data[lex.pos+1:lex.pos+3]), 16, 8); err == nil {
+				b = append(b, byte(v))
+				lex.pos += 3
+				continue
+			}
+		}
+		b = append(b, c)
+		lex.pos++
+	}
+	return name(b)
+}
+
+func (lex *lexer) literal() []byte {
+	lex.pos++
+	var b []byte
+	depth := 1
+	for lex.pos < len(lex.data) {
+		c := lex.data[lex.pos]
+		lex.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return b
+			}
+		case '\\':
+			if lex.pos >= len(lex.data) {
+				return b
+			}
+			c = lex.data[lex.pos]
+			lex.pos++
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if lex.pos < len(lex.data) && lex.data[lex.pos] == '\n' {
+					lex.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					v := int(c - '0')
+					for i := 0; i < 2 && lex.pos < len(lex.data); i++ {
+						d := lex.data[lex.pos]
+						if d < '0' || d > '7' {
+							break
+						}
+						v = v*8 + int(d-'0')
+						lex.pos++
+					}
+					c = byte(v)
+				}
+			}
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+func (lex *lexer) hex() []byte {
+	lex.pos++
+	var b []byte
+	var digits []byte
+	for lex.pos < len(lex.data) {
+		c := lex.data[lex.pos]
+		lex.pos++
+		if c == '>' {
+			break
+		}
+		if isSpace(c) {
+			continue
+		}
+		digits = append(digits, c)
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	for i := 0; i < len(digits); i += 2 {
+		v, err := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+		if err != nil {
+			continue
+		}
+		b = append(b, byte(v))
+	}
+	return b
+}
@@ -0,0 +1,705 @@
+package textract
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// PDF returns text of the document, pages are separated by blank lines.
+// Only unencrypted documents with Flate compressed or plain streams are
+// supported, which covers what office suites and resume builders produce.
+func PDF(data []byte) (string, error) {
+	doc, err := parseDocument(data)
+	if err != nil {
+		return "", err
+	}
+	pages := doc.pages()
+	if len(pages) == 0 {
+		return "", ErrNoPages
+	}
+
+	texts := make([]string, 0, len(pages))
+	for _, page := range pages {
+		ext := &extractor{doc: doc, fonts: make(map[interface{}]*font)}
+		var content []byte
+		contents := doc.resolve(page["Contents"])
+		if a, ok := contents.(array); ok {
+			for _, c := range a {
+				if s, ok := doc.resolve(c).(*stream); ok {
+					data, err := doc.decode(s)
+					if err == nil {
+						content = append(content, data...)
+						content = append(content, '\n')
+					}
+				}
+			}
+		} else if s, ok := contents.(*stream); ok {
+			content, _ = doc.decode(s)
+		}
+		ext.run(content, doc.dict(page["Resources"]), identity, 0)
+		if text := cleanLines(ext.text.String()); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, "\n\n"), nil
+}
+
+// cleanLines trims spaces around lines and drops empty lines.
+func cleanLines(text string) string {
+	lines := strings.Split(text, "\n")
+	result := lines[:0]
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+// matrix is a PDF transformation matrix [a b c d e f].
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+func translate(x, y float64) matrix {
+	return matrix{1, 0, 0, 1, x, y}
+}
+
+// graphicsState holds the parts of the graphics state affecting text.
+type graphicsState struct {
+	ctm     matrix
+	font    *font
+	size    float64
+	charSp  float64
+	wordSp  float64
+	scale   float64
+	leading float64
+}
+
+// extractor interprets content streams collecting shown text.
+type extractor struct {
+	doc   *document
+	fonts map[interface{}]*font
+	text  strings.Builder
+
+	// Position of the end of the previous text and its font size in device
+	// space.
+	shown      bool
+	lastX      float64
+	lastY      float64
+	lastSize   float64
+	tm, tlm    matrix
+	state      graphicsState
+	stateStack []graphicsState
+}
+
+var errInlineImage = errors.New("pdf inline image is not terminated")
+
+func (ext *extractor) run(content []byte, resources dict, ctm matrix, depth int) {
+	if depth > maxDepth {
+		return
+	}
+	saved, savedStack := ext.state, ext.stateStack
+	ext.state = graphicsState{ctm: ctm, scale: 1, font: saved.font, size: saved.size}
+	ext.stateStack = nil
+	defer func() { ext.state, ext.stateStack = saved, savedStack }()
+
+	lex := &lexer{data: content}
+	var operands []interface{}
+	for {
+		v, err := lex.object()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			operands = operands[:0]
+			continue
+		}
+		op, ok := v.(operator)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+		if op == "BI" {
+			if ext.skipInlineImage(lex) != nil {
+				return
+			}
+		} else {
+			ext.apply(op, operands, resources, depth)
+		}
+		operands = operands[:0]
+	}
+}
+
+// skipInlineImage moves past the image data of BI ... ID data EI.
+func (ext *extractor) skipInlineImage(lex *lexer) error {
+	for {
+		v, err := lex.token()
+		if err != nil {
+			return err
+		}
+		if v == operator("ID") {
+			break
+		}
+	}
+	for i := lex.pos; i+2 <= len(lex.data); i++ {
+		if lex.data[i] == 'E' && lex.data[i+1] == 'I' && (i == 0 || isSpace(lex.data[i-1])) &&
+			(i+2 == len(lex.data) || isSpace(lex.data[i+2])) {
+			lex.pos = i + 2
+			return nil
+		}
+	}
+	return errInlineImage
+}
+
+func numbers(operands []interface{}, n int) ([]float64, bool) {
+	if len(operands) < n {
+		return nil, false
+	}
+	result := make([]float64, n)
+	for i, v := range operands[len(operands)-n:] {
+		f, ok := v.(float64)
+		if !ok {
+			return nil, false
+		}
+		result[i] = f
+	}
+	return result, true
+}
+
+func (ext *extractor) apply(op operator, operands []interface{}, resources dict, depth int) {
+	st := &ext.state
+	switch op {
+	case "q":
+		ext.stateStack = append(ext.stateStack, *st)
+	case "Q":
+		if n := len(ext.stateStack); n > 0 {
+			*st = ext.stateStack[n-1]
+			ext.stateStack = ext.stateStack[:n-1]
+		}
+	case "cm":
+		if v, ok := numbers(operands, 6); ok {
+			st.ctm = matrix{v[0], v[1], v[2], v[3], v[4], v[5]}.mul(st.ctm)
+		}
+	case "BT":
+		ext.tm, ext.tlm = identity, identity
+	case "Tf":
+		if len(operands) >= 2 {
+			fontName, _ := operands[len(operands)-2].(name)
+			size, _ := operands[len(operands)-1].(float64)
+			st.size = size
+			st.font = ext.font(resources, fontName)
+		}
+	case "Tc":
+		if v, ok := numbers(operands, 1); ok {
+			st.charSp = v[0]
+		}
+	case "Tw":
+		if v, ok := numbers(operands, 1); ok {
+			st.wordSp = v[0]
+		}
+	case "Tz":
+		if v, ok := numbers(operands, 1); ok {
+			st.scale = v[0] / 100
+		}
+	case "TL":
+		if v, ok := numbers(operands, 1); ok {
+			st.leading = v[0]
+		}
+	case "Td":
+		if v, ok := numbers(operands, 2); ok {
+			ext.moveLine(v[0], v[1])
+		}
+	case "TD":
+		if v, ok := numbers(operands, 2); ok {
+			st.leading = -v[1]
+			ext.moveLine(v[0], v[1])
+		}
+	case "Tm":
+		if v, ok := numbers(operands, 6); ok {
+			ext.tm = matrix{v[0], v[1], v[2], v[3], v[4], v[5]}
+			ext.tlm = ext.tm
+		}
+	case "T*":
+		ext.moveLine(0, -st.leading)
+	case "Tj":
+		if len(operands) > 0 {
+			s, _ := operands[len(operands)-1].([]byte)
+			ext.show(s)
+		}
+	case "'":
+		ext.moveLine(0, -st.leading)
+		if len(operands) > 0 {
+			s, _ := operands[len(operands)-1].([]byte)
+			ext.show(s)
+		}
+	case "\"":
+		if len(operands) >= 3 {
+			st.wordSp, _ = operands[len(operands)-3].(float64)
+			st.charSp, _ = operands[len(operands)-2].(float64)
+			ext.moveLine(0, -st.leading)
+			s, _ := operands[len(operands)-1].([]byte)
+			ext.show(s)
+		}
+	case "TJ":
+		if len(operands) == 0 {
+			return
+		}
+		items, _ := operands[len(operands)-1].(array)
+		for _, item := range items {
+			switch v := item.(type) {
+			case []byte:
+				ext.show(v)
+			case float64:
+				tx := -v / 1000 * st.size * st.scale
+				ext.tm = translate(tx, 0).mul(ext.tm)
+			}
+		}
+	case "Do":
+		if len(operands) > 0 {
+			xname, _ := operands[len(operands)-1].(name)
+			ext.form(resources, xname, depth)
+		}
+	}
+}
+
+func (ext *extractor) moveLine(x, y float64) {
+	ext.tlm = translate(x, y).mul(ext.tlm)
+	ext.tm = ext.tlm
+}
+
+// form runs content of the form XObject.
+func (ext *extractor) form(resources dict, xname name, depth int) {
+	xobjects := ext.doc.dict(resources["XObject"])
+	s, ok := ext.doc.resolve(xobjects[xname]).(*stream)
+	if !ok || s.dict["Subtype"] != name("Form") {
+		return
+	}
+	content, err := ext.doc.decode(s)
+	if err != nil {
+		return
+	}
+
+	ctm := ext.state.ctm
+	if m := ext.doc.array(s.dict["Matrix"]); len(m) == 6 {
+		var fm matrix
+		for i := range fm {
+			fm[i] = ext.doc.number(m[i], identity[i])
+		}
+		ctm = fm.mul(ctm)
+	}
+	formResources := ext.doc.dict(s.dict["Resources"])
+	if formResources == nil {
+		formResources = resources
+	}
+	tm, tlm := ext.tm, ext.tlm
+	ext.run(content, formResources, ctm, depth+1)
+	ext.tm, ext.tlm = tm, tlm
+}
+
+// show writes the string, separating it from the previous text by a line
+// break or a space judging by positions.
+func (ext *extractor) show(s []byte) {
+	st := &ext.state
+	f := st.font
+	if f == nil {
+		f = fallbackFont
+	}
+
+	for _, g := range f.decode(s) {
+		trm := matrix{st.size * st.scale, 0, 0, st.size, 0, 0}.mul(ext.tm).mul(st.ctm)
+		x, y := trm[4], trm[5]
+		size := math.Hypot(trm[2], trm[3])
+		if size == 0 {
+			size = 1
+		}
+
+		if ext.shown && g.text != "" {
+			switch {
+			case math.Abs(y-ext.lastY) > 0.5*math.Max(size, ext.lastSize):
+				ext.text.WriteByte('\n')
+			case x-ext.lastX > 0.15*math.Max(size, ext.lastSize) && !ext.endsWithSpace():
+				if !strings.HasPrefix(g.text, " ") {
+					ext.text.WriteByte(' ')
+				}
+			}
+		}
+		ext.text.WriteString(g.text)
+
+		tx := g.width/1000*st.size + st.charSp
+		if g.space {
+			tx += st.wordSp
+		}
+		ext.tm = translate(tx*st.scale, 0).mul(ext.tm)
+		if g.text != "" {
+			end := matrix{st.size * st.scale, 0, 0, st.size, 0, 0}.mul(ext.tm).mul(st.ctm)
+			ext.shown = true
+			ext.lastX, ext.lastY, ext.lastSize = end[4], end[5], size
+		}
+	}
+}
+
+func (ext *extractor) endsWithSpace() bool {
+	s := ext.text.String()
+	return s == "" || strings.HasSuffix(s, " ") || strings.HasSuffix(s, "\n")
+}
+
+// font returns the font of the resources, loading it once.
+func (ext *extractor) font(resources dict, fontName name) *font {
+	v := ext.doc.dict(resources["Font"])[fontName]
+	key := v
+	if _, ok := v.(ref); !ok {
+		key = fontName
+	}
+	if f, ok := ext.fonts[key]; ok {
+		return f
+	}
+	f := loadFont(ext.doc, ext.doc.dict(v))
+	ext.fonts[key] = f
+	return f
+}
+
+// glyph is a decoded character code.
+type glyph struct {
+	text  string
+	width float64
+	space bool
+}
+
+// font maps character codes to text and widths.
+type font struct {
+	codeLen   int
+	toUnicode map[uint32]string
+	encoding  *[256]rune
+	widths    map[uint32]float64
+	defWidth  float64
+}
+
+var fallbackFont = &font{codeLen: 1, encoding: &winAnsi, defWidth: 500}
+
+func loadFont(doc *document, d dict) *font {
+	f := &font{codeLen: 1, defWidth: 500, widths: make(map[uint32]float64)}
+	if d == nil {
+		f.encoding = &winAnsi
+		return f
+	}
+
+	if d["Subtype"] == name("Type0") {
+		f.codeLen = 2
+		f.defWidth = 1000
+		descendants := doc.array(d["DescendantFonts"])
+		if len(descendants) > 0 {
+			cid := doc.dict(descendants[0])
+			f.defWidth = doc.number(cid["DW"], 1000)
+			f.cidWidths(doc, doc.array(cid["W"]))
+		}
+	} else {
+		f.encoding = simpleEncoding(doc, d["Encoding"])
+		first := int(doc.number(d["FirstChar"], 0))
+		for i, w := range doc.array(d["Widths"]) {
+			f.widths[uint32(first+i)] = doc.number(w, 0)
+		}
+	}
+
+	if s, ok := doc.resolve(d["ToUnicode"]).(*stream); ok {
+		data, err := doc.decode(s)
+		if err == nil {
+			f.parseCMap(data)
+		}
+	}
+	return f
+}
+
+// cidWidths reads widths of a CID font: "c [w1 w2 ...]" and "c1 c2 w".
+func (f *font) cidWidths(doc *document, w array) {
+	for i := 0; i < len(w); {
+		first, ok := doc.resolve(w[i]).(float64)
+		if !ok || i+1 >= len(w) {
+			return
+		}
+		if list, ok := doc.resolve(w[i+1]).(array); ok {
+			for j, v := range list {
+				f.widths[uint32(first)+uint32(j)] = doc.number(v, f.defWidth)
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= len(w) {
+			return
+		}
+		last := doc.number(w[i+1], first)
+		width := doc.number(w[i+2], f.defWidth)
+		for c := first; c <= last && c-first < 1<<16; c++ {
+			f.widths[uint32(c)] = width
+		}
+		i += 3
+	}
+}
+
+// parseCMap reads ToUnicode mapping.
+func (f *font) parseCMap(data []byte) {
+	f.toUnicode = make(map[uint32]string)
+	lex := &lexer{data: data}
+	var operands []interface{}
+	mode := operator("")
+	for {
+		v, err := lex.object()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			continue
+		}
+		op, ok := v.(operator)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+		switch op {
+		case "begincodespacerange", "beginbfchar", "beginbfrange":
+			mode = op
+		case "endcodespacerange":
+			if len(operands) >= 2 {
+				if lo, ok := operands[0].([]byte); ok && len(lo) > 0 {
+					f.codeLen = len(lo)
+				}
+			}
+			mode = ""
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, _ := operands[i].([]byte)
+				f.toUnicode[code(src)] = cmapText(operands[i+1])
+			}
+			mode = ""
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, _ := operands[i].([]byte)
+				hi, _ := operands[i+1].([]byte)
+				f.mapRange(code(lo), code(hi), operands[i+2])
+			}
+			mode = ""
+		}
+		if mode == "" || op == mode {
+			operands = operands[:0]
+		}
+	}
+}
+
+func (f *font) mapRange(lo, hi uint32, dst interface{}) {
+	if hi < lo || hi-lo >= 1<<16 {
+		return
+	}
+	switch dst := dst.(type) {
+	case array:
+		for i, v := range dst {
+			if lo+uint32(i) > hi {
+				break
+			}
+			f.toUnicode[lo+uint32(i)] = cmapText(v)
+		}
+	case []byte:
+		if len(dst) == 0 || len(dst) > 8 {
+			return
+		}
+		base := uint64(0)
+		for _, b := range dst {
+			base = base<<8 | uint64(b)
+		}
+		buf := make([]byte, len(dst))
+		for c := lo; c <= hi; c++ {
+			v := base + uint64(c-lo)
+			for i := len(buf) - 1; i >= 0; i-- {
+				buf[i] = byte(v)
+				v >>= 8
+			}
+			f.toUnicode[c] = utf16Text(buf)
+		}
+	}
+}
+
+func code(b []byte) uint32 {
+	var c uint32
+	for _, v := range b {
+		c = c<<8 | uint32(v)
+	}
+	return c
+}
+
+func cmapText(v interface{}) string {
+	switch v := v.(type) {
+	case []byte:
+		return utf16Text(v)
+	case name:
+		if r := glyphRune(string(v)); r != 0 {
+			return string(r)
+		}
+	}
+	return ""
+}
+
+func utf16Text(b []byte) string {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+func (f *font) decode(s []byte) []glyph {
+	n := f.codeLen
+	if n < 1 || n > 4 {
+		n = 1
+	}
+	glyphs := make([]glyph, 0, len(s)/n)
+	for i := 0; i < len(s); i += n {
+		end := i + n
+		if end > len(s) {
+			end = len(s)
+		}
+		c := code(s[i:end])
+
+		g := glyph{width: f.defWidth, space: n == 1 && c == ' '}
+		if w, ok := f.widths[c]; ok {
+			g.width = w
+		}
+		if text, ok := f.toUnicode[c]; ok {
+			g.text = text
+		} else if f.encoding != nil && c < 256 {
+			if r := f.encoding[c]; r != 0 {
+				g.text = string(r)
+			}
+		}
+		glyphs = append(glyphs, g)
+	}
+	return glyphs
+}
+
+// simpleEncoding returns encoding of a simple font. Base encodings other
+// than WinAnsi differ in a few punctuation marks only, so WinAnsi stands in
+// for all of them.
+func simpleEncoding(doc *document, v interface{}) *[256]rune {
+	d := doc.dict(v)
+	if d == nil {
+		return &winAnsi
+	}
+	differences := doc.array(d["Differences"])
+	if len(differences) == 0 {
+		return &winAnsi
+	}
+
+	enc := winAnsi
+	c := 0
+	for _, item := range differences {
+		switch item := doc.resolve(item).(type) {
+		case float64:
+			c = int(item)
+		case name:
+			if c >= 0 && c < 256 {
+				enc[c] = glyphRune(string(item))
+			}
+			c++
+		}
+	}
+	return &enc
+}
+
+// glyphNames maps names of the Adobe Glyph List used by common fonts.
+var glyphNames = map[string]rune{
+	"space": ' ', "exclam": '!', "quotedbl": '"', "numbersign": '#',
+	"dollar": '$', "percent": '%', "ampersand": '&', "quotesingle": '\'',
+	"parenleft": '(', "parenright": ')', "asterisk": '*', "plus": '+',
+	"comma": ',', "hyphen": '-', "period": '.', "slash": '/',
+	"zero": '0', "one": '1', "two": '2', "three": '3', "four": '4',
+	"five": '5', "six": '6', "seven": '7', "eight": '8', "nine": '9',
+	"colon": ':', "semicolon": ';', "less": '<', "equal": '=',
+	"greater": '>', "question": '?', "at": '@', "bracketleft": '[',
+	"backslash": '\\', "bracketright": ']', "asciicircum": '^',
+	"underscore": '_', "grave": '`', "braceleft": '{', "bar": '|',
+	"braceright": '}', "asciitilde": '~', "quoteleft": '‘',
+	"quoteright": '’', "quotedblleft": '“', "quotedblright": '”',
+	"quotedblbase": '„', "endash": '–', "emdash": '—', "bullet": '•',
+	"ellipsis": '…', "guillemotleft": '«', "guillemotright": '»',
+	"numero": '№', "afii61352": '№', "nbspace": ' ',
+	"copyright": '©', "registered": '®', "degree": '°', "minus": '−',
+}
+
+// glyphRune returns the character of the glyph name, 0 if unknown.
+func glyphRune(glyphName string) rune {
+	if i := strings.IndexByte(glyphName, '.'); i > 0 {
+		glyphName = glyphName[:i]
+	}
+	if r, ok := glyphNames[glyphName]; ok {
+		return r
+	}
+	if len(glyphName) == 1 {
+		return rune(glyphName[0])
+	}
+	hex := ""
+	switch {
+	case strings.HasPrefix(glyphName, "uni") && len(glyphName) >= 7:
+		hex = glyphName[3:7]
+	case strings.HasPrefix(glyphName, "u") && len(glyphName) >= 5 && len(glyphName) <= 7:
+		hex = glyphName[1:]
+	}
+	if hex != "" {
+		if v, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return rune(v)
+		}
+	}
+	if n, err := strconv.Atoi(strings.TrimPrefix(glyphName, "afii")); err == nil {
+		return afiiCyrillic(n)
+	}
+	return 0
+}
+
+// afiiCyrillic maps the afii names of Russian letters.
+func afiiCyrillic(n int) rune {
+	switch {
+	case n == 10023:
+		return 'Ё'
+	case n == 10071:
+		return 'ё'
+	case n >= 10017 && n <= 10049:
+		if n > 10023 {
+			n--
+		}
+		return rune('А' + n - 10017)
+	case n >= 10065 && n <= 10097:
+		if n > 10071 {
+			n--
+		}
+		return rune('а' + n - 10065)
+	}
+	return 0
+}
+
+// winAnsi is the Windows-1252 based encoding of PDF.
+var winAnsi = func() [256]rune {
+	var enc [256]rune
+	for c := 0x20; c < 0x7f; c++ {
+		enc[c] = rune(c)
+	}
+	for c := 0xa0; c < 0x100; c++ {
+		enc[c] = rune(c)
+	}
+	for i, r := range []rune("€\x00‚ƒ„…†‡ˆ‰Š‹Œ\x00Ž\x00\x00‘’“”•–—˜™š›œ\x00žŸ") {
+		enc[0x80+i] = r
+	}
+	return enc
+}()
@@ -0,0 +1,54 @@
+// Package textract extracts plain text from PDF and DOCX documents using the
+// standard library only, so documents never leave the process.
+//
+// Extraction aims at documents like resumes: text is returned in the order
+// the producer drew it with lines and words separated, layout and styling
+// are lost.
+package textract
+
+import (
+	"bytes"
+	"errors"
+	"unicode/utf8"
+)
+
+// Content types of supported documents.
+const (
+	TypePDF  = "application/pdf"
+	TypeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	TypeText = "text/plain; charset=utf-8"
+)
+
+var ErrUnsupported = errors.New("unsupported document type")
+
+// Detect returns content type of the document judging by its content, empty
+// if the type is not supported.
+func Detect(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		return TypePDF
+	case isDOCX(data):
+		return TypeDOCX
+	case len(data) > 0 && utf8.Valid(data) && !bytes.ContainsRune(data, 0):
+		return TypeText
+	}
+	return ""
+}
+
+// Extract returns text of the document and its detected content type.
+func Extract(data []byte) (string, string, error) {
+	contentType := Detect(data)
+	var text string
+	var err error
+	switch contentType {
+	case TypePDF:
+		text, err = PDF(data)
+	case TypeDOCX:
+		text, err = DOCX(data)
+	case TypeText:
+		text = string(data)
+	default:
+		err = ErrUnsupported
+	}
+	return text, contentType, err
+}
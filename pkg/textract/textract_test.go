@@ -0,0 +1,153 @@
+package textract
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"testing"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func testPDF(t *testing.T, setup func(pdf *fpdf.Fpdf)) []byte {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	setup(pdf)
+	var buf bytes.Buffer
+	require.NoError(t, pdf.Output(&buf))
+	return buf.Bytes()
+}
+
+func TestPDF(t *testing.T) {
+	test := func(data []byte, want string) func(*testing.T) {
+		return func(t *testing.T) {
+			got, err := PDF(data)
+			require.NoError(t, err)
+			require.Exactly(t, want, got)
+		}
+	}
+
+	unicode := testPDF(t, func(pdf *fpdf.Fpdf) {
+		pdf.AddUTF8FontFromBytes("go", "", goregular.TTF)
+		pdf.SetFont("go", "", 12)
+		pdf.AddPage()
+		pdf.Cell(40, 8, "Иванов Иван")
+		pdf.Ln(8)
+		pdf.Cell(30, 8, "Телефон:")
+		pdf.Cell(50, 8, "+7 (999) 123-45-67")
+		pdf.AddPage()
+		pdf.Cell(40, 8, "Навыки: Go, PostgreSQL")
+	})
+	t.Run("unicode font", test(unicode,
+		"Иванов Иван\nТелефон: +7 (999) 123-45-67\n\nНавыки: Go, PostgreSQL"))
+
+	core := testPDF(t, func(pdf *fpdf.Fpdf) {
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.AddPage()
+		pdf.MultiCell(0, 6, "John Smith\njohn@example.com", "", "L", false)
+	})
+	t.Run("core font", test(core, "John Smith\njohn@example.com"))
+
+	uncompressed := testPDF(t, func(pdf *fpdf.Fpdf) {
+		pdf.SetCompression(false)
+		pdf.SetFont("Courier", "", 10)
+		pdf.AddPage()
+		pdf.Text(20, 20, "Experience")
+		pdf.Text(20, 30, "2019 - 2024")
+	})
+	t.Run("uncompressed", test(uncompressed, "Experience\n2019 - 2024"))
+
+	t.Run("object stream", test(objectStreamPDF(t), "Anna (Smith)\nkerned text"))
+}
+
+// objectStreamPDF builds a document keeping its page objects in a
+// compressed object stream and drawing with differences encoding and
+// kerning.
+func objectStreamPDF(t *testing.T) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 /Resources << /Font << /F1 5 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Contents 4 0 R >>",
+	}
+	var header, body bytes.Buffer
+	for i, object := range objects {
+		fmt.Fprintf(&header, "%d %d ", i+1, body.Len())
+		body.WriteString(object + "\n")
+	}
+
+	content := "BT /F1 12 Tf 1 0 0 1 72 720 Tm (Anna \\(Smith\\)) Tj " +
+		"0 -14 Td [(k) -20 (erned) -600 <01> (ext)] TJ ET"
+	var objStm bytes.Buffer
+	zw := zlib.NewWriter(&objStm)
+	_, err := zw.Write(append(header.Bytes(), body.Bytes()...))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.5\n")
+	fmt.Fprintf(&pdf, "4 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n",
+		len(content), content)
+	pdf.WriteString("5 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica " +
+		"/Encoding << /Differences [1 /t] >> >>\nendobj\n")
+	fmt.Fprintf(&pdf, "6 0 obj\n<< /Type /ObjStm /N %d /First %d /Filter /FlateDecode /Length %d >>\nstream\n",
+		len(objects), header.Len(), objStm.Len())
+	pdf.Write(objStm.Bytes())
+	pdf.WriteString("\nendstream\nendobj\ntrailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return pdf.Bytes()
+}
+
+func testDOCX(t *testing.T, body string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("[Content_Types].xml")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(`<?xml version="1.0"?><Types/>`))
+	require.NoError(t, err)
+	w, err = zw.Create(docxBody)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+		`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:body>` + body + `</w:body></w:document>`))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestDOCX(t *testing.T) {
+	data := testDOCX(t,
+		`<w:p><w:r><w:t>Петров</w:t></w:r><w:r><w:t xml:space="preserve"> Пётр</w:t></w:r></w:p>`+
+			`<w:p><w:r><w:t>Email:</w:t><w:tab/><w:t>petr@example.com</w:t></w:r></w:p>`+
+			`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>2020</w:t></w:r></w:p></w:tc>`+
+			`<w:tc><w:p><w:r><w:t>Go</w:t><w:br/><w:t>Kafka</w:t></w:r></w:p></w:tc></w:tr></w:tbl>`)
+
+	got, err := DOCX(data)
+	require.NoError(t, err)
+	require.Exactly(t, "Петров Пётр\nEmail:\tpetr@example.com\n2020\nGo\nKafka\n", got)
+
+	_, err = DOCX([]byte("not a zip"))
+	require.Error(t, err)
+}
+
+func TestExtract(t *testing.T) {
+	test := func(data []byte, wantType string, wantErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			require.Exactly(t, wantType, Detect(data))
+			text, contentType, err := Extract(data)
+			require.ErrorIs(t, err, wantErr)
+			require.Exactly(t, wantType, contentType)
+			if err == nil {
+				require.NotEmpty(t, text)
+			}
+		}
+	}
+
+	t.Run("pdf", test(objectStreamPDF(t), TypePDF, nil))
+	t.Run("docx", test(testDOCX(t, `<w:p><w:r><w:t>Go</w:t></w:r></w:p>`), TypeDOCX, nil))
+	t.Run("text", test([]byte("Иван Иванов"), TypeText, nil))
+	t.Run("binary", test([]byte{0x89, 'P', 'N', 'G', 0}, "", ErrUnsupported))
+	t.Run("empty", test(nil, "", ErrUnsupported))
+	t.Run("encrypted", test([]byte("%PDF-1.4\ntrailer << /Encrypt 5 0 R >>"), TypePDF, ErrEncrypted))
+	t.Run("no pages", test([]byte("%PDF-1.4\n1 0 obj << >> endobj"), TypePDF, ErrNoPages))
+}
@@ -0,0 +1,349 @@
+// Package xlsx reads and writes single sheet Office Open XML workbooks as
+// tables of strings using the standard library only.
+//
+// Only cell values are supported: numbers are read as written, dates as the
+// serial numbers spreadsheets store them as, formulas as their cached values.
+// Written cells are all inline strings, the ones with line breaks wrapped.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ContentType of workbooks.
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// maxPart limits the unpacked size of a workbook part.
+const maxPart = 64 << 20
+
+// maxRows is the number of rows in a sheet spreadsheets allow.
+const maxRows = 1 << 20
+
+var (
+	ErrNoSheet    = errors.New("xlsx has no sheets")
+	ErrInvalidRef = errors.New("invalid xlsx cell reference")
+)
+
+// IsXLSX reports whether the data looks like a workbook.
+func IsXLSX(data []byte) bool {
+	if !bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return false
+	}
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return false
+	}
+	for _, f := range r.File {
+		if f.Name == "xl/workbook.xml" {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`
+	packageRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+	styles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="1"><fill><patternFill patternType="none"/></fill></fills>` +
+		`<borders count="1"><border/></borders>` +
+		`<cellStyleXfs count="1"><xf/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf/><xf applyAlignment="1"><alignment wrapText="1" vertical="top"/></xf></cellXfs>` +
+		`</styleSheet>`
+)
+
+// Write writes the rows as the only sheet of a workbook. Cells with line
+// breaks are wrapped.
+func Write(w io.Writer, sheet string, rows [][]string) error {
+	z := zip.NewWriter(w)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", packageRels},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+		{"xl/styles.xml", styles},
+		{"xl/workbook.xml", workbook(sheet)},
+	}
+	for _, part := range parts {
+		f, err := z.Create(part.name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(f, part.content)
+		if err != nil {
+			return err
+		}
+	}
+
+	f, err := z.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	err = writeSheet(f, rows)
+	if err != nil {
+		return err
+	}
+	return z.Close()
+}
+
+func workbook(sheet string) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="`)
+	xml.EscapeText(&b, []byte(sheet))
+	b.WriteString(`" sheetId="1" r:id="rId1"/></sheets></workbook>`)
+	return b.String()
+}
+
+func writeSheet(w io.Writer, rows [][]string) error {
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for j, cell := range row {
+			if cell == "" {
+				continue
+			}
+			style := ""
+			if strings.Contains(cell, "\n") {
+				style = ` s="1"`
+			}
+			fmt.Fprintf(&b, `<c r="%s%d" t="inlineStr"%s><is><t xml:space="preserve">`, column(j), i+1, style)
+			err := xml.EscapeText(&b, []byte(cell))
+			if err != nil {
+				return err
+			}
+			b.WriteString(`</t></is></c>`)
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	_, err := b.WriteTo(w)
+	return err
+}
+
+// column returns the letters of the zero based column: A, B, ..., Z, AA.
+func column(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// columnIndex returns the zero based column of a cell reference like "AB12".
+func columnIndex(ref string) (int, error) {
+	i := 0
+	n := 0
+	for ; n < len(ref) && ref[n] >= 'A' && ref[n] <= 'Z'; n++ {
+		i = i*26 + int(ref[n]-'A'+1)
+	}
+	if n == 0 || n > 3 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRef, ref)
+	}
+	return i - 1, nil
+}
+
+type xmlWorkbook struct {
+	Sheets []struct {
+		ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xmlRelationships struct {
+	Items []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xmlText is a string item, either plain or made of rich text runs.
+type xmlText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t *xmlText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xmlSharedStrings struct {
+	Items []xmlText `xml:"si"`
+}
+
+type xmlSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string  `xml:"r,attr"`
+			T      string  `xml:"t,attr"`
+			V      string  `xml:"v"`
+			Inline xmlText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// Read returns rows of the first sheet of the workbook. Empty rows in the
+// middle are kept, trailing empty cells of rows are not.
+func Read(data []byte) ([][]string, error) {
+	z, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File, len(z.File))
+	for _, f := range z.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheet(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared xmlSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		err = decodePart(f, &shared)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var sheet xmlSheet
+	err = decodePart(files[sheetPath], &sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		index := len(rows)
+		if row.R > maxRows {
+			return nil, fmt.Errorf("%w: row %d", ErrInvalidRef, row.R)
+		}
+		if row.R > 0 {
+			index = row.R - 1
+		}
+		for len(rows) <= index {
+			rows = append(rows, nil)
+		}
+
+		var cells []string
+		for k, c := range row.Cells {
+			col := k
+			if c.R != "" {
+				col, err = columnIndex(c.R)
+				if err != nil {
+					return nil, err
+				}
+			}
+			value := c.V
+			switch c.T {
+			case "s":
+				i, err := strconv.Atoi(c.V)
+				if err != nil || i < 0 || i >= len(shared.Items) {
+					return nil, fmt.Errorf("%w: shared string %q", ErrInvalidRef, c.V)
+				}
+				value = shared.Items[i].String()
+			case "inlineStr":
+				value = c.Inline.String()
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+			cells[col] = value
+		}
+		for len(cells) > 0 && cells[len(cells)-1] == "" {
+			cells = cells[:len(cells)-1]
+		}
+		rows[index] = cells
+	}
+	return rows, nil
+}
+
+// firstSheet returns the part of the first sheet listed in the workbook.
+func firstSheet(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	var wb xmlWorkbook
+	f, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", ErrNoSheet
+	}
+	err := decodePart(f, &wb)
+	if err != nil {
+		return "", err
+	}
+	if len(wb.Sheets) == 0 {
+		return "", ErrNoSheet
+	}
+
+	var rels xmlRelationships
+	if f, ok := files["xl/_rels/workbook.xml.rels"]; ok {
+		err = decodePart(f, &rels)
+		if err != nil {
+			return "", err
+		}
+	}
+	for _, rel := range rels.Items {
+		if rel.ID != wb.Sheets[0].ID {
+			continue
+		}
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		if _, ok := files[target]; ok {
+			return target, nil
+		}
+	}
+	if _, ok := files[fallback]; ok {
+		return fallback, nil
+	}
+	return "", ErrNoSheet
+}
+
+func decodePart(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(io.LimitReader(rc, maxPart)).Decode(v)
+}
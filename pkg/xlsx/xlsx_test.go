@@ -0,0 +1,79 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRead(t *testing.T) {
+	rows := [][]string{
+		{"title", "skills", "duties"},
+		{"Go developer", "Go*; PostgreSQL", "code\nreview"},
+		{},
+		{"Разработчик <C++> & \"Rust\"", "", "", "", "last"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, "Vacancies", rows))
+	require.True(t, IsXLSX(buf.Bytes()))
+	require.False(t, IsXLSX([]byte("title,skills\n")))
+
+	got, err := Read(buf.Bytes())
+	require.NoError(t, err)
+	require.Exactly(t, [][]string{
+		{"title", "skills", "duties"},
+		{"Go developer", "Go*; PostgreSQL", "code\nreview"},
+		nil,
+		{"Разработчик <C++> & \"Rust\"", "", "", "", "last"},
+	}, got)
+}
+
+func TestRead_SharedStrings(t *testing.T) {
+	// Spreadsheets keep text in shared strings and put sheets wherever the
+	// workbook relationships say.
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Plan" sheetId="1" r:id="rId3"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId3" Target="/xl/worksheets/plan.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<si><t>title</t></si><si><t>experience</t></si>` +
+			`<si><r><t>Go </t></r><r><t>developer</t></r></si></sst>`,
+		"xl/worksheets/plan.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>` +
+			`<row r="3"><c r="A3" t="s"><v>2</v></c><c r="C3"><v>3</v></c></row>` +
+			`</sheetData></worksheet>`,
+	}
+	var buf bytes.Buffer
+	z := zip.NewWriter(&buf)
+	for name, content := range parts {
+		f, err := z.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, z.Close())
+
+	rows, err := Read(buf.Bytes())
+	require.NoError(t, err)
+	require.Exactly(t, [][]string{
+		{"title", "", "experience"},
+		nil,
+		{"Go developer", "", "3"},
+	}, rows)
+}
+
+func TestColumn(t *testing.T) {
+	for i, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		require.Exactly(t, name, column(i))
+		got, err := columnIndex(name + "12")
+		require.NoError(t, err)
+		require.Exactly(t, i, got)
+	}
+	_, err := columnIndex("12")
+	require.ErrorIs(t, err, ErrInvalidRef)
+}